@@ -0,0 +1,89 @@
+// Package intervals solves common scheduling problems over half-open
+// intervals, such as counting the meeting rooms a calendar needs
+package intervals
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/anwar-arif/golang-dsa/intervalset"
+	"github.com/anwar-arif/golang-dsa/priorityqueue"
+)
+
+// Interval is the half-open range [Lo, Hi), so a meeting ending at 10 and
+// one starting at 10 do not overlap
+type Interval[T cmp.Ordered] = intervalset.Interval[T]
+
+// byStart orders intervals by start, then by end
+func byStart[T cmp.Ordered](a, b Interval[T]) int {
+	if c := cmp.Compare(a.Lo, b.Lo); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Hi, b.Hi)
+}
+
+// MinMeetingRooms returns the fewest rooms that can host every interval
+// without two overlapping intervals sharing a room
+// Intervals are taken in order of start while a min-queue holds the end
+// times of the rooms in use; a room whose meeting has ended by the next
+// start is reused. O(n log n); empty intervals need no room
+func MinMeetingRooms[T cmp.Ordered](intervals []Interval[T]) int {
+	sorted := slices.SortedFunc(slices.Values(intervals), byStart[T])
+	ends := priorityqueue.NewMinValueQueue(cmp.Compare[T])
+
+	rooms := 0
+	for _, iv := range sorted {
+		if iv.IsEmpty() {
+			continue
+		}
+		if end, err := ends.Peek(); err == nil && end <= iv.Lo {
+			ends.Pop()
+		}
+		ends.Push(iv.Hi)
+		rooms = max(rooms, ends.Size())
+	}
+	return rooms
+}
+
+// MergeIntervals returns the union of intervals as disjoint intervals sorted
+// by start; overlapping and touching intervals are joined and empty ones
+// are dropped
+// The input is not modified. O(n log n)
+func MergeIntervals[T cmp.Ordered](intervals []Interval[T]) []Interval[T] {
+	sorted := slices.SortedFunc(slices.Values(intervals), byStart[T])
+
+	merged := make([]Interval[T], 0, len(sorted))
+	for _, iv := range sorted {
+		if iv.IsEmpty() {
+			continue
+		}
+		if n := len(merged); n > 0 && iv.Lo <= merged[n-1].Hi {
+			merged[n-1].Hi = max(merged[n-1].Hi, iv.Hi)
+			continue
+		}
+		merged = append(merged, iv)
+	}
+	return merged
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Intervals Examples ===")
+
+	// Example 1: Meeting rooms, in minutes since midnight
+	fmt.Println("1. Meeting Rooms:")
+	meetings := []Interval[int]{
+		{Lo: 540, Hi: 600}, // 9:00-10:00
+		{Lo: 570, Hi: 630}, // 9:30-10:30
+		{Lo: 600, Hi: 660}, // 10:00-11:00, reuses the first room
+		{Lo: 615, Hi: 645}, // 10:15-10:45
+	}
+	fmt.Printf("  Meetings: %v\n", meetings)
+	fmt.Printf("  Rooms needed: %d\n", MinMeetingRooms(meetings))
+
+	// Example 2: Merging busy times
+	fmt.Println("\n2. Merge Intervals:")
+	busy := []Interval[int]{{Lo: 1, Hi: 3}, {Lo: 8, Hi: 10}, {Lo: 2, Hi: 6}, {Lo: 6, Hi: 7}}
+	fmt.Printf("  %v -> %v\n", busy, MergeIntervals(busy))
+}
@@ -0,0 +1,88 @@
+package intervals
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+type iv = Interval[int]
+
+// ivs builds intervals from [lo, hi] pairs
+func ivs(pairs ...[2]int) []iv {
+	result := make([]iv, len(pairs))
+	for i, p := range pairs {
+		result[i] = iv{Lo: p[0], Hi: p[1]}
+	}
+	return result
+}
+
+func TestMinMeetingRooms(t *testing.T) {
+	tests := []struct {
+		name      string
+		intervals []iv
+		expected  int
+	}{
+		{"none", nil, 0},
+		{"single", ivs([2]int{1, 5}), 1},
+		{"back to back", ivs([2]int{1, 5}, [2]int{5, 9}), 1},
+		{"overlapping", ivs([2]int{0, 30}, [2]int{5, 10}, [2]int{15, 20}), 2},
+		{"nested", ivs([2]int{1, 10}, [2]int{2, 9}, [2]int{3, 8}), 3},
+		{"empty intervals", ivs([2]int{3, 3}, [2]int{4, 2}), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MinMeetingRooms(tt.intervals); got != tt.expected {
+				t.Errorf("Expected %d rooms, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestMinMeetingRoomsMatchesSweep(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for round := 0; round < 50; round++ {
+		var intervals []iv
+		load := make([]int, 101)
+		for i := 0; i < 30; i++ {
+			lo := rng.Intn(90)
+			hi := lo + 1 + rng.Intn(10)
+			intervals = append(intervals, iv{Lo: lo, Hi: hi})
+			for p := lo; p < hi; p++ {
+				load[p]++
+			}
+		}
+		if got, want := MinMeetingRooms(intervals), slices.Max(load); got != want {
+			t.Fatalf("Round %d: expected %d rooms, got %d", round, want, got)
+		}
+	}
+}
+
+func TestMergeIntervals(t *testing.T) {
+	input := ivs([2]int{8, 10}, [2]int{1, 3}, [2]int{2, 6}, [2]int{6, 7}, [2]int{15, 18}, [2]int{4, 4})
+	expected := ivs([2]int{1, 7}, [2]int{8, 10}, [2]int{15, 18})
+
+	if got := MergeIntervals(input); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if input[0] != (iv{Lo: 8, Hi: 10}) {
+		t.Error("Expected the input to be left unchanged")
+	}
+	if got := MergeIntervals[int](nil); len(got) != 0 {
+		t.Errorf("Expected no intervals, got %v", got)
+	}
+}
+
+// Benchmark tests
+func BenchmarkMinMeetingRooms(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	intervals := make([]iv, 10000)
+	for i := range intervals {
+		lo := rng.Intn(1000000)
+		intervals[i] = iv{Lo: lo, Hi: lo + rng.Intn(1000)}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MinMeetingRooms(intervals)
+	}
+}
@@ -0,0 +1,170 @@
+// Package alloc provides node allocators that node-based structures can use
+// instead of the garbage-collected heap
+package alloc
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DefaultChunkSize is the number of values an Arena allocates at a time when
+// NewArena is given a size < 1
+const DefaultChunkSize = 1024
+
+// Allocator hands out zeroed values and takes them back
+// A structure calls Free once it no longer references a value; the value must
+// not be used afterwards
+type Allocator[T any] interface {
+	New() *T
+	Free(p *T)
+}
+
+// Heap allocates every value with new and leaves freeing to the garbage collector
+type Heap[T any] struct{}
+
+// New returns a new zeroed value
+func (Heap[T]) New() *T {
+	return new(T)
+}
+
+// Free does nothing
+func (Heap[T]) Free(*T) {}
+
+// Pool recycles freed values through a sync.Pool
+// It suits long-lived structures with a steady churn of nodes and is safe for
+// concurrent use
+type Pool[T any] struct {
+	pool sync.Pool
+}
+
+// NewPool creates an empty pool
+func NewPool[T any]() *Pool[T] {
+	return &Pool[T]{}
+}
+
+// New returns a zeroed value, reusing a freed one when available
+func (p *Pool[T]) New() *T {
+	if v, ok := p.pool.Get().(*T); ok {
+		return v
+	}
+	return new(T)
+}
+
+// Free zeroes v, so it no longer keeps anything alive, and makes it available to New
+func (p *Pool[T]) Free(v *T) {
+	var zero T
+	*v = zero
+	p.pool.Put(v)
+}
+
+// Arena hands out values from large chunks and frees them all at once with Reset
+// Individual frees are ignored, which makes allocation a bump of an index and
+// suits bulk algorithm runs that build a structure, use it and throw it away
+// Chunks are kept across resets, so repeated runs stop allocating entirely
+// An Arena is not safe for concurrent use
+type Arena[T any] struct {
+	chunks    [][]T
+	chunk     int // index of the chunk being filled
+	next      int // next free slot in that chunk
+	chunkSize int
+	allocated int
+}
+
+// NewArena creates an arena that allocates chunkSize values at a time
+func NewArena[T any](chunkSize int) *Arena[T] {
+	if chunkSize < 1 {
+		chunkSize = DefaultChunkSize
+	}
+	return &Arena[T]{chunkSize: chunkSize}
+}
+
+// New returns a zeroed value from the current chunk
+func (a *Arena[T]) New() *T {
+	if a.chunk == len(a.chunks) {
+		a.chunks = append(a.chunks, make([]T, a.chunkSize))
+	}
+
+	p := &a.chunks[a.chunk][a.next]
+	a.next++
+	a.allocated++
+	if a.next == a.chunkSize {
+		a.chunk++
+		a.next = 0
+	}
+	return p
+}
+
+// Free does nothing; memory is reclaimed by Reset
+func (a *Arena[T]) Free(*T) {}
+
+// Reset frees every value handed out since the last reset in one step
+// Values are zeroed and their chunks reused; pointers obtained before Reset
+// must not be used afterwards
+func (a *Arena[T]) Reset() {
+	for i := 0; i < a.chunk && i < len(a.chunks); i++ {
+		clear(a.chunks[i])
+	}
+	if a.chunk < len(a.chunks) {
+		clear(a.chunks[a.chunk][:a.next])
+	}
+	a.chunk = 0
+	a.next = 0
+	a.allocated = 0
+}
+
+// Release drops every chunk so the garbage collector can reclaim them
+func (a *Arena[T]) Release() {
+	a.chunks = nil
+	a.chunk = 0
+	a.next = 0
+	a.allocated = 0
+}
+
+// Allocated returns the number of values handed out since the last reset
+func (a *Arena[T]) Allocated() int {
+	return a.allocated
+}
+
+// Capacity returns the number of values the arena can hand out before it
+// needs another chunk
+func (a *Arena[T]) Capacity() int {
+	return len(a.chunks) * a.chunkSize
+}
+
+// String returns a string representation of the arena
+func (a *Arena[T]) String() string {
+	return fmt.Sprintf("Arena{allocated: %d, capacity: %d, chunks: %d}", a.allocated, a.Capacity(), len(a.chunks))
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Allocator Examples ===")
+
+	type node struct {
+		value int
+		next  *node
+	}
+
+	// Example 1: Building and discarding a list with an arena
+	fmt.Println("1. Arena:")
+	arena := NewArena[node](256)
+	for run := 1; run <= 3; run++ {
+		var head *node
+		for i := 0; i < 1000; i++ {
+			n := arena.New()
+			n.value = i
+			n.next = head
+			head = n
+		}
+		fmt.Printf("  Run %d: %v\n", run, arena)
+		arena.Reset() // every node of the run is freed at once
+	}
+
+	// Example 2: Recycling through a pool
+	fmt.Println("\n2. Pool:")
+	pool := NewPool[node]()
+	n := pool.New()
+	n.value = 42
+	pool.Free(n)
+	fmt.Printf("  Recycled values come back zeroed: %d\n", pool.New().value)
+}
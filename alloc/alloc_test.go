@@ -0,0 +1,101 @@
+package alloc
+
+import "testing"
+
+type node struct {
+	value int
+	next  *node
+}
+
+func TestHeap(t *testing.T) {
+	var h Allocator[node] = Heap[node]{}
+	a, b := h.New(), h.New()
+	if a == b || a.value != 0 {
+		t.Error("Expected distinct zeroed values")
+	}
+	h.Free(a)
+}
+
+func TestPool(t *testing.T) {
+	var p Allocator[node] = NewPool[node]()
+	n := p.New()
+	n.value = 7
+	n.next = n
+	p.Free(n)
+
+	if n.value != 0 || n.next != nil {
+		t.Error("Expected Free to zero the value")
+	}
+	if m := p.New(); m.value != 0 || m.next != nil {
+		t.Error("Expected New to return a zeroed value")
+	}
+}
+
+func TestArena(t *testing.T) {
+	a := NewArena[node](4)
+
+	seen := make(map[*node]bool)
+	for i := 0; i < 10; i++ {
+		n := a.New()
+		if seen[n] || n.value != 0 {
+			t.Fatalf("Expected a fresh zeroed value at %d", i)
+		}
+		seen[n] = true
+		n.value = i + 1
+	}
+	if a.Allocated() != 10 || a.Capacity() != 12 {
+		t.Errorf("Expected 10 allocated in 3 chunks, got %v", a)
+	}
+
+	a.Reset()
+	if a.Allocated() != 0 || a.Capacity() != 12 {
+		t.Errorf("Expected chunks to be kept after Reset, got %v", a)
+	}
+
+	// Reused slots come back zeroed and no new chunk is needed
+	for i := 0; i < 12; i++ {
+		if n := a.New(); n.value != 0 || !seen[n] && i < 10 {
+			t.Fatalf("Expected reused zeroed slot at %d", i)
+		}
+	}
+	if a.Capacity() != 12 {
+		t.Errorf("Expected no new chunks, got %v", a)
+	}
+
+	a.New()
+	if a.Capacity() != 16 {
+		t.Errorf("Expected a fourth chunk, got %v", a)
+	}
+
+	a.Release()
+	if a.Capacity() != 0 || a.Allocated() != 0 {
+		t.Errorf("Expected no chunks after Release, got %v", a)
+	}
+}
+
+// Benchmark tests
+func BenchmarkHeap(b *testing.B) {
+	var head *node
+	for i := 0; i < b.N; i++ {
+		n := new(node)
+		n.next = head
+		head = n
+		if i%1024 == 1023 {
+			head = nil
+		}
+	}
+}
+
+func BenchmarkArena(b *testing.B) {
+	a := NewArena[node](1024)
+	var head *node
+	for i := 0; i < b.N; i++ {
+		n := a.New()
+		n.next = head
+		head = n
+		if i%1024 == 1023 {
+			head = nil
+			a.Reset()
+		}
+	}
+}
@@ -0,0 +1,491 @@
+// Package avl provides an ordered map backed by an AVL tree, a binary search
+// tree that rebalances itself on every change so its height stays within
+// about 1.44 log2(n) and every operation is O(log n) in the worst case
+package avl
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+
+	"github.com/anwar-arif/golang-dsa/collections"
+	"github.com/anwar-arif/golang-dsa/compare"
+)
+
+// node is a node of the tree, augmented with its subtree size for rank queries
+type node[K, V any] struct {
+	key         K
+	value       V
+	left, right *node[K, V]
+	height      int // of the subtree; a leaf has height 1
+	size        int // of the subtree
+}
+
+func height[K, V any](n *node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func size[K, V any](n *node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// update recomputes the height and size of n from its children
+func (n *node[K, V]) update() {
+	n.height = max(height(n.left), height(n.right)) + 1
+	n.size = size(n.left) + size(n.right) + 1
+}
+
+// balance returns how much taller the left subtree of n is than the right
+func (n *node[K, V]) balance() int {
+	return height(n.left) - height(n.right)
+}
+
+// rotateRight lifts the left child of n into its place
+func rotateRight[K, V any](n *node[K, V]) *node[K, V] {
+	l := n.left
+	n.left, l.right = l.right, n
+	n.update()
+	l.update()
+	return l
+}
+
+// rotateLeft lifts the right child of n into its place
+func rotateLeft[K, V any](n *node[K, V]) *node[K, V] {
+	r := n.right
+	n.right, r.left = r.left, n
+	n.update()
+	r.update()
+	return r
+}
+
+// rebalance restores the AVL property at n, whose subtrees are balanced and
+// differ in height by at most two, and returns the new subtree root
+func rebalance[K, V any](n *node[K, V]) *node[K, V] {
+	n.update()
+	switch b := n.balance(); {
+	case b > 1:
+		if n.left.balance() < 0 {
+			n.left = rotateLeft(n.left) // left-right case
+		}
+		return rotateRight(n)
+	case b < -1:
+		if n.right.balance() > 0 {
+			n.right = rotateRight(n.right) // right-left case
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
+
+// Tree is an ordered map based on an AVL tree
+// Every node records its subtree size, so Rank and Select are O(log n) too
+// A Tree is not safe for concurrent use
+type Tree[K, V any] struct {
+	root    *node[K, V]
+	compare compare.CompareFunc[K]
+}
+
+// Tree can be snapshotted through the shared Cloner interface
+var _ collections.Cloner[*Tree[int, int]] = (*Tree[int, int])(nil)
+
+// New creates an empty tree ordered by compare
+func New[K, V any](compare compare.CompareFunc[K]) *Tree[K, V] {
+	return &Tree[K, V]{compare: compare}
+}
+
+// NewOrdered creates an empty tree ordered by the natural order of K
+func NewOrdered[K cmp.Ordered, V any]() *Tree[K, V] {
+	return New[K, V](compare.Ordered[K]())
+}
+
+// find returns the node holding key, or nil
+func (t *Tree[K, V]) find(key K) *node[K, V] {
+	n := t.root
+	for n != nil {
+		switch c := t.compare(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// Get returns the value stored for key
+func (t *Tree[K, V]) Get(key K) (V, bool) {
+	if n := t.find(key); n != nil {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether key is present
+func (t *Tree[K, V]) Contains(key K) bool {
+	return t.find(key) != nil
+}
+
+// Put stores value for key and reports whether the key was newly inserted
+func (t *Tree[K, V]) Put(key K, value V) bool {
+	var inserted bool
+	t.root, inserted = t.put(t.root, key, value)
+	t.debugCheck()
+	return inserted
+}
+
+func (t *Tree[K, V]) put(n *node[K, V], key K, value V) (*node[K, V], bool) {
+	if n == nil {
+		return &node[K, V]{key: key, value: value, height: 1, size: 1}, true
+	}
+
+	var inserted bool
+	switch c := t.compare(key, n.key); {
+	case c < 0:
+		n.left, inserted = t.put(n.left, key, value)
+	case c > 0:
+		n.right, inserted = t.put(n.right, key, value)
+	default:
+		n.value = value
+		return n, false
+	}
+	return rebalance(n), inserted
+}
+
+// Delete removes key and reports whether it was present
+func (t *Tree[K, V]) Delete(key K) bool {
+	var deleted bool
+	t.root, deleted = t.delete(t.root, key)
+	t.debugCheck()
+	return deleted
+}
+
+func (t *Tree[K, V]) delete(n *node[K, V], key K) (*node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var deleted bool
+	switch c := t.compare(key, n.key); {
+	case c < 0:
+		n.left, deleted = t.delete(n.left, key)
+	case c > 0:
+		n.right, deleted = t.delete(n.right, key)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		// Replace n with its successor, the minimum of the right subtree
+		var succ *node[K, V]
+		n.right, succ = deleteMin(n.right)
+		succ.left, succ.right = n.left, n.right
+		return rebalance(succ), true
+	}
+	return rebalance(n), deleted
+}
+
+// deleteMin unlinks the minimum of the subtree at n and returns the new
+// subtree root and the unlinked node
+func deleteMin[K, V any](n *node[K, V]) (*node[K, V], *node[K, V]) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var first *node[K, V]
+	n.left, first = deleteMin(n.left)
+	return rebalance(n), first
+}
+
+// entry returns the key and value of n, or zero values and false for nil
+func entry[K, V any](n *node[K, V]) (K, V, bool) {
+	if n == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return n.key, n.value, true
+}
+
+// Floor returns the greatest key less than or equal to key, with its value
+func (t *Tree[K, V]) Floor(key K) (K, V, bool) {
+	var best *node[K, V]
+	for n := t.root; n != nil; {
+		c := t.compare(key, n.key)
+		if c == 0 {
+			return n.key, n.value, true
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			best, n = n, n.right
+		}
+	}
+	return entry(best)
+}
+
+// Ceiling returns the least key greater than or equal to key, with its value
+func (t *Tree[K, V]) Ceiling(key K) (K, V, bool) {
+	var best *node[K, V]
+	for n := t.root; n != nil; {
+		c := t.compare(key, n.key)
+		if c == 0 {
+			return n.key, n.value, true
+		}
+		if c > 0 {
+			n = n.right
+		} else {
+			best, n = n, n.left
+		}
+	}
+	return entry(best)
+}
+
+// Min returns the smallest key with its value
+func (t *Tree[K, V]) Min() (K, V, bool) {
+	n := t.root
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return entry(n)
+}
+
+// Max returns the largest key with its value
+func (t *Tree[K, V]) Max() (K, V, bool) {
+	n := t.root
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return entry(n)
+}
+
+// Rank returns the number of keys less than key, which is the index key has
+// or would have in ascending order
+func (t *Tree[K, V]) Rank(key K) int {
+	rank := 0
+	for n := t.root; n != nil; {
+		c := t.compare(key, n.key)
+		if c <= 0 {
+			if c == 0 {
+				return rank + size(n.left)
+			}
+			n = n.left
+		} else {
+			rank += size(n.left) + 1
+			n = n.right
+		}
+	}
+	return rank
+}
+
+// Select returns the key at index i in ascending order, with its value
+// It returns false if i is outside [0, Len)
+func (t *Tree[K, V]) Select(i int) (K, V, bool) {
+	if i < 0 || i >= size(t.root) {
+		return entry[K, V](nil)
+	}
+	n := t.root
+	for {
+		switch l := size(n.left); {
+		case i < l:
+			n = n.left
+		case i > l:
+			i -= l + 1
+			n = n.right
+		default:
+			return n.key, n.value, true
+		}
+	}
+}
+
+// Len returns the number of keys in the tree
+func (t *Tree[K, V]) Len() int {
+	return size(t.root)
+}
+
+// IsEmpty returns true if the tree has no keys
+func (t *Tree[K, V]) IsEmpty() bool {
+	return t.root == nil
+}
+
+// Height returns the height of the tree, 0 when it is empty
+func (t *Tree[K, V]) Height() int {
+	return height(t.root)
+}
+
+// Clear removes every key
+func (t *Tree[K, V]) Clear() {
+	t.root = nil
+}
+
+// Clone returns a copy of the tree in O(n) with the same shape and compare function
+func (t *Tree[K, V]) Clone() *Tree[K, V] {
+	return t.CloneWith(func(v V) V { return v })
+}
+
+// CloneWith returns a copy of the tree with every value passed through
+// copyFn, for values that need a deep copy; keys are copied by assignment
+func (t *Tree[K, V]) CloneWith(copyFn func(V) V) *Tree[K, V] {
+	clone := &Tree[K, V]{root: cloneNode(t.root, copyFn), compare: t.compare}
+	clone.debugCheck()
+	return clone
+}
+
+func cloneNode[K, V any](n *node[K, V], copyFn func(V) V) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+	c := *n
+	c.value = copyFn(n.value)
+	c.left = cloneNode(n.left, copyFn)
+	c.right = cloneNode(n.right, copyFn)
+	return &c
+}
+
+// walk calls fn in order for the keys of the subtree at n that are within
+// the bounds, where a nil bound is open, and reports whether fn wants more
+func (t *Tree[K, V]) walk(n *node[K, V], lo, hi *K, fn func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	aboveLo := lo == nil || t.compare(n.key, *lo) >= 0
+	belowHi := hi == nil || t.compare(n.key, *hi) <= 0
+	if aboveLo && !t.walk(n.left, lo, hi, fn) {
+		return false
+	}
+	if aboveLo && belowHi && !fn(n.key, n.value) {
+		return false
+	}
+	if belowHi {
+		return t.walk(n.right, lo, hi, fn)
+	}
+	return true
+}
+
+// Range calls fn for every key in ascending order until fn returns false
+func (t *Tree[K, V]) Range(fn func(key K, value V) bool) {
+	t.walk(t.root, nil, nil, fn)
+}
+
+// RangeBetween calls fn in ascending order for every key k with lo <= k <= hi
+// until fn returns false
+func (t *Tree[K, V]) RangeBetween(lo, hi K, fn func(key K, value V) bool) {
+	t.walk(t.root, &lo, &hi, fn)
+}
+
+// All returns an iterator over the keys in ascending order
+func (t *Tree[K, V]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		t.Range(func(key K, _ V) bool {
+			return yield(key)
+		})
+	}
+}
+
+// All2 returns an iterator over the key/value pairs in ascending key order
+func (t *Tree[K, V]) All2() iter.Seq2[K, V] {
+	return t.Range
+}
+
+// Keys returns the keys in ascending order
+func (t *Tree[K, V]) Keys() []K {
+	keys := make([]K, 0, t.Len())
+	for key := range t.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Validate checks the tree invariants: keys ascend in order, every node's
+// height and size match its children, and no node is out of balance
+func (t *Tree[K, V]) Validate() error {
+	_, err := t.validate(t.root, nil, nil)
+	return err
+}
+
+// validate checks the subtree at n, whose keys must lie strictly between the
+// bounds, and returns the number of nodes in it
+func (t *Tree[K, V]) validate(n *node[K, V], lo, hi *K) (int, error) {
+	if n == nil {
+		return 0, nil
+	}
+	if (lo != nil && t.compare(n.key, *lo) <= 0) || (hi != nil && t.compare(n.key, *hi) >= 0) {
+		return 0, fmt.Errorf("avl: key %v out of order", n.key)
+	}
+	left, err := t.validate(n.left, lo, &n.key)
+	if err != nil {
+		return 0, err
+	}
+	right, err := t.validate(n.right, &n.key, hi)
+	if err != nil {
+		return 0, err
+	}
+
+	if want := max(height(n.left), height(n.right)) + 1; n.height != want {
+		return 0, fmt.Errorf("avl: node %v has height %d, expected %d", n.key, n.height, want)
+	}
+	if n.size != left+right+1 {
+		return 0, fmt.Errorf("avl: node %v has size %d, expected %d", n.key, n.size, left+right+1)
+	}
+	if b := n.balance(); b < -1 || b > 1 {
+		return 0, fmt.Errorf("avl: node %v is out of balance by %d", n.key, b)
+	}
+	return n.size, nil
+}
+
+// debugCheck panics if the tree is invalid; it does nothing unless built with
+// the dsadebug tag
+func (t *Tree[K, V]) debugCheck() {
+	if !debugChecks {
+		return
+	}
+	if err := t.Validate(); err != nil {
+		panic(err)
+	}
+}
+
+// String returns a string representation of the tree
+func (t *Tree[K, V]) String() string {
+	return fmt.Sprintf("Tree{size: %d, height: %d}", t.Len(), t.Height())
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== AVL Tree Examples ===")
+
+	// Example 1: Sorted inserts stay balanced
+	fmt.Println("1. Balance:")
+	t := NewOrdered[int, string]()
+	for i := 1; i <= 1000; i++ {
+		t.Put(i, fmt.Sprintf("v%d", i))
+	}
+	fmt.Printf("  1000 ascending inserts: %v\n", t)
+
+	// Example 2: Ordered lookups
+	fmt.Println("\n2. Floor and Ceiling:")
+	t.Delete(500)
+	floor, _, _ := t.Floor(500)
+	ceiling, _, _ := t.Ceiling(500)
+	fmt.Printf("  After deleting 500: Floor(500) = %d, Ceiling(500) = %d\n", floor, ceiling)
+
+	// Example 3: Rank queries
+	fmt.Println("\n3. Rank and Select:")
+	median, _, _ := t.Select(t.Len() / 2)
+	fmt.Printf("  Rank(750) = %d, median key = %d\n", t.Rank(750), median)
+
+	// Example 4: Range
+	fmt.Println("\n4. RangeBetween(498, 503):")
+	t.RangeBetween(498, 503, func(k int, v string) bool {
+		fmt.Printf("  %d -> %s\n", k, v)
+		return true
+	})
+}
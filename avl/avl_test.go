@@ -0,0 +1,269 @@
+package avl
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// shape renders the tree structure as nested (left key right) groups
+func shape[K, V any](n *node[K, V], b *strings.Builder, key func(K) string) {
+	if n == nil {
+		b.WriteString(".")
+		return
+	}
+	b.WriteString("(")
+	shape(n.left, b, key)
+	b.WriteString(" " + key(n.key) + " ")
+	shape(n.right, b, key)
+	b.WriteString(")")
+}
+
+func shapeOf(t *Tree[string, int]) string {
+	var b strings.Builder
+	shape(t.root, &b, func(k string) string { return k })
+	return b.String()
+}
+
+func TestRotations(t *testing.T) {
+	// Each insertion order triggers one of the four rebalancing cases and
+	// must end with b at the root
+	testCases := []struct {
+		name  string
+		order []string
+	}{
+		{"left-left", []string{"c", "b", "a"}},
+		{"right-right", []string{"a", "b", "c"}},
+		{"left-right", []string{"c", "a", "b"}},
+		{"right-left", []string{"a", "c", "b"}},
+	}
+
+	for _, tc := range testCases {
+		tree := NewOrdered[string, int]()
+		for _, k := range tc.order {
+			tree.Put(k, 0)
+		}
+		if got := shapeOf(tree); got != "((. a .) b (. c .))" {
+			t.Errorf("%s: expected b at the root, got %s", tc.name, got)
+		}
+		if err := tree.Validate(); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		}
+	}
+}
+
+func TestDeleteRebalances(t *testing.T) {
+	tree := NewOrdered[string, int]()
+	for _, k := range []string{"b", "a", "d", "c", "e"} {
+		tree.Put(k, 0)
+	}
+	// Removing a leaves b right-heavy, so d rotates up
+	tree.Delete("a")
+	if got := shapeOf(tree); got != "((. b (. c .)) d (. e .))" {
+		t.Errorf("Unexpected shape after delete: %s", got)
+	}
+
+	// Deleting a node with two children promotes its successor, e, which is
+	// then left-right heavy
+	tree.Delete("d")
+	if got := shapeOf(tree); got != "((. b .) c (. e .))" {
+		t.Errorf("Unexpected shape after deleting the root: %s", got)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBasic(t *testing.T) {
+	tree := NewOrdered[int, string]()
+	if _, ok := tree.Get(1); ok {
+		t.Error("Expected missing key in an empty tree")
+	}
+	if _, _, ok := tree.Min(); ok {
+		t.Error("Expected no min in an empty tree")
+	}
+
+	if !tree.Put(2, "two") || !tree.Put(1, "one") || tree.Put(2, "TWO") {
+		t.Error("Unexpected Put results")
+	}
+	if v, _ := tree.Get(2); v != "TWO" || tree.Len() != 2 {
+		t.Errorf("Expected TWO and length 2, got %s and %d", v, tree.Len())
+	}
+	if tree.Delete(3) || !tree.Delete(1) || tree.Contains(1) {
+		t.Error("Unexpected Delete results")
+	}
+
+	tree.Clear()
+	if !tree.IsEmpty() || tree.Height() != 0 {
+		t.Error("Expected an empty tree after Clear")
+	}
+}
+
+func TestOrderedQueries(t *testing.T) {
+	tree := NewOrdered[int, int]()
+	for k := 10; k <= 100; k += 10 {
+		tree.Put(k, k/10)
+	}
+
+	if k, v, ok := tree.Floor(55); !ok || k != 50 || v != 5 {
+		t.Errorf("Floor(55): expected 50, got %d (%v)", k, ok)
+	}
+	if k, _, ok := tree.Floor(10); !ok || k != 10 {
+		t.Errorf("Floor(10): expected 10, got %d", k)
+	}
+	if _, _, ok := tree.Floor(5); ok {
+		t.Error("Floor(5): expected none")
+	}
+	if k, _, ok := tree.Ceiling(55); !ok || k != 60 {
+		t.Errorf("Ceiling(55): expected 60, got %d", k)
+	}
+	if _, _, ok := tree.Ceiling(101); ok {
+		t.Error("Ceiling(101): expected none")
+	}
+	if k, _, _ := tree.Min(); k != 10 {
+		t.Errorf("Expected min 10, got %d", k)
+	}
+	if k, _, _ := tree.Max(); k != 100 {
+		t.Errorf("Expected max 100, got %d", k)
+	}
+
+	for i, k := range tree.Keys() {
+		if r := tree.Rank(k); r != i {
+			t.Errorf("Rank(%d): expected %d, got %d", k, i, r)
+		}
+		if got, _, _ := tree.Select(i); got != k {
+			t.Errorf("Select(%d): expected %d, got %d", i, k, got)
+		}
+	}
+	if r := tree.Rank(55); r != 5 {
+		t.Errorf("Rank(55): expected 5, got %d", r)
+	}
+	if _, _, ok := tree.Select(10); ok {
+		t.Error("Select(10): expected none")
+	}
+
+	var keys []int
+	tree.RangeBetween(25, 70, func(k, _ int) bool {
+		keys = append(keys, k)
+		return true
+	})
+	if !slices.Equal(keys, []int{30, 40, 50, 60, 70}) {
+		t.Errorf("Expected [30 40 50 60 70], got %v", keys)
+	}
+	keys = keys[:0]
+	tree.Range(func(k, _ int) bool {
+		keys = append(keys, k)
+		return len(keys) < 3
+	})
+	if !slices.Equal(keys, []int{10, 20, 30}) {
+		t.Errorf("Expected Range to stop after 3 keys, got %v", keys)
+	}
+}
+
+func TestHeightBound(t *testing.T) {
+	// Sorted input is the worst case for an unbalanced tree
+	tree := NewOrdered[int, struct{}]()
+	const n = 1 << 14
+	for i := 0; i < n; i++ {
+		tree.Put(i, struct{}{})
+	}
+	bound := int(1.45 * math.Log2(n+2))
+	if h := tree.Height(); h > bound {
+		t.Errorf("Expected height at most %d, got %d", bound, h)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClone(t *testing.T) {
+	tree := NewOrdered[int, []int]()
+	for i := 0; i < 100; i++ {
+		tree.Put(i, []int{i})
+	}
+
+	clone := tree.Clone()
+	if clone.Height() != tree.Height() || !slices.Equal(clone.Keys(), tree.Keys()) {
+		t.Errorf("Expected the clone to match the original")
+	}
+	if err := clone.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	// Changes to either tree stay out of the other
+	clone.Delete(5)
+	tree.Put(500, nil)
+	if !tree.Contains(5) || clone.Contains(500) || clone.Len() != 99 {
+		t.Errorf("Expected independent trees, got sizes %d and %d", tree.Len(), clone.Len())
+	}
+
+	// CloneWith copies the values themselves
+	deep := tree.CloneWith(slices.Clone[[]int])
+	v, _ := deep.Get(1)
+	v[0] = -1
+	if orig, _ := tree.Get(1); orig[0] != 1 {
+		t.Errorf("Expected CloneWith to deep copy values, original now %v", orig)
+	}
+}
+
+func TestMatchesModel(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewOrdered[int, int]()
+	model := map[int]int{}
+
+	for i := 0; i < 4000; i++ {
+		k := rng.Intn(300)
+		if rng.Intn(3) == 0 {
+			_, existed := model[k]
+			if deleted := tree.Delete(k); deleted != existed {
+				t.Fatalf("Delete(%d): expected %v, got %v", k, existed, deleted)
+			}
+			delete(model, k)
+		} else {
+			_, existed := model[k]
+			if inserted := tree.Put(k, i); inserted == existed {
+				t.Fatalf("Put(%d): expected inserted %v", k, !existed)
+			}
+			model[k] = i
+		}
+
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("Step %d: %v", i, err)
+		}
+	}
+
+	if tree.Len() != len(model) {
+		t.Fatalf("Expected %d keys, got %d", len(model), tree.Len())
+	}
+	for k, v := range tree.All2() {
+		if model[k] != v {
+			t.Fatalf("Key %d: expected %d, got %d", k, model[k], v)
+		}
+	}
+	if !slices.IsSorted(tree.Keys()) {
+		t.Error("Expected keys in ascending order")
+	}
+}
+
+// Benchmark tests
+func BenchmarkPut(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewOrdered[int, int]()
+	for i := 0; i < b.N; i++ {
+		tree.Put(rng.Intn(1<<16), i)
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	tree := NewOrdered[int, int]()
+	for i := 0; i < 1<<16; i++ {
+		tree.Put(i, i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Get(i & (1<<16 - 1))
+	}
+}
@@ -0,0 +1,223 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/anwar-arif/golang-dsa/internal/wake"
+	"github.com/anwar-arif/golang-dsa/queue"
+)
+
+// ErrClosed is returned by Add once the batcher has shut down
+var ErrClosed = errors.New("batcher is closed")
+
+// entry remembers when an item arrived so its linger deadline is known
+type entry[T any] struct {
+	value T
+	at    time.Time
+}
+
+// Batcher collects items and hands them to a flush callback in batches
+// A batch is flushed as soon as it reaches the maximum size or its oldest item
+// has waited for the linger duration, whichever comes first
+// Flushes run one at a time on the batcher's own goroutine
+type Batcher[T any] struct {
+	mu      sync.Mutex
+	items   *queue.Queue[entry[T]]
+	closed  bool
+	maxSize int
+	linger  time.Duration
+	flush   func(batch []T)
+	kick    wake.Signal
+	closing chan struct{}
+	done    chan struct{}
+}
+
+// New starts a batcher that flushes at most maxSize items at a time and never
+// holds an item longer than linger
+// When ctx is cancelled the remaining items are flushed and Add starts failing
+func New[T any](ctx context.Context, maxSize int, linger time.Duration, flush func(batch []T)) *Batcher[T] {
+	if maxSize < 1 {
+		maxSize = 1
+	}
+
+	b := &Batcher[T]{
+		items:   queue.NewQueue[entry[T]](),
+		maxSize: maxSize,
+		linger:  linger,
+		flush:   flush,
+		kick:    wake.New(),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go b.run(ctx)
+	return b
+}
+
+// Add queues an item for the next batch
+func (b *Batcher[T]) Add(item T) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	b.items.Push(entry[T]{value: item, at: time.Now()})
+
+	// Wake the loop for the first item (to arm the timer) and for full batches
+	if n := b.items.Size(); n == 1 || n >= b.maxSize {
+		b.kick.Notify()
+	}
+	return nil
+}
+
+// take removes up to n items from the front of the queue
+func (b *Batcher[T]) take(n int) []T {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if size := b.items.Size(); size < n {
+		n = size
+	}
+
+	batch := make([]T, 0, n)
+	for i := 0; i < n; i++ {
+		e, _ := b.items.Pop()
+		batch = append(batch, e.value)
+	}
+	return batch
+}
+
+// run is the flushing loop
+func (b *Batcher[T]) run(ctx context.Context) {
+	defer close(b.done)
+
+	timer := time.NewTimer(b.linger)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		b.mu.Lock()
+		size := b.items.Size()
+		var oldest time.Time
+		if front, err := b.items.Front(); err == nil {
+			oldest = front.at
+		}
+		b.mu.Unlock()
+
+		if size >= b.maxSize {
+			b.flush(b.take(b.maxSize))
+			continue
+		}
+
+		var timeout <-chan time.Time
+		if size > 0 {
+			wait := b.linger - time.Since(oldest)
+			if wait <= 0 {
+				b.flush(b.take(b.maxSize))
+				continue
+			}
+			timer.Reset(wait)
+			timeout = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			b.shutdown()
+			return
+		case <-b.closing:
+			b.shutdown()
+			return
+		case <-b.kick:
+		case <-timeout:
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+	}
+}
+
+// shutdown rejects new items and flushes everything that is left
+func (b *Batcher[T]) shutdown() {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	for {
+		batch := b.take(b.maxSize)
+		if len(batch) == 0 {
+			return
+		}
+		b.flush(batch)
+	}
+}
+
+// Close flushes the remaining items and waits for the final flush to return
+func (b *Batcher[T]) Close() {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.closing)
+	}
+	b.mu.Unlock()
+
+	<-b.done
+}
+
+// Done returns a channel that is closed after the final flush
+func (b *Batcher[T]) Done() <-chan struct{} {
+	return b.done
+}
+
+// Len returns the number of items waiting for the next flush
+func (b *Batcher[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.items.Size()
+}
+
+// String returns a string representation of the batcher
+func (b *Batcher[T]) String() string {
+	return fmt.Sprintf("Batcher{pending: %d, maxSize: %d, linger: %v}", b.Len(), b.maxSize, b.linger)
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Batcher Examples ===")
+
+	// Example 1: Size-triggered flushes
+	fmt.Println("1. Flush Every 3 Items:")
+	b := New(context.Background(), 3, time.Hour, func(batch []int) {
+		fmt.Printf("  Flushed %v\n", batch)
+	})
+	for i := 1; i <= 7; i++ {
+		b.Add(i)
+	}
+	b.Close() // flushes the leftover [7]
+
+	// Example 2: Time-triggered flushes
+	fmt.Println("\n2. Flush After 50ms Linger:")
+	ctx, cancel := context.WithCancel(context.Background())
+	logs := New(ctx, 100, 50*time.Millisecond, func(batch []string) {
+		fmt.Printf("  Wrote %d log lines\n", len(batch))
+	})
+	logs.Add("starting")
+	logs.Add("ready")
+	time.Sleep(80 * time.Millisecond)
+
+	// Example 3: Context cancellation flushes what is left
+	fmt.Println("\n3. Shutdown:")
+	logs.Add("stopping")
+	cancel()
+	<-logs.Done()
+	fmt.Printf("  Add after shutdown: %v\n", logs.Add("late"))
+}
@@ -0,0 +1,179 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder collects flushed batches safely
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]int
+}
+
+func (r *recorder) flush(batch []int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, batch)
+}
+
+func (r *recorder) snapshot() [][]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]int(nil), r.batches...)
+}
+
+func TestFlushOnSize(t *testing.T) {
+	r := &recorder{}
+	b := New(context.Background(), 3, time.Hour, r.flush)
+
+	for i := 1; i <= 6; i++ {
+		if err := b.Add(i); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(r.snapshot()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	batches := r.snapshot()
+	if len(batches) != 2 {
+		t.Fatalf("Expected 2 batches, got %d", len(batches))
+	}
+
+	expected := [][]int{{1, 2, 3}, {4, 5, 6}}
+	for i, batch := range expected {
+		for j, v := range batch {
+			if batches[i][j] != v {
+				t.Errorf("Expected %d in batch %d position %d, got %d", v, i, j, batches[i][j])
+			}
+		}
+	}
+
+	b.Close()
+}
+
+func TestFlushOnLinger(t *testing.T) {
+	r := &recorder{}
+	b := New(context.Background(), 100, 30*time.Millisecond, r.flush)
+	defer b.Close()
+
+	start := time.Now()
+	b.Add(1)
+	b.Add(2)
+
+	deadline := time.Now().Add(time.Second)
+	for len(r.snapshot()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	elapsed := time.Since(start)
+
+	batches := r.snapshot()
+	if len(batches) != 1 || len(batches[0]) != 2 {
+		t.Fatalf("Expected one batch of 2, got %v", batches)
+	}
+	if elapsed < 25*time.Millisecond {
+		t.Errorf("Expected flush after the linger duration, got %v", elapsed)
+	}
+	if b.Len() != 0 {
+		t.Errorf("Expected no pending items, got %d", b.Len())
+	}
+}
+
+func TestCloseFlushesRemaining(t *testing.T) {
+	r := &recorder{}
+	b := New(context.Background(), 4, time.Hour, r.flush)
+
+	for i := 0; i < 10; i++ {
+		b.Add(i)
+	}
+	b.Close()
+
+	total := 0
+	for _, batch := range r.snapshot() {
+		if len(batch) > 4 {
+			t.Errorf("Batch exceeds max size: %v", batch)
+		}
+		total += len(batch)
+	}
+	if total != 10 {
+		t.Errorf("Expected 10 flushed items, got %d", total)
+	}
+
+	if err := b.Add(11); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+
+	// Closing twice is harmless
+	b.Close()
+}
+
+func TestContextCancelFlushes(t *testing.T) {
+	r := &recorder{}
+	ctx, cancel := context.WithCancel(context.Background())
+	b := New(ctx, 100, time.Hour, r.flush)
+
+	b.Add(1)
+	b.Add(2)
+	cancel()
+
+	select {
+	case <-b.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Batcher did not shut down on cancel")
+	}
+
+	batches := r.snapshot()
+	if len(batches) != 1 || len(batches[0]) != 2 {
+		t.Errorf("Expected remaining items flushed, got %v", batches)
+	}
+
+	if err := b.Add(3); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+}
+
+func TestConcurrentAdds(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	b := New(context.Background(), 16, 5*time.Millisecond, func(batch []int) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, v := range batch {
+			seen[v] = true
+		}
+	})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				b.Add(g*100 + i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	b.Close()
+
+	if len(seen) != 800 {
+		t.Errorf("Expected 800 distinct items, got %d", len(seen))
+	}
+}
+
+// Benchmark tests
+func BenchmarkAdd(b *testing.B) {
+	bt := New(context.Background(), 256, time.Millisecond, func(batch []int) {})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bt.Add(i)
+	}
+	bt.Close()
+}
@@ -0,0 +1,183 @@
+package boundedchan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/anwar-arif/golang-dsa/queue"
+)
+
+// ErrClosed is returned by sends after Close and by receives once the channel
+// is closed and drained
+var ErrClosed = errors.New("bounded channel is closed")
+
+// Stats is a point-in-time view of a bounded channel
+type Stats struct {
+	Len           int
+	Cap           int
+	HighWaterMark int   // deepest the buffer has been since creation or the last reset
+	Sent          int64 // values accepted
+	Received      int64 // values handed to receivers
+	Rejected      int64 // TrySend calls that found the buffer full
+}
+
+// BoundedChan is a bounded FIFO with channel-like send and receive operations
+// Unlike a raw channel it exposes its depth and high-water mark, and its
+// blocking operations take a context instead of needing a select
+// The buffer is a queue.BoundedQueue; BoundedChan adds the traffic counters
+type BoundedChan[T any] struct {
+	items    *queue.BoundedQueue[T]
+	sent     atomic.Int64
+	received atomic.Int64
+	rejected atomic.Int64
+}
+
+// New creates a bounded channel that buffers up to capacity values
+// A capacity below 1 is treated as 1
+func New[T any](capacity int) *BoundedChan[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &BoundedChan[T]{
+		items: queue.NewBoundedQueue[T](capacity, queue.WithFullPolicy(queue.BlockWhenFull)),
+	}
+}
+
+// TrySend adds value without blocking and reports whether there was room
+// It returns ErrClosed if the channel is closed
+func (c *BoundedChan[T]) TrySend(value T) (bool, error) {
+	switch err := c.items.TryPush(value); {
+	case errors.Is(err, queue.ErrFull):
+		c.rejected.Add(1)
+		return false, nil
+	case errors.Is(err, queue.ErrClosed):
+		return false, ErrClosed
+	}
+	c.sent.Add(1)
+	return true, nil
+}
+
+// SendCtx blocks until there is room for value, the channel is closed, or ctx is done
+func (c *BoundedChan[T]) SendCtx(ctx context.Context, value T) error {
+	if err := c.items.PushContext(ctx, value); err != nil {
+		if errors.Is(err, queue.ErrClosed) {
+			return ErrClosed
+		}
+		return err
+	}
+	c.sent.Add(1)
+	return nil
+}
+
+// TryReceive removes the front value without blocking
+// ok is false if the buffer is empty
+func (c *BoundedChan[T]) TryReceive() (value T, ok bool) {
+	value, err := c.items.Pop()
+	if err != nil {
+		return value, false
+	}
+	c.received.Add(1)
+	return value, true
+}
+
+// ReceiveCtx blocks until a value is available or ctx is done
+// Values buffered before Close are still delivered; after that it returns ErrClosed
+func (c *BoundedChan[T]) ReceiveCtx(ctx context.Context) (T, error) {
+	value, err := c.items.PopContext(ctx)
+	if err != nil {
+		if errors.Is(err, queue.ErrClosed) {
+			return value, ErrClosed
+		}
+		return value, err
+	}
+	c.received.Add(1)
+	return value, nil
+}
+
+// Close stops accepting values and wakes every blocked sender and receiver
+// Closing an already closed channel is a no-op
+func (c *BoundedChan[T]) Close() {
+	c.items.Close()
+}
+
+// Len returns the number of buffered values
+func (c *BoundedChan[T]) Len() int {
+	return c.items.Size()
+}
+
+// Cap returns the capacity of the buffer
+func (c *BoundedChan[T]) Cap() int {
+	return c.items.Cap()
+}
+
+// HighWaterMark returns the deepest the buffer has been
+func (c *BoundedChan[T]) HighWaterMark() int {
+	return c.items.HighWaterMark()
+}
+
+// ResetHighWaterMark sets the high-water mark back to the current depth
+func (c *BoundedChan[T]) ResetHighWaterMark() {
+	c.items.ResetHighWaterMark()
+}
+
+// Stats returns a snapshot of the channel's counters
+// The counters are read one at a time, so under concurrent use they may be
+// a few operations apart
+func (c *BoundedChan[T]) Stats() Stats {
+	return Stats{
+		Len:           c.items.Size(),
+		Cap:           c.items.Cap(),
+		HighWaterMark: c.items.HighWaterMark(),
+		Sent:          c.sent.Load(),
+		Received:      c.received.Load(),
+		Rejected:      c.rejected.Load(),
+	}
+}
+
+// String returns a string representation of the bounded channel
+func (c *BoundedChan[T]) String() string {
+	s := c.Stats()
+	return fmt.Sprintf("BoundedChan{len: %d, cap: %d, highWater: %d}", s.Len, s.Cap, s.HighWaterMark)
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Bounded Channel Examples ===")
+	ctx := context.Background()
+
+	// Example 1: Non-blocking sends report backpressure
+	fmt.Println("1. TrySend:")
+	ch := New[int](3)
+	for i := 1; i <= 5; i++ {
+		ok, _ := ch.TrySend(i)
+		fmt.Printf("  TrySend(%d): %t\n", i, ok)
+	}
+	fmt.Printf("  %v\n", ch)
+
+	// Example 2: Draining
+	fmt.Println("\n2. ReceiveCtx:")
+	for ch.Len() > 0 {
+		v, _ := ch.ReceiveCtx(ctx)
+		fmt.Printf("  Received %d\n", v)
+	}
+
+	// Example 3: Producer and consumer
+	fmt.Println("\n3. Producer/Consumer:")
+	go func() {
+		for i := 0; i < 10; i++ {
+			ch.SendCtx(ctx, i)
+		}
+		ch.Close()
+	}()
+	sum := 0
+	for {
+		v, err := ch.ReceiveCtx(ctx)
+		if err != nil {
+			break
+		}
+		sum += v
+	}
+	fmt.Printf("  Sum: %d, stats: %+v\n", sum, ch.Stats())
+}
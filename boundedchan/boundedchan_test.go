@@ -0,0 +1,198 @@
+package boundedchan
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTrySendAndReceive(t *testing.T) {
+	c := New[int](2)
+
+	for i := 1; i <= 2; i++ {
+		if ok, err := c.TrySend(i); !ok || err != nil {
+			t.Fatalf("Expected TrySend(%d) to succeed, got %t, %v", i, ok, err)
+		}
+	}
+	if ok, _ := c.TrySend(3); ok {
+		t.Error("Expected TrySend to fail on a full channel")
+	}
+
+	for i := 1; i <= 2; i++ {
+		if v, ok := c.TryReceive(); !ok || v != i {
+			t.Errorf("Expected %d, got %d (ok %t)", i, v, ok)
+		}
+	}
+	if _, ok := c.TryReceive(); ok {
+		t.Error("Expected TryReceive to fail on an empty channel")
+	}
+
+	s := c.Stats()
+	if s.Sent != 2 || s.Received != 2 || s.Rejected != 1 || s.HighWaterMark != 2 {
+		t.Errorf("Unexpected stats %+v", s)
+	}
+}
+
+func TestCapacityFloor(t *testing.T) {
+	if c := New[int](0); c.Cap() != 1 {
+		t.Errorf("Expected capacity 1, got %d", c.Cap())
+	}
+}
+
+func TestSendCtxBlocksWhenFull(t *testing.T) {
+	c := New[int](1)
+	c.TrySend(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := c.SendCtx(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+
+	sent := make(chan error)
+	go func() { sent <- c.SendCtx(context.Background(), 2) }()
+
+	time.Sleep(10 * time.Millisecond)
+	c.TryReceive()
+
+	select {
+	case err := <-sent:
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected blocked sender to proceed after a receive")
+	}
+}
+
+func TestReceiveCtxWaits(t *testing.T) {
+	c := New[string](1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.ReceiveCtx(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		c.TrySend("hello")
+	}()
+
+	v, err := c.ReceiveCtx(context.Background())
+	if err != nil || v != "hello" {
+		t.Errorf("Expected hello, got %q with error %v", v, err)
+	}
+}
+
+func TestClose(t *testing.T) {
+	c := New[int](3)
+	c.TrySend(1)
+	c.Close()
+	c.Close()
+
+	if _, err := c.TrySend(2); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed from TrySend, got %v", err)
+	}
+	if err := c.SendCtx(context.Background(), 2); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed from SendCtx, got %v", err)
+	}
+
+	if v, err := c.ReceiveCtx(context.Background()); err != nil || v != 1 {
+		t.Errorf("Expected buffered value 1, got %d with error %v", v, err)
+	}
+	if _, err := c.ReceiveCtx(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed once drained, got %v", err)
+	}
+}
+
+func TestCloseWakesReceivers(t *testing.T) {
+	c := New[int](1)
+	done := make(chan error)
+	go func() {
+		_, err := c.ReceiveCtx(context.Background())
+		done <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	c.Close()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrClosed) {
+			t.Errorf("Expected ErrClosed, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected Close to wake the receiver")
+	}
+}
+
+func TestHighWaterMark(t *testing.T) {
+	c := New[int](5)
+	for i := 0; i < 4; i++ {
+		c.TrySend(i)
+	}
+	c.TryReceive()
+	c.TryReceive()
+
+	if c.HighWaterMark() != 4 {
+		t.Errorf("Expected high-water mark 4, got %d", c.HighWaterMark())
+	}
+
+	c.ResetHighWaterMark()
+	if c.HighWaterMark() != 2 {
+		t.Errorf("Expected high-water mark 2 after reset, got %d", c.HighWaterMark())
+	}
+}
+
+func TestConcurrentProducersConsumers(t *testing.T) {
+	c := New[int](4)
+	ctx := context.Background()
+	const producers, perProducer = 4, 250
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				c.SendCtx(ctx, 1)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		c.Close()
+	}()
+
+	total := 0
+	for {
+		v, err := c.ReceiveCtx(ctx)
+		if err != nil {
+			break
+		}
+		total += v
+	}
+
+	if total != producers*perProducer {
+		t.Errorf("Expected %d values, got %d", producers*perProducer, total)
+	}
+	if c.HighWaterMark() > c.Cap() {
+		t.Errorf("High-water mark %d exceeds capacity %d", c.HighWaterMark(), c.Cap())
+	}
+}
+
+// Benchmark tests
+func BenchmarkSendReceive(b *testing.B) {
+	c := New[int](64)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.SendCtx(ctx, i)
+		c.ReceiveCtx(ctx)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/anwar-arif/golang-dsa/priorityqueue"
+	"github.com/anwar-arif/golang-dsa/queue"
+	"github.com/anwar-arif/golang-dsa/skiplist"
+	"github.com/anwar-arif/golang-dsa/stack"
+)
+
+// benchmark is a built-in workload that pushes and then removes size items
+type benchmark struct {
+	name string
+	fn   func(b *testing.B, size int)
+}
+
+var benchmarks = []benchmark{
+	{"stack/push-pop", func(b *testing.B, size int) {
+		for i := 0; i < b.N; i++ {
+			s := stack.NewStack[int]()
+			for j := 0; j < size; j++ {
+				s.Push(j)
+			}
+			for !s.IsEmpty() {
+				s.Pop()
+			}
+		}
+	}},
+	{"queue/push-pop", func(b *testing.B, size int) {
+		for i := 0; i < b.N; i++ {
+			q := queue.NewQueue[int]()
+			for j := 0; j < size; j++ {
+				q.Push(j)
+			}
+			for !q.IsEmpty() {
+				q.Pop()
+			}
+		}
+	}},
+	{"priorityqueue/push-pop", func(b *testing.B, size int) {
+		for i := 0; i < b.N; i++ {
+			pq := priorityqueue.NewOrderedMinQueue[int]()
+			for j := 0; j < size; j++ {
+				pq.Push((j * 7919) % size)
+			}
+			for !pq.IsEmpty() {
+				pq.Pop()
+			}
+		}
+	}},
+	{"skiplist/put-delete", func(b *testing.B, size int) {
+		for i := 0; i < b.N; i++ {
+			m := skiplist.NewOrderedConcurrentMap[int, int]()
+			for j := 0; j < size; j++ {
+				m.Put((j*7919)%size, j)
+			}
+			for j := 0; j < size; j++ {
+				m.Delete(j)
+			}
+		}
+	}},
+}
+
+func runBench(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	size := fs.Int("size", 1000, "number of items per operation")
+	filter := fs.String("filter", "", "only run benchmarks whose name contains this")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *size < 1 {
+		return fmt.Errorf("size must be positive, got %d", *size)
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "benchmark\tns/op\tns/item\tallocs/op\tB/op\t")
+
+	ran := 0
+	var baseline float64
+	for _, bm := range benchmarks {
+		if !strings.Contains(bm.name, *filter) {
+			continue
+		}
+
+		fn := bm.fn
+		r := testing.Benchmark(func(b *testing.B) {
+			b.ReportAllocs()
+			fn(b, *size)
+		})
+
+		perItem := float64(r.NsPerOp()) / float64(*size)
+		if baseline == 0 {
+			baseline = perItem
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%.1f (%.2fx)\t%d\t%d\t\n",
+			bm.name, r.NsPerOp(), perItem, perItem/baseline, r.AllocsPerOp(), r.AllocedBytesPerOp())
+		ran++
+	}
+	if ran == 0 {
+		return fmt.Errorf("no benchmark matches %q", *filter)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/anwar-arif/golang-dsa/compare"
+)
+
+// sortCSV sorts the data rows of a CSV by column, keeping the header first
+// Numeric sorting places cells that do not parse as numbers after all numbers
+// The sort is stable, so equal keys keep their input order
+func sortCSV(r io.Reader, w io.Writer, column string, numeric, desc bool) error {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("empty input")
+	}
+
+	header := records[0]
+	col := slices.Index(header, column)
+	if col == -1 {
+		if n, err := strconv.Atoi(column); err == nil && n >= 0 && n < len(header) {
+			col = n
+		} else {
+			return fmt.Errorf("unknown column %q", column)
+		}
+	}
+
+	cell := func(row []string) string {
+		if col < len(row) {
+			return row[col]
+		}
+		return ""
+	}
+
+	var byColumn compare.CompareFunc[[]string]
+	if numeric {
+		byColumn = func(a, b []string) int {
+			x, errA := strconv.ParseFloat(cell(a), 64)
+			y, errB := strconv.ParseFloat(cell(b), 64)
+			switch {
+			case errA != nil && errB != nil:
+				return compare.Ordered[string]()(cell(a), cell(b))
+			case errA != nil:
+				return 1
+			case errB != nil:
+				return -1
+			}
+			return compare.Ordered[float64]()(x, y)
+		}
+	} else {
+		byColumn = compare.By(cell)
+	}
+	if desc {
+		byColumn = compare.Reverse(byColumn)
+	}
+
+	rows := records[1:]
+	slices.SortStableFunc(rows, byColumn)
+
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(records); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func runSort(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("sort", flag.ContinueOnError)
+	file := fs.String("file", "", "CSV file with a header row (\"-\" for stdin)")
+	column := fs.String("column", "", "column name or zero-based index to sort by")
+	numeric := fs.Bool("numeric", false, "compare values as numbers")
+	desc := fs.Bool("desc", false, "sort in descending order")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" || *column == "" {
+		fs.Usage()
+		return errUsage
+	}
+
+	r := io.Reader(os.Stdin)
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	return sortCSV(r, stdout, *column, *numeric, *desc)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/anwar-arif/golang-dsa/algorithms/intervals"
+	"github.com/anwar-arif/golang-dsa/alloc"
+	"github.com/anwar-arif/golang-dsa/avl"
+	"github.com/anwar-arif/golang-dsa/batcher"
+	"github.com/anwar-arif/golang-dsa/boundedchan"
+	"github.com/anwar-arif/golang-dsa/codec"
+	"github.com/anwar-arif/golang-dsa/compare"
+	"github.com/anwar-arif/golang-dsa/counter"
+	"github.com/anwar-arif/golang-dsa/expr"
+	"github.com/anwar-arif/golang-dsa/fn"
+	"github.com/anwar-arif/golang-dsa/future"
+	"github.com/anwar-arif/golang-dsa/hashmap"
+	"github.com/anwar-arif/golang-dsa/hashutil"
+	"github.com/anwar-arif/golang-dsa/intervalset"
+	"github.com/anwar-arif/golang-dsa/linkedlist"
+	"github.com/anwar-arif/golang-dsa/mailbox"
+	"github.com/anwar-arif/golang-dsa/metrics"
+	"github.com/anwar-arif/golang-dsa/multimap"
+	"github.com/anwar-arif/golang-dsa/multiset"
+	"github.com/anwar-arif/golang-dsa/persist"
+	"github.com/anwar-arif/golang-dsa/persistent"
+	"github.com/anwar-arif/golang-dsa/pipeline"
+	"github.com/anwar-arif/golang-dsa/priorityqueue"
+	"github.com/anwar-arif/golang-dsa/pubsub"
+	"github.com/anwar-arif/golang-dsa/queue"
+	"github.com/anwar-arif/golang-dsa/ratelimit"
+	"github.com/anwar-arif/golang-dsa/scheduler"
+	"github.com/anwar-arif/golang-dsa/semaphore"
+	"github.com/anwar-arif/golang-dsa/singleflight"
+	"github.com/anwar-arif/golang-dsa/skiplist"
+	"github.com/anwar-arif/golang-dsa/sortedlist"
+	"github.com/anwar-arif/golang-dsa/sparseset"
+	"github.com/anwar-arif/golang-dsa/stack"
+	"github.com/anwar-arif/golang-dsa/stripedlock"
+	"github.com/anwar-arif/golang-dsa/timeseries"
+	"github.com/anwar-arif/golang-dsa/undo"
+	"github.com/anwar-arif/golang-dsa/viz"
+	"github.com/anwar-arif/golang-dsa/workerpool"
+)
+
+// demos maps a demo name to the package's ExampleUsage
+var demos = map[string]func(){
+	"alloc":         alloc.ExampleUsage,
+	"avl":           avl.ExampleUsage,
+	"batcher":       batcher.ExampleUsage,
+	"boundedchan":   boundedchan.ExampleUsage,
+	"codec":         codec.ExampleUsage,
+	"compare":       compare.ExampleUsage,
+	"counter":       counter.ExampleUsage,
+	"expr":          expr.ExampleUsage,
+	"fn":            fn.ExampleUsage,
+	"future":        future.ExampleUsage,
+	"hashmap":       hashmap.ExampleUsage,
+	"hashutil":      hashutil.ExampleUsage,
+	"intervals":     intervals.ExampleUsage,
+	"intervalset":   intervalset.ExampleUsage,
+	"linkedlist":    linkedlist.ExampleUsage,
+	"mailbox":       mailbox.ExampleUsage,
+	"metrics":       metrics.ExampleUsage,
+	"multimap":      multimap.ExampleUsage,
+	"multiset":      multiset.ExampleUsage,
+	"persist":       persist.ExampleUsage,
+	"persistent":    persistent.ExampleUsage,
+	"pipeline":      pipeline.ExampleUsage,
+	"priorityqueue": priorityqueue.ExampleUsage,
+	"pubsub":        pubsub.ExampleUsage,
+	"queue":         queue.ExampleUsage,
+	"ratelimit":     ratelimit.ExampleUsage,
+	"scheduler":     scheduler.ExampleUsage,
+	"semaphore":     semaphore.ExampleUsage,
+	"singleflight":  singleflight.ExampleUsage,
+	"skiplist":      skiplist.ExampleUsage,
+	"sortedlist":    sortedlist.ExampleUsage,
+	"sparseset":     sparseset.ExampleUsage,
+	"stack":         stack.ExampleUsage,
+	"stripedlock":   stripedlock.ExampleUsage,
+	"timeseries":    timeseries.ExampleUsage,
+	"undo":          undo.ExampleUsage,
+	"viz":           viz.ExampleUsage,
+	"workerpool":    workerpool.ExampleUsage,
+}
+
+func demoNames() []string {
+	names := make([]string, 0, len(demos))
+	for name := range demos {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runDemo runs the named demos, or lists them when none are given
+// The demos print straight to standard output
+func runDemo(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("demo", flag.ContinueOnError)
+	all := fs.Bool("all", false, "run every demo")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: dsa demo [-all] [name...]")
+		fmt.Fprintf(fs.Output(), "Demos: %s\n", strings.Join(demoNames(), ", "))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	names := fs.Args()
+	if *all {
+		names = demoNames()
+	}
+	if len(names) == 0 {
+		fmt.Fprintln(stdout, "Available demos:")
+		for _, name := range demoNames() {
+			fmt.Fprintf(stdout, "  %s\n", name)
+		}
+		return nil
+	}
+
+	for _, name := range names {
+		if _, ok := demos[name]; !ok {
+			return fmt.Errorf("unknown demo %q", name)
+		}
+	}
+	for i, name := range names {
+		if i > 0 {
+			fmt.Fprintln(stdout)
+		}
+		demos[name]()
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/anwar-arif/golang-dsa/priorityqueue"
+	"github.com/anwar-arif/golang-dsa/stack"
+)
+
+// graph is a weighted adjacency list with nodes numbered in order of appearance
+type graph struct {
+	names []string
+	ids   map[string]int
+	adj   [][]arc
+}
+
+type arc struct {
+	to     int
+	weight int
+}
+
+func newGraph() *graph {
+	return &graph{ids: make(map[string]int)}
+}
+
+func (g *graph) node(name string) int {
+	if id, ok := g.ids[name]; ok {
+		return id
+	}
+	id := len(g.names)
+	g.ids[name] = id
+	g.names = append(g.names, name)
+	g.adj = append(g.adj, nil)
+	return id
+}
+
+// edgeLine matches "a -> b [attrs]" and "a -- b [attrs]", with optional quotes
+var edgeLine = regexp.MustCompile(`^\s*("[^"]*"|[\w.]+)\s*(->|--)\s*("[^"]*"|[\w.]+)\s*(?:\[(.*)\])?\s*;?\s*$`)
+
+// weightAttr finds a weight= or label= attribute
+var weightAttr = regexp.MustCompile(`\b(?:weight|label)\s*=\s*"?(-?\d+)"?`)
+
+// parseDOT reads the edges of a DOT graph
+// Only edge statements are interpreted; the weight comes from a weight or
+// label attribute and defaults to 1. "--" edges are added in both directions
+func parseDOT(r io.Reader) (*graph, error) {
+	g := newGraph()
+	scanner := bufio.NewScanner(r)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		m := edgeLine.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+
+		weight := 1
+		if w := weightAttr.FindStringSubmatch(m[4]); w != nil {
+			weight, _ = strconv.Atoi(w[1])
+		}
+		if weight < 0 {
+			return nil, fmt.Errorf("line %d: negative weight %d", line, weight)
+		}
+
+		from := g.node(strings.Trim(m[1], `"`))
+		to := g.node(strings.Trim(m[3], `"`))
+		g.adj[from] = append(g.adj[from], arc{to: to, weight: weight})
+		if m[2] == "--" {
+			g.adj[to] = append(g.adj[to], arc{to: from, weight: weight})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(g.names) == 0 {
+		return nil, errors.New("no edges found")
+	}
+	return g, nil
+}
+
+// dijkstra returns the distance to every node from source and each node's
+// predecessor on a shortest path, using an indexed min priority queue whose
+// Set lowers the distance of a queued node in place (decrease-key)
+func dijkstra(g *graph, source int) (dist, prev []int) {
+	dist = make([]int, len(g.names))
+	prev = make([]int, len(g.names))
+	for i := range dist {
+		dist[i] = math.MaxInt
+		prev[i] = -1
+	}
+	dist[source] = 0
+
+	pq := priorityqueue.NewIndexedMinQueue[int](cmp.Compare[int])
+	pq.Push(source, 0)
+
+	for !pq.IsEmpty() {
+		id, distance, _ := pq.Pop()
+		for _, a := range g.adj[id] {
+			if d := distance + a.weight; d < dist[a.to] {
+				dist[a.to] = d
+				prev[a.to] = id
+				pq.Set(a.to, d) // decrease-key, or push if not queued yet
+			}
+		}
+	}
+	return dist, prev
+}
+
+// path rebuilds the route to target from the predecessor list
+func path(g *graph, prev []int, target int) []string {
+	s := stack.NewStack[string]()
+	for n := target; n != -1; n = prev[n] {
+		s.Push(g.names[n])
+	}
+
+	route := make([]string, 0, s.Size())
+	for v := range s.All() {
+		route = append(route, v)
+	}
+	return route
+}
+
+func runDijkstra(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("dijkstra", flag.ContinueOnError)
+	file := fs.String("file", "", "DOT file with weighted edges (\"-\" for stdin)")
+	from := fs.String("from", "", "source node")
+	to := fs.String("to", "", "target node; all nodes when empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" || *from == "" {
+		fs.Usage()
+		return errUsage
+	}
+
+	r := io.Reader(os.Stdin)
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	g, err := parseDOT(r)
+	if err != nil {
+		return err
+	}
+
+	source, ok := g.ids[*from]
+	if !ok {
+		return fmt.Errorf("unknown node %q", *from)
+	}
+	dist, prev := dijkstra(g, source)
+
+	targets := make([]int, 0, len(g.names))
+	if *to != "" {
+		target, ok := g.ids[*to]
+		if !ok {
+			return fmt.Errorf("unknown node %q", *to)
+		}
+		targets = append(targets, target)
+	} else {
+		for id := range g.names {
+			targets = append(targets, id)
+		}
+	}
+
+	for _, t := range targets {
+		if dist[t] == math.MaxInt {
+			fmt.Fprintf(stdout, "%s: unreachable\n", g.names[t])
+			continue
+		}
+		fmt.Fprintf(stdout, "%s: %d via %s\n", g.names[t], dist[t], strings.Join(path(g, prev, t), " -> "))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Command dsa runs demos, algorithm examples and benchmarks for the data
+// structures in this module
+//
+// Usage:
+//
+//	dsa demo [name...]
+//	dsa dijkstra -file graph.dot -from A [-to B]
+//	dsa sort -file data.csv -column name [-numeric] [-desc]
+//	dsa bench [-size N] [-filter substr]
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// command is a CLI subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdout io.Writer) error
+}
+
+var commands = []command{
+	{"demo", "run interactive demos of each structure", runDemo},
+	{"dijkstra", "shortest paths on a weighted DOT graph", runDijkstra},
+	{"sort", "sort a CSV file by one column", runSort},
+	{"bench", "run and compare built-in benchmarks", runBench},
+}
+
+// errUsage signals that usage has already been printed
+var errUsage = errors.New("usage")
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: dsa <command> [flags]")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-9s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(w, "\nRun 'dsa <command> -h' for command flags.")
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+
+	for _, c := range commands {
+		if c.name != args[0] {
+			continue
+		}
+		err := c.run(args[1:], stdout)
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp), errors.Is(err, errUsage):
+			return 2
+		default:
+			fmt.Fprintf(stderr, "dsa %s: %v\n", c.name, err)
+			return 1
+		}
+	}
+
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stdout)
+		return 0
+	}
+	fmt.Fprintf(stderr, "dsa: unknown command %q\n\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
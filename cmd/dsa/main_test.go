@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const sampleDOT = `digraph roads {
+  A -> B [label=4];
+  A -> C [weight=1];
+  C -> B [label="2"];
+  B -> D [label=5];
+  "E F" -- D;
+}
+`
+
+func TestParseDOT(t *testing.T) {
+	g, err := parseDOT(strings.NewReader(sampleDOT))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(g.names) != 5 {
+		t.Fatalf("Expected 5 nodes, got %d (%v)", len(g.names), g.names)
+	}
+	if _, ok := g.ids["E F"]; !ok {
+		t.Error("Expected quoted node name to be unquoted")
+	}
+	// The undirected edge is added both ways
+	if len(g.adj[g.ids["D"]]) != 1 || len(g.adj[g.ids["E F"]]) != 1 {
+		t.Error("Expected undirected edge in both directions")
+	}
+
+	if _, err := parseDOT(strings.NewReader("digraph {}")); err == nil {
+		t.Error("Expected error for a graph without edges")
+	}
+	if _, err := parseDOT(strings.NewReader("a -> b [weight=-1]")); err == nil {
+		t.Error("Expected error for a negative weight")
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	g, _ := parseDOT(strings.NewReader(sampleDOT))
+	dist, prev := dijkstra(g, g.ids["A"])
+
+	expected := map[string]int{"A": 0, "B": 3, "C": 1, "D": 8, "E F": 9}
+	for name, d := range expected {
+		if dist[g.ids[name]] != d {
+			t.Errorf("Expected distance %d to %s, got %d", d, name, dist[g.ids[name]])
+		}
+	}
+
+	if route := strings.Join(path(g, prev, g.ids["D"]), " "); route != "A C B D" {
+		t.Errorf("Expected route A C B D, got %s", route)
+	}
+}
+
+func TestSortCSV(t *testing.T) {
+	input := "name,score\nbob,10\nann,9\ncid,x\ndan,100\n"
+
+	tests := []struct {
+		column   string
+		numeric  bool
+		desc     bool
+		expected string
+	}{
+		{"name", false, false, "name,score\nann,9\nbob,10\ncid,x\ndan,100\n"},
+		{"score", false, false, "name,score\nbob,10\ndan,100\nann,9\ncid,x\n"},
+		{"score", true, false, "name,score\nann,9\nbob,10\ndan,100\ncid,x\n"},
+		{"1", true, true, "name,score\ncid,x\ndan,100\nbob,10\nann,9\n"},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		if err := sortCSV(strings.NewReader(input), &out, tt.column, tt.numeric, tt.desc); err != nil {
+			t.Errorf("sortCSV(%s) unexpected error: %v", tt.column, err)
+			continue
+		}
+		if out.String() != tt.expected {
+			t.Errorf("sortCSV(%s, numeric=%t, desc=%t) =\n%s\nexpected\n%s", tt.column, tt.numeric, tt.desc, out.String(), tt.expected)
+		}
+	}
+
+	if err := sortCSV(strings.NewReader(input), &bytes.Buffer{}, "missing", false, false); err == nil {
+		t.Error("Expected error for an unknown column")
+	}
+}
+
+func TestRun(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := run(nil, &stdout, &stderr); code != 2 {
+		t.Errorf("Expected exit code 2 without arguments, got %d", code)
+	}
+	if code := run([]string{"nope"}, &stdout, &stderr); code != 2 || !strings.Contains(stderr.String(), "unknown command") {
+		t.Errorf("Expected unknown command error, got %d: %s", code, stderr.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"demo"}, &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "priorityqueue") {
+		t.Errorf("Expected demo listing, got %d: %s", code, stdout.String())
+	}
+	if code := run([]string{"demo", "missing"}, &stdout, &stderr); code != 1 {
+		t.Errorf("Expected exit code 1 for an unknown demo, got %d", code)
+	}
+	if code := run([]string{"bench", "-filter", "nothing-matches"}, &stdout, &stderr); code != 1 {
+		t.Errorf("Expected exit code 1 when no benchmark matches, got %d", code)
+	}
+}
@@ -0,0 +1,267 @@
+// Package codec defines pluggable encoders and decoders used by the data
+// structures in this module to save and load their contents
+package codec
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrTooLarge is returned when a length prefix exceeds the decoder's limit
+var ErrTooLarge = errors.New("codec: length prefix too large")
+
+// DefaultMaxFrame bounds a length prefix read by String and Slice so corrupt
+// input cannot force a huge allocation; WithMaxFrame changes it
+const DefaultMaxFrame = 16 << 20
+
+// Option configures the framed codecs String and Slice
+type Option func(*options)
+
+type options struct {
+	maxFrame uint64
+}
+
+func buildOptions(opts []Option) options {
+	o := options{maxFrame: DefaultMaxFrame}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithMaxFrame sets the largest length prefix a decoder accepts: the byte
+// length of a string or element, and the element count of a slice
+// Panics if n is negative
+func WithMaxFrame(n int) Option {
+	if n < 0 {
+		panic(fmt.Sprintf("codec: max frame must not be negative, got %d", n))
+	}
+	return func(o *options) { o.maxFrame = uint64(n) }
+}
+
+// Encoder writes values of type T to a stream
+type Encoder[T any] interface {
+	Encode(w io.Writer, v T) error
+}
+
+// Decoder reads values of type T from a stream
+// Decoders may read past the end of the value unless r implements io.ByteReader
+type Decoder[T any] interface {
+	Decode(r io.Reader) (T, error)
+}
+
+// Codec is both an Encoder and a Decoder for the same type
+type Codec[T any] interface {
+	Encoder[T]
+	Decoder[T]
+}
+
+// funcCodec adapts a pair of functions to Codec
+type funcCodec[T any] struct {
+	encode func(w io.Writer, v T) error
+	decode func(r io.Reader) (T, error)
+}
+
+func (c funcCodec[T]) Encode(w io.Writer, v T) error { return c.encode(w, v) }
+func (c funcCodec[T]) Decode(r io.Reader) (T, error) { return c.decode(r) }
+
+// Funcs builds a codec from an encode and a decode function
+func Funcs[T any](encode func(w io.Writer, v T) error, decode func(r io.Reader) (T, error)) Codec[T] {
+	return funcCodec[T]{encode: encode, decode: decode}
+}
+
+// JSON encodes values as JSON documents
+func JSON[T any]() Codec[T] {
+	return Funcs(
+		func(w io.Writer, v T) error {
+			return json.NewEncoder(w).Encode(v)
+		},
+		func(r io.Reader) (T, error) {
+			var v T
+			err := json.NewDecoder(r).Decode(&v)
+			return v, err
+		},
+	)
+}
+
+// Gob encodes values with encoding/gob
+func Gob[T any]() Codec[T] {
+	return Funcs(
+		func(w io.Writer, v T) error {
+			return gob.NewEncoder(w).Encode(v)
+		},
+		func(r io.Reader) (T, error) {
+			var v T
+			err := gob.NewDecoder(r).Decode(&v)
+			return v, err
+		},
+	)
+}
+
+// Binary encodes fixed-size values with encoding/binary in the given byte order
+// T must be a fixed-size type such as int32, float64 or a struct of them;
+// int, string and slices are rejected with an error
+func Binary[T any](order binary.ByteOrder) Codec[T] {
+	return Funcs(
+		func(w io.Writer, v T) error {
+			return binary.Write(w, order, v)
+		},
+		func(r io.Reader) (T, error) {
+			var v T
+			err := binary.Read(r, order, &v)
+			return v, err
+		},
+	)
+}
+
+// String encodes strings as a uvarint length followed by the raw bytes
+func String(opts ...Option) Codec[string] {
+	o := buildOptions(opts)
+	return Funcs(
+		func(w io.Writer, v string) error {
+			return writeFrame(w, []byte(v))
+		},
+		func(r io.Reader) (string, error) {
+			b, err := readFrame(r, o.maxFrame)
+			return string(b), err
+		},
+	)
+}
+
+// Slice encodes a slice as a uvarint count followed by each element framed
+// with its length, so element codecs that read ahead (such as JSON) can be
+// combined safely
+func Slice[T any](elem Codec[T], opts ...Option) Codec[[]T] {
+	o := buildOptions(opts)
+	return Funcs(
+		func(w io.Writer, values []T) error {
+			if err := writeUvarint(w, uint64(len(values))); err != nil {
+				return err
+			}
+			var buf bytes.Buffer
+			for _, v := range values {
+				buf.Reset()
+				if err := elem.Encode(&buf, v); err != nil {
+					return err
+				}
+				if err := writeFrame(w, buf.Bytes()); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		func(r io.Reader) ([]T, error) {
+			br := byteReader(r)
+			n, err := binary.ReadUvarint(br)
+			if err != nil {
+				return nil, err
+			}
+			if n > o.maxFrame {
+				return nil, ErrTooLarge
+			}
+
+			values := make([]T, 0, min(n, 1024))
+			for i := uint64(0); i < n; i++ {
+				frame, err := readFrame(br, o.maxFrame)
+				if err != nil {
+					return nil, err
+				}
+				v, err := elem.Decode(bytes.NewReader(frame))
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, v)
+			}
+			return values, nil
+		},
+	)
+}
+
+// Marshal encodes v into a byte slice
+func Marshal[T any](enc Encoder[T], v T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := enc.Encode(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes a value from data
+func Unmarshal[T any](dec Decoder[T], data []byte) (T, error) {
+	return dec.Decode(bytes.NewReader(data))
+}
+
+// byteReader returns r as an io.ByteReader, wrapping it only when needed
+func byteReader(r io.Reader) interface {
+	io.Reader
+	io.ByteReader
+} {
+	if br, ok := r.(interface {
+		io.Reader
+		io.ByteReader
+	}); ok {
+		return br
+	}
+	return bufio.NewReader(r)
+}
+
+func writeUvarint(w io.Writer, n uint64) error {
+	var buf [binary.MaxVarintLen64]byte
+	_, err := w.Write(buf[:binary.PutUvarint(buf[:], n)])
+	return err
+}
+
+// writeFrame writes b prefixed with its length
+func writeFrame(w io.Writer, b []byte) error {
+	if err := writeUvarint(w, uint64(len(b))); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+// readFrame reads a length-prefixed byte slice of at most limit bytes
+func readFrame(r io.Reader, limit uint64) ([]byte, error) {
+	br := byteReader(r)
+	n, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+	if n > limit {
+		return nil, ErrTooLarge
+	}
+
+	b := make([]byte, n)
+	if _, err := io.ReadFull(br, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Codec Examples ===")
+
+	// Example 1: JSON
+	fmt.Println("1. JSON:")
+	data, _ := Marshal(JSON[[]int](), []int{1, 2, 3})
+	fmt.Printf("  Encoded: %s", data)
+
+	// Example 2: Fixed-size binary
+	fmt.Println("\n2. Binary:")
+	data, _ = Marshal(Binary[int32](binary.LittleEndian), 258)
+	fmt.Printf("  Encoded: %v\n", data)
+
+	// Example 3: Composing codecs
+	fmt.Println("\n3. Slice of Strings:")
+	words := Slice(String())
+	data, _ = Marshal(words, []string{"go", "dsa"})
+	decoded, _ := Unmarshal(words, data)
+	fmt.Printf("  %d bytes -> %v\n", len(data), decoded)
+}
@@ -0,0 +1,167 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type point struct {
+	X, Y int32
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	c := JSON[map[string]int]()
+
+	data, err := Marshal(c, map[string]int{"a": 1, "b": 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	v, err := Unmarshal(c, data)
+	if err != nil || v["a"] != 1 || v["b"] != 2 {
+		t.Errorf("Unexpected round trip %v with error %v", v, err)
+	}
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	c := Gob[[]string]()
+
+	data, err := Marshal(c, []string{"x", "y"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	v, err := Unmarshal(c, data)
+	if err != nil || !slices.Equal(v, []string{"x", "y"}) {
+		t.Errorf("Unexpected round trip %v with error %v", v, err)
+	}
+}
+
+func TestBinary(t *testing.T) {
+	c := Binary[point](binary.BigEndian)
+
+	data, err := Marshal(c, point{X: 1, Y: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bytes.Equal(data, []byte{0, 0, 0, 1, 0, 0, 0, 2}) {
+		t.Errorf("Unexpected encoding %v", data)
+	}
+
+	v, err := Unmarshal(c, data)
+	if err != nil || v != (point{1, 2}) {
+		t.Errorf("Unexpected round trip %v with error %v", v, err)
+	}
+
+	if _, err := Marshal(Binary[int](binary.LittleEndian), 1); err == nil {
+		t.Error("Expected error for a type without a fixed size")
+	}
+}
+
+func TestSliceOfJSON(t *testing.T) {
+	c := Slice(JSON[point]())
+	values := []point{{1, 2}, {3, 4}, {5, 6}}
+
+	var buf bytes.Buffer
+	if err := c.Encode(&buf, values); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// A trailing value must still be readable after the slice
+	String().Encode(&buf, "tail")
+
+	r := bytes.NewReader(buf.Bytes())
+	got, err := c.Decode(r)
+	if err != nil || !slices.Equal(got, values) {
+		t.Errorf("Unexpected round trip %v with error %v", got, err)
+	}
+
+	tail, err := String().Decode(r)
+	if err != nil || tail != "tail" {
+		t.Errorf("Expected tail, got %q with error %v", tail, err)
+	}
+}
+
+func TestSliceEmpty(t *testing.T) {
+	c := Slice(String())
+
+	data, _ := Marshal(c, nil)
+	got, err := Unmarshal(c, data)
+	if err != nil || len(got) != 0 {
+		t.Errorf("Expected empty slice, got %v with error %v", got, err)
+	}
+}
+
+func TestTruncatedInput(t *testing.T) {
+	c := Slice(String())
+	data, _ := Marshal(c, []string{"hello", "world"})
+
+	if _, err := Unmarshal(c, data[:len(data)-2]); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected unexpected EOF, got %v", err)
+	}
+}
+
+func TestTooLarge(t *testing.T) {
+	var buf bytes.Buffer
+	writeUvarint(&buf, DefaultMaxFrame+1)
+
+	if _, err := String().Decode(&buf); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge, got %v", err)
+	}
+}
+
+func TestWithMaxFrame(t *testing.T) {
+	data, _ := Marshal(Slice(String()), []string{"ab", "abcdef"})
+
+	// The second string is longer than the limit
+	if _, err := Unmarshal(Slice(String(WithMaxFrame(4))), data); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge for a long element, got %v", err)
+	}
+	// The element count is limited too
+	if _, err := Unmarshal(Slice(String(), WithMaxFrame(1)), data); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge for too many elements, got %v", err)
+	}
+	if got, err := Unmarshal(Slice(String(WithMaxFrame(6))), data); err != nil || len(got) != 2 {
+		t.Errorf("Expected both elements within the limit, got %v with error %v", got, err)
+	}
+}
+
+func TestFuncs(t *testing.T) {
+	upper := Funcs(
+		func(w io.Writer, v string) error {
+			_, err := io.WriteString(w, strings.ToUpper(v))
+			return err
+		},
+		func(r io.Reader) (string, error) {
+			b, err := io.ReadAll(r)
+			return strings.ToLower(string(b)), err
+		},
+	)
+
+	data, _ := Marshal(upper, "abc")
+	if string(data) != "ABC" {
+		t.Errorf("Expected ABC, got %s", data)
+	}
+	if v, _ := Unmarshal(upper, data); v != "abc" {
+		t.Errorf("Expected abc, got %s", v)
+	}
+}
+
+// Benchmark tests
+func BenchmarkSliceBinary(b *testing.B) {
+	c := Slice(Binary[int64](binary.LittleEndian))
+	values := make([]int64, 1000)
+	for i := range values {
+		values[i] = int64(i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		data, _ := Marshal(c, values)
+		Unmarshal(c, data)
+	}
+}
@@ -0,0 +1,46 @@
+// Package collections defines the interfaces shared by the data structures in
+// this module, so generic helpers and tests can work with any of them
+package collections
+
+import (
+	"encoding"
+	"iter"
+)
+
+// Container is implemented by every structure that holds a number of elements
+type Container interface {
+	// Size returns the number of elements
+	Size() int
+	// IsEmpty reports whether Size is zero
+	IsEmpty() bool
+	// Clear removes every element
+	Clear()
+}
+
+// Iterable is implemented by structures whose elements can be ranged over
+// Each implementation documents the order All yields elements in
+type Iterable[T any] interface {
+	All() iter.Seq[T]
+}
+
+// Collection is a Container whose elements can be ranged over
+type Collection[T any] interface {
+	Container
+	Iterable[T]
+}
+
+// Serializable is implemented by structures that can be saved to and restored
+// from bytes
+// UnmarshalBinary replaces the receiver's contents and keeps any configuration
+// that is not serialized, such as a compare function
+type Serializable interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+// Cloner is implemented by structures that can copy themselves
+// Clone returns a copy that can be modified without affecting the original;
+// elements are copied by assignment, so pointers inside them are shared
+type Cloner[T any] interface {
+	Clone() T
+}
@@ -0,0 +1,43 @@
+package collections
+
+import "iter"
+
+// Collect gathers the values of seq into a slice, in iteration order
+func Collect[T any](seq iter.Seq[T]) []T {
+	var result []T
+	for v := range seq {
+		result = append(result, v)
+	}
+	return result
+}
+
+// Count returns the number of values yielded by seq
+func Count[T any](seq iter.Seq[T]) int {
+	n := 0
+	for range seq {
+		n++
+	}
+	return n
+}
+
+// Any reports whether pred holds for at least one value of seq
+// It stops at the first match
+func Any[T any](seq iter.Seq[T], pred func(T) bool) bool {
+	for v := range seq {
+		if pred(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// All reports whether pred holds for every value of seq
+// It stops at the first mismatch and returns true for an empty sequence
+func All[T any](seq iter.Seq[T], pred func(T) bool) bool {
+	for v := range seq {
+		if !pred(v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCollect(t *testing.T) {
+	got := Collect(slices.Values([]int{3, 1, 2}))
+	if !slices.Equal(got, []int{3, 1, 2}) {
+		t.Errorf("Expected [3 1 2], got %v", got)
+	}
+
+	if got := Collect(slices.Values([]int{})); len(got) != 0 {
+		t.Errorf("Expected empty slice, got %v", got)
+	}
+}
+
+func TestCount(t *testing.T) {
+	if n := Count(slices.Values([]string{"a", "b", "c"})); n != 3 {
+		t.Errorf("Expected 3, got %d", n)
+	}
+}
+
+func TestAnyAll(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+
+	tests := []struct {
+		values []int
+		any    bool
+		all    bool
+	}{
+		{[]int{1, 3, 5}, false, false},
+		{[]int{1, 2, 3}, true, false},
+		{[]int{2, 4, 6}, true, true},
+		{[]int{}, false, true},
+	}
+
+	for _, tt := range tests {
+		if got := Any(slices.Values(tt.values), even); got != tt.any {
+			t.Errorf("Any(%v) = %t, expected %t", tt.values, got, tt.any)
+		}
+		if got := All(slices.Values(tt.values), even); got != tt.all {
+			t.Errorf("All(%v) = %t, expected %t", tt.values, got, tt.all)
+		}
+	}
+}
+
+func TestAnyStopsEarly(t *testing.T) {
+	visited := 0
+	seq := func(yield func(int) bool) {
+		for i := 0; i < 100; i++ {
+			visited++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	Any(seq, func(n int) bool { return n == 2 })
+	if visited != 3 {
+		t.Errorf("Expected Any to stop after 3 values, visited %d", visited)
+	}
+}
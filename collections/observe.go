@@ -0,0 +1,76 @@
+package collections
+
+import "slices"
+
+// Op identifies the kind of change reported to mutation observers
+type Op int
+
+const (
+	OpPush   Op = iota // a value was added
+	OpPop              // a value was removed from the front, top or head
+	OpRemove           // a specific value was removed
+	OpUpdate           // a value changed in place
+	OpClear            // every value was removed; the reported value is the zero value
+)
+
+var opNames = [...]string{"push", "pop", "remove", "update", "clear"}
+
+// String returns the lower-case name of the operation
+func (op Op) String() string {
+	if op < 0 || int(op) >= len(opNames) {
+		return "unknown"
+	}
+	return opNames[op]
+}
+
+// Observers is a list of mutation callbacks that structures embed to support
+// OnMutate; the zero value is ready to use
+// Callbacks run synchronously on the mutating goroutine, after the change
+// has been applied, and must not mutate the structure they observe
+type Observers[T any] struct {
+	fns    []observer[T]
+	nextID uint64
+}
+
+// observer is a registered callback with the id its remove function looks for
+type observer[T any] struct {
+	id uint64
+	fn func(op Op, value T)
+}
+
+// Add registers fn and returns a function that unregisters it
+func (o *Observers[T]) Add(fn func(op Op, value T)) (remove func()) {
+	o.nextID++
+	id := o.nextID
+	o.fns = append(o.fns, observer[T]{id: id, fn: fn})
+	return func() {
+		i := slices.IndexFunc(o.fns, func(ob observer[T]) bool { return ob.id == id })
+		if i < 0 {
+			return
+		}
+		// Build a new slice so a Notify in progress keeps iterating the old one
+		o.fns = slices.Concat(o.fns[:i], o.fns[i+1:])
+	}
+}
+
+// AddOp registers fn for changes of kind op only and returns a function that
+// unregisters it
+func (o *Observers[T]) AddOp(op Op, fn func(value T)) (remove func()) {
+	return o.Add(func(got Op, value T) {
+		if got == op {
+			fn(value)
+		}
+	})
+}
+
+// Notify calls every registered callback
+func (o *Observers[T]) Notify(op Op, value T) {
+	for _, ob := range o.fns {
+		ob.fn(op, value)
+	}
+}
+
+// Len returns the number of registered callbacks
+func (o *Observers[T]) Len() int {
+	return len(o.fns)
+}
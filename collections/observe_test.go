@@ -0,0 +1,87 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOpString(t *testing.T) {
+	if OpPush.String() != "push" || OpClear.String() != "clear" || Op(42).String() != "unknown" {
+		t.Error("Unexpected Op names")
+	}
+}
+
+func TestObservers(t *testing.T) {
+	var o Observers[int]
+	var log []string
+
+	removeFirst := o.Add(func(op Op, v int) { log = append(log, op.String()) })
+	o.Add(func(op Op, v int) {
+		if v != 7 {
+			t.Errorf("Expected value 7, got %d", v)
+		}
+	})
+
+	o.Notify(OpPush, 7)
+	if len(log) != 1 || log[0] != "push" {
+		t.Errorf("Expected [push], got %v", log)
+	}
+
+	removeFirst()
+	removeFirst()
+	o.Notify(OpPop, 7)
+	if len(log) != 1 {
+		t.Errorf("Expected removed observer not to run, got %v", log)
+	}
+	if o.Len() != 1 {
+		t.Errorf("Expected 1 observer, got %d", o.Len())
+	}
+}
+
+func TestObserversAddOp(t *testing.T) {
+	var o Observers[int]
+	var pushed, popped []int
+	o.AddOp(OpPush, func(v int) { pushed = append(pushed, v) })
+	removePop := o.AddOp(OpPop, func(v int) { popped = append(popped, v) })
+
+	o.Notify(OpPush, 1)
+	o.Notify(OpPop, 2)
+	o.Notify(OpClear, 0)
+	removePop()
+	o.Notify(OpPop, 3)
+
+	if len(pushed) != 1 || pushed[0] != 1 {
+		t.Errorf("Expected pushes [1], got %v", pushed)
+	}
+	if len(popped) != 1 || popped[0] != 2 {
+		t.Errorf("Expected pops [2], got %v", popped)
+	}
+}
+
+func TestObserversRemoveCompacts(t *testing.T) {
+	var o Observers[int]
+	var log []int
+	o.Add(func(op Op, v int) { log = append(log, 1) })
+
+	for i := 0; i < 100; i++ {
+		o.Add(func(op Op, v int) {})()
+	}
+	if len(o.fns) != 1 {
+		t.Errorf("Expected removed observers to be dropped, got %d slots", len(o.fns))
+	}
+
+	// An observer that removes itself while being notified does not make
+	// Notify skip the next one
+	var removeSelf func()
+	removeSelf = o.Add(func(op Op, v int) { log = append(log, 2); removeSelf() })
+	o.Add(func(op Op, v int) { log = append(log, 3) })
+	o.Notify(OpPush, 0)
+	o.Notify(OpPush, 0)
+
+	if want := []int{1, 2, 3, 1, 3}; !slices.Equal(log, want) {
+		t.Errorf("Expected calls %v, got %v", want, log)
+	}
+	if o.Len() != 2 {
+		t.Errorf("Expected 2 observers, got %d", o.Len())
+	}
+}
@@ -0,0 +1,54 @@
+package collections
+
+// Stats is a snapshot of a container's activity since it was created, shaped
+// for export to a metrics system such as Prometheus
+type Stats struct {
+	Size     int   // values held now
+	PeakSize int   // most values held at once
+	Pushes   int64 // values added
+	Pops     int64 // values taken from the front, top or head
+	Allocs   int64 // nodes or items the container allocated for its values
+}
+
+// StatsReporter is implemented by containers that keep Stats
+type StatsReporter interface {
+	Stats() Stats
+}
+
+// StatsCounter accumulates Stats for a container that embeds it; the zero
+// value is ready to use
+// The counters cost a few increments per operation and are never reset, so
+// Clear and Load do not erase a container's history
+type StatsCounter struct {
+	peak   int
+	pushes int64
+	pops   int64
+	allocs int64
+}
+
+// Push records n values added, after which the container holds size values
+func (c *StatsCounter) Push(n, size int) {
+	c.pushes += int64(n)
+	c.peak = max(c.peak, size)
+}
+
+// Pop records n values taken
+func (c *StatsCounter) Pop(n int) {
+	c.pops += int64(n)
+}
+
+// Alloc records n allocations
+func (c *StatsCounter) Alloc(n int) {
+	c.allocs += int64(n)
+}
+
+// Snapshot returns the accumulated Stats for a container holding size values
+func (c *StatsCounter) Snapshot(size int) Stats {
+	return Stats{
+		Size:     size,
+		PeakSize: max(c.peak, size),
+		Pushes:   c.pushes,
+		Pops:     c.pops,
+		Allocs:   c.allocs,
+	}
+}
@@ -0,0 +1,19 @@
+package collections
+
+import "testing"
+
+func TestStatsCounter(t *testing.T) {
+	var c StatsCounter
+	c.Push(1, 1)
+	c.Alloc(1)
+	c.Push(2, 3)
+	c.Pop(3)
+
+	expected := Stats{Size: 0, PeakSize: 3, Pushes: 3, Pops: 3, Allocs: 1}
+	if got := c.Snapshot(0); got != expected {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+	if got := c.Snapshot(5).PeakSize; got != 5 {
+		t.Errorf("Expected the current size to raise the peak to 5, got %d", got)
+	}
+}
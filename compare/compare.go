@@ -0,0 +1,119 @@
+// Package compare provides the comparison function type used across this
+// module together with helpers to build and combine comparators
+package compare
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// CompareFunc defines a comparison function type
+// Returns:
+//
+//	-1 if a < b
+//	 0 if a == b
+//	 1 if a > b
+//
+// Any negative or positive number is accepted in place of -1 or 1
+type CompareFunc[T any] func(a, b T) int
+
+// Ordered returns the natural ascending comparator for an ordered type
+// NaN sorts before every other float, as with cmp.Compare
+func Ordered[T cmp.Ordered]() CompareFunc[T] {
+	return cmp.Compare[T]
+}
+
+// Reverse returns a comparator that orders the opposite way to c
+func Reverse[T any](c CompareFunc[T]) CompareFunc[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// By compares values by an ordered key extracted from each of them
+func By[T any, K cmp.Ordered](key func(T) K) CompareFunc[T] {
+	return func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// ByFunc compares values by a key extracted from each of them, using c to
+// compare the keys
+func ByFunc[T, K any](key func(T) K, c CompareFunc[K]) CompareFunc[T] {
+	return func(a, b T) int {
+		return c(key(a), key(b))
+	}
+}
+
+// Then returns a comparator that uses first and breaks ties with each of
+// rest in turn
+func Then[T any](first CompareFunc[T], rest ...CompareFunc[T]) CompareFunc[T] {
+	return func(a, b T) int {
+		if c := first(a, b); c != 0 {
+			return c
+		}
+		for _, next := range rest {
+			if c := next(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+}
+
+// Min returns the smaller of a and b according to c, preferring a on ties
+func Min[T any](c CompareFunc[T], a, b T) T {
+	if c(b, a) < 0 {
+		return b
+	}
+	return a
+}
+
+// Max returns the larger of a and b according to c, preferring a on ties
+func Max[T any](c CompareFunc[T], a, b T) T {
+	if c(b, a) > 0 {
+		return b
+	}
+	return a
+}
+
+// Equal returns a predicate reporting whether two values compare as equal under c
+func Equal[T any](c CompareFunc[T]) func(a, b T) bool {
+	return func(a, b T) bool {
+		return c(a, b) == 0
+	}
+}
+
+// Less returns a predicate reporting whether a sorts before b under c, in the
+// shape expected by sort.Slice style APIs
+func Less[T any](c CompareFunc[T]) func(a, b T) bool {
+	return func(a, b T) bool {
+		return c(a, b) < 0
+	}
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Compare Examples ===")
+
+	type Person struct {
+		Name string
+		Age  int
+	}
+	people := []Person{{"Ann", 30}, {"Bob", 25}, {"Cid", 30}}
+
+	// Example 1: Natural and reversed order
+	fmt.Println("1. Ordered and Reverse:")
+	ints := Ordered[int]()
+	fmt.Printf("  Ordered(1, 2) = %d, Reverse(1, 2) = %d\n", ints(1, 2), Reverse(ints)(1, 2))
+
+	// Example 2: Comparing by a key
+	fmt.Println("\n2. By Key:")
+	byAge := By(func(p Person) int { return p.Age })
+	fmt.Printf("  Youngest of Ann and Bob: %s\n", Min(byAge, people[0], people[1]).Name)
+
+	// Example 3: Tie-breaking
+	fmt.Println("\n3. Then:")
+	byAgeDescThenName := Then(Reverse(byAge), By(func(p Person) string { return p.Name }))
+	fmt.Printf("  Ann vs Cid: %d\n", byAgeDescThenName(people[0], people[2]))
+}
@@ -0,0 +1,100 @@
+package compare
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+func TestOrdered(t *testing.T) {
+	ints := Ordered[int]()
+	if ints(1, 2) >= 0 || ints(2, 1) <= 0 || ints(2, 2) != 0 {
+		t.Error("Unexpected int ordering")
+	}
+
+	strs := Ordered[string]()
+	if strs("apple", "banana") >= 0 {
+		t.Error("Expected apple before banana")
+	}
+
+	floats := Ordered[float64]()
+	if floats(math.NaN(), -math.MaxFloat64) >= 0 {
+		t.Error("Expected NaN to sort first")
+	}
+}
+
+func TestReverse(t *testing.T) {
+	r := Reverse(Ordered[int]())
+	if r(1, 2) <= 0 || r(2, 1) >= 0 || r(3, 3) != 0 {
+		t.Error("Expected reversed ordering")
+	}
+}
+
+func TestByAndThen(t *testing.T) {
+	people := []person{{"cid", 30}, {"ann", 30}, {"bob", 25}}
+
+	byAge := By(func(p person) int { return p.age })
+	byName := By(func(p person) string { return p.name })
+
+	slices.SortFunc(people, Then(byAge, byName))
+
+	expected := []string{"bob", "ann", "cid"}
+	for i, name := range expected {
+		if people[i].name != name {
+			t.Errorf("Expected %s at position %d, got %s", name, i, people[i].name)
+		}
+	}
+
+	byNameLen := ByFunc(func(p person) string { return p.name }, func(a, b string) int {
+		return len(a) - len(b)
+	})
+	if byNameLen(person{name: "al"}, person{name: "bob"}) >= 0 {
+		t.Error("Expected shorter name first")
+	}
+}
+
+func TestThenAllTied(t *testing.T) {
+	zero := func(a, b int) int { return 0 }
+	if Then[int](zero, zero)(1, 2) != 0 {
+		t.Error("Expected 0 when every comparator ties")
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	ints := Ordered[int]()
+	if Min(ints, 3, 1) != 1 || Max(ints, 3, 1) != 3 {
+		t.Error("Unexpected Min/Max")
+	}
+
+	byAge := By(func(p person) int { return p.age })
+	a, b := person{"a", 1}, person{"b", 1}
+	if Min(byAge, a, b).name != "a" || Max(byAge, a, b).name != "a" {
+		t.Error("Expected ties to prefer the first argument")
+	}
+}
+
+func TestEqualLess(t *testing.T) {
+	ints := Ordered[int]()
+	if !Equal(ints)(4, 4) || Equal(ints)(4, 5) {
+		t.Error("Unexpected Equal result")
+	}
+	if !Less(ints)(4, 5) || Less(ints)(5, 4) {
+		t.Error("Unexpected Less result")
+	}
+}
+
+// Benchmark tests
+func BenchmarkThen(b *testing.B) {
+	c := Then(By(func(p person) int { return p.age }), By(func(p person) string { return p.name }))
+	x, y := person{"ann", 30}, person{"bob", 30}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c(x, y)
+	}
+}
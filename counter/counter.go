@@ -0,0 +1,265 @@
+// Package counter counts occurrences and answers top-k queries without
+// sorting every key on each query
+package counter
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/anwar-arif/golang-dsa/collections"
+	"github.com/anwar-arif/golang-dsa/priorityqueue"
+)
+
+// DefaultTrack is the number of leaders tracked when New is given k < 1
+const DefaultTrack = 10
+
+// Entry pairs a key with its count
+type Entry[T comparable] struct {
+	Value T
+	Count int
+}
+
+func byCount[T comparable](a, b Entry[T]) int {
+	return cmp.Compare(a.Count, b.Count)
+}
+
+// Counter counts occurrences of keys and keeps the k most frequent keys in a
+// bounded min-heap that is updated on every increment
+// Increments keep the heap exact in O(log k); a decrement of a tracked key
+// may let an untracked key overtake it, so the heap is rebuilt lazily on the
+// next TopK call
+type Counter[T comparable] struct {
+	counts  map[T]int
+	total   int
+	k       int
+	top     *priorityqueue.PriorityQueue[Entry[T]]
+	tracked map[T]*priorityqueue.Item[Entry[T]]
+	stale   bool
+}
+
+// Counter satisfies the shared collection interface
+var _ collections.Collection[int] = (*Counter[int])(nil)
+
+// New creates a counter that tracks the k most frequent keys
+func New[T comparable](k int) *Counter[T] {
+	if k < 1 {
+		k = DefaultTrack
+	}
+	return &Counter[T]{
+		counts:  make(map[T]int),
+		k:       k,
+		top:     priorityqueue.NewMinQueue(byCount[T]),
+		tracked: make(map[T]*priorityqueue.Item[Entry[T]]),
+	}
+}
+
+// Increment adds one to the count of key and returns the new count
+func (c *Counter[T]) Increment(key T) int {
+	return c.Add(key, 1)
+}
+
+// Decrement subtracts one from the count of key and returns the new count
+func (c *Counter[T]) Decrement(key T) int {
+	return c.Add(key, -1)
+}
+
+// Add changes the count of key by delta and returns the new count
+// Counts never go below zero; a key whose count reaches zero is removed
+func (c *Counter[T]) Add(key T, delta int) int {
+	old := c.counts[key]
+	n := max(old+delta, 0)
+	if n == old {
+		return n
+	}
+
+	if n == 0 {
+		delete(c.counts, key)
+	} else {
+		c.counts[key] = n
+	}
+	c.total += n - old
+
+	if !c.stale {
+		c.track(key, n, n < old)
+	}
+	return n
+}
+
+// track updates the leader heap after the count of key changed to n
+func (c *Counter[T]) track(key T, n int, decreased bool) {
+	item, ok := c.tracked[key]
+
+	// A tracked key that dropped can only be kept exact when every key is tracked
+	if decreased && ok && len(c.counts) >= c.k {
+		c.stale = true
+		return
+	}
+
+	switch {
+	case ok && n == 0:
+		c.top.Remove(item)
+		delete(c.tracked, key)
+	case ok:
+		item.Value.Count = n
+		c.top.UpdateItem(item)
+	case n == 0:
+		// Untracked keys never matter once they are gone
+	case c.top.Size() < c.k:
+		c.push(key, n)
+	default:
+		if smallest, _ := c.top.Peek(); n > smallest.Count {
+			evicted, _ := c.top.Pop()
+			delete(c.tracked, evicted.Value)
+			c.push(key, n)
+		}
+	}
+}
+
+func (c *Counter[T]) push(key T, n int) {
+	item := priorityqueue.NewItem(Entry[T]{Value: key, Count: n})
+	c.top.PushItem(item)
+	c.tracked[key] = item
+}
+
+// rebuild recomputes the leader heap from the full map
+func (c *Counter[T]) rebuild() {
+	c.top.Clear()
+	clear(c.tracked)
+	c.stale = false
+	for key, n := range c.counts {
+		c.track(key, n, false)
+	}
+}
+
+// TopK returns up to k keys with the highest counts, most frequent first
+// Queries for at most the tracked number of keys read the heap in O(k log k);
+// larger queries fall back to a bounded heap over every key
+// Ties are broken arbitrarily
+func (c *Counter[T]) TopK(k int) []Entry[T] {
+	if k <= 0 {
+		return nil
+	}
+	if c.stale {
+		c.rebuild()
+	}
+
+	var entries []Entry[T]
+	if k <= c.k {
+		entries = make([]Entry[T], 0, c.top.Size())
+		for _, item := range c.top.ToSlice() {
+			entries = append(entries, item.Value)
+		}
+	} else {
+		entries = topK(c.counts, k)
+	}
+
+	slices.SortFunc(entries, func(a, b Entry[T]) int {
+		return byCount(b, a)
+	})
+	return entries[:min(k, len(entries))]
+}
+
+// topK selects the k largest counts with a priorityqueue.TopK
+func topK[T comparable](counts map[T]int, k int) []Entry[T] {
+	k = min(k, len(counts))
+	if k == 0 {
+		return nil
+	}
+
+	top := priorityqueue.NewTopK(k, byCount[T])
+	for key, n := range counts {
+		top.Offer(Entry[T]{Value: key, Count: n})
+	}
+	return top.Values()
+}
+
+// Count returns the count of key
+func (c *Counter[T]) Count(key T) int {
+	return c.counts[key]
+}
+
+// Total returns the sum of all counts
+func (c *Counter[T]) Total() int {
+	return c.total
+}
+
+// Size returns the number of keys with a non-zero count
+func (c *Counter[T]) Size() int {
+	return len(c.counts)
+}
+
+// IsEmpty returns true if no key has a non-zero count
+func (c *Counter[T]) IsEmpty() bool {
+	return len(c.counts) == 0
+}
+
+// Clear resets every count
+func (c *Counter[T]) Clear() {
+	clear(c.counts)
+	c.total = 0
+	c.top.Clear()
+	clear(c.tracked)
+	c.stale = false
+}
+
+// All returns an iterator over the keys in unspecified order
+func (c *Counter[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for key := range c.counts {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Counts returns an iterator over the keys and their counts in unspecified order
+func (c *Counter[T]) Counts() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for key, n := range c.counts {
+			if !yield(key, n) {
+				return
+			}
+		}
+	}
+}
+
+// String returns a string representation of the counter
+func (c *Counter[T]) String() string {
+	return fmt.Sprintf("Counter{keys: %d, total: %d, tracking: %d}", len(c.counts), c.total, c.k)
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Counter Examples ===")
+
+	// Example 1: Word counts
+	fmt.Println("1. Word Count:")
+	words := New[string](3)
+	text := "red blue red green blue red yellow blue red"
+	start := 0
+	for i := 0; i <= len(text); i++ {
+		if i == len(text) || text[i] == ' ' {
+			words.Increment(text[start:i])
+			start = i + 1
+		}
+	}
+	fmt.Printf("  %v\n", words)
+
+	// Example 2: Streaming top-k
+	fmt.Println("\n2. TopK(2):")
+	for _, e := range words.TopK(2) {
+		fmt.Printf("  %s: %d\n", e.Value, e.Count)
+	}
+
+	// Example 3: Decrements
+	fmt.Println("\n3. After removing two 'red':")
+	words.Decrement("red")
+	words.Decrement("red")
+	for _, e := range words.TopK(2) {
+		fmt.Printf("  %s: %d\n", e.Value, e.Count)
+	}
+	fmt.Printf("  Total words: %d\n", words.Total())
+}
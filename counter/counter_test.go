@@ -0,0 +1,114 @@
+package counter
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestIncrementDecrement(t *testing.T) {
+	c := New[string](2)
+
+	c.Increment("a")
+	c.Increment("a")
+	c.Add("b", 5)
+	if c.Count("a") != 2 || c.Count("b") != 5 || c.Total() != 7 || c.Size() != 2 {
+		t.Errorf("Unexpected state %v", c)
+	}
+
+	if n := c.Decrement("missing"); n != 0 || c.Size() != 2 {
+		t.Errorf("Expected decrementing a missing key to be a no-op, got %d", n)
+	}
+	if n := c.Add("a", -10); n != 0 || c.Count("a") != 0 || c.Total() != 5 || c.Size() != 1 {
+		t.Errorf("Expected a to be clamped and removed, got %d with %v", n, c)
+	}
+
+	c.Clear()
+	if !c.IsEmpty() || c.Total() != 0 || len(c.TopK(1)) != 0 {
+		t.Errorf("Expected empty counter, got %v", c)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	c := New[string](2)
+	for key, n := range map[string]int{"a": 1, "b": 4, "c": 3, "d": 2} {
+		c.Add(key, n)
+	}
+
+	expected := []Entry[string]{{"b", 4}, {"c", 3}}
+	if got := c.TopK(2); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	// A tracked key falling behind an untracked one triggers a rebuild
+	c.Add("b", -3)
+	expected = []Entry[string]{{"c", 3}, {"d", 2}}
+	if got := c.TopK(2); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v after decrement, got %v", expected, got)
+	}
+
+	// Queries larger than the tracked size still work
+	if got := c.TopK(10); len(got) != 4 || got[0].Value != "c" {
+		t.Errorf("Expected 4 entries led by c, got %v", got)
+	}
+	if got := c.TopK(1); len(got) != 1 || got[0].Value != "c" {
+		t.Errorf("Expected [c], got %v", got)
+	}
+}
+
+func TestTopKMatchesSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	c := New[int](5)
+	counts := make(map[int]int)
+
+	for i := 0; i < 5000; i++ {
+		key := rng.Intn(40)
+		delta := rng.Intn(5) - 1
+		c.Add(key, delta)
+		counts[key] = max(counts[key]+delta, 0)
+
+		if i%50 != 0 {
+			continue
+		}
+
+		expected := make([]int, 0, len(counts))
+		for _, n := range counts {
+			if n > 0 {
+				expected = append(expected, n)
+			}
+		}
+		slices.SortFunc(expected, func(a, b int) int { return b - a })
+		expected = expected[:min(5, len(expected))]
+
+		got := make([]int, 0, 5)
+		for _, e := range c.TopK(5) {
+			if e.Count != counts[e.Value] {
+				t.Fatalf("Step %d: stale count for %d: %d vs %d", i, e.Value, e.Count, counts[e.Value])
+			}
+			got = append(got, e.Count)
+		}
+		if !slices.Equal(got, expected) {
+			t.Fatalf("Step %d: expected counts %v, got %v", i, expected, got)
+		}
+	}
+}
+
+// Benchmark tests
+func BenchmarkIncrement(b *testing.B) {
+	c := New[int](10)
+	for i := 0; i < b.N; i++ {
+		c.Increment(i % 10000)
+	}
+}
+
+func BenchmarkTopK(b *testing.B) {
+	c := New[int](10)
+	for i := 0; i < 100000; i++ {
+		c.Increment(i % 9973 * (i % 7))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.TopK(10)
+	}
+}
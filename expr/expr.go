@@ -0,0 +1,255 @@
+// Package expr evaluates arithmetic expressions by converting infix notation
+// to postfix with the shunting-yard algorithm and evaluating the postfix
+// tokens on a stack
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/anwar-arif/golang-dsa/stack"
+)
+
+var (
+	// ErrSyntax is returned for malformed expressions; the wrapping error
+	// says what was wrong and where
+	ErrSyntax = errors.New("expr: syntax error")
+	// ErrDivisionByZero is returned when an expression divides by zero
+	ErrDivisionByZero = errors.New("expr: division by zero")
+)
+
+// Neg is the postfix token for unary minus, which ToPostfix emits so it is
+// not confused with subtraction
+const Neg = "neg"
+
+// operator describes how an operator binds
+type operator struct {
+	prec       int
+	rightAssoc bool
+	unary      bool
+}
+
+// operators lists the supported operators; unary minus binds tighter than
+// multiplication but looser than exponentiation, so -2^2 is -4
+var operators = map[string]operator{
+	"+": {prec: 1},
+	"-": {prec: 1},
+	"*": {prec: 2},
+	"/": {prec: 2},
+	Neg: {prec: 3, rightAssoc: true, unary: true},
+	"^": {prec: 4, rightAssoc: true},
+}
+
+// token is a piece of an infix expression and its byte offset
+type token struct {
+	text string
+	pos  int
+}
+
+// ToPostfix converts an infix expression to postfix tokens
+// It accepts decimal numbers, + - * / ^ (exponentiation, right-associative),
+// parentheses and unary minus and plus; unary minus becomes Neg
+// Returns an error wrapping ErrSyntax that names the offending offset if the
+// expression is malformed
+func ToPostfix(infix string) ([]string, error) {
+	var output []string
+	ops := stack.NewStack[token]()
+	expectOperand := true
+
+	// popWhile moves operators to the output while they bind at least as
+	// tightly as op
+	popWhile := func(op operator) {
+		for {
+			top, err := ops.Peek()
+			if err != nil || top.text == "(" {
+				return
+			}
+			o := operators[top.text]
+			if o.prec < op.prec || (o.prec == op.prec && op.rightAssoc) {
+				return
+			}
+			ops.Pop()
+			output = append(output, top.text)
+		}
+	}
+
+	for i := 0; i < len(infix); {
+		c := infix[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case isDigit(c) || c == '.':
+			if !expectOperand {
+				return nil, fmt.Errorf("%w: unexpected number at offset %d", ErrSyntax, i)
+			}
+			start := i
+			for i < len(infix) && (isDigit(infix[i]) || infix[i] == '.') {
+				i++
+			}
+			if _, err := strconv.ParseFloat(infix[start:i], 64); err != nil {
+				return nil, fmt.Errorf("%w: invalid number %q at offset %d", ErrSyntax, infix[start:i], start)
+			}
+			output = append(output, infix[start:i])
+			expectOperand = false
+
+		case c == '(':
+			if !expectOperand {
+				return nil, fmt.Errorf("%w: unexpected '(' at offset %d", ErrSyntax, i)
+			}
+			ops.Push(token{text: "(", pos: i})
+			i++
+
+		case c == ')':
+			if expectOperand {
+				return nil, fmt.Errorf("%w: unexpected ')' at offset %d", ErrSyntax, i)
+			}
+			for {
+				top, err := ops.Pop()
+				if err != nil {
+					return nil, fmt.Errorf("%w: unmatched ')' at offset %d", ErrSyntax, i)
+				}
+				if top.text == "(" {
+					break
+				}
+				output = append(output, top.text)
+			}
+			i++
+
+		case strings.IndexByte("+-*/^", c) >= 0:
+			text := string(c)
+			if expectOperand {
+				switch text {
+				case "+":
+					// Unary plus changes nothing
+				case "-":
+					// Prefix operators wait for their operand, so pop nothing
+					ops.Push(token{text: Neg, pos: i})
+				default:
+					return nil, fmt.Errorf("%w: missing operand before %q at offset %d", ErrSyntax, text, i)
+				}
+				i++
+				continue
+			}
+			popWhile(operators[text])
+			ops.Push(token{text: text, pos: i})
+			expectOperand = true
+			i++
+
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q at offset %d", ErrSyntax, c, i)
+		}
+	}
+
+	if expectOperand {
+		return nil, fmt.Errorf("%w: missing operand at end of expression", ErrSyntax)
+	}
+	for !ops.IsEmpty() {
+		top, _ := ops.Pop()
+		if top.text == "(" {
+			return nil, fmt.Errorf("%w: unmatched '(' at offset %d", ErrSyntax, top.pos)
+		}
+		output = append(output, top.text)
+	}
+	return output, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// EvalPostfix evaluates postfix tokens as produced by ToPostfix
+// Returns an error wrapping ErrSyntax that names the offending token index if
+// the tokens do not form a single expression, or ErrDivisionByZero
+func EvalPostfix(tokens []string) (float64, error) {
+	values := stack.NewStack[float64]()
+
+	for i, tok := range tokens {
+		op, isOp := operators[tok]
+		if !isOp {
+			v, err := strconv.ParseFloat(tok, 64)
+			if err != nil {
+				return 0, fmt.Errorf("%w: invalid token %q at index %d", ErrSyntax, tok, i)
+			}
+			values.Push(v)
+			continue
+		}
+
+		if op.unary {
+			a, err := values.Pop()
+			if err != nil {
+				return 0, fmt.Errorf("%w: %q at index %d lacks an operand", ErrSyntax, tok, i)
+			}
+			values.Push(-a)
+			continue
+		}
+
+		b, errB := values.Pop()
+		a, errA := values.Pop()
+		if errA != nil || errB != nil {
+			return 0, fmt.Errorf("%w: %q at index %d lacks operands", ErrSyntax, tok, i)
+		}
+		switch tok {
+		case "+":
+			values.Push(a + b)
+		case "-":
+			values.Push(a - b)
+		case "*":
+			values.Push(a * b)
+		case "/":
+			if b == 0 {
+				return 0, fmt.Errorf("%w at index %d", ErrDivisionByZero, i)
+			}
+			values.Push(a / b)
+		case "^":
+			values.Push(math.Pow(a, b))
+		}
+	}
+
+	result, err := values.Pop()
+	if err != nil {
+		return 0, fmt.Errorf("%w: empty expression", ErrSyntax)
+	}
+	if !values.IsEmpty() {
+		return 0, fmt.Errorf("%w: %d values left without an operator", ErrSyntax, values.Size()+1)
+	}
+	return result, nil
+}
+
+// Eval evaluates an infix expression
+func Eval(infix string) (float64, error) {
+	tokens, err := ToPostfix(infix)
+	if err != nil {
+		return 0, err
+	}
+	return EvalPostfix(tokens)
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Expression Evaluation Examples ===")
+
+	// Example 1: Infix to postfix
+	fmt.Println("1. Shunting-yard conversion:")
+	for _, infix := range []string{"3 + 4 * 2", "(1 + 2) * (3 + 4)", "2 ^ 3 ^ 2", "-(2.5 - 4)"} {
+		postfix, _ := ToPostfix(infix)
+		fmt.Printf("  %-20s -> %s\n", infix, strings.Join(postfix, " "))
+	}
+
+	// Example 2: Evaluation
+	fmt.Println("\n2. Evaluation:")
+	for _, infix := range []string{"3 + 4 * 2", "-2 ^ 2", "10 / 4", "2 * -3"} {
+		v, _ := Eval(infix)
+		fmt.Printf("  %-12s = %g\n", infix, v)
+	}
+
+	// Example 3: Errors name what went wrong
+	fmt.Println("\n3. Errors:")
+	for _, infix := range []string{"(1 + 2", "1 + * 2", "1 / (2 - 2)"} {
+		_, err := Eval(infix)
+		fmt.Printf("  %-12s: %v\n", infix, err)
+	}
+}
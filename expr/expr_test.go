@@ -0,0 +1,107 @@
+package expr
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestToPostfix(t *testing.T) {
+	testCases := []struct {
+		infix    string
+		expected string
+	}{
+		{"3 + 4", "3 4 +"},
+		{"3 + 4 * 2", "3 4 2 * +"},
+		{"(3 + 4) * 2", "3 4 + 2 *"},
+		{"8 - 3 - 2", "8 3 - 2 -"},
+		{"2 ^ 3 ^ 2", "2 3 2 ^ ^"},
+		{"-2 ^ 2", "2 2 ^ neg"},
+		{"2 * -3", "2 3 neg *"},
+		{"--1", "1 neg neg"},
+		{"+1.5", "1.5"},
+		{"((1))", "1"},
+	}
+
+	for _, tc := range testCases {
+		got, err := ToPostfix(tc.infix)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", tc.infix, err)
+			continue
+		}
+		if !slices.Equal(got, strings.Fields(tc.expected)) {
+			t.Errorf("%q: expected %s, got %v", tc.infix, tc.expected, got)
+		}
+	}
+}
+
+func TestEval(t *testing.T) {
+	testCases := []struct {
+		infix    string
+		expected float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * (3 + 4)", 21},
+		{"10 / 4", 2.5},
+		{"8 - 3 - 2", 3},
+		{"2 ^ 3 ^ 2", 512},
+		{"-2 ^ 2", -4},
+		{"(-2) ^ 2", 4},
+		{"2 ^ -1", 0.5},
+		{"-(1.5 - 4) * 2", 5},
+		{".5 + 0.25", 0.75},
+	}
+
+	for _, tc := range testCases {
+		got, err := Eval(tc.infix)
+		if err != nil || got != tc.expected {
+			t.Errorf("%q: expected %v, got %v (%v)", tc.infix, tc.expected, got, err)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	testCases := []struct {
+		infix  string
+		target error
+		detail string
+	}{
+		{"", ErrSyntax, "missing operand"},
+		{"1 +", ErrSyntax, "missing operand"},
+		{"* 2", ErrSyntax, "offset 0"},
+		{"(1 + 2", ErrSyntax, "unmatched '(' at offset 0"},
+		{"1 + 2)", ErrSyntax, "unmatched ')' at offset 5"},
+		{"1 2", ErrSyntax, "offset 2"},
+		{"()", ErrSyntax, "unexpected ')'"},
+		{"1.2.3", ErrSyntax, "invalid number"},
+		{"2 x 3", ErrSyntax, "'x'"},
+		{"1 / (3 - 3)", ErrDivisionByZero, "index"},
+	}
+
+	for _, tc := range testCases {
+		_, err := Eval(tc.infix)
+		if !errors.Is(err, tc.target) || !strings.Contains(err.Error(), tc.detail) {
+			t.Errorf("%q: expected %v mentioning %q, got %v", tc.infix, tc.target, tc.detail, err)
+		}
+	}
+}
+
+func TestEvalPostfix(t *testing.T) {
+	if v, err := EvalPostfix([]string{"1", "2", "+", "3", "4", "+", "*"}); err != nil || v != 21 {
+		t.Errorf("Expected 21, got %v (%v)", v, err)
+	}
+
+	for _, tokens := range [][]string{{}, {"+"}, {"1", "+"}, {"neg"}, {"1", "2"}, {"1", "x", "+"}} {
+		if _, err := EvalPostfix(tokens); !errors.Is(err, ErrSyntax) {
+			t.Errorf("%v: expected ErrSyntax, got %v", tokens, err)
+		}
+	}
+}
+
+// Benchmark tests
+func BenchmarkEval(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Eval("(1 + 2.5) * -3 ^ 2 / (4 - 1.5) + 7")
+	}
+}
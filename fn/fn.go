@@ -0,0 +1,183 @@
+// Package fn provides functional helpers over iter.Seq and slices, so the
+// iterators exposed by the containers can be transformed without loops
+// Sequence helpers are lazy: nothing runs until the result is ranged over
+package fn
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/anwar-arif/golang-dsa/compare"
+)
+
+// Map returns a sequence of f applied to each value of seq
+func Map[T, U any](seq iter.Seq[T], f func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter returns a sequence of the values of seq for which pred holds
+func Filter[T any](seq iter.Seq[T], pred func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if pred(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Reduce folds seq into a single value, starting from init
+func Reduce[T, A any](seq iter.Seq[T], init A, f func(acc A, v T) A) A {
+	acc := init
+	for v := range seq {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// GroupBy collects the values of seq into slices keyed by key(v), keeping
+// the order values arrived in within each group
+func GroupBy[T any, K comparable](seq iter.Seq[T], key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for v := range seq {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// Chunk returns a sequence of consecutive slices of at most n values
+// Each chunk is a fresh slice the caller may keep
+// It panics if n is less than 1
+func Chunk[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	if n < 1 {
+		panic("fn: chunk size must be at least 1")
+	}
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, n)
+		for v := range seq {
+			chunk = append(chunk, v)
+			if len(chunk) == n {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, n)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Zip pairs up the values of a and b, stopping when either runs out
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		nextB, stop := iter.Pull(b)
+		defer stop()
+
+		for va := range a {
+			vb, ok := nextB()
+			if !ok || !yield(va, vb) {
+				return
+			}
+		}
+	}
+}
+
+// Distinct returns a sequence of the values of seq with repeats removed,
+// keeping the first occurrence of each
+func Distinct[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := make(map[T]struct{})
+		for v := range seq {
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// MinBy returns the smallest value of seq according to c, the first one on
+// ties; ok is false if seq is empty
+func MinBy[T any](seq iter.Seq[T], c compare.CompareFunc[T]) (min T, ok bool) {
+	for v := range seq {
+		if !ok || c(v, min) < 0 {
+			min, ok = v, true
+		}
+	}
+	return min, ok
+}
+
+// MaxBy returns the largest value of seq according to c, the first one on
+// ties; ok is false if seq is empty
+func MaxBy[T any](seq iter.Seq[T], c compare.CompareFunc[T]) (max T, ok bool) {
+	for v := range seq {
+		if !ok || c(v, max) > 0 {
+			max, ok = v, true
+		}
+	}
+	return max, ok
+}
+
+// MapSlice returns a new slice of f applied to each element of s
+func MapSlice[T, U any](s []T, f func(T) U) []U {
+	result := make([]U, len(s))
+	for i, v := range s {
+		result[i] = f(v)
+	}
+	return result
+}
+
+// FilterSlice returns a new slice of the elements of s for which pred holds
+func FilterSlice[T any](s []T, pred func(T) bool) []T {
+	return slices.Collect(Filter(slices.Values(s), pred))
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Functional Helper Examples ===")
+	numbers := slices.Values([]int{5, 3, 8, 3, 1, 8, 9})
+
+	// Example 1: Map and Filter
+	fmt.Println("1. Map and Filter:")
+	evensSquared := Map(Filter(numbers, func(n int) bool { return n%2 == 0 }), func(n int) int { return n * n })
+	fmt.Printf("  Even numbers squared: %v\n", slices.Collect(evensSquared))
+
+	// Example 2: Reduce
+	fmt.Println("\n2. Reduce:")
+	sum := Reduce(numbers, 0, func(acc, n int) int { return acc + n })
+	fmt.Printf("  Sum: %d\n", sum)
+
+	// Example 3: Distinct, Chunk and GroupBy
+	fmt.Println("\n3. Distinct, Chunk and GroupBy:")
+	fmt.Printf("  Distinct: %v\n", slices.Collect(Distinct(numbers)))
+	fmt.Printf("  Chunks of 3: %v\n", slices.Collect(Chunk(numbers, 3)))
+	parity := GroupBy(numbers, func(n int) string {
+		if n%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	})
+	fmt.Printf("  Odd: %v, even: %v\n", parity["odd"], parity["even"])
+
+	// Example 4: Zip and MaxBy
+	fmt.Println("\n4. Zip and MaxBy:")
+	names := slices.Values([]string{"ann", "bob", "cid"})
+	for name, score := range Zip(names, numbers) {
+		fmt.Printf("  %s -> %d\n", name, score)
+	}
+	longest, _ := MaxBy(names, compare.By(func(s string) int { return len(s) }))
+	fmt.Printf("  First longest name: %s\n", longest)
+}
@@ -0,0 +1,125 @@
+package fn
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/anwar-arif/golang-dsa/compare"
+)
+
+func TestMapFilter(t *testing.T) {
+	seq := slices.Values([]int{1, 2, 3, 4, 5})
+
+	got := slices.Collect(Map(Filter(seq, func(n int) bool { return n%2 == 1 }), strconv.Itoa))
+	if !slices.Equal(got, []string{"1", "3", "5"}) {
+		t.Errorf("Expected [1 3 5], got %v", got)
+	}
+
+	// Breaking out early stops the underlying sequence
+	calls := 0
+	for range Map(seq, func(n int) int { calls++; return n }) {
+		break
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call to f, got %d", calls)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	product := Reduce(slices.Values([]int{1, 2, 3, 4}), 1, func(acc, n int) int { return acc * n })
+	if product != 24 {
+		t.Errorf("Expected 24, got %d", product)
+	}
+
+	joined := Reduce(slices.Values([]int{}), "start", func(acc string, n int) string { return acc + strconv.Itoa(n) })
+	if joined != "start" {
+		t.Errorf("Expected init for empty sequence, got %s", joined)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	groups := GroupBy(slices.Values([]string{"apple", "avocado", "banana", "blueberry", "cherry"}), func(s string) byte {
+		return s[0]
+	})
+
+	if !slices.Equal(groups['a'], []string{"apple", "avocado"}) || len(groups['c']) != 1 || len(groups) != 3 {
+		t.Errorf("Unexpected groups %v", groups)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	chunks := slices.Collect(Chunk(slices.Values([]int{1, 2, 3, 4, 5}), 2))
+	expected := [][]int{{1, 2}, {3, 4}, {5}}
+
+	if len(chunks) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, chunks)
+	}
+	for i := range expected {
+		if !slices.Equal(chunks[i], expected[i]) {
+			t.Errorf("Expected chunk %v, got %v", expected[i], chunks[i])
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for chunk size 0")
+		}
+	}()
+	Chunk(slices.Values([]int{1}), 0)
+}
+
+func TestZip(t *testing.T) {
+	var pairs []string
+	for a, b := range Zip(slices.Values([]string{"a", "b", "c"}), slices.Values([]int{1, 2})) {
+		pairs = append(pairs, a+strconv.Itoa(b))
+	}
+
+	if !slices.Equal(pairs, []string{"a1", "b2"}) {
+		t.Errorf("Expected [a1 b2], got %v", pairs)
+	}
+}
+
+func TestDistinct(t *testing.T) {
+	got := slices.Collect(Distinct(slices.Values([]int{3, 1, 3, 2, 1})))
+	if !slices.Equal(got, []int{3, 1, 2}) {
+		t.Errorf("Expected [3 1 2], got %v", got)
+	}
+}
+
+func TestMinMaxBy(t *testing.T) {
+	words := slices.Values([]string{"bb", "a", "ccc", "dd", "e"})
+	byLen := compare.By(func(s string) int { return len(s) })
+
+	if v, ok := MinBy(words, byLen); !ok || v != "a" {
+		t.Errorf("Expected a, got %s", v)
+	}
+	if v, ok := MaxBy(words, byLen); !ok || v != "ccc" {
+		t.Errorf("Expected ccc, got %s", v)
+	}
+	if _, ok := MinBy(slices.Values([]string{}), byLen); ok {
+		t.Error("Expected ok to be false for an empty sequence")
+	}
+}
+
+func TestSliceHelpers(t *testing.T) {
+	if got := MapSlice([]int{1, 2}, func(n int) int { return n * 10 }); !slices.Equal(got, []int{10, 20}) {
+		t.Errorf("Expected [10 20], got %v", got)
+	}
+	if got := FilterSlice([]int{1, 2, 3}, func(n int) bool { return n > 1 }); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("Expected [2 3], got %v", got)
+	}
+}
+
+// Benchmark tests
+func BenchmarkMapFilterReduce(b *testing.B) {
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = i
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Reduce(Map(Filter(slices.Values(values), func(n int) bool { return n%2 == 0 }), func(n int) int { return n * n }), 0, func(acc, n int) int { return acc + n })
+	}
+}
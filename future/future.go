@@ -0,0 +1,204 @@
+package future
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrPanicked wraps a panic raised by the function behind a Future
+var ErrPanicked = errors.New("future function panicked")
+
+// Future is the read side of a value that becomes available later
+type Future[T any] struct {
+	done  chan struct{}
+	once  sync.Once
+	value T
+	err   error
+}
+
+func newFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+// complete settles the future; only the first call has any effect
+func (f *Future[T]) complete(value T, err error) bool {
+	settled := false
+	f.once.Do(func() {
+		f.value = value
+		f.err = err
+		close(f.done)
+		settled = true
+	})
+	return settled
+}
+
+// Get blocks until the future is settled or ctx is done
+func (f *Future[T]) Get(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Done returns a channel that is closed once the future is settled
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// String returns a string representation of the future
+func (f *Future[T]) String() string {
+	select {
+	case <-f.done:
+		if f.err != nil {
+			return fmt.Sprintf("Future{error: %v}", f.err)
+		}
+		return fmt.Sprintf("Future{value: %v}", f.value)
+	default:
+		return "Future{pending}"
+	}
+}
+
+// Promise is the write side of a Future
+type Promise[T any] struct {
+	future *Future[T]
+}
+
+// NewPromise creates a promise with a pending future
+func NewPromise[T any]() *Promise[T] {
+	return &Promise[T]{future: newFuture[T]()}
+}
+
+// Future returns the future settled by this promise
+func (p *Promise[T]) Future() *Future[T] {
+	return p.future
+}
+
+// Resolve settles the future with a value and reports whether this call settled it
+func (p *Promise[T]) Resolve(value T) bool {
+	return p.future.complete(value, nil)
+}
+
+// Reject settles the future with an error and reports whether this call settled it
+func (p *Promise[T]) Reject(err error) bool {
+	var zero T
+	return p.future.complete(zero, err)
+}
+
+// Complete settles the future with a value and error pair
+func (p *Promise[T]) Complete(value T, err error) bool {
+	return p.future.complete(value, err)
+}
+
+// Go runs fn on a new goroutine and returns a future for its result
+// A panic inside fn is turned into an error wrapping ErrPanicked
+func Go[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) *Future[T] {
+	p := NewPromise[T]()
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				p.Reject(fmt.Errorf("%w: %v", ErrPanicked, r))
+			}
+		}()
+		p.Complete(fn(ctx))
+	}()
+	return p.Future()
+}
+
+// Resolved returns a future that already holds value
+func Resolved[T any](value T) *Future[T] {
+	f := newFuture[T]()
+	f.complete(value, nil)
+	return f
+}
+
+// Rejected returns a future that already holds err
+func Rejected[T any](err error) *Future[T] {
+	var zero T
+	f := newFuture[T]()
+	f.complete(zero, err)
+	return f
+}
+
+// Then returns a future for fn applied to the result of f
+// Errors from f skip fn and are passed through unchanged
+func Then[T, U any](f *Future[T], fn func(T) (U, error)) *Future[U] {
+	p := NewPromise[U]()
+	go func() {
+		<-f.done
+		if f.err != nil {
+			p.Reject(f.err)
+			return
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				p.Reject(fmt.Errorf("%w: %v", ErrPanicked, r))
+			}
+		}()
+		p.Complete(fn(f.value))
+	}()
+	return p.Future()
+}
+
+// All waits for every future and returns their values in order
+// It returns the first error in argument order, or ctx.Err() if ctx ends first
+func All[T any](ctx context.Context, futures ...*Future[T]) ([]T, error) {
+	values := make([]T, len(futures))
+	for i, f := range futures {
+		v, err := f.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Future Examples ===")
+	ctx := context.Background()
+
+	// Example 1: Run work asynchronously
+	fmt.Println("1. Async Computation:")
+	sum := Go(ctx, func(ctx context.Context) (int, error) {
+		total := 0
+		for i := 1; i <= 100; i++ {
+			total += i
+		}
+		return total, nil
+	})
+	v, _ := sum.Get(ctx)
+	fmt.Printf("  Sum 1..100 = %d\n", v)
+
+	// Example 2: Chaining with Then
+	fmt.Println("\n2. Chaining:")
+	label := Then(sum, func(n int) (string, error) {
+		return fmt.Sprintf("total=%d", n), nil
+	})
+	s, _ := label.Get(ctx)
+	fmt.Printf("  %s\n", s)
+
+	// Example 3: Manual promise
+	fmt.Println("\n3. Promise:")
+	p := NewPromise[string]()
+	go p.Reject(errors.New("upstream unavailable"))
+	if _, err := p.Future().Get(ctx); err != nil {
+		fmt.Printf("  Error: %v\n", err)
+	}
+
+	// Example 4: Waiting for many futures
+	fmt.Println("\n4. All:")
+	squares := make([]*Future[int], 5)
+	for i := range squares {
+		squares[i] = Go(ctx, func(ctx context.Context) (int, error) {
+			return i * i, nil
+		})
+	}
+	values, _ := All(ctx, squares...)
+	fmt.Printf("  Squares: %v\n", values)
+}
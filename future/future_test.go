@@ -0,0 +1,136 @@
+package future
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPromiseResolve(t *testing.T) {
+	p := NewPromise[int]()
+	f := p.Future()
+
+	select {
+	case <-f.Done():
+		t.Fatal("Expected pending future")
+	default:
+	}
+
+	if !p.Resolve(42) {
+		t.Error("Expected first Resolve to settle the future")
+	}
+	if p.Resolve(7) || p.Reject(errors.New("late")) {
+		t.Error("Expected later calls to be ignored")
+	}
+
+	v, err := f.Get(context.Background())
+	if err != nil || v != 42 {
+		t.Errorf("Expected 42, got %v with error %v", v, err)
+	}
+
+	if f.String() != "Future{value: 42}" {
+		t.Errorf("Unexpected string %q", f.String())
+	}
+}
+
+func TestPromiseReject(t *testing.T) {
+	p := NewPromise[string]()
+	boom := errors.New("boom")
+	p.Reject(boom)
+
+	if _, err := p.Future().Get(context.Background()); !errors.Is(err, boom) {
+		t.Errorf("Expected boom, got %v", err)
+	}
+}
+
+func TestGetContextCancelled(t *testing.T) {
+	p := NewPromise[int]()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := p.Future().Get(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+
+	if p.Future().String() != "Future{pending}" {
+		t.Errorf("Unexpected string %q", p.Future().String())
+	}
+}
+
+func TestGo(t *testing.T) {
+	ctx := context.Background()
+
+	f := Go(ctx, func(ctx context.Context) (int, error) {
+		time.Sleep(5 * time.Millisecond)
+		return 10, nil
+	})
+
+	v, err := f.Get(ctx)
+	if err != nil || v != 10 {
+		t.Errorf("Expected 10, got %v with error %v", v, err)
+	}
+
+	panicking := Go(ctx, func(ctx context.Context) (int, error) {
+		panic("kaboom")
+	})
+
+	if _, err := panicking.Get(ctx); !errors.Is(err, ErrPanicked) {
+		t.Errorf("Expected ErrPanicked, got %v", err)
+	}
+}
+
+func TestThen(t *testing.T) {
+	ctx := context.Background()
+
+	doubled := Then(Resolved(21), func(n int) (int, error) {
+		return n * 2, nil
+	})
+	if v, err := doubled.Get(ctx); err != nil || v != 42 {
+		t.Errorf("Expected 42, got %v with error %v", v, err)
+	}
+
+	boom := errors.New("boom")
+	called := false
+	skipped := Then(Rejected[int](boom), func(n int) (string, error) {
+		called = true
+		return "", nil
+	})
+	if _, err := skipped.Get(ctx); !errors.Is(err, boom) {
+		t.Errorf("Expected boom to pass through, got %v", err)
+	}
+	if called {
+		t.Error("Expected fn to be skipped on error")
+	}
+}
+
+func TestAll(t *testing.T) {
+	ctx := context.Background()
+
+	futures := []*Future[int]{Resolved(1), Resolved(2), Resolved(3)}
+	values, err := All(ctx, futures...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i, v := range []int{1, 2, 3} {
+		if values[i] != v {
+			t.Errorf("Expected %d at position %d, got %d", v, i, values[i])
+		}
+	}
+
+	boom := errors.New("boom")
+	if _, err := All(ctx, Resolved(1), Rejected[int](boom)); !errors.Is(err, boom) {
+		t.Errorf("Expected boom, got %v", err)
+	}
+}
+
+// Benchmark tests
+func BenchmarkGo(b *testing.B) {
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Go(ctx, func(ctx context.Context) (int, error) { return i, nil }).Get(ctx)
+	}
+}
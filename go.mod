@@ -1,3 +1,3 @@
 module github.com/anwar-arif/golang-dsa
 
-go 1.22.5
+go 1.24
@@ -0,0 +1,344 @@
+// Package hashmap implements an open-addressing hash map with Robin Hood probing
+package hashmap
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/anwar-arif/golang-dsa/collections"
+	"github.com/anwar-arif/golang-dsa/hashutil"
+)
+
+// slot states
+const (
+	empty uint8 = iota
+	full
+	deleted // tombstone
+)
+
+// minCapacity is the smallest non-empty table
+const minCapacity = 8
+
+// slot is one cell of the table
+// The hash is cached so growing never calls the hasher and most mismatches
+// are rejected without comparing keys
+type slot[K comparable, V any] struct {
+	hash  uint64
+	key   K
+	value V
+	dist  uint32 // distance from the home slot
+	state uint8
+}
+
+// Stats describes the shape of the table
+type Stats struct {
+	Len        int
+	Capacity   int
+	Tombstones int
+	MaxProbe   int     // longest distance of an entry from its home slot
+	AvgProbe   float64 // mean distance of the entries from their home slots
+}
+
+// Map is a hash map using open addressing with linear Robin Hood probing
+//
+// On insert, an entry that is further from its home slot than the resident
+// entry takes the slot and the resident moves on, which keeps probe lengths
+// short and even. Lookups can therefore stop as soon as they meet an entry
+// closer to home than the key would be.
+//
+// Deleted entries become tombstones that keep their probe distance, so the
+// early stop stays valid; inserts reuse a tombstone when that keeps the Robin
+// Hood order, and a tombstone is turned back into an empty slot right away
+// when no probe sequence runs through it. Tombstones count towards the load
+// factor and are purged whenever the table is rebuilt.
+//
+// A Map is not safe for concurrent use.
+type Map[K comparable, V any] struct {
+	slots      []slot[K, V]
+	mask       uint64
+	size       int
+	tombstones int
+	hasher     hashutil.Hasher[K]
+}
+
+// Map satisfies the shared container interface
+var _ collections.Container = (*Map[int, int])(nil)
+
+// New creates an empty map using a randomly seeded hasher for K
+func New[K comparable, V any]() *Map[K, V] {
+	return NewWithHasher[K, V](hashutil.Comparable[K](), 0)
+}
+
+// NewWithHasher creates an empty map that hashes keys with h and has room for
+// capacity entries before it grows
+func NewWithHasher[K comparable, V any](h hashutil.Hasher[K], capacity int) *Map[K, V] {
+	m := &Map[K, V]{hasher: h}
+	if capacity > 0 {
+		m.resize(tableSize(capacity))
+	}
+	return m
+}
+
+// tableSize returns the smallest power-of-two table that holds n entries
+// under the maximum load factor
+func tableSize(n int) int {
+	size := minCapacity
+	for maxLoad(size) < n {
+		size <<= 1
+	}
+	return size
+}
+
+// maxLoad is the number of occupied slots (entries plus tombstones) a table
+// of the given size may have; 7/8 keeps Robin Hood probes short
+func maxLoad(size int) int {
+	return size - size/8
+}
+
+// find returns the slot index of key, or -1
+func (m *Map[K, V]) find(key K, h uint64) int {
+	if m.size == 0 {
+		return -1
+	}
+
+	i := h & m.mask
+	for d := uint32(0); ; d++ {
+		s := &m.slots[i]
+		if s.state == empty || s.dist < d {
+			return -1
+		}
+		if s.state == full && s.hash == h && s.key == key {
+			return int(i)
+		}
+		i = (i + 1) & m.mask
+	}
+}
+
+// Get returns the value stored for key
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	if i := m.find(key, m.hasher.Hash(key)); i >= 0 {
+		return m.slots[i].value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether key is in the map
+func (m *Map[K, V]) Contains(key K) bool {
+	return m.find(key, m.hasher.Hash(key)) >= 0
+}
+
+// Put stores value for key and reports whether the key was newly inserted
+func (m *Map[K, V]) Put(key K, value V) bool {
+	h := m.hasher.Hash(key)
+	if i := m.find(key, h); i >= 0 {
+		m.slots[i].value = value
+		return false
+	}
+
+	if m.size+m.tombstones+1 > maxLoad(len(m.slots)) {
+		m.grow()
+	}
+	m.insert(slot[K, V]{hash: h, key: key, value: value, state: full})
+	m.size++
+	return true
+}
+
+// insert places an entry that is known to be absent; the table must have room
+func (m *Map[K, V]) insert(cur slot[K, V]) {
+	i := cur.hash & m.mask
+	for {
+		s := &m.slots[i]
+		switch {
+		case s.state == empty:
+			*s = cur
+			return
+		case s.state == deleted && s.dist <= cur.dist:
+			// Every probe running through here is at most s.dist long at
+			// this point, so an entry at least as far from home keeps the
+			// early stop valid
+			*s = cur
+			m.tombstones--
+			return
+		case s.state == full && s.dist < cur.dist:
+			// Robin Hood: take from the rich, the displaced entry moves on
+			*s, cur = cur, *s
+		}
+		i = (i + 1) & m.mask
+		cur.dist++
+	}
+}
+
+// Delete removes key and reports whether it was present
+func (m *Map[K, V]) Delete(key K) bool {
+	i := m.find(key, m.hasher.Hash(key))
+	if i < 0 {
+		return false
+	}
+
+	s := &m.slots[i]
+	var zeroK K
+	var zeroV V
+	s.key, s.value = zeroK, zeroV // release references held by the entry
+	s.state = deleted
+	m.size--
+	m.tombstones++
+
+	m.reclaim(uint64(i))
+	return true
+}
+
+// reclaim turns the tombstone at i, and the tombstones before it, back into
+// empty slots when no probe sequence continues past them
+// A probe runs through slot i only if it goes on to slot i+1 at distance one
+// or more, which is impossible when that slot is empty or at its home
+func (m *Map[K, V]) reclaim(i uint64) {
+	for m.slots[i].state == deleted {
+		next := &m.slots[(i+1)&m.mask]
+		if next.state != empty && next.dist != 0 {
+			return
+		}
+		m.slots[i] = slot[K, V]{}
+		m.tombstones--
+		i = (i - 1) & m.mask
+	}
+}
+
+// grow rebuilds the table, doubling it unless tombstones are most of the load
+func (m *Map[K, V]) grow() {
+	size := len(m.slots)
+	switch {
+	case size == 0:
+		size = minCapacity
+	case m.size+1 > maxLoad(size)/2:
+		size <<= 1
+	}
+	m.resize(size)
+}
+
+// resize rebuilds the table with the given power-of-two size, dropping tombstones
+func (m *Map[K, V]) resize(size int) {
+	old := m.slots
+	m.slots = make([]slot[K, V], size)
+	m.mask = uint64(size - 1)
+	m.tombstones = 0
+
+	for i := range old {
+		if old[i].state == full {
+			e := old[i]
+			e.dist = 0
+			m.insert(e)
+		}
+	}
+}
+
+// Compact rebuilds the table in place to purge tombstones
+func (m *Map[K, V]) Compact() {
+	if len(m.slots) > 0 {
+		m.resize(len(m.slots))
+	}
+}
+
+// Size returns the number of entries
+func (m *Map[K, V]) Size() int {
+	return m.size
+}
+
+// IsEmpty returns true if the map has no entries
+func (m *Map[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Clear removes every entry but keeps the table
+func (m *Map[K, V]) Clear() {
+	clear(m.slots)
+	m.size = 0
+	m.tombstones = 0
+}
+
+// All returns an iterator over the entries in table order
+// The map must not be modified during iteration
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for i := range m.slots {
+			if s := &m.slots[i]; s.state == full {
+				if !yield(s.key, s.value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over the keys in table order
+func (m *Map[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Stats returns the current shape of the table
+func (m *Map[K, V]) Stats() Stats {
+	st := Stats{Len: m.size, Capacity: len(m.slots), Tombstones: m.tombstones}
+	total := 0
+	for i := range m.slots {
+		if s := &m.slots[i]; s.state == full {
+			total += int(s.dist)
+			st.MaxProbe = max(st.MaxProbe, int(s.dist))
+		}
+	}
+	if m.size > 0 {
+		st.AvgProbe = float64(total) / float64(m.size)
+	}
+	return st
+}
+
+// String returns a string representation of the map
+func (m *Map[K, V]) String() string {
+	return fmt.Sprintf("HashMap{size: %d, capacity: %d, tombstones: %d}", m.size, len(m.slots), m.tombstones)
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Hash Map Examples ===")
+
+	// Example 1: Basic operations
+	fmt.Println("1. Basic Operations:")
+	ages := New[string, int]()
+	ages.Put("alice", 30)
+	ages.Put("bob", 25)
+	ages.Put("alice", 31)
+	age, _ := ages.Get("alice")
+	fmt.Printf("  alice=%d, size=%d\n", age, ages.Size())
+	ages.Delete("bob")
+	fmt.Printf("  Contains(bob) after delete: %t\n", ages.Contains("bob"))
+
+	// Example 2: Custom key type via the hasher framework
+	fmt.Println("\n2. Custom Hasher:")
+	type point struct{ X, Y int }
+	byXY := hashutil.Fields(
+		hashutil.Field(func(p point) int { return p.X }, hashutil.Int[int]()),
+		hashutil.Field(func(p point) int { return p.Y }, hashutil.Int[int]()),
+	)
+	grid := NewWithHasher[point, string](byXY, 16)
+	grid.Put(point{0, 0}, "origin")
+	grid.Put(point{3, 4}, "target")
+	label, _ := grid.Get(point{3, 4})
+	fmt.Printf("  (3,4) -> %s\n", label)
+
+	// Example 3: Probe statistics under load
+	fmt.Println("\n3. Robin Hood Probe Lengths:")
+	m := New[int, int]()
+	for i := 0; i < 10000; i++ {
+		m.Put(i, i)
+	}
+	for i := 0; i < 10000; i += 3 {
+		m.Delete(i)
+	}
+	st := m.Stats()
+	fmt.Printf("  %d entries in %d slots, max probe %d\n", st.Len, st.Capacity, st.MaxProbe)
+}
@@ -0,0 +1,230 @@
+package hashmap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/anwar-arif/golang-dsa/hashutil"
+)
+
+// checkInvariants verifies the Robin Hood order and the cached counters
+func checkInvariants[K comparable, V any](t *testing.T, m *Map[K, V]) {
+	t.Helper()
+
+	size, tombstones := 0, 0
+	for i := range m.slots {
+		s := &m.slots[i]
+		switch s.state {
+		case full:
+			size++
+		case deleted:
+			tombstones++
+		default:
+			continue
+		}
+		if home := s.hash & m.mask; (home+uint64(s.dist))&m.mask != uint64(i) {
+			t.Fatalf("Slot %d: distance %d does not match home %d", i, s.dist, home)
+		}
+		// Every slot between home and here must be occupied with a distance
+		// at least as long as this entry's at that point
+		for d := uint32(0); d < s.dist; d++ {
+			p := &m.slots[(uint64(i)-uint64(s.dist-d))&m.mask]
+			if p.state == empty || p.dist < d {
+				t.Fatalf("Slot %d: probe path broken at distance %d", i, d)
+			}
+		}
+	}
+	if size != m.size || tombstones != m.tombstones {
+		t.Fatalf("Expected %d entries and %d tombstones, counted %d and %d", m.size, m.tombstones, size, tombstones)
+	}
+}
+
+func TestBasicOperations(t *testing.T) {
+	m := New[string, int]()
+
+	if _, ok := m.Get("missing"); ok || m.Delete("missing") {
+		t.Error("Expected lookups on an empty map to fail")
+	}
+
+	if !m.Put("a", 1) || !m.Put("b", 2) || m.Put("a", 3) {
+		t.Error("Unexpected Put result")
+	}
+	if v, ok := m.Get("a"); !ok || v != 3 || m.Size() != 2 {
+		t.Errorf("Expected a=3 and size 2, got %d and %d", v, m.Size())
+	}
+
+	if !m.Delete("a") || m.Contains("a") || m.Size() != 1 {
+		t.Error("Expected a to be deleted")
+	}
+
+	m.Clear()
+	if !m.IsEmpty() || m.Contains("b") || m.Stats().Capacity == 0 {
+		t.Errorf("Expected an empty map that keeps its table, got %v", m)
+	}
+}
+
+func TestMatchesBuiltinMap(t *testing.T) {
+	hashers := map[string]hashutil.Hasher[int]{
+		"default": hashutil.Comparable[int](),
+		// Every key collides, exercising long probe runs and tombstones
+		"constant": hashutil.Func[int](func(int) uint64 { return 7 }),
+		// Few distinct home slots
+		"clustered": hashutil.Func[int](func(k int) uint64 { return uint64(k % 4) }),
+	}
+
+	for name, h := range hashers {
+		rng := rand.New(rand.NewSource(1))
+		m := NewWithHasher[int, int](h, 0)
+		ref := make(map[int]int)
+
+		keys := 2000
+		if name == "constant" {
+			keys = 200
+		}
+
+		for step := 0; step < 20000; step++ {
+			k := rng.Intn(keys)
+			if rng.Intn(3) == 0 {
+				_, present := ref[k]
+				if m.Delete(k) != present {
+					t.Fatalf("%s step %d: Delete(%d) disagreed with map", name, step, k)
+				}
+				delete(ref, k)
+			} else {
+				_, present := ref[k]
+				if m.Put(k, step) == present {
+					t.Fatalf("%s step %d: Put(%d) disagreed with map", name, step, k)
+				}
+				ref[k] = step
+			}
+
+			if step%1000 == 0 {
+				checkInvariants(t, m)
+			}
+		}
+
+		checkInvariants(t, m)
+		if m.Size() != len(ref) {
+			t.Fatalf("%s: expected size %d, got %d", name, len(ref), m.Size())
+		}
+		for k := 0; k < keys; k++ {
+			v, ok := m.Get(k)
+			if expected, present := ref[k]; ok != present || v != expected {
+				t.Fatalf("%s: Get(%d) = %d, %t; expected %d, %t", name, k, v, ok, expected, present)
+			}
+		}
+
+		seen := 0
+		for k, v := range m.All() {
+			if ref[k] != v {
+				t.Fatalf("%s: iteration yielded %d=%d, expected %d", name, k, v, ref[k])
+			}
+			seen++
+		}
+		if seen != len(ref) {
+			t.Fatalf("%s: iteration yielded %d entries, expected %d", name, seen, len(ref))
+		}
+	}
+}
+
+func TestTombstones(t *testing.T) {
+	// With a constant hash every key shares one probe run
+	m := NewWithHasher[int, string](hashutil.Func[int](func(int) uint64 { return 0 }), 8)
+	for i := 0; i < 5; i++ {
+		m.Put(i, "v")
+	}
+
+	// Deleting from the middle of the run leaves a tombstone
+	m.Delete(2)
+	if m.Stats().Tombstones != 1 {
+		t.Errorf("Expected 1 tombstone, got %v", m.Stats())
+	}
+	if !m.Contains(3) || !m.Contains(4) {
+		t.Error("Expected lookups to probe past the tombstone")
+	}
+	checkInvariants(t, m)
+
+	// Deleting the end of the run reclaims it and the tombstone before it
+	m.Delete(4)
+	m.Delete(3)
+	if st := m.Stats(); st.Tombstones != 0 || st.Len != 2 {
+		t.Errorf("Expected trailing tombstones to be reclaimed, got %+v", st)
+	}
+	checkInvariants(t, m)
+
+	// Reinsertion reuses freed slots
+	m.Put(9, "w")
+	m.Delete(0)
+	capacity := m.Stats().Capacity
+	m.Compact()
+	if st := m.Stats(); st.Tombstones != 0 || st.Len != 2 || st.Capacity != capacity {
+		t.Errorf("Expected Compact to purge tombstones in place, got %+v", st)
+	}
+	checkInvariants(t, m)
+}
+
+func TestChurnDoesNotGrow(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 100; i++ {
+		m.Put(i, i)
+	}
+	capacity := m.Stats().Capacity
+
+	// A steady set size with constant churn rebuilds in place instead of growing
+	for i := 100; i < 100000; i++ {
+		m.Put(i, i)
+		m.Delete(i - 100)
+	}
+	if st := m.Stats(); st.Len != 100 || st.Capacity > capacity*2 {
+		t.Errorf("Expected capacity to stay near %d, got %+v", capacity, st)
+	}
+}
+
+func TestProbeLengths(t *testing.T) {
+	m := NewWithHasher[int, int](hashutil.Int[int](), 100000)
+	for i := 0; i < 100000; i++ {
+		m.Put(i, i)
+	}
+	if st := m.Stats(); st.AvgProbe > 2 || st.MaxProbe > 32 {
+		t.Errorf("Expected short Robin Hood probes, got %+v", st)
+	}
+}
+
+// Benchmark tests
+func BenchmarkPut(b *testing.B) {
+	m := New[int, int]()
+	for i := 0; i < b.N; i++ {
+		m.Put(i&0xffff, i)
+	}
+}
+
+func BenchmarkBuiltinPut(b *testing.B) {
+	m := make(map[int]int)
+	for i := 0; i < b.N; i++ {
+		m[i&0xffff] = i
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	m := NewWithHasher[int, int](hashutil.Int[int](), 1<<16)
+	for i := 0; i < 1<<16; i++ {
+		m.Put(i, i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Get(i & 0xffff)
+	}
+}
+
+func BenchmarkBuiltinGet(b *testing.B) {
+	m := make(map[int]int, 1<<16)
+	for i := 0; i < 1<<16; i++ {
+		m[i] = i
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = m[i&0xffff]
+	}
+}
@@ -0,0 +1,101 @@
+package hashutil
+
+import (
+	"math/bits"
+	"testing"
+)
+
+type point struct {
+	X, Y  int
+	Label string
+}
+
+func TestDefaultHashers(t *testing.T) {
+	s := String()
+	if s.Hash("a") != s.Hash("a") || s.Hash("a") == s.Hash("b") {
+		t.Error("Unexpected string hashes")
+	}
+
+	b := Bytes()
+	if b.Hash([]byte("abc")) != b.Hash([]byte("abc")) {
+		t.Error("Expected byte hashes to depend only on contents")
+	}
+
+	c := Comparable[point]()
+	if c.Hash(point{1, 2, "a"}) != c.Hash(point{1, 2, "a"}) || c.Hash(point{1, 2, "a"}) == c.Hash(point{2, 1, "a"}) {
+		t.Error("Unexpected comparable hashes")
+	}
+
+	// Int is deterministic across hasher instances
+	if Int[int]().Hash(42) != Int[int]().Hash(42) || Int[uint8]().Hash(42) != Int[int]().Hash(42) {
+		t.Error("Expected Int hashes to be deterministic")
+	}
+}
+
+func TestMix64Avalanche(t *testing.T) {
+	// Flipping one input bit should flip about half of the output bits
+	total := 0
+	for i := 0; i < 64; i++ {
+		total += bits.OnesCount64(Mix64(12345) ^ Mix64(12345^(1<<i)))
+	}
+	if avg := float64(total) / 64; avg < 28 || avg > 36 {
+		t.Errorf("Expected about 32 flipped bits on average, got %.1f", avg)
+	}
+	if Mix64(0) == Mix64(1) {
+		t.Error("Expected distinct inputs to hash differently")
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	byXY := Fields(
+		Field(func(p point) int { return p.X }, Int[int]()),
+		Field(func(p point) int { return p.Y }, Int[int]()),
+	)
+
+	if byXY.Hash(point{1, 2, "a"}) != byXY.Hash(point{1, 2, "b"}) {
+		t.Error("Expected fields outside the key to be ignored")
+	}
+	if byXY.Hash(point{1, 2, ""}) == byXY.Hash(point{2, 1, ""}) {
+		t.Error("Expected field order to matter")
+	}
+
+	if Combine(1, 2) == Combine(2, 1) {
+		t.Error("Expected Combine to be order-sensitive")
+	}
+
+	s := Slice(Int[int]())
+	if s.Hash([]int{1, 2}) != s.Hash([]int{1, 2}) || s.Hash([]int{1, 2}) == s.Hash([]int{2, 1}) {
+		t.Error("Unexpected slice hashes")
+	}
+	if s.Hash(nil) != s.Hash([]int{}) || s.Hash([]int{0}) == s.Hash(nil) {
+		t.Error("Expected length to be part of slice hashes")
+	}
+}
+
+func TestDistribution(t *testing.T) {
+	h := Int[int]()
+	var buckets [16]int
+	for i := 0; i < 16000; i++ {
+		buckets[h.Hash(i)%16]++
+	}
+	for i, n := range buckets {
+		if n < 800 || n > 1200 {
+			t.Errorf("Bucket %d has %d entries, expected about 1000", i, n)
+		}
+	}
+}
+
+// Benchmark tests
+func BenchmarkString(b *testing.B) {
+	h := String()
+	for i := 0; i < b.N; i++ {
+		h.Hash("benchmark-key")
+	}
+}
+
+func BenchmarkInt(b *testing.B) {
+	h := Int[int]()
+	for i := 0; i < b.N; i++ {
+		h.Hash(i)
+	}
+}
@@ -0,0 +1,44 @@
+// Package testclock provides the manually advanced clock that tests of
+// time-dependent structures install in place of time.Now
+package testclock
+
+import (
+	"sync"
+	"time"
+)
+
+// Start is the time every new Clock reads until it is moved
+var Start = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Clock is a fake clock that only moves when told to
+// It is safe for concurrent use, so a structure may read it from its own goroutines
+type Clock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+// New creates a clock reading Start
+func New() *Clock {
+	return &Clock{t: Start}
+}
+
+// Now returns the current fake time; pass the method value as a now function
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+// Advance moves the clock by d, which may be negative
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+// Set moves the clock to t
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = t
+}
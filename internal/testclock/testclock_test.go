@@ -0,0 +1,24 @@
+package testclock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClock(t *testing.T) {
+	c := New()
+	if !c.Now().Equal(Start) {
+		t.Errorf("Expected %v, got %v", Start, c.Now())
+	}
+
+	c.Advance(time.Minute)
+	c.Advance(-time.Second)
+	if expected := Start.Add(59 * time.Second); !c.Now().Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, c.Now())
+	}
+
+	c.Set(Start)
+	if !c.Now().Equal(Start) {
+		t.Errorf("Expected Set to move the clock back to %v, got %v", Start, c.Now())
+	}
+}
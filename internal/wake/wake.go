@@ -0,0 +1,22 @@
+// Package wake provides the wake-up signal the blocking structures share to
+// rouse a waiting goroutine without holding their lock while it waits
+package wake
+
+// Signal is a wake-up channel holding at most one pending notification
+// Waiters receive from it in a select next to their context; a woken waiter
+// must recheck its condition, since a notification only means it may hold
+type Signal chan struct{}
+
+// New creates a signal with no pending notification
+func New() Signal {
+	return make(Signal, 1)
+}
+
+// Notify leaves a notification for one waiter without blocking; it does
+// nothing if one is already pending
+func (s Signal) Notify() {
+	select {
+	case s <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,22 @@
+package wake
+
+import "testing"
+
+func TestSignal(t *testing.T) {
+	s := New()
+	select {
+	case <-s:
+		t.Fatal("Expected no pending notification")
+	default:
+	}
+
+	// Notifications coalesce rather than block
+	s.Notify()
+	s.Notify()
+	<-s
+	select {
+	case <-s:
+		t.Error("Expected repeated notifications to coalesce into one")
+	default:
+	}
+}
@@ -0,0 +1,222 @@
+// Package intervalset maintains a set of disjoint half-open intervals
+package intervalset
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/anwar-arif/golang-dsa/collections"
+)
+
+// Interval is the half-open range [Lo, Hi)
+type Interval[T cmp.Ordered] struct {
+	Lo T
+	Hi T
+}
+
+// IsEmpty reports whether the interval contains no points
+func (iv Interval[T]) IsEmpty() bool {
+	return iv.Lo >= iv.Hi
+}
+
+// Contains reports whether p lies in the interval
+func (iv Interval[T]) Contains(p T) bool {
+	return iv.Lo <= p && p < iv.Hi
+}
+
+// String returns a string representation of the interval
+func (iv Interval[T]) String() string {
+	return fmt.Sprintf("[%v, %v)", iv.Lo, iv.Hi)
+}
+
+// Set is a set of points stored as sorted, non-overlapping intervals
+// Overlapping or touching intervals are merged on insert, so [1, 3) and
+// [3, 5) become [1, 5); removing a range from the middle of an interval
+// splits it in two
+// Lookups are O(log n) in the number of intervals; Add and Remove also shift
+// the intervals after the change
+type Set[T cmp.Ordered] struct {
+	intervals []Interval[T]
+}
+
+// Set satisfies the shared collection interface
+var _ collections.Collection[Interval[int]] = (*Set[int])(nil)
+
+// New creates an empty interval set
+func New[T cmp.Ordered]() *Set[T] {
+	return &Set[T]{}
+}
+
+// search returns the index of the first interval for which pred holds;
+// pred must be false for a prefix of the intervals and true afterwards
+func (s *Set[T]) search(pred func(iv Interval[T]) bool) int {
+	return sort.Search(len(s.intervals), func(i int) bool {
+		return pred(s.intervals[i])
+	})
+}
+
+// Add inserts [lo, hi), merging it with every interval it overlaps or touches
+// Empty ranges are ignored
+func (s *Set[T]) Add(lo, hi T) {
+	if lo >= hi {
+		return
+	}
+
+	i := s.search(func(iv Interval[T]) bool { return iv.Hi >= lo })
+	j := s.search(func(iv Interval[T]) bool { return iv.Lo > hi })
+
+	merged := Interval[T]{Lo: lo, Hi: hi}
+	if i < j {
+		merged.Lo = min(lo, s.intervals[i].Lo)
+		merged.Hi = max(hi, s.intervals[j-1].Hi)
+	}
+	s.intervals = slices.Replace(s.intervals, i, j, merged)
+}
+
+// Remove deletes every point in [lo, hi), splitting intervals that straddle
+// either end
+func (s *Set[T]) Remove(lo, hi T) {
+	if lo >= hi {
+		return
+	}
+
+	i := s.search(func(iv Interval[T]) bool { return iv.Hi > lo })
+	j := s.search(func(iv Interval[T]) bool { return iv.Lo >= hi })
+	if i >= j {
+		return
+	}
+
+	kept := make([]Interval[T], 0, 2)
+	if first := s.intervals[i]; first.Lo < lo {
+		kept = append(kept, Interval[T]{Lo: first.Lo, Hi: lo})
+	}
+	if last := s.intervals[j-1]; last.Hi > hi {
+		kept = append(kept, Interval[T]{Lo: hi, Hi: last.Hi})
+	}
+	s.intervals = slices.Replace(s.intervals, i, j, kept...)
+}
+
+// Find returns the interval containing p
+func (s *Set[T]) Find(p T) (Interval[T], bool) {
+	i := s.search(func(iv Interval[T]) bool { return iv.Hi > p })
+	if i < len(s.intervals) && s.intervals[i].Lo <= p {
+		return s.intervals[i], true
+	}
+	return Interval[T]{}, false
+}
+
+// Contains reports whether p is in the set
+func (s *Set[T]) Contains(p T) bool {
+	_, ok := s.Find(p)
+	return ok
+}
+
+// ContainsRange reports whether every point of [lo, hi) is in the set
+// Empty ranges are always contained
+func (s *Set[T]) ContainsRange(lo, hi T) bool {
+	if lo >= hi {
+		return true
+	}
+	iv, ok := s.Find(lo)
+	return ok && iv.Hi >= hi
+}
+
+// Overlaps reports whether any point of [lo, hi) is in the set
+func (s *Set[T]) Overlaps(lo, hi T) bool {
+	if lo >= hi {
+		return false
+	}
+	i := s.search(func(iv Interval[T]) bool { return iv.Hi > lo })
+	return i < len(s.intervals) && s.intervals[i].Lo < hi
+}
+
+// Gaps returns the parts of [lo, hi) that are not in the set, in order
+func (s *Set[T]) Gaps(lo, hi T) []Interval[T] {
+	var gaps []Interval[T]
+	if lo >= hi {
+		return gaps
+	}
+
+	cur := lo
+	for i := s.search(func(iv Interval[T]) bool { return iv.Hi > lo }); i < len(s.intervals); i++ {
+		iv := s.intervals[i]
+		if iv.Lo >= hi {
+			break
+		}
+		if iv.Lo > cur {
+			gaps = append(gaps, Interval[T]{Lo: cur, Hi: iv.Lo})
+		}
+		cur = max(cur, iv.Hi)
+	}
+	if cur < hi {
+		gaps = append(gaps, Interval[T]{Lo: cur, Hi: hi})
+	}
+	return gaps
+}
+
+// Intervals returns a copy of the intervals in ascending order
+func (s *Set[T]) Intervals() []Interval[T] {
+	return slices.Clone(s.intervals)
+}
+
+// All returns an iterator over the intervals in ascending order
+func (s *Set[T]) All() iter.Seq[Interval[T]] {
+	return slices.Values(s.intervals)
+}
+
+// Size returns the number of disjoint intervals
+func (s *Set[T]) Size() int {
+	return len(s.intervals)
+}
+
+// IsEmpty returns true if the set holds no points
+func (s *Set[T]) IsEmpty() bool {
+	return len(s.intervals) == 0
+}
+
+// Clear removes every interval
+func (s *Set[T]) Clear() {
+	s.intervals = s.intervals[:0]
+}
+
+// String returns a string representation of the set
+func (s *Set[T]) String() string {
+	parts := make([]string, len(s.intervals))
+	for i, iv := range s.intervals {
+		parts[i] = iv.String()
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Interval Set Examples ===")
+
+	// Example 1: Room bookings in minutes since midnight
+	fmt.Println("1. Room Bookings:")
+	booked := New[int]()
+	booked.Add(540, 600) // 9:00-10:00
+	booked.Add(600, 630) // 10:00-10:30, merges with the previous booking
+	booked.Add(780, 840) // 13:00-14:00
+	fmt.Printf("  Booked: %v\n", booked)
+	fmt.Printf("  Free 9:00-17:00: %v\n", booked.Gaps(540, 1020))
+	fmt.Printf("  Can book 10:15-10:45: %t\n", !booked.Overlaps(615, 645))
+
+	// Example 2: Cancelling part of a booking splits it
+	fmt.Println("\n2. Cancel 9:30-10:00:")
+	booked.Remove(570, 600)
+	fmt.Printf("  Booked: %v\n", booked)
+
+	// Example 3: IP ranges
+	fmt.Println("\n3. Blocked IPv4 Ranges:")
+	ip := func(a, b, c, d uint32) uint32 { return a<<24 | b<<16 | c<<8 | d }
+	blocked := New[uint32]()
+	blocked.Add(ip(10, 0, 0, 0), ip(11, 0, 0, 0))
+	blocked.Add(ip(192, 168, 0, 0), ip(192, 169, 0, 0))
+	fmt.Printf("  10.1.2.3 blocked: %t\n", blocked.Contains(ip(10, 1, 2, 3)))
+	fmt.Printf("  8.8.8.8 blocked: %t\n", blocked.Contains(ip(8, 8, 8, 8)))
+}
@@ -0,0 +1,151 @@
+package intervalset
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+type iv = Interval[int]
+
+func TestAddMerges(t *testing.T) {
+	tests := []struct {
+		name     string
+		adds     [][2]int
+		expected []iv
+	}{
+		{"disjoint", [][2]int{{5, 7}, {1, 3}}, []iv{{1, 3}, {5, 7}}},
+		{"touching", [][2]int{{1, 3}, {3, 5}}, []iv{{1, 5}}},
+		{"overlapping", [][2]int{{1, 4}, {3, 6}}, []iv{{1, 6}}},
+		{"spanning", [][2]int{{1, 2}, {4, 5}, {7, 8}, {0, 10}}, []iv{{0, 10}}},
+		{"bridging", [][2]int{{1, 2}, {4, 5}, {7, 8}, {2, 4}}, []iv{{1, 5}, {7, 8}}},
+		{"contained", [][2]int{{1, 10}, {3, 4}}, []iv{{1, 10}}},
+		{"empty", [][2]int{{3, 3}, {5, 1}}, nil},
+	}
+
+	for _, tt := range tests {
+		s := New[int]()
+		for _, a := range tt.adds {
+			s.Add(a[0], a[1])
+		}
+		if got := s.Intervals(); !slices.Equal(got, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestRemoveSplits(t *testing.T) {
+	tests := []struct {
+		name     string
+		lo, hi   int
+		expected []iv
+	}{
+		{"middle", 3, 5, []iv{{0, 3}, {5, 10}, {20, 30}}},
+		{"prefix", 0, 4, []iv{{4, 10}, {20, 30}}},
+		{"across", 8, 25, []iv{{0, 8}, {25, 30}}},
+		{"whole", 0, 10, []iv{{20, 30}}},
+		{"gap", 12, 18, []iv{{0, 10}, {20, 30}}},
+		{"everything", -5, 50, nil},
+	}
+
+	for _, tt := range tests {
+		s := New[int]()
+		s.Add(0, 10)
+		s.Add(20, 30)
+		s.Remove(tt.lo, tt.hi)
+		if got := s.Intervals(); !slices.Equal(got, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestQueries(t *testing.T) {
+	s := New[int]()
+	s.Add(0, 10)
+	s.Add(20, 30)
+
+	for p, expected := range map[int]bool{-1: false, 0: true, 9: true, 10: false, 15: false, 20: true, 30: false} {
+		if s.Contains(p) != expected {
+			t.Errorf("Contains(%d): expected %t", p, expected)
+		}
+	}
+
+	if found, ok := s.Find(25); !ok || found != (iv{20, 30}) {
+		t.Errorf("Expected [20, 30), got %v", found)
+	}
+	if !s.ContainsRange(2, 10) || s.ContainsRange(5, 21) || !s.ContainsRange(7, 7) {
+		t.Error("Unexpected ContainsRange result")
+	}
+	if !s.Overlaps(9, 20) || s.Overlaps(10, 20) || s.Overlaps(40, 50) {
+		t.Error("Unexpected Overlaps result")
+	}
+
+	expected := []iv{{-5, 0}, {10, 20}, {30, 35}}
+	if got := s.Gaps(-5, 35); !slices.Equal(got, expected) {
+		t.Errorf("Expected gaps %v, got %v", expected, got)
+	}
+	if got := s.Gaps(2, 8); len(got) != 0 {
+		t.Errorf("Expected no gaps inside an interval, got %v", got)
+	}
+
+	if s.String() != "{[0, 10) [20, 30)}" {
+		t.Errorf("Unexpected string %s", s)
+	}
+}
+
+func TestMatchesBitmap(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	s := New[int]()
+	var ref [100]bool
+
+	for step := 0; step < 2000; step++ {
+		lo := rng.Intn(100)
+		hi := lo + rng.Intn(20)
+		hi = min(hi, 100)
+
+		add := rng.Intn(2) == 0
+		if add {
+			s.Add(lo, hi)
+		} else {
+			s.Remove(lo, hi)
+		}
+		for p := lo; p < hi; p++ {
+			ref[p] = add
+		}
+
+		for p := range ref {
+			if s.Contains(p) != ref[p] {
+				t.Fatalf("Step %d: Contains(%d) = %t, expected %t", step, p, !ref[p], ref[p])
+			}
+		}
+
+		// Intervals must stay sorted, non-empty and non-touching
+		ivs := s.Intervals()
+		for i, x := range ivs {
+			if x.IsEmpty() || i > 0 && ivs[i-1].Hi >= x.Lo {
+				t.Fatalf("Step %d: invalid intervals %v", step, ivs)
+			}
+		}
+	}
+}
+
+// Benchmark tests
+func BenchmarkAdd(b *testing.B) {
+	s := New[int]()
+	for i := 0; i < b.N; i++ {
+		lo := (i * 7919) % 1000000
+		s.Add(lo, lo+3)
+	}
+}
+
+func BenchmarkContains(b *testing.B) {
+	s := New[int]()
+	for i := 0; i < 10000; i++ {
+		s.Add(i*10, i*10+5)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(i % 100000)
+	}
+}
@@ -0,0 +1,209 @@
+package linkedlist
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/anwar-arif/golang-dsa/alloc"
+	"github.com/anwar-arif/golang-dsa/collections"
+)
+
+// Circular is a singly linked circular list
+// Only the tail is stored; tail.Next is always the front
+type Circular[T any] struct {
+	tail  *Node[T]
+	size  int
+	alloc alloc.Allocator[Node[T]] // optional, see NewCircularWithAllocator
+}
+
+// Circular satisfies the shared collection interfaces
+var (
+	_ collections.Collection[int]        = (*Circular[int])(nil)
+	_ collections.Cloner[*Circular[int]] = (*Circular[int])(nil)
+)
+
+// NewCircular creates a new empty circular list
+func NewCircular[T any]() *Circular[T] {
+	return &Circular[T]{}
+}
+
+// NewCircularWithAllocator creates a new empty circular list that takes its
+// nodes from a and hands them back when they are removed
+func NewCircularWithAllocator[T any](a alloc.Allocator[Node[T]]) *Circular[T] {
+	return &Circular[T]{alloc: a}
+}
+
+// PushFront adds a value before the current front
+func (l *Circular[T]) PushFront(value T) {
+	n := allocate(l.alloc)
+	n.Value = value
+	if l.tail == nil {
+		n.Next = n
+		l.tail = n
+	} else {
+		n.Next = l.tail.Next
+		l.tail.Next = n
+	}
+	l.size++
+}
+
+// PushBack adds a value after the current back
+func (l *Circular[T]) PushBack(value T) {
+	l.PushFront(value)
+	l.tail = l.tail.Next
+}
+
+// PopFront removes and returns the value at the front
+func (l *Circular[T]) PopFront() (T, error) {
+	var zero T
+	if l.tail == nil {
+		return zero, ErrEmpty
+	}
+
+	front := l.tail.Next
+	value := front.Value
+	if front == l.tail {
+		l.tail = nil
+	} else {
+		l.tail.Next = front.Next
+	}
+	l.size--
+	release(l.alloc, front)
+	return value, nil
+}
+
+// Front returns the value at the front
+func (l *Circular[T]) Front() (T, error) {
+	var zero T
+	if l.tail == nil {
+		return zero, ErrEmpty
+	}
+	return l.tail.Next.Value, nil
+}
+
+// Back returns the value at the back
+func (l *Circular[T]) Back() (T, error) {
+	var zero T
+	if l.tail == nil {
+		return zero, ErrEmpty
+	}
+	return l.tail.Value, nil
+}
+
+// Rotate advances the front by n positions, so the element at index n
+// becomes the front; n is taken modulo Size, so a negative n moves the front
+// back
+func (l *Circular[T]) Rotate(n int) {
+	if l.size == 0 {
+		return
+	}
+	for n = (n%l.size + l.size) % l.size; n > 0; n-- {
+		l.tail = l.tail.Next
+	}
+}
+
+// Next returns the value at the front and advances the front past it, so
+// repeated calls cycle through the values forever, round-robin style
+func (l *Circular[T]) Next() (T, error) {
+	var zero T
+	if l.tail == nil {
+		return zero, ErrEmpty
+	}
+	l.tail = l.tail.Next
+	return l.tail.Value, nil
+}
+
+// Eliminate removes every k-th value, counting around the ring from the
+// front, until the list is empty and returns the values in removal order,
+// as in the Josephus problem. O(n*k)
+// Panics if k < 1
+func (l *Circular[T]) Eliminate(k int) []T {
+	if k < 1 {
+		panic(fmt.Sprintf("linkedlist: elimination step must be at least 1, got %d", k))
+	}
+	order := make([]T, 0, l.size)
+	for l.size > 0 {
+		l.Rotate(k - 1)
+		v, _ := l.PopFront()
+		order = append(order, v)
+	}
+	return order
+}
+
+// Josephus returns the order in which people 1 to n standing in a circle are
+// eliminated when every k-th is removed; the last is the survivor
+// Panics if k < 1
+func Josephus(n, k int) []int {
+	l := NewCircular[int]()
+	for i := 1; i <= n; i++ {
+		l.PushBack(i)
+	}
+	return l.Eliminate(k)
+}
+
+// SpliceBack moves every element of other to the back of l in O(1)
+// other is left empty
+func (l *Circular[T]) SpliceBack(other *Circular[T]) {
+	if other == l || other.tail == nil {
+		return
+	}
+	if l.tail != nil {
+		// Swapping the successors of the two tails joins the rings
+		l.tail.Next, other.tail.Next = other.tail.Next, l.tail.Next
+	}
+	l.tail = other.tail
+	l.size += other.size
+	other.Clear()
+}
+
+// Size returns the number of elements in the list
+func (l *Circular[T]) Size() int {
+	return l.size
+}
+
+// IsEmpty returns true if the list is empty
+func (l *Circular[T]) IsEmpty() bool {
+	return l.size == 0
+}
+
+// Clear removes all elements from the list
+func (l *Circular[T]) Clear() {
+	l.tail = nil
+	l.size = 0
+}
+
+// Clone returns a copy of the list in O(n) that uses the same allocator
+func (l *Circular[T]) Clone() *Circular[T] {
+	return l.CloneWith(func(v T) T { return v })
+}
+
+// CloneWith returns a copy of the list with every value passed through
+// copyFn, for values that need a deep copy
+func (l *Circular[T]) CloneWith(copyFn func(T) T) *Circular[T] {
+	clone := NewCircularWithAllocator(l.alloc)
+	for v := range l.All() {
+		clone.PushBack(copyFn(v))
+	}
+	return clone
+}
+
+// All returns an iterator over one full turn of the ring, starting at the front
+func (l *Circular[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if l.tail == nil {
+			return
+		}
+		n := l.tail.Next
+		for i := 0; i < l.size; i++ {
+			if !yield(n.Value) {
+				return
+			}
+			n = n.Next
+		}
+	}
+}
+
+// String returns a string representation of the list
+func (l *Circular[T]) String() string {
+	return format(l.All(), " -> ")
+}
@@ -0,0 +1,428 @@
+package linkedlist
+
+import (
+	"iter"
+
+	"github.com/anwar-arif/golang-dsa/alloc"
+	"github.com/anwar-arif/golang-dsa/collections"
+)
+
+// Element is a node of a doubly linked list
+// Each element records the list that owns it, as container/list does, so
+// the methods taking an element ignore one that was removed or belongs to
+// another list instead of corrupting it
+type Element[T any] struct {
+	Value T
+	next  *Element[T]
+	prev  *Element[T]
+	owner *owner[T] // nil once removed
+}
+
+// owner stands for a list in its elements; splicing forwards the owner of
+// the emptied list to the receiving list instead of visiting every element,
+// and lookups follow the forwards with path compression as in union-find
+type owner[T any] struct {
+	forward *owner[T] // set once the elements have moved to another list
+}
+
+// resolve returns the owner at the end of the forwarding chain, pointing
+// every owner on the way straight at it
+func (o *owner[T]) resolve() *owner[T] {
+	root := o
+	for root.forward != nil {
+		root = root.forward
+	}
+	for o != root {
+		next := o.forward
+		o.forward = root
+		o = next
+	}
+	return root
+}
+
+// Next returns the following element, or nil at the back
+func (e *Element[T]) Next() *Element[T] {
+	return e.next
+}
+
+// Prev returns the preceding element, or nil at the front
+func (e *Element[T]) Prev() *Element[T] {
+	return e.prev
+}
+
+// Doubly is a doubly linked list
+type Doubly[T any] struct {
+	head  *Element[T]
+	tail  *Element[T]
+	size  int
+	owner *owner[T]                   // created on the first insert
+	alloc alloc.Allocator[Element[T]] // optional, see NewDoublyWithAllocator
+}
+
+// Doubly satisfies the shared collection interfaces
+var (
+	_ collections.Collection[int]      = (*Doubly[int])(nil)
+	_ collections.Cloner[*Doubly[int]] = (*Doubly[int])(nil)
+)
+
+// NewDoubly creates a new empty doubly linked list
+func NewDoubly[T any]() *Doubly[T] {
+	return &Doubly[T]{}
+}
+
+// NewDoublyWithAllocator creates a new empty doubly linked list that takes its
+// elements from a and hands them back when they are removed
+// Removed elements may be reused right away, so callers must drop their
+// handles to them
+func NewDoublyWithAllocator[T any](a alloc.Allocator[Element[T]]) *Doubly[T] {
+	return &Doubly[T]{alloc: a}
+}
+
+// newElement returns an unlinked element holding value
+func (l *Doubly[T]) newElement(value T) *Element[T] {
+	e := allocate(l.alloc)
+	e.Value = value
+	return e
+}
+
+// owns reports whether e is an element of l
+func (l *Doubly[T]) owns(e *Element[T]) bool {
+	return e != nil && e.owner != nil && l.owner != nil && e.owner.resolve() == l.owner
+}
+
+// Front returns the first element, or nil for an empty list
+func (l *Doubly[T]) Front() *Element[T] {
+	return l.head
+}
+
+// Back returns the last element, or nil for an empty list
+func (l *Doubly[T]) Back() *Element[T] {
+	return l.tail
+}
+
+// insertAfter links e after at, or at the front when at is nil
+func (l *Doubly[T]) insertAfter(e, at *Element[T]) *Element[T] {
+	if l.owner == nil {
+		l.owner = &owner[T]{}
+	}
+	e.owner = l.owner
+	if at == nil {
+		e.next = l.head
+		if l.head != nil {
+			l.head.prev = e
+		}
+		l.head = e
+	} else {
+		e.prev = at
+		e.next = at.next
+		if at.next != nil {
+			at.next.prev = e
+		}
+		at.next = e
+	}
+	if e.next == nil {
+		l.tail = e
+	}
+	l.size++
+	return e
+}
+
+// PushFront adds a value at the front and returns its element
+func (l *Doubly[T]) PushFront(value T) *Element[T] {
+	return l.insertAfter(l.newElement(value), nil)
+}
+
+// PushBack adds a value at the back and returns its element
+func (l *Doubly[T]) PushBack(value T) *Element[T] {
+	return l.insertAfter(l.newElement(value), l.tail)
+}
+
+// InsertAfter adds a value right after mark and returns its element
+// It returns nil and leaves the list alone if mark is not an element of l
+func (l *Doubly[T]) InsertAfter(value T, mark *Element[T]) *Element[T] {
+	if !l.owns(mark) {
+		return nil
+	}
+	return l.insertAfter(l.newElement(value), mark)
+}
+
+// InsertBefore adds a value right before mark and returns its element
+// It returns nil and leaves the list alone if mark is not an element of l
+func (l *Doubly[T]) InsertBefore(value T, mark *Element[T]) *Element[T] {
+	if !l.owns(mark) {
+		return nil
+	}
+	return l.insertAfter(l.newElement(value), mark.prev)
+}
+
+// Remove unlinks e from the list in O(1) and returns its value
+// With an allocator, e goes back to it and must not be used afterwards
+// An element that was already removed or belongs to another list is left
+// alone, and its value is still returned
+func (l *Doubly[T]) Remove(e *Element[T]) T {
+	if !l.owns(e) {
+		return e.Value
+	}
+	l.unlink(e)
+	value := e.Value
+	release(l.alloc, e)
+	return value
+}
+
+// unlink detaches e from its neighbours without releasing it
+func (l *Doubly[T]) unlink(e *Element[T]) {
+	if e.prev == nil {
+		l.head = e.next
+	} else {
+		e.prev.next = e.next
+	}
+	if e.next == nil {
+		l.tail = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+	e.next, e.prev, e.owner = nil, nil, nil
+	l.size--
+}
+
+// MoveToFront moves e to the front of the list in O(1), keeping the handle valid
+// It does nothing if e is not an element of l
+func (l *Doubly[T]) MoveToFront(e *Element[T]) {
+	if l.head == e || !l.owns(e) {
+		return
+	}
+	l.unlink(e)
+	l.insertAfter(e, nil)
+}
+
+// MoveToBack moves e to the back of the list in O(1), keeping the handle valid
+// It does nothing if e is not an element of l
+func (l *Doubly[T]) MoveToBack(e *Element[T]) {
+	if l.tail == e || !l.owns(e) {
+		return
+	}
+	l.unlink(e)
+	l.insertAfter(e, l.tail)
+}
+
+// MoveAfter moves e right after mark in O(1), keeping the handle valid
+// It does nothing unless both e and mark are elements of l
+func (l *Doubly[T]) MoveAfter(e, mark *Element[T]) {
+	if e == mark || !l.owns(e) || !l.owns(mark) || mark.next == e {
+		return
+	}
+	l.unlink(e)
+	l.insertAfter(e, mark)
+}
+
+// MoveBefore moves e right before mark in O(1), keeping the handle valid
+// It does nothing unless both e and mark are elements of l
+func (l *Doubly[T]) MoveBefore(e, mark *Element[T]) {
+	if e == mark || !l.owns(e) || !l.owns(mark) || mark.prev == e {
+		return
+	}
+	l.unlink(e)
+	l.insertAfter(e, mark.prev)
+}
+
+// PopFront removes and returns the value at the front
+func (l *Doubly[T]) PopFront() (T, error) {
+	var zero T
+	if l.head == nil {
+		return zero, ErrEmpty
+	}
+	return l.Remove(l.head), nil
+}
+
+// PopBack removes and returns the value at the back
+func (l *Doubly[T]) PopBack() (T, error) {
+	var zero T
+	if l.tail == nil {
+		return zero, ErrEmpty
+	}
+	return l.Remove(l.tail), nil
+}
+
+// elementAt returns the element at index i, walking from the nearer end
+func (l *Doubly[T]) elementAt(i int) *Element[T] {
+	if i < l.size/2 {
+		e := l.head
+		for ; i > 0; i-- {
+			e = e.next
+		}
+		return e
+	}
+	e := l.tail
+	for i = l.size - 1 - i; i > 0; i-- {
+		e = e.prev
+	}
+	return e
+}
+
+// Get returns the value at index i
+func (l *Doubly[T]) Get(i int) (T, error) {
+	var zero T
+	if err := checkIndex(i, l.size-1); err != nil {
+		return zero, err
+	}
+	return l.elementAt(i).Value, nil
+}
+
+// InsertAt inserts a value so that it ends up at index i
+// i may equal Size to append
+func (l *Doubly[T]) InsertAt(i int, value T) (*Element[T], error) {
+	if err := checkIndex(i, l.size); err != nil {
+		return nil, err
+	}
+	if i == l.size {
+		return l.PushBack(value), nil
+	}
+	return l.InsertBefore(value, l.elementAt(i)), nil
+}
+
+// RemoveAt removes and returns the value at index i
+func (l *Doubly[T]) RemoveAt(i int) (T, error) {
+	var zero T
+	if err := checkIndex(i, l.size-1); err != nil {
+		return zero, err
+	}
+	return l.Remove(l.elementAt(i)), nil
+}
+
+// Find returns the first element whose value matches pred, or nil
+func (l *Doubly[T]) Find(pred func(T) bool) *Element[T] {
+	for e := l.head; e != nil; e = e.next {
+		if pred(e.Value) {
+			return e
+		}
+	}
+	return nil
+}
+
+// Reverse reverses the list in place
+func (l *Doubly[T]) Reverse() {
+	for e := l.head; e != nil; e = e.prev {
+		e.next, e.prev = e.prev, e.next
+	}
+	l.head, l.tail = l.tail, l.head
+}
+
+// Middle returns the middle value
+// For an even number of elements the second of the two middles is returned
+func (l *Doubly[T]) Middle() (T, error) {
+	var zero T
+	if l.head == nil {
+		return zero, ErrEmpty
+	}
+	return l.elementAt(l.size / 2).Value, nil
+}
+
+// SpliceBack moves every element of other to the back of l in O(1)
+// other is left empty
+func (l *Doubly[T]) SpliceBack(other *Doubly[T]) {
+	if other == l || other.head == nil {
+		return
+	}
+	if l.tail == nil {
+		l.head = other.head
+	} else {
+		l.tail.next = other.head
+		other.head.prev = l.tail
+	}
+	l.tail = other.tail
+	l.size += other.size
+	l.adopt(other)
+}
+
+// SpliceFront moves every element of other to the front of l in O(1)
+// other is left empty
+func (l *Doubly[T]) SpliceFront(other *Doubly[T]) {
+	if other == l || other.head == nil {
+		return
+	}
+	if l.head == nil {
+		l.tail = other.tail
+	} else {
+		other.tail.next = l.head
+		l.head.prev = other.tail
+	}
+	l.head = other.head
+	l.size += other.size
+	l.adopt(other)
+}
+
+// adopt hands the elements of other, already linked into l, over to l in
+// O(1) by forwarding other's owner, and leaves other empty
+func (l *Doubly[T]) adopt(other *Doubly[T]) {
+	if l.owner == nil {
+		l.owner = &owner[T]{}
+	}
+	other.owner.forward = l.owner
+	other.head, other.tail, other.size, other.owner = nil, nil, 0, nil
+}
+
+// Size returns the number of elements in the list
+func (l *Doubly[T]) Size() int {
+	return l.size
+}
+
+// IsEmpty returns true if the list is empty
+func (l *Doubly[T]) IsEmpty() bool {
+	return l.size == 0
+}
+
+// Clear removes all elements from the list
+// Handles to the old elements are no longer recognised as belonging to l
+func (l *Doubly[T]) Clear() {
+	l.head = nil
+	l.tail = nil
+	l.size = 0
+	l.owner = nil
+}
+
+// Clone returns a copy of the list in O(n) that uses the same allocator
+func (l *Doubly[T]) Clone() *Doubly[T] {
+	return l.CloneWith(func(v T) T { return v })
+}
+
+// CloneWith returns a copy of the list with every value passed through
+// copyFn, for values that need a deep copy
+func (l *Doubly[T]) CloneWith(copyFn func(T) T) *Doubly[T] {
+	clone := NewDoublyWithAllocator(l.alloc)
+	for v := range l.All() {
+		clone.PushBack(copyFn(v))
+	}
+	return clone
+}
+
+// All returns an iterator over the values from front to back
+func (l *Doubly[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := l.head; e != nil; e = e.next {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the values from back to front
+func (l *Doubly[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := l.tail; e != nil; e = e.prev {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns the values from front to back
+func (l *Doubly[T]) ToSlice() []T {
+	return collections.Collect(l.All())
+}
+
+// String returns a string representation of the list
+func (l *Doubly[T]) String() string {
+	return format(l.All(), " <-> ")
+}
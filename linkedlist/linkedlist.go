@@ -0,0 +1,95 @@
+// Package linkedlist provides generic singly, doubly and circular linked lists
+package linkedlist
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anwar-arif/golang-dsa/alloc"
+)
+
+var (
+	// ErrEmpty is returned when reading from an empty list
+	ErrEmpty = errors.New("list is empty")
+	// ErrIndexOutOfRange is returned for positions outside the list
+	ErrIndexOutOfRange = errors.New("index out of range")
+)
+
+// checkIndex validates i against a list of the given size; max is size for
+// inserts and size-1 for reads and removals
+func checkIndex(i, max int) error {
+	if i < 0 || i > max {
+		return fmt.Errorf("%w: %d", ErrIndexOutOfRange, i)
+	}
+	return nil
+}
+
+// allocate returns a zeroed node from a, or from the heap when a is nil
+func allocate[N any](a alloc.Allocator[N]) *N {
+	if a == nil {
+		return new(N)
+	}
+	return a.New()
+}
+
+// release hands a node that is no longer referenced back to a, if any
+func release[N any](a alloc.Allocator[N], n *N) {
+	if a != nil {
+		a.Free(n)
+	}
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Linked List Examples ===")
+
+	// Example 1: Singly linked list
+	fmt.Println("1. Singly Linked List:")
+	s := NewSingly[int]()
+	for i := 1; i <= 5; i++ {
+		s.PushBack(i)
+	}
+	s.InsertAt(2, 99)
+	fmt.Printf("  After InsertAt(2, 99): %v\n", s)
+	mid, _ := s.Middle()
+	fmt.Printf("  Middle: %d\n", mid)
+	s.Reverse()
+	fmt.Printf("  Reversed: %v\n", s)
+
+	// Example 2: Doubly linked list with O(1) removal by element
+	fmt.Println("\n2. Doubly Linked List:")
+	d := NewDoubly[string]()
+	d.PushBack("b")
+	a := d.PushFront("a")
+	d.PushBack("c")
+	d.Remove(a)
+	fmt.Printf("  After removing a: %v\n", d)
+	d.MoveToFront(d.Back())
+	fmt.Printf("  After moving the back to the front: %v\n", d)
+
+	// Example 3: Splicing lists in O(1)
+	fmt.Println("\n3. Splice:")
+	other := NewDoubly[string]()
+	other.PushBack("x")
+	other.PushBack("y")
+	d.SpliceBack(other)
+	fmt.Printf("  Spliced: %v (other now has %d)\n", d, other.Size())
+
+	// Example 4: Josephus problem with a circular list
+	fmt.Println("\n4. Circular List (Josephus, n=7, k=3):")
+	order := Josephus(7, 3)
+	fmt.Printf("  Elimination order: %v, survivor %d\n", order, order[len(order)-1])
+
+	// Example 5: Round-robin turns with Next
+	fmt.Println("\n5. Round Robin:")
+	players := NewCircular[string]()
+	players.PushBack("ann")
+	players.PushBack("bob")
+	players.PushBack("cy")
+	turns := make([]string, 0, 5)
+	for range 5 {
+		p, _ := players.Next()
+		turns = append(turns, p)
+	}
+	fmt.Printf("  Turns: %v\n", turns)
+}
@@ -0,0 +1,477 @@
+package linkedlist
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/anwar-arif/golang-dsa/alloc"
+)
+
+func singlyOf(values ...int) *Singly[int] {
+	l := NewSingly[int]()
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return l
+}
+
+func doublyOf(values ...int) *Doubly[int] {
+	l := NewDoubly[int]()
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return l
+}
+
+func TestSinglyInsertRemove(t *testing.T) {
+	l := singlyOf(1, 2, 4)
+
+	if err := l.InsertAt(2, 3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	l.InsertAt(0, 0)
+	l.InsertAt(5, 5)
+	if got := l.ToSlice(); !slices.Equal(got, []int{0, 1, 2, 3, 4, 5}) {
+		t.Errorf("Expected [0 1 2 3 4 5], got %v", got)
+	}
+
+	if v, err := l.RemoveAt(5); err != nil || v != 5 {
+		t.Errorf("Expected 5, got %d with error %v", v, err)
+	}
+	// The tail must follow removal of the last element
+	l.PushBack(6)
+	if got := l.ToSlice(); !slices.Equal(got, []int{0, 1, 2, 3, 4, 6}) {
+		t.Errorf("Expected [0 1 2 3 4 6], got %v", got)
+	}
+
+	if err := l.InsertAt(10, 0); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Expected ErrIndexOutOfRange, got %v", err)
+	}
+	if _, err := l.RemoveAt(-1); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Expected ErrIndexOutOfRange, got %v", err)
+	}
+	if _, err := NewSingly[int]().PopFront(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	if i, v, ok := l.Find(func(v int) bool { return v > 2 }); !ok || i != 3 || v != 3 {
+		t.Errorf("Expected index 3 value 3, got %d %d %t", i, v, ok)
+	}
+	if _, _, ok := l.Find(func(v int) bool { return v > 100 }); ok {
+		t.Error("Expected no match")
+	}
+}
+
+func TestSinglyReverseMiddle(t *testing.T) {
+	l := singlyOf(1, 2, 3, 4)
+	l.Reverse()
+	l.PushBack(0)
+	if got := l.ToSlice(); !slices.Equal(got, []int{4, 3, 2, 1, 0}) {
+		t.Errorf("Expected [4 3 2 1 0], got %v", got)
+	}
+	if m, _ := l.Middle(); m != 2 {
+		t.Errorf("Expected middle 2, got %d", m)
+	}
+	if m, _ := singlyOf(1, 2, 3, 4).Middle(); m != 3 {
+		t.Errorf("Expected second middle 3, got %d", m)
+	}
+	if _, err := NewSingly[int]().Middle(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+}
+
+func TestCycleDetection(t *testing.T) {
+	l := singlyOf(1, 2, 3, 4, 5)
+	if l.HasCycle() {
+		t.Error("Expected no cycle")
+	}
+
+	// Link the tail back to the node holding 3
+	third := l.Front().Next.Next
+	tail := third.Next.Next
+	tail.Next = third
+
+	if !l.HasCycle() {
+		t.Error("Expected cycle")
+	}
+	if start := CycleStart(l.Front()); start != third {
+		t.Errorf("Expected cycle to start at 3, got %v", start)
+	}
+}
+
+func TestSinglySplice(t *testing.T) {
+	a, b := singlyOf(1, 2), singlyOf(3, 4)
+	a.SpliceBack(b)
+	a.PushBack(5)
+
+	if got := a.ToSlice(); !slices.Equal(got, []int{1, 2, 3, 4, 5}) || a.Size() != 5 {
+		t.Errorf("Expected [1 2 3 4 5], got %v", got)
+	}
+	if !b.IsEmpty() {
+		t.Error("Expected spliced list to be empty")
+	}
+
+	empty := NewSingly[int]()
+	empty.SpliceBack(a)
+	if empty.Size() != 5 || !a.IsEmpty() {
+		t.Error("Expected splice into an empty list to move everything")
+	}
+}
+
+func TestDoubly(t *testing.T) {
+	l := doublyOf(1, 3)
+	two, _ := l.InsertAt(1, 2)
+	l.InsertAfter(4, l.Back())
+	l.InsertBefore(0, l.Front())
+
+	if got := l.ToSlice(); !slices.Equal(got, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("Expected [0 1 2 3 4], got %v", got)
+	}
+	if got := slices.Collect(l.Backward()); !slices.Equal(got, []int{4, 3, 2, 1, 0}) {
+		t.Errorf("Expected [4 3 2 1 0], got %v", got)
+	}
+
+	if v := l.Remove(two); v != 2 || l.Size() != 4 {
+		t.Errorf("Expected to remove 2, got %d with size %d", v, l.Size())
+	}
+	if v, _ := l.Get(3); v != 4 {
+		t.Errorf("Expected 4 at index 3, got %d", v)
+	}
+	if v, _ := l.RemoveAt(3); v != 4 || l.Back().Value != 3 {
+		t.Errorf("Expected to remove 4 and leave 3 at the back")
+	}
+	if e := l.Find(func(v int) bool { return v == 1 }); e == nil || e.Prev().Value != 0 || e.Next().Value != 3 {
+		t.Error("Expected to find 1 between 0 and 3")
+	}
+
+	l.Reverse()
+	if got := l.ToSlice(); !slices.Equal(got, []int{3, 1, 0}) {
+		t.Errorf("Expected [3 1 0], got %v", got)
+	}
+	if got := slices.Collect(l.Backward()); !slices.Equal(got, []int{0, 1, 3}) {
+		t.Errorf("Expected [0 1 3] backwards, got %v", got)
+	}
+	if m, _ := l.Middle(); m != 1 {
+		t.Errorf("Expected middle 1, got %d", m)
+	}
+
+	for !l.IsEmpty() {
+		l.PopBack()
+	}
+	if _, err := l.PopFront(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+	if l.Front() != nil || l.Back() != nil {
+		t.Error("Expected nil ends on an empty list")
+	}
+}
+
+func TestDoublySplice(t *testing.T) {
+	a, b := doublyOf(3, 4), doublyOf(1, 2)
+	a.SpliceFront(b)
+	a.SpliceBack(doublyOf(5))
+
+	if got := a.ToSlice(); !slices.Equal(got, []int{1, 2, 3, 4, 5}) || a.Size() != 5 {
+		t.Errorf("Expected [1 2 3 4 5], got %v", got)
+	}
+	if got := slices.Collect(a.Backward()); !slices.Equal(got, []int{5, 4, 3, 2, 1}) {
+		t.Errorf("Expected [5 4 3 2 1] backwards, got %v", got)
+	}
+	if !b.IsEmpty() || b.Front() != nil {
+		t.Error("Expected spliced list to be empty")
+	}
+}
+
+func TestDoublyMove(t *testing.T) {
+	l := NewDoubly[int]()
+	e1, e2, e3, e4 := l.PushBack(1), l.PushBack(2), l.PushBack(3), l.PushBack(4)
+
+	steps := []struct {
+		move     func()
+		expected []int
+	}{
+		{func() { l.MoveToFront(e3) }, []int{3, 1, 2, 4}},
+		{func() { l.MoveToFront(e3) }, []int{3, 1, 2, 4}},
+		{func() { l.MoveToBack(e3) }, []int{1, 2, 4, 3}},
+		{func() { l.MoveToBack(e3) }, []int{1, 2, 4, 3}},
+		{func() { l.MoveAfter(e1, e4) }, []int{2, 4, 1, 3}},
+		{func() { l.MoveBefore(e3, e2) }, []int{3, 2, 4, 1}},
+		{func() { l.MoveBefore(e2, e4) }, []int{3, 2, 4, 1}},
+		{func() { l.MoveAfter(e4, e4) }, []int{3, 2, 4, 1}},
+		{func() { l.MoveAfter(e2, e1) }, []int{3, 4, 1, 2}},
+	}
+	for i, st := range steps {
+		st.move()
+		if got := l.ToSlice(); !slices.Equal(got, st.expected) || l.Size() != 4 {
+			t.Fatalf("Step %d: expected %v, got %v (size %d)", i, st.expected, got, l.Size())
+		}
+		backward := slices.Collect(l.Backward())
+		slices.Reverse(backward)
+		if !slices.Equal(backward, st.expected) {
+			t.Fatalf("Step %d: backward links out of sync, got %v", i, backward)
+		}
+	}
+
+	// Handles stay valid after moves
+	if l.Remove(e4) != 4 || l.Front() != e3 || l.Back() != e2 {
+		t.Error("Expected handles to survive moves")
+	}
+}
+
+func TestDoublyForeignElements(t *testing.T) {
+	l := NewDoubly[int]()
+	e1, e2 := l.PushBack(1), l.PushBack(2)
+	other := NewDoubly[int]()
+	x := other.PushBack(9)
+
+	check := func(step string, expected []int) {
+		t.Helper()
+		got := l.ToSlice()
+		backward := slices.Collect(l.Backward())
+		slices.Reverse(backward)
+		if !slices.Equal(got, expected) || !slices.Equal(backward, expected) || l.Size() != len(expected) {
+			t.Fatalf("%s: expected %v, got %v / %v (size %d)", step, expected, got, backward, l.Size())
+		}
+	}
+
+	if v := l.Remove(e1); v != 1 {
+		t.Errorf("Expected Remove to return 1, got %d", v)
+	}
+	if v := l.Remove(e1); v != 1 {
+		t.Errorf("Expected a second Remove to still return 1, got %d", v)
+	}
+	check("double Remove", []int{2})
+
+	l.MoveToFront(e1)
+	l.MoveToBack(e1)
+	l.MoveAfter(e1, e2)
+	l.MoveBefore(e2, e1)
+	check("moving a removed element", []int{2})
+
+	l.Remove(x)
+	l.MoveToFront(x)
+	l.MoveAfter(e2, x)
+	if l.InsertAfter(5, x) != nil || l.InsertBefore(5, e1) != nil {
+		t.Error("Expected inserts next to foreign marks to return nil")
+	}
+	check("foreign elements", []int{2})
+	if !slices.Equal(other.ToSlice(), []int{9}) {
+		t.Errorf("Expected the other list untouched, got %v", other.ToSlice())
+	}
+
+	// Spliced elements change owner without being visited
+	l.SpliceBack(other)
+	third := NewDoubly[int]()
+	third.PushBack(7)
+	third.SpliceFront(l)
+	l.MoveToFront(x)
+	third.MoveToFront(x)
+	if !slices.Equal(third.ToSlice(), []int{9, 2, 7}) || !l.IsEmpty() {
+		t.Errorf("Expected [9 2 7] after splicing twice, got %v", third.ToSlice())
+	}
+
+	third.Clear()
+	third.Remove(x)
+	if third.Size() != 0 {
+		t.Errorf("Expected elements from before Clear to be ignored, got size %d", third.Size())
+	}
+}
+
+func TestCircular(t *testing.T) {
+	l := NewCircular[int]()
+	if _, err := l.Front(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	l.PushBack(2)
+	l.PushBack(3)
+	l.PushFront(1)
+	if got := slices.Collect(l.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", got)
+	}
+
+	l.Rotate(4)
+	if f, _ := l.Front(); f != 2 {
+		t.Errorf("Expected front 2 after rotating, got %d", f)
+	}
+	if b, _ := l.Back(); b != 1 {
+		t.Errorf("Expected back 1 after rotating, got %d", b)
+	}
+
+	other := NewCircular[int]()
+	other.PushBack(8)
+	other.PushBack(9)
+	l.SpliceBack(other)
+	if got := slices.Collect(l.All()); !slices.Equal(got, []int{2, 3, 1, 8, 9}) {
+		t.Errorf("Expected [2 3 1 8 9], got %v", got)
+	}
+
+	var popped []int
+	for !l.IsEmpty() {
+		v, _ := l.PopFront()
+		popped = append(popped, v)
+	}
+	if !slices.Equal(popped, []int{2, 3, 1, 8, 9}) {
+		t.Errorf("Expected [2 3 1 8 9], got %v", popped)
+	}
+}
+
+func TestCircularCycling(t *testing.T) {
+	l := NewCircular[int]()
+	if _, err := l.Next(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	for i := 1; i <= 3; i++ {
+		l.PushBack(i)
+	}
+	var turns []int
+	for range 7 {
+		v, _ := l.Next()
+		turns = append(turns, v)
+	}
+	if !slices.Equal(turns, []int{1, 2, 3, 1, 2, 3, 1}) {
+		t.Errorf("Expected Next to cycle past the tail, got %v", turns)
+	}
+
+	l.Rotate(-1)
+	if got := slices.Collect(l.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Expected a negative rotation to move the front back, got %v", got)
+	}
+}
+
+func TestJosephus(t *testing.T) {
+	if got := Josephus(7, 3); !slices.Equal(got, []int{3, 6, 2, 7, 5, 1, 4}) {
+		t.Errorf("Expected [3 6 2 7 5 1 4], got %v", got)
+	}
+	if got := Josephus(4, 1); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected k = 1 to remove in order, got %v", got)
+	}
+	if got := Josephus(0, 2); len(got) != 0 {
+		t.Errorf("Expected nobody eliminated from an empty circle, got %v", got)
+	}
+
+	// Check survivors against the recurrence J(n) = (J(n-1) + k) mod n
+	for k := 1; k <= 5; k++ {
+		survivor := 0
+		for n := 1; n <= 30; n++ {
+			if n > 1 {
+				survivor = (survivor + k) % n
+			}
+			order := Josephus(n, k)
+			if order[n-1] != survivor+1 {
+				t.Fatalf("n=%d k=%d: expected survivor %d, got %d", n, k, survivor+1, order[n-1])
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for k < 1")
+		}
+	}()
+	Josephus(3, 0)
+}
+
+func TestAllocator(t *testing.T) {
+	arena := alloc.NewArena[Node[int]](8)
+	s := NewSinglyWithAllocator[int](arena)
+	for i := 0; i < 5; i++ {
+		s.PushBack(i)
+	}
+	s.InsertAt(2, 9)
+	s.RemoveAt(0)
+	if got := s.ToSlice(); !slices.Equal(got, []int{1, 9, 2, 3, 4}) || arena.Allocated() != 6 {
+		t.Errorf("Expected [1 9 2 3 4] from 6 arena nodes, got %v and %v", got, arena)
+	}
+
+	pool := alloc.NewPool[Element[string]]()
+	d := NewDoublyWithAllocator[string](pool)
+	a := d.PushBack("a")
+	d.PushBack("b")
+	if v := d.Remove(a); v != "a" {
+		t.Errorf("Expected removed value a, got %q", v)
+	}
+	d.PushFront("c")
+	other := NewDoublyWithAllocator[string](pool)
+	other.PushBack("z")
+	d.SpliceFront(other)
+	if got := d.ToSlice(); !slices.Equal(got, []string{"z", "c", "b"}) {
+		t.Errorf("Expected [z c b], got %v", got)
+	}
+	if v, _ := d.PopBack(); v != "b" {
+		t.Errorf("Expected b, got %q", v)
+	}
+
+	c := NewCircularWithAllocator[int](alloc.NewPool[Node[int]]())
+	c.PushBack(1)
+	c.PushBack(2)
+	if v, _ := c.PopFront(); v != 1 {
+		t.Errorf("Expected 1, got %d", v)
+	}
+	if v, _ := c.PopFront(); v != 2 || !c.IsEmpty() {
+		t.Errorf("Expected 2 and an empty list, got %d", v)
+	}
+}
+
+func TestClone(t *testing.T) {
+	s := singlyOf(1, 2, 3)
+	sc := s.Clone()
+	sc.PushBack(4)
+	s.Reverse()
+	if got := sc.ToSlice(); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected singly clone [1 2 3 4], got %v", got)
+	}
+
+	d := doublyOf(1, 2, 3)
+	dc := d.CloneWith(func(v int) int { return v * 10 })
+	d.PopFront()
+	if got := dc.ToSlice(); !slices.Equal(got, []int{10, 20, 30}) {
+		t.Errorf("Expected doubly clone [10 20 30], got %v", got)
+	}
+
+	c := NewCircular[int]()
+	c.PushBack(1)
+	c.PushBack(2)
+	cc := c.Clone()
+	c.Rotate(1)
+	c.PopFront()
+	if got := slices.Collect(cc.All()); !slices.Equal(got, []int{1, 2}) || cc.Size() != 2 {
+		t.Errorf("Expected circular clone [1 2], got %v", got)
+	}
+
+	// Clones draw from the same allocator
+	arena := alloc.NewArena[Node[int]](8)
+	a := NewSinglyWithAllocator[int](arena)
+	a.PushBack(1)
+	a.Clone()
+	if arena.Allocated() != 2 {
+		t.Errorf("Expected 2 arena nodes, got %d", arena.Allocated())
+	}
+}
+
+func TestString(t *testing.T) {
+	if s := singlyOf(1, 2).String(); s != "[1 -> 2]" {
+		t.Errorf("Expected [1 -> 2], got %s", s)
+	}
+	if s := doublyOf(1, 2).String(); s != "[1 <-> 2]" {
+		t.Errorf("Expected [1 <-> 2], got %s", s)
+	}
+}
+
+// Benchmark tests
+func BenchmarkSinglyPushBack(b *testing.B) {
+	l := NewSingly[int]()
+	for i := 0; i < b.N; i++ {
+		l.PushBack(i)
+	}
+}
+
+func BenchmarkDoublyPushPop(b *testing.B) {
+	l := NewDoubly[int]()
+	for i := 0; i < b.N; i++ {
+		l.PushBack(i)
+		l.PopFront()
+	}
+}
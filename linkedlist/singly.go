@@ -0,0 +1,306 @@
+package linkedlist
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/anwar-arif/golang-dsa/alloc"
+	"github.com/anwar-arif/golang-dsa/collections"
+)
+
+// Node is a node of a singly linked list
+type Node[T any] struct {
+	Value T
+	Next  *Node[T]
+}
+
+// Singly is a singly linked list with head and tail pointers
+type Singly[T any] struct {
+	head  *Node[T]
+	tail  *Node[T]
+	size  int
+	alloc alloc.Allocator[Node[T]] // optional, see NewSinglyWithAllocator
+}
+
+// Singly satisfies the shared collection interfaces
+var (
+	_ collections.Collection[int]      = (*Singly[int])(nil)
+	_ collections.Cloner[*Singly[int]] = (*Singly[int])(nil)
+)
+
+// NewSingly creates a new empty singly linked list
+func NewSingly[T any]() *Singly[T] {
+	return &Singly[T]{}
+}
+
+// NewSinglyWithAllocator creates a new empty singly linked list that takes its
+// nodes from a and hands them back when they are removed
+func NewSinglyWithAllocator[T any](a alloc.Allocator[Node[T]]) *Singly[T] {
+	return &Singly[T]{alloc: a}
+}
+
+// newNode returns a node holding value and next
+func (l *Singly[T]) newNode(value T, next *Node[T]) *Node[T] {
+	n := allocate(l.alloc)
+	n.Value = value
+	n.Next = next
+	return n
+}
+
+// Front returns the first node, or nil for an empty list
+func (l *Singly[T]) Front() *Node[T] {
+	return l.head
+}
+
+// PushFront adds a value at the head of the list
+func (l *Singly[T]) PushFront(value T) {
+	l.head = l.newNode(value, l.head)
+	if l.tail == nil {
+		l.tail = l.head
+	}
+	l.size++
+}
+
+// PushBack adds a value at the tail of the list
+func (l *Singly[T]) PushBack(value T) {
+	n := l.newNode(value, nil)
+	if l.tail == nil {
+		l.head = n
+	} else {
+		l.tail.Next = n
+	}
+	l.tail = n
+	l.size++
+}
+
+// PopFront removes and returns the value at the head of the list
+func (l *Singly[T]) PopFront() (T, error) {
+	var zero T
+	if l.head == nil {
+		return zero, ErrEmpty
+	}
+
+	n := l.head
+	value := n.Value
+	l.head = n.Next
+	if l.head == nil {
+		l.tail = nil
+	}
+	l.size--
+	release(l.alloc, n)
+	return value, nil
+}
+
+// nodeAt returns the node at index i, which must be in range
+func (l *Singly[T]) nodeAt(i int) *Node[T] {
+	n := l.head
+	for ; i > 0; i-- {
+		n = n.Next
+	}
+	return n
+}
+
+// Get returns the value at index i
+func (l *Singly[T]) Get(i int) (T, error) {
+	var zero T
+	if err := checkIndex(i, l.size-1); err != nil {
+		return zero, err
+	}
+	return l.nodeAt(i).Value, nil
+}
+
+// InsertAt inserts a value so that it ends up at index i
+// i may equal Size to append
+func (l *Singly[T]) InsertAt(i int, value T) error {
+	if err := checkIndex(i, l.size); err != nil {
+		return err
+	}
+
+	switch i {
+	case 0:
+		l.PushFront(value)
+	case l.size:
+		l.PushBack(value)
+	default:
+		prev := l.nodeAt(i - 1)
+		prev.Next = l.newNode(value, prev.Next)
+		l.size++
+	}
+	return nil
+}
+
+// RemoveAt removes and returns the value at index i
+func (l *Singly[T]) RemoveAt(i int) (T, error) {
+	var zero T
+	if err := checkIndex(i, l.size-1); err != nil {
+		return zero, err
+	}
+	if i == 0 {
+		return l.PopFront()
+	}
+
+	prev := l.nodeAt(i - 1)
+	n := prev.Next
+	value := n.Value
+	prev.Next = n.Next
+	if n == l.tail {
+		l.tail = prev
+	}
+	l.size--
+	release(l.alloc, n)
+	return value, nil
+}
+
+// Find returns the index and value of the first element matching pred
+func (l *Singly[T]) Find(pred func(T) bool) (int, T, bool) {
+	i := 0
+	for n := l.head; n != nil; n = n.Next {
+		if pred(n.Value) {
+			return i, n.Value, true
+		}
+		i++
+	}
+	var zero T
+	return -1, zero, false
+}
+
+// Reverse reverses the list in place
+func (l *Singly[T]) Reverse() {
+	var prev *Node[T]
+	l.tail = l.head
+	for n := l.head; n != nil; {
+		next := n.Next
+		n.Next = prev
+		prev = n
+		n = next
+	}
+	l.head = prev
+}
+
+// Middle returns the middle value using the fast/slow pointer technique
+// For an even number of elements the second of the two middles is returned
+func (l *Singly[T]) Middle() (T, error) {
+	var zero T
+	if l.head == nil {
+		return zero, ErrEmpty
+	}
+
+	slow, fast := l.head, l.head
+	for fast != nil && fast.Next != nil {
+		slow = slow.Next
+		fast = fast.Next.Next
+	}
+	return slow.Value, nil
+}
+
+// HasCycle reports whether following Next from the head loops forever
+// Lists built only through this API never have cycles, but nodes reached via
+// Front can be relinked by callers
+func (l *Singly[T]) HasCycle() bool {
+	return CycleStart(l.head) != nil
+}
+
+// CycleStart returns the first node of the cycle reachable from head, or nil
+// if there is none (Floyd's tortoise and hare)
+func CycleStart[T any](head *Node[T]) *Node[T] {
+	slow, fast := head, head
+	for fast != nil && fast.Next != nil {
+		slow = slow.Next
+		fast = fast.Next.Next
+		if slow == fast {
+			// Restarting one pointer from head makes them meet at the cycle start
+			for slow = head; slow != fast; {
+				slow = slow.Next
+				fast = fast.Next
+			}
+			return slow
+		}
+	}
+	return nil
+}
+
+// SpliceBack moves every element of other to the end of l in O(1)
+// other is left empty
+func (l *Singly[T]) SpliceBack(other *Singly[T]) {
+	if other == l || other.head == nil {
+		return
+	}
+	if l.tail == nil {
+		l.head = other.head
+	} else {
+		l.tail.Next = other.head
+	}
+	l.tail = other.tail
+	l.size += other.size
+	other.Clear()
+}
+
+// Size returns the number of elements in the list
+func (l *Singly[T]) Size() int {
+	return l.size
+}
+
+// IsEmpty returns true if the list is empty
+func (l *Singly[T]) IsEmpty() bool {
+	return l.size == 0
+}
+
+// Clear removes all elements from the list
+func (l *Singly[T]) Clear() {
+	l.head = nil
+	l.tail = nil
+	l.size = 0
+}
+
+// Clone returns a copy of the list in O(n) that uses the same allocator
+func (l *Singly[T]) Clone() *Singly[T] {
+	return l.CloneWith(func(v T) T { return v })
+}
+
+// CloneWith returns a copy of the list with every value passed through
+// copyFn, for values that need a deep copy
+func (l *Singly[T]) CloneWith(copyFn func(T) T) *Singly[T] {
+	clone := NewSinglyWithAllocator(l.alloc)
+	for v := range l.All() {
+		clone.PushBack(copyFn(v))
+	}
+	return clone
+}
+
+// All returns an iterator over the values from head to tail
+func (l *Singly[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := l.head; n != nil; n = n.Next {
+			if !yield(n.Value) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns the values from head to tail
+func (l *Singly[T]) ToSlice() []T {
+	return collections.Collect(l.All())
+}
+
+// String returns a string representation of the list
+func (l *Singly[T]) String() string {
+	return format(l.All(), " -> ")
+}
+
+// format joins the values of seq with sep
+func format[T any](seq iter.Seq[T], sep string) string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	first := true
+	for v := range seq {
+		if !first {
+			sb.WriteString(sep)
+		}
+		first = false
+		fmt.Fprint(&sb, v)
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
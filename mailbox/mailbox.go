@@ -0,0 +1,183 @@
+package mailbox
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/anwar-arif/golang-dsa/internal/wake"
+	"github.com/anwar-arif/golang-dsa/queue"
+)
+
+var (
+	// ErrFull is returned by Send when a capped mailbox has no room left
+	ErrFull = errors.New("mailbox is full")
+	// ErrStopped is returned by Send after Stop or Drain
+	ErrStopped = errors.New("mailbox is stopped")
+)
+
+// Mailbox is a lightweight actor: messages sent to it are queued and handled
+// one at a time, in order, by a single consumer goroutine
+// The handler may call Send on its own mailbox but not Stop or Drain, which
+// wait for the handler to return; it should start them in a new goroutine
+type Mailbox[T any] struct {
+	mu       sync.Mutex
+	items    *queue.Queue[T]
+	capacity int // 0 means unbounded
+	handler  func(T)
+	stopped  bool // no more sends accepted
+	discard  bool // pending messages are dropped instead of handled
+	wake     wake.Signal
+	done     chan struct{}
+}
+
+// New starts a mailbox whose consumer calls handler for every message
+// A capacity of 0 makes the mailbox unbounded
+func New[T any](handler func(T), capacity int) *Mailbox[T] {
+	m := &Mailbox[T]{
+		items:    queue.NewQueue[T](),
+		capacity: capacity,
+		handler:  handler,
+		wake:     wake.New(),
+		done:     make(chan struct{}),
+	}
+	go m.run()
+	return m
+}
+
+// run is the consumer loop
+func (m *Mailbox[T]) run() {
+	defer close(m.done)
+
+	for {
+		m.mu.Lock()
+		if m.discard {
+			m.mu.Unlock()
+			return
+		}
+
+		msg, err := m.items.Pop()
+		if err != nil {
+			if m.stopped {
+				m.mu.Unlock()
+				return
+			}
+			m.mu.Unlock()
+			<-m.wake
+			continue
+		}
+		m.mu.Unlock()
+
+		m.handler(msg)
+	}
+}
+
+// Send queues a message for the consumer
+func (m *Mailbox[T]) Send(msg T) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stopped {
+		return ErrStopped
+	}
+	if m.capacity > 0 && m.items.Size() >= m.capacity {
+		return ErrFull
+	}
+
+	m.items.Push(msg)
+	m.wake.Notify()
+	return nil
+}
+
+// Stop rejects further sends, discards every message still queued and waits
+// for the message currently being handled, if any
+// It returns the number of discarded messages
+// Calling Stop from the handler deadlocks; use go m.Stop() there instead
+func (m *Mailbox[T]) Stop() int {
+	m.mu.Lock()
+	dropped := m.items.Size()
+	m.items.Clear()
+	m.stopped = true
+	m.discard = true
+	m.mu.Unlock()
+
+	m.wake.Notify()
+	<-m.done
+	return dropped
+}
+
+// Drain rejects further sends and waits until every queued message is handled
+// Calling Drain from the handler deadlocks; use go m.Drain() there instead
+func (m *Mailbox[T]) Drain() {
+	m.mu.Lock()
+	m.stopped = true
+	m.mu.Unlock()
+
+	m.wake.Notify()
+	<-m.done
+}
+
+// Len returns the number of messages waiting to be handled
+func (m *Mailbox[T]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.items.Size()
+}
+
+// Done returns a channel that is closed once the consumer has exited
+func (m *Mailbox[T]) Done() <-chan struct{} {
+	return m.done
+}
+
+// String returns a string representation of the mailbox
+func (m *Mailbox[T]) String() string {
+	return fmt.Sprintf("Mailbox{pending: %d}", m.Len())
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Mailbox Examples ===")
+
+	// Example 1: A counter actor owns its state, so no locks are needed
+	fmt.Println("1. Counter Actor:")
+
+	type command struct {
+		delta int
+		reply chan int
+	}
+
+	total := 0
+	counter := New(func(cmd command) {
+		total += cmd.delta
+		if cmd.reply != nil {
+			cmd.reply <- total
+		}
+	}, 0)
+
+	for i := 1; i <= 10; i++ {
+		counter.Send(command{delta: i})
+	}
+
+	reply := make(chan int, 1)
+	counter.Send(command{reply: reply})
+	fmt.Printf("  Total: %d\n", <-reply)
+	counter.Drain()
+
+	// Example 2: Capped mailbox rejects messages when full
+	fmt.Println("\n2. Capped Mailbox:")
+	block := make(chan struct{})
+	slow := New(func(msg string) { <-block }, 2)
+
+	for _, msg := range []string{"a", "b", "c", "d"} {
+		if err := slow.Send(msg); err != nil {
+			fmt.Printf("  Send %s: %v\n", msg, err)
+		}
+	}
+	close(block)
+
+	// Example 3: Stop discards what is left
+	fmt.Println("\n3. Stop:")
+	fmt.Printf("  Discarded %d messages\n", slow.Stop())
+	fmt.Printf("  Send after stop: %v\n", slow.Send("e"))
+}
@@ -0,0 +1,193 @@
+package mailbox
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMessagesHandledInOrder(t *testing.T) {
+	var got []int
+	m := New(func(v int) {
+		got = append(got, v)
+	}, 0)
+
+	for i := 0; i < 100; i++ {
+		if err := m.Send(i); err != nil {
+			t.Fatalf("Unexpected send error: %v", err)
+		}
+	}
+
+	m.Drain()
+
+	if len(got) != 100 {
+		t.Fatalf("Expected 100 messages, got %d", len(got))
+	}
+	for i, v := range got {
+		if v != i {
+			t.Errorf("Expected %d at position %d, got %d", i, i, v)
+		}
+	}
+}
+
+func TestSingleConsumer(t *testing.T) {
+	var active, peak atomic.Int32
+	m := New(func(v int) {
+		cur := active.Add(1)
+		if cur > peak.Load() {
+			peak.Store(cur)
+		}
+		time.Sleep(100 * time.Microsecond)
+		active.Add(-1)
+	}, 0)
+
+	var wg sync.WaitGroup
+	for s := 0; s < 4; s++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 25; i++ {
+				m.Send(i)
+			}
+		}()
+	}
+	wg.Wait()
+	m.Drain()
+
+	if peak.Load() != 1 {
+		t.Errorf("Expected exactly one handler at a time, peak was %d", peak.Load())
+	}
+}
+
+func TestCappedMailbox(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	m := New(func(v int) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+	}, 2)
+
+	m.Send(1)
+	<-started // consumer holds message 1
+
+	if err := m.Send(2); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := m.Send(3); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := m.Send(4); !errors.Is(err, ErrFull) {
+		t.Errorf("Expected ErrFull, got %v", err)
+	}
+
+	if m.Len() != 2 {
+		t.Errorf("Expected 2 pending messages, got %d", m.Len())
+	}
+
+	close(release)
+	m.Drain()
+}
+
+func TestStopDiscardsPending(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	var handled atomic.Int32
+
+	m := New(func(v int) {
+		handled.Add(1)
+		if v == 0 {
+			started <- struct{}{}
+			<-release
+		}
+	}, 0)
+
+	for i := 0; i < 5; i++ {
+		m.Send(i)
+	}
+	<-started
+
+	stopped := make(chan int)
+	go func() { stopped <- m.Stop() }()
+
+	// Stop waits for the in-flight message
+	select {
+	case <-stopped:
+		t.Fatal("Stop returned before the running handler finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	if dropped := <-stopped; dropped != 4 {
+		t.Errorf("Expected 4 discarded messages, got %d", dropped)
+	}
+
+	if handled.Load() != 1 {
+		t.Errorf("Expected 1 handled message, got %d", handled.Load())
+	}
+
+	if err := m.Send(9); !errors.Is(err, ErrStopped) {
+		t.Errorf("Expected ErrStopped, got %v", err)
+	}
+
+	// Stopping twice is harmless
+	if dropped := m.Stop(); dropped != 0 {
+		t.Errorf("Expected 0 discarded on second stop, got %d", dropped)
+	}
+}
+
+func TestDrainThenSend(t *testing.T) {
+	m := New(func(v string) {}, 0)
+	m.Send("x")
+	m.Drain()
+
+	select {
+	case <-m.Done():
+	default:
+		t.Error("Expected Done to be closed after Drain")
+	}
+
+	if err := m.Send("y"); !errors.Is(err, ErrStopped) {
+		t.Errorf("Expected ErrStopped, got %v", err)
+	}
+}
+
+func TestStopFromHandler(t *testing.T) {
+	// The handler cannot wait for itself, so it stops its mailbox from a new goroutine
+	var m *Mailbox[int]
+	var handled atomic.Int32
+	m = New(func(v int) {
+		handled.Add(1)
+		if v == 0 {
+			go m.Stop()
+		}
+	}, 0)
+	m.Send(0)
+
+	select {
+	case <-m.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Expected the mailbox to stop")
+	}
+	if err := m.Send(1); !errors.Is(err, ErrStopped) {
+		t.Errorf("Expected ErrStopped, got %v", err)
+	}
+	if handled.Load() != 1 {
+		t.Errorf("Expected 1 handled message, got %d", handled.Load())
+	}
+}
+
+// Benchmark tests
+func BenchmarkSend(b *testing.B) {
+	m := New(func(v int) {}, 0)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Send(i)
+	}
+	m.Drain()
+}
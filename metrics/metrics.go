@@ -0,0 +1,192 @@
+// Package metrics defines a small recording interface that data structures
+// in this module can report counters and gauges through, with in-memory and
+// expvar implementations
+package metrics
+
+import (
+	"expvar"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Recorder receives metric updates
+// Implementations must be safe for concurrent use and cheap to call, since
+// instrumented structures call them on every operation
+type Recorder interface {
+	// Count adds delta to the counter called name
+	Count(name string, delta int64)
+	// Gauge sets the gauge called name to value
+	Gauge(name string, value float64)
+}
+
+// Nop is a Recorder that discards everything
+var Nop Recorder = nop{}
+
+type nop struct{}
+
+func (nop) Count(string, int64)   {}
+func (nop) Gauge(string, float64) {}
+
+// prefixed prepends a fixed prefix to every metric name
+type prefixed struct {
+	prefix string
+	r      Recorder
+}
+
+func (p prefixed) Count(name string, delta int64)   { p.r.Count(p.prefix+name, delta) }
+func (p prefixed) Gauge(name string, value float64) { p.r.Gauge(p.prefix+name, value) }
+
+// WithPrefix returns a Recorder that prepends prefix to every name before
+// passing the update to r, so several instrumented structures can share one
+// recorder without their metrics colliding
+func WithPrefix(r Recorder, prefix string) Recorder {
+	return prefixed{prefix: prefix, r: r}
+}
+
+// Memory is a Recorder that keeps the latest values in memory, useful in tests
+// and for periodic reporting
+type Memory struct {
+	mu       sync.Mutex
+	counters map[string]int64
+	gauges   map[string]float64
+}
+
+// NewMemory creates an empty in-memory recorder
+func NewMemory() *Memory {
+	return &Memory{
+		counters: make(map[string]int64),
+		gauges:   make(map[string]float64),
+	}
+}
+
+// Count adds delta to the counter called name
+func (m *Memory) Count(name string, delta int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.counters[name] += delta
+}
+
+// Gauge sets the gauge called name to value
+func (m *Memory) Gauge(name string, value float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.gauges[name] = value
+}
+
+// Counter returns the current value of a counter, zero if it was never updated
+func (m *Memory) Counter(name string) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.counters[name]
+}
+
+// GaugeValue returns the last value of a gauge and whether it was ever set
+func (m *Memory) GaugeValue(name string) (float64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	v, ok := m.gauges[name]
+	return v, ok
+}
+
+// Names returns the names of every recorded metric in sorted order
+func (m *Memory) Names() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.counters)+len(m.gauges))
+	for name := range m.counters {
+		names = append(names, name)
+	}
+	for name := range m.gauges {
+		if _, dup := m.counters[name]; !dup {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// String returns a string representation of the recorder
+func (m *Memory) String() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return fmt.Sprintf("Memory{counters: %v, gauges: %v}", m.counters, m.gauges)
+}
+
+// Expvar publishes metrics as entries of an expvar.Map, so they show up under
+// /debug/vars when the expvar handler is served
+type Expvar struct {
+	m *expvar.Map
+
+	mu     sync.Mutex
+	gauges map[string]*expvar.Float
+}
+
+// NewExpvar publishes a map under name, or reuses the map already published
+// under that name
+// It panics if name is already used by a variable that is not an expvar.Map
+func NewExpvar(name string) *Expvar {
+	var m *expvar.Map
+	if existing := expvar.Get(name); existing != nil {
+		m = existing.(*expvar.Map)
+	} else {
+		m = expvar.NewMap(name)
+	}
+	return &Expvar{m: m, gauges: make(map[string]*expvar.Float)}
+}
+
+// Count adds delta to the counter called name
+func (e *Expvar) Count(name string, delta int64) {
+	e.m.Add(name, delta)
+}
+
+// Gauge sets the gauge called name to value
+func (e *Expvar) Gauge(name string, value float64) {
+	e.mu.Lock()
+	g, ok := e.gauges[name]
+	if !ok {
+		g = new(expvar.Float)
+		e.gauges[name] = g
+		e.m.Set(name, g)
+	}
+	e.mu.Unlock()
+
+	g.Set(value)
+}
+
+// Map returns the underlying expvar map
+func (e *Expvar) Map() *expvar.Map {
+	return e.m
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Metrics Examples ===")
+
+	// Example 1: In-memory recording
+	fmt.Println("1. Memory Recorder:")
+	mem := NewMemory()
+	mem.Count("requests", 1)
+	mem.Count("requests", 2)
+	mem.Gauge("depth", 7)
+	fmt.Printf("  requests=%d\n", mem.Counter("requests"))
+
+	// Example 2: Namespacing with a prefix
+	fmt.Println("\n2. Prefixes:")
+	jobs := WithPrefix(mem, "jobs.")
+	jobs.Count("done", 1)
+	fmt.Printf("  Names: %v\n", mem.Names())
+
+	// Example 3: Publishing through expvar
+	fmt.Println("\n3. Expvar:")
+	ev := NewExpvar("dsa_example")
+	ev.Count("hits", 3)
+	ev.Gauge("hit_rate", 0.75)
+	fmt.Printf("  %s\n", ev.Map())
+}
@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestMemory(t *testing.T) {
+	m := NewMemory()
+	m.Count("hits", 2)
+	m.Count("hits", 3)
+	m.Gauge("depth", 4)
+	m.Gauge("depth", 1)
+
+	if m.Counter("hits") != 5 {
+		t.Errorf("Expected 5 hits, got %d", m.Counter("hits"))
+	}
+	if v, ok := m.GaugeValue("depth"); !ok || v != 1 {
+		t.Errorf("Expected depth 1, got %v (set %t)", v, ok)
+	}
+	if _, ok := m.GaugeValue("missing"); ok {
+		t.Error("Expected missing gauge to be unset")
+	}
+	if names := m.Names(); !slices.Equal(names, []string{"depth", "hits"}) {
+		t.Errorf("Unexpected names %v", names)
+	}
+}
+
+func TestMemoryConcurrent(t *testing.T) {
+	m := NewMemory()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.Count("n", 1)
+				m.Gauge("g", float64(j))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if m.Counter("n") != 800 {
+		t.Errorf("Expected 800, got %d", m.Counter("n"))
+	}
+}
+
+func TestWithPrefix(t *testing.T) {
+	m := NewMemory()
+	r := WithPrefix(m, "queue.")
+	r.Count("push", 1)
+	r.Gauge("depth", 2)
+
+	if m.Counter("queue.push") != 1 {
+		t.Error("Expected prefixed counter")
+	}
+	if v, _ := m.GaugeValue("queue.depth"); v != 2 {
+		t.Error("Expected prefixed gauge")
+	}
+}
+
+func TestNop(t *testing.T) {
+	Nop.Count("x", 1)
+	Nop.Gauge("y", 1)
+}
+
+func TestExpvar(t *testing.T) {
+	e := NewExpvar("metrics_test")
+	e.Count("hits", 2)
+	e.Gauge("ratio", 0.5)
+	e.Gauge("ratio", 0.25)
+
+	if got := e.Map().Get("hits").String(); got != "2" {
+		t.Errorf("Expected hits 2, got %s", got)
+	}
+	if got := e.Map().Get("ratio").String(); got != "0.25" {
+		t.Errorf("Expected ratio 0.25, got %s", got)
+	}
+
+	// Publishing the same name again reuses the map
+	again := NewExpvar("metrics_test")
+	again.Count("hits", 1)
+	if got := e.Map().Get("hits").String(); got != "3" {
+		t.Errorf("Expected shared map with hits 3, got %s", got)
+	}
+}
+
+// Benchmark tests
+func BenchmarkMemoryCount(b *testing.B) {
+	m := NewMemory()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Count("n", 1)
+	}
+}
@@ -0,0 +1,233 @@
+// Package multimap provides an ordered map from each key to many values
+package multimap
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/anwar-arif/golang-dsa/compare"
+	"github.com/anwar-arif/golang-dsa/skiplist"
+)
+
+// Multimap maps keys to lists of values and iterates keys in comparator order
+// Values under a key keep their insertion order
+// The keys live in a skip list and a read/write lock guards it together with
+// the per-key value lists, so a Multimap is safe for concurrent use
+type Multimap[K any, V comparable] struct {
+	mu   sync.RWMutex
+	keys *skiplist.Map[K, []V]
+	size int
+}
+
+// New creates an empty multimap whose keys are ordered by compare
+func New[K any, V comparable](compare compare.CompareFunc[K]) *Multimap[K, V] {
+	return &Multimap[K, V]{
+		keys: skiplist.NewMap[K, []V](compare),
+	}
+}
+
+// NewOrdered creates an empty multimap ordered by the natural order of K
+func NewOrdered[K cmp.Ordered, V comparable]() *Multimap[K, V] {
+	return New[K, V](compare.Ordered[K]())
+}
+
+// Put appends value to the values of key
+func (m *Multimap[K, V]) Put(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	values, _ := m.keys.Get(key)
+	m.keys.Set(key, append(values, value))
+	m.size++
+}
+
+// Get returns a copy of the values of key in insertion order
+func (m *Multimap[K, V]) Get(key K) []V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	values, _ := m.keys.Get(key)
+	return slices.Clone(values)
+}
+
+// ContainsKey reports whether key has at least one value
+func (m *Multimap[K, V]) ContainsKey(key K) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.keys.Contains(key)
+}
+
+// Contains reports whether value is stored under key
+func (m *Multimap[K, V]) Contains(key K, value V) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	values, _ := m.keys.Get(key)
+	return slices.Contains(values, value)
+}
+
+// DeleteValue removes the first occurrence of value under key and reports
+// whether one was found; a key left without values is removed
+func (m *Multimap[K, V]) DeleteValue(key K, value V) bool {
+	removed := false
+	m.DeleteFunc(key, func(v V) bool {
+		if !removed && v == value {
+			removed = true
+			return true
+		}
+		return false
+	})
+	return removed
+}
+
+// DeleteFunc removes every value under key for which del returns true and
+// returns how many were removed
+func (m *Multimap[K, V]) DeleteFunc(key K, del func(V) bool) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	values, ok := m.keys.Get(key)
+	if !ok {
+		return 0
+	}
+
+	n := len(values)
+	kept := slices.DeleteFunc(values, del)
+	removed := n - len(kept)
+	m.store(key, kept)
+	m.size -= removed
+	return removed
+}
+
+// DeleteKey removes key with all its values and returns how many values it had
+func (m *Multimap[K, V]) DeleteKey(key K) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	values, _ := m.keys.Get(key)
+	m.store(key, nil)
+	m.size -= len(values)
+	return len(values)
+}
+
+// store replaces the values of key, deleting the key when values is empty;
+// callers must hold the write lock
+func (m *Multimap[K, V]) store(key K, values []V) {
+	if len(values) == 0 {
+		m.keys.Delete(key)
+		return
+	}
+	m.keys.Set(key, values)
+}
+
+// Size returns the total number of values
+func (m *Multimap[K, V]) Size() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.size
+}
+
+// KeyCount returns the number of distinct keys
+func (m *Multimap[K, V]) KeyCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.keys.Len()
+}
+
+// IsEmpty returns true if the multimap holds no values
+func (m *Multimap[K, V]) IsEmpty() bool {
+	return m.Size() == 0
+}
+
+// Clear removes every key and value
+func (m *Multimap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.keys.Clear()
+	m.size = 0
+}
+
+// Keys returns the distinct keys in ascending order
+func (m *Multimap[K, V]) Keys() []K {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.keys.Keys()
+}
+
+// All returns an iterator over every key/value pair in ascending key order
+// The read lock is held while iterating, so the loop body must not modify
+// the multimap
+func (m *Multimap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+
+		for key, values := range m.keys.All2() {
+			for _, v := range values {
+				if !yield(key, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the pairs whose key k satisfies lo <= k <= hi,
+// in ascending key order; like All it holds the read lock while iterating
+func (m *Multimap[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+
+		m.keys.RangeBetween(lo, hi, func(key K, values []V) bool {
+			for _, v := range values {
+				if !yield(key, v) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+}
+
+// String returns a string representation of the multimap
+func (m *Multimap[K, V]) String() string {
+	return fmt.Sprintf("Multimap{keys: %d, values: %d}", m.KeyCount(), m.Size())
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Multimap Examples ===")
+
+	// Example 1: Events indexed by minute
+	fmt.Println("1. Time-Indexed Events:")
+	events := NewOrdered[int, string]()
+	events.Put(905, "deploy started")
+	events.Put(900, "standup")
+	events.Put(905, "alert fired")
+	events.Put(930, "deploy finished")
+	events.Put(1000, "retro")
+	for minute, e := range events.All() {
+		fmt.Printf("  %d: %s\n", minute, e)
+	}
+
+	// Example 2: Range over a key interval
+	fmt.Println("\n2. Events Between 9:00 and 9:30:")
+	for minute, e := range events.Range(900, 930) {
+		fmt.Printf("  %d: %s\n", minute, e)
+	}
+
+	// Example 3: Deleting a single value
+	fmt.Println("\n3. DeleteValue:")
+	events.DeleteValue(905, "alert fired")
+	fmt.Printf("  Events at 905: %v\n", events.Get(905))
+	fmt.Printf("  %v\n", events)
+}
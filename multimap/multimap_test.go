@@ -0,0 +1,185 @@
+package multimap
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/anwar-arif/golang-dsa/compare"
+)
+
+type pair struct {
+	key   int
+	value string
+}
+
+func collect(seq func(func(int, string) bool)) []pair {
+	var pairs []pair
+	for k, v := range seq {
+		pairs = append(pairs, pair{k, v})
+	}
+	return pairs
+}
+
+func TestPutGet(t *testing.T) {
+	m := NewOrdered[int, string]()
+	m.Put(2, "b")
+	m.Put(1, "a")
+	m.Put(2, "c")
+	m.Put(2, "b")
+
+	if got := m.Get(2); !slices.Equal(got, []string{"b", "c", "b"}) {
+		t.Errorf("Expected [b c b], got %v", got)
+	}
+	if m.Size() != 4 || m.KeyCount() != 2 {
+		t.Errorf("Expected 4 values under 2 keys, got %v", m)
+	}
+	if m.Get(3) != nil || m.ContainsKey(3) || !m.Contains(2, "c") || m.Contains(1, "c") {
+		t.Error("Unexpected lookup result")
+	}
+
+	// Returned slices are copies
+	got := m.Get(1)
+	got[0] = "z"
+	if m.Get(1)[0] != "a" {
+		t.Error("Expected Get to return a copy")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	m := NewOrdered[int, string]()
+	for _, v := range []string{"x", "y", "x", "z"} {
+		m.Put(1, v)
+	}
+	m.Put(2, "w")
+
+	if !m.DeleteValue(1, "x") || !slices.Equal(m.Get(1), []string{"y", "x", "z"}) {
+		t.Errorf("Expected first x removed, got %v", m.Get(1))
+	}
+	if m.DeleteValue(1, "missing") || m.DeleteValue(9, "x") {
+		t.Error("Expected deleting a missing value to fail")
+	}
+	if n := m.DeleteFunc(1, func(v string) bool { return v != "z" }); n != 2 {
+		t.Errorf("Expected 2 removed, got %d", n)
+	}
+
+	m.DeleteValue(1, "z")
+	if m.ContainsKey(1) || m.KeyCount() != 1 || m.Size() != 1 {
+		t.Errorf("Expected key 1 to be gone, got %v", m)
+	}
+
+	if n := m.DeleteKey(2); n != 1 || !m.IsEmpty() {
+		t.Errorf("Expected DeleteKey to remove 1 value, got %d", n)
+	}
+
+	m.Put(5, "v")
+	m.Clear()
+	if !m.IsEmpty() || m.KeyCount() != 0 {
+		t.Error("Expected empty multimap after Clear")
+	}
+}
+
+func TestOrderedIteration(t *testing.T) {
+	m := NewOrdered[int, string]()
+	m.Put(30, "c")
+	m.Put(10, "a1")
+	m.Put(20, "b")
+	m.Put(10, "a2")
+	m.Put(40, "d")
+
+	expected := []pair{{10, "a1"}, {10, "a2"}, {20, "b"}, {30, "c"}, {40, "d"}}
+	if got := collect(m.All()); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := collect(m.Range(15, 30)); !slices.Equal(got, expected[2:4]) {
+		t.Errorf("Expected %v, got %v", expected[2:4], got)
+	}
+
+	// Stopping early also stops inside a key's value list
+	var first []pair
+	for k, v := range m.All() {
+		first = append(first, pair{k, v})
+		if len(first) == 1 {
+			break
+		}
+	}
+	if len(first) != 1 {
+		t.Errorf("Expected iteration to stop after one pair, got %v", first)
+	}
+
+	if got := m.Keys(); !slices.Equal(got, []int{10, 20, 30, 40}) {
+		t.Errorf("Expected sorted keys, got %v", got)
+	}
+}
+
+func TestCustomOrder(t *testing.T) {
+	m := New[string, int](compare.ByFunc(strings.ToLower, compare.Ordered[string]()))
+	m.Put("b", 1)
+	m.Put("A", 2)
+	m.Put("a", 3)
+
+	if got := m.Get("a"); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("Expected keys equal under the comparator to share values, got %v", got)
+	}
+}
+
+func TestConcurrentPut(t *testing.T) {
+	m := NewOrdered[int, int]()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				m.Put(i%10, g)
+				m.Get(i % 10)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if m.Size() != 4000 || m.KeyCount() != 10 || len(m.Get(3)) != 400 {
+		t.Errorf("Expected 4000 values across 10 keys, got %v", m)
+	}
+}
+
+func TestConcurrentReadersAndClear(t *testing.T) {
+	m := NewOrdered[int, int]()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			m.Put(i%10, i)
+			if i%50 == 0 {
+				m.Clear()
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			m.ContainsKey(i % 10)
+			m.KeyCount()
+			if keys := m.Keys(); !slices.IsSorted(keys) {
+				t.Errorf("Expected sorted keys, got %v", keys)
+			}
+		}
+	}()
+	wg.Wait()
+
+	if m.KeyCount() != 10 || len(m.Keys()) != 10 {
+		t.Errorf("Expected 10 keys after the last Clear, got %v", m)
+	}
+}
+
+// Benchmark tests
+func BenchmarkPut(b *testing.B) {
+	m := NewOrdered[int, int]()
+	for i := 0; i < b.N; i++ {
+		m.Put(i%1000, i)
+	}
+}
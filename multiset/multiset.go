@@ -0,0 +1,263 @@
+// Package multiset provides a bag that counts how often each element occurs
+package multiset
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/anwar-arif/golang-dsa/collections"
+	"github.com/anwar-arif/golang-dsa/priorityqueue"
+)
+
+// Entry pairs an element with its count
+type Entry[T comparable] struct {
+	Value T
+	Count int
+}
+
+// Multiset is an unordered collection that may hold an element many times
+// The zero value is not usable; create one with New
+type Multiset[T comparable] struct {
+	counts map[T]int
+	size   int
+}
+
+// Multiset satisfies the shared collection interface
+var _ collections.Collection[int] = (*Multiset[int])(nil)
+
+// New creates a multiset holding values
+func New[T comparable](values ...T) *Multiset[T] {
+	m := &Multiset[T]{counts: make(map[T]int)}
+	for _, v := range values {
+		m.Add(v)
+	}
+	return m
+}
+
+// Add adds one occurrence of value
+func (m *Multiset[T]) Add(value T) {
+	m.AddN(value, 1)
+}
+
+// AddN adds n occurrences of value; n <= 0 is a no-op
+func (m *Multiset[T]) AddN(value T, n int) {
+	if n <= 0 {
+		return
+	}
+	m.counts[value] += n
+	m.size += n
+}
+
+// Remove removes one occurrence of value and reports whether there was one
+func (m *Multiset[T]) Remove(value T) bool {
+	return m.RemoveN(value, 1) == 1
+}
+
+// RemoveN removes up to n occurrences of value and returns how many were removed
+func (m *Multiset[T]) RemoveN(value T, n int) int {
+	c := m.counts[value]
+	if n <= 0 || c == 0 {
+		return 0
+	}
+	if n >= c {
+		n = c
+		delete(m.counts, value)
+	} else {
+		m.counts[value] = c - n
+	}
+	m.size -= n
+	return n
+}
+
+// RemoveAll removes every occurrence of value and returns how many there were
+func (m *Multiset[T]) RemoveAll(value T) int {
+	return m.RemoveN(value, m.counts[value])
+}
+
+// Count returns the number of occurrences of value
+func (m *Multiset[T]) Count(value T) int {
+	return m.counts[value]
+}
+
+// Contains reports whether value occurs at least once
+func (m *Multiset[T]) Contains(value T) bool {
+	return m.counts[value] > 0
+}
+
+// Size returns the total number of occurrences
+func (m *Multiset[T]) Size() int {
+	return m.size
+}
+
+// Distinct returns the number of distinct elements
+func (m *Multiset[T]) Distinct() int {
+	return len(m.counts)
+}
+
+// IsEmpty returns true if the multiset is empty
+func (m *Multiset[T]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Clear removes all elements
+func (m *Multiset[T]) Clear() {
+	clear(m.counts)
+	m.size = 0
+}
+
+// All returns an iterator that yields every occurrence, so an element with
+// count 3 is yielded three times; the order is unspecified
+func (m *Multiset[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v, c := range m.counts {
+			for ; c > 0; c-- {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Counts returns an iterator over the distinct elements and their counts
+func (m *Multiset[T]) Counts() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for v, c := range m.counts {
+			if !yield(v, c) {
+				return
+			}
+		}
+	}
+}
+
+// Union returns a new multiset where each count is the larger of the two
+func (m *Multiset[T]) Union(other *Multiset[T]) *Multiset[T] {
+	result := m.Clone()
+	for v, c := range other.counts {
+		result.AddN(v, c-result.counts[v])
+	}
+	return result
+}
+
+// Intersection returns a new multiset where each count is the smaller of the two
+func (m *Multiset[T]) Intersection(other *Multiset[T]) *Multiset[T] {
+	result := New[T]()
+	for v, c := range m.counts {
+		result.AddN(v, min(c, other.counts[v]))
+	}
+	return result
+}
+
+// Difference returns a new multiset with other's counts subtracted, dropping
+// elements whose count reaches zero
+func (m *Multiset[T]) Difference(other *Multiset[T]) *Multiset[T] {
+	result := New[T]()
+	for v, c := range m.counts {
+		result.AddN(v, c-other.counts[v])
+	}
+	return result
+}
+
+// Sum returns a new multiset where the counts of both are added together
+func (m *Multiset[T]) Sum(other *Multiset[T]) *Multiset[T] {
+	result := m.Clone()
+	for v, c := range other.counts {
+		result.AddN(v, c)
+	}
+	return result
+}
+
+// IsSubset reports whether every count in m is at most the count in other
+func (m *Multiset[T]) IsSubset(other *Multiset[T]) bool {
+	for v, c := range m.counts {
+		if c > other.counts[v] {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether both multisets hold the same elements with the same counts
+func (m *Multiset[T]) Equal(other *Multiset[T]) bool {
+	return m.size == other.size && len(m.counts) == len(other.counts) && m.IsSubset(other)
+}
+
+// Clone returns an independent copy of the multiset
+func (m *Multiset[T]) Clone() *Multiset[T] {
+	counts := make(map[T]int, len(m.counts))
+	for v, c := range m.counts {
+		counts[v] = c
+	}
+	return &Multiset[T]{counts: counts, size: m.size}
+}
+
+// byCount orders entries by count only
+func byCount[T comparable](a, b Entry[T]) int {
+	return cmp.Compare(a.Count, b.Count)
+}
+
+// MostCommon returns up to n entries with the highest counts, most common first
+// It feeds every element through a priorityqueue.TopK of size n, so it runs
+// in O(d log n) for d distinct elements; ties are broken arbitrarily
+func (m *Multiset[T]) MostCommon(n int) []Entry[T] {
+	n = min(n, len(m.counts))
+	if n <= 0 {
+		return nil
+	}
+
+	top := priorityqueue.NewTopK(n, byCount[T])
+	for v, c := range m.counts {
+		top.Offer(Entry[T]{Value: v, Count: c})
+	}
+	return top.Values()
+}
+
+// Entries returns every distinct element with its count, most common first
+func (m *Multiset[T]) Entries() []Entry[T] {
+	entries := make([]Entry[T], 0, len(m.counts))
+	for v, c := range m.counts {
+		entries = append(entries, Entry[T]{Value: v, Count: c})
+	}
+	slices.SortStableFunc(entries, func(a, b Entry[T]) int {
+		return byCount(b, a)
+	})
+	return entries
+}
+
+// String returns a string representation of the multiset
+func (m *Multiset[T]) String() string {
+	return fmt.Sprintf("Multiset{size: %d, distinct: %d}", m.size, len(m.counts))
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Multiset Examples ===")
+
+	// Example 1: Word frequencies
+	fmt.Println("1. Word Frequencies:")
+	words := New("the", "cat", "sat", "on", "the", "mat", "the", "cat")
+	fmt.Printf("  %v\n", words)
+	fmt.Printf("  Count(the) = %d, Count(dog) = %d\n", words.Count("the"), words.Count("dog"))
+
+	// Example 2: Most common elements
+	fmt.Println("\n2. MostCommon(2):")
+	for _, e := range words.MostCommon(2) {
+		fmt.Printf("  %s: %d\n", e.Value, e.Count)
+	}
+
+	// Example 3: Multiset algebra
+	fmt.Println("\n3. Set Operations:")
+	a := New(1, 1, 2, 3)
+	b := New(1, 2, 2, 4)
+	fmt.Printf("  Union count of 1 and 2: %d, %d\n", a.Union(b).Count(1), a.Union(b).Count(2))
+	fmt.Printf("  Intersection size: %d\n", a.Intersection(b).Size())
+	fmt.Printf("  Difference a-b count of 1: %d\n", a.Difference(b).Count(1))
+
+	// Example 4: Can a word be built from the letters of another
+	fmt.Println("\n4. Anagram Check:")
+	letters := func(s string) *Multiset[rune] { return New([]rune(s)...) }
+	fmt.Printf("  listen vs silent: %t\n", letters("listen").Equal(letters("silent")))
+	fmt.Printf("  'cat' from 'tactic': %t\n", letters("cat").IsSubset(letters("tactic")))
+}
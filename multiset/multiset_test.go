@@ -0,0 +1,136 @@
+package multiset
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAddRemoveCount(t *testing.T) {
+	m := New("a", "b", "a")
+	m.AddN("c", 3)
+	m.AddN("d", 0)
+
+	if m.Size() != 6 || m.Distinct() != 3 {
+		t.Errorf("Expected size 6 and 3 distinct, got %d and %d", m.Size(), m.Distinct())
+	}
+	if m.Count("a") != 2 || m.Count("c") != 3 || m.Contains("d") {
+		t.Errorf("Unexpected counts: a=%d c=%d d=%d", m.Count("a"), m.Count("c"), m.Count("d"))
+	}
+
+	if !m.Remove("a") || m.Count("a") != 1 {
+		t.Error("Expected one a to be removed")
+	}
+	if m.Remove("z") {
+		t.Error("Expected removing a missing element to fail")
+	}
+	if n := m.RemoveN("c", 10); n != 3 || m.Contains("c") {
+		t.Errorf("Expected to remove 3 c's, removed %d", n)
+	}
+	if n := m.RemoveAll("b"); n != 1 || m.Distinct() != 1 || m.Size() != 1 {
+		t.Errorf("Expected one b removed leaving only a, got %d removed and %v", n, m)
+	}
+
+	if got := len(slices.Collect(New(1, 1, 2).All())); got != 3 {
+		t.Errorf("Expected All to yield every occurrence, got %d", got)
+	}
+
+	m.Clear()
+	if !m.IsEmpty() || m.Distinct() != 0 {
+		t.Error("Expected empty multiset after Clear")
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	a := New(1, 1, 2, 3)
+	b := New(1, 2, 2, 4)
+
+	tests := []struct {
+		name     string
+		got      *Multiset[int]
+		expected map[int]int
+	}{
+		{"union", a.Union(b), map[int]int{1: 2, 2: 2, 3: 1, 4: 1}},
+		{"intersection", a.Intersection(b), map[int]int{1: 1, 2: 1}},
+		{"difference", a.Difference(b), map[int]int{1: 1, 3: 1}},
+		{"sum", a.Sum(b), map[int]int{1: 3, 2: 3, 3: 1, 4: 1}},
+	}
+
+	for _, tt := range tests {
+		total := 0
+		for v, c := range tt.expected {
+			total += c
+			if tt.got.Count(v) != c {
+				t.Errorf("%s: expected count %d for %d, got %d", tt.name, c, v, tt.got.Count(v))
+			}
+		}
+		if tt.got.Size() != total || tt.got.Distinct() != len(tt.expected) {
+			t.Errorf("%s: expected size %d with %d distinct, got %v", tt.name, total, len(tt.expected), tt.got)
+		}
+	}
+
+	// Operands are not modified
+	if a.Size() != 4 || b.Size() != 4 {
+		t.Error("Expected operands to be unchanged")
+	}
+
+	if !New(1, 2).IsSubset(a) || New(2, 2).IsSubset(a) {
+		t.Error("Unexpected IsSubset result")
+	}
+	if !New(1, 2, 1).Equal(New(2, 1, 1)) || New(1, 2).Equal(New(1, 2, 2)) {
+		t.Error("Unexpected Equal result")
+	}
+}
+
+func TestMostCommon(t *testing.T) {
+	m := New[string]()
+	m.AddN("a", 5)
+	m.AddN("b", 1)
+	m.AddN("c", 3)
+	m.AddN("d", 4)
+
+	top := m.MostCommon(3)
+	expected := []Entry[string]{{"a", 5}, {"d", 4}, {"c", 3}}
+	if !slices.Equal(top, expected) {
+		t.Errorf("Expected %v, got %v", expected, top)
+	}
+
+	if got := m.MostCommon(10); len(got) != 4 || got[3].Value != "b" {
+		t.Errorf("Expected all 4 entries ending with b, got %v", got)
+	}
+	if got := m.MostCommon(0); got != nil {
+		t.Errorf("Expected nil for n=0, got %v", got)
+	}
+	if got := m.Entries(); got[0].Value != "a" || got[3].Value != "b" {
+		t.Errorf("Expected entries sorted by count, got %v", got)
+	}
+}
+
+func TestClone(t *testing.T) {
+	m := New(1, 1)
+	c := m.Clone()
+	c.Add(1)
+
+	if m.Count(1) != 2 || c.Count(1) != 3 {
+		t.Errorf("Expected independent clone, got %d and %d", m.Count(1), c.Count(1))
+	}
+}
+
+// Benchmark tests
+func BenchmarkAdd(b *testing.B) {
+	m := New[int]()
+	for i := 0; i < b.N; i++ {
+		m.Add(i % 1000)
+	}
+}
+
+func BenchmarkMostCommon(b *testing.B) {
+	m := New[int]()
+	for i := 0; i < 10000; i++ {
+		m.AddN(i, i%97)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.MostCommon(10)
+	}
+}
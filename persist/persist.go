@@ -0,0 +1,233 @@
+// Package persist checkpoints structures to disk atomically, wrapping their
+// encoded form in a versioned header with a checksum
+package persist
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/anwar-arif/golang-dsa/codec"
+)
+
+// FormatVersion is the version of the file layout written by Save
+const FormatVersion = 1
+
+// magic identifies files written by this package
+var magic = [4]byte{'D', 'S', 'A', 'P'}
+
+// headerSize is magic + version (uint16) + payload length (uint64) + CRC32 (uint32)
+const headerSize = 4 + 2 + 8 + 4
+
+var (
+	// ErrNotSnapshot is returned when a file does not start with the snapshot magic
+	ErrNotSnapshot = errors.New("persist: not a snapshot file")
+	// ErrVersion is returned for files written with an unsupported format version
+	ErrVersion = errors.New("persist: unsupported format version")
+	// ErrChecksum is returned when the payload does not match its checksum
+	ErrChecksum = errors.New("persist: checksum mismatch")
+	// ErrTruncated is returned when the file is shorter than its header says
+	ErrTruncated = errors.New("persist: truncated file")
+)
+
+// Header describes a snapshot file
+type Header struct {
+	Version  uint16 // format version
+	Size     uint64 // payload size in bytes
+	Checksum uint32 // CRC-32 (IEEE) of the payload
+}
+
+// Save atomically writes s to path
+// The data goes to a temporary file in the same directory which is synced
+// and then renamed over path, so readers see either the old or the new
+// snapshot and never a partial one
+func Save(path string, s encoding.BinaryMarshaler) error {
+	payload, err := s.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return writeFile(path, payload)
+}
+
+// Load reads a snapshot written by Save into into
+func Load(path string, into encoding.BinaryUnmarshaler) error {
+	payload, err := readFile(path)
+	if err != nil {
+		return err
+	}
+	return into.UnmarshalBinary(payload)
+}
+
+// SaveWith atomically writes v to path using enc for the payload
+func SaveWith[T any](path string, v T, enc codec.Encoder[T]) error {
+	payload, err := codec.Marshal(enc, v)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, payload)
+}
+
+// LoadWith reads a snapshot written by SaveWith using dec for the payload
+func LoadWith[T any](path string, dec codec.Decoder[T]) (T, error) {
+	payload, err := readFile(path)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return codec.Unmarshal(dec, payload)
+}
+
+// Stat reads and checks the header of a snapshot without loading the payload
+func Stat(path string) (Header, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Header{}, err
+	}
+	defer f.Close()
+
+	return readHeader(f)
+}
+
+// writeFile frames payload and atomically replaces path with it
+func writeFile(path string, payload []byte) (err error) {
+	var header [headerSize]byte
+	copy(header[:4], magic[:])
+	binary.LittleEndian.PutUint16(header[4:], FormatVersion)
+	binary.LittleEndian.PutUint64(header[6:], uint64(len(payload)))
+	binary.LittleEndian.PutUint32(header[14:], crc32.ChecksumIEEE(payload))
+
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := os.CreateTemp(dir, "."+base+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(header[:]); err != nil {
+		return err
+	}
+	if _, err = tmp.Write(payload); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	// Persist the rename itself; not every platform supports syncing a directory
+	if d, dirErr := os.Open(dir); dirErr == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
+
+// readHeader reads and validates the fixed-size header
+func readHeader(r io.Reader) (Header, error) {
+	var header [headerSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return Header{}, ErrNotSnapshot
+		}
+		return Header{}, err
+	}
+	if !bytes.Equal(header[:4], magic[:]) {
+		return Header{}, ErrNotSnapshot
+	}
+
+	h := Header{
+		Version:  binary.LittleEndian.Uint16(header[4:]),
+		Size:     binary.LittleEndian.Uint64(header[6:]),
+		Checksum: binary.LittleEndian.Uint32(header[14:]),
+	}
+	if h.Version != FormatVersion {
+		return h, fmt.Errorf("%w: %d", ErrVersion, h.Version)
+	}
+	return h, nil
+}
+
+// readFile reads and verifies the payload of a snapshot
+func readFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h, err := readHeader(f)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if uint64(info.Size()-headerSize) < h.Size {
+		return nil, ErrTruncated
+	}
+
+	payload := make([]byte, h.Size)
+	if _, err := io.ReadFull(f, payload); err != nil {
+		return nil, ErrTruncated
+	}
+	if crc32.ChecksumIEEE(payload) != h.Checksum {
+		return nil, ErrChecksum
+	}
+	return payload, nil
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Persistence Examples ===")
+
+	dir, err := os.MkdirTemp("", "persist-example")
+	if err != nil {
+		fmt.Printf("  Error: %v\n", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "scores.snap")
+
+	// Example 1: Save and load with a codec
+	fmt.Println("1. SaveWith / LoadWith:")
+	scores := map[string]int{"alice": 1500, "bob": 2000}
+	if err := SaveWith(path, scores, codec.JSON[map[string]int]()); err != nil {
+		fmt.Printf("  Save error: %v\n", err)
+		return
+	}
+	loaded, _ := LoadWith(path, codec.JSON[map[string]int]())
+	fmt.Printf("  Loaded: %v\n", loaded)
+
+	// Example 2: Inspecting the header
+	fmt.Println("\n2. Stat:")
+	h, _ := Stat(path)
+	fmt.Printf("  Version %d, %d payload bytes, checksum %08x\n", h.Version, h.Size, h.Checksum)
+
+	// Example 3: Corruption is detected
+	fmt.Println("\n3. Corruption:")
+	data, _ := os.ReadFile(path)
+	data[len(data)-2] ^= 0xff
+	os.WriteFile(path, data, 0o644)
+	_, err = LoadWith(path, codec.JSON[map[string]int]())
+	fmt.Printf("  Load error: %v\n", err)
+}
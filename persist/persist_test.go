@@ -0,0 +1,108 @@
+package persist
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anwar-arif/golang-dsa/codec"
+	"github.com/anwar-arif/golang-dsa/stack"
+)
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stack.snap")
+
+	s := stack.NewStack[int]()
+	s.Push(1)
+	s.Push(2)
+	s.Push(3)
+
+	if err := Save(path, s); err != nil {
+		t.Fatalf("Unexpected save error: %v", err)
+	}
+
+	restored := stack.NewStack[int]()
+	if err := Load(path, restored); err != nil {
+		t.Fatalf("Unexpected load error: %v", err)
+	}
+	if top, _ := restored.Peek(); top != 3 || restored.Size() != 3 {
+		t.Errorf("Expected top 3 and size 3, got %d and %d", top, restored.Size())
+	}
+}
+
+func TestSaveWithLoadWith(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.snap")
+	c := codec.Slice(codec.String())
+
+	if err := SaveWith(path, []string{"a", "b"}, c); err != nil {
+		t.Fatalf("Unexpected save error: %v", err)
+	}
+	// Overwriting replaces the previous snapshot
+	if err := SaveWith(path, []string{"c"}, c); err != nil {
+		t.Fatalf("Unexpected save error: %v", err)
+	}
+
+	got, err := LoadWith(path, c)
+	if err != nil || len(got) != 1 || got[0] != "c" {
+		t.Errorf("Expected [c], got %v with error %v", got, err)
+	}
+
+	h, err := Stat(path)
+	if err != nil || h.Version != FormatVersion || h.Size == 0 {
+		t.Errorf("Unexpected header %+v with error %v", h, err)
+	}
+
+	// No temporary files are left behind
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expected only the snapshot in the directory, got %d entries", len(entries))
+	}
+}
+
+func TestCorruption(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.snap")
+	c := codec.JSON[[]int]()
+	SaveWith(path, []int{1, 2, 3}, c)
+	original, _ := os.ReadFile(path)
+
+	tests := []struct {
+		name     string
+		mutate   func([]byte) []byte
+		expected error
+	}{
+		{"flipped payload byte", func(b []byte) []byte { b[len(b)-2] ^= 0xff; return b }, ErrChecksum},
+		{"truncated payload", func(b []byte) []byte { return b[:len(b)-3] }, ErrTruncated},
+		{"bad magic", func(b []byte) []byte { b[0] = 'X'; return b }, ErrNotSnapshot},
+		{"short header", func(b []byte) []byte { return b[:5] }, ErrNotSnapshot},
+		{"future version", func(b []byte) []byte { b[4] = 9; return b }, ErrVersion},
+	}
+
+	for _, tt := range tests {
+		data := tt.mutate(append([]byte(nil), original...))
+		os.WriteFile(path, data, 0o644)
+
+		if _, err := LoadWith(path, c); !errors.Is(err, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, err)
+		}
+	}
+}
+
+func TestSaveFailureKeepsOldSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.snap")
+	SaveWith(path, 1, codec.JSON[int]())
+
+	// Channels cannot be encoded as JSON
+	if err := SaveWith(path, make(chan int), codec.JSON[chan int]()); err == nil {
+		t.Fatal("Expected encode error")
+	}
+
+	if v, err := LoadWith(path, codec.JSON[int]()); err != nil || v != 1 {
+		t.Errorf("Expected old snapshot 1, got %d with error %v", v, err)
+	}
+
+	if err := SaveWith(filepath.Join(t.TempDir(), "missing", "x.snap"), 1, codec.JSON[int]()); err == nil {
+		t.Error("Expected error for a missing directory")
+	}
+}
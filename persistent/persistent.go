@@ -0,0 +1,53 @@
+// Package persistent provides immutable data structures whose operations
+// return new versions that share structure with the old ones, so every
+// version stays valid and cheap to keep
+package persistent
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+)
+
+// ErrEmpty is returned when reading from an empty structure
+var ErrEmpty = errors.New("persistent: structure is empty")
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Persistent Structure Examples ===")
+
+	// Example 1: Every push returns a new version; old versions are untouched
+	fmt.Println("1. Persistent Priority Queue:")
+	v1 := NewMinPriorityQueue(cmp.Compare[int]).Push(5).Push(2)
+	v2 := v1.Push(1)
+	top1, _ := v1.Peek()
+	top2, _ := v2.Peek()
+	fmt.Printf("  v1 top: %d (size %d), v2 top: %d (size %d)\n", top1, v1.Size(), top2, v2.Size())
+
+	// Example 2: Popping yields the value and the remaining version
+	fmt.Println("\n2. Draining without losing the original:")
+	for q := v2; !q.IsEmpty(); {
+		var v int
+		v, q, _ = q.Pop()
+		fmt.Printf("  Popped %d\n", v)
+	}
+	fmt.Printf("  v2 still has %d values\n", v2.Size())
+
+	// Example 3: Merge is O(log n)
+	fmt.Println("\n3. Merge:")
+	other := NewMinPriorityQueue(cmp.Compare[int]).Push(4).Push(0)
+	fmt.Printf("  Merged sorted: %v\n", v2.Merge(other).Sorted())
+
+	// Example 4: A functional FIFO queue
+	fmt.Println("\n4. Persistent Queue:")
+	jobs := NewQueue("build", "test")
+	next, rest, _ := jobs.Pop()
+	rest = rest.Push("deploy")
+	fmt.Printf("  Took %q, remaining %v, original %v\n", next, rest.ToSlice(), jobs.ToSlice())
+
+	// Example 5: Stack versions share their tails, so snapshots are free
+	fmt.Println("\n5. Persistent Stack:")
+	base := NewStack("a", "b")
+	left, right := base.Push("c"), base.Push("d")
+	fmt.Printf("  Base %v, left %v, right %v\n", base.ToSlice(), left.ToSlice(), right.ToSlice())
+}
@@ -0,0 +1,136 @@
+package persistent
+
+import (
+	"iter"
+
+	"github.com/anwar-arif/golang-dsa/compare"
+)
+
+// heapNode is a node of a leftist heap; nodes are never modified once built
+type heapNode[T any] struct {
+	value       T
+	rank        int // length of the rightmost path, which leftist heaps keep short
+	left, right *heapNode[T]
+}
+
+func rank[T any](n *heapNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.rank
+}
+
+// PriorityQueue is an immutable priority queue built on a leftist heap
+// Push, Pop and Merge return a new queue in O(log n), copying only the
+// O(log n) nodes on the path they change and sharing the rest; the receiver
+// is never modified, so any version can be kept and used later
+// The zero value is not usable; create queues with NewMinPriorityQueue or
+// NewMaxPriorityQueue
+type PriorityQueue[T any] struct {
+	root    *heapNode[T]
+	size    int
+	compare compare.CompareFunc[T]
+}
+
+// NewMinPriorityQueue creates an empty queue that pops the smallest value first
+func NewMinPriorityQueue[T any](c compare.CompareFunc[T]) PriorityQueue[T] {
+	return PriorityQueue[T]{compare: c}
+}
+
+// NewMaxPriorityQueue creates an empty queue that pops the largest value first
+func NewMaxPriorityQueue[T any](c compare.CompareFunc[T]) PriorityQueue[T] {
+	return PriorityQueue[T]{compare: compare.Reverse(c)}
+}
+
+// merge joins two leftist heaps along their right spines
+func (q PriorityQueue[T]) merge(a, b *heapNode[T]) *heapNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if q.compare(b.value, a.value) < 0 {
+		a, b = b, a
+	}
+	left, right := a.left, q.merge(a.right, b)
+	if rank(left) < rank(right) {
+		left, right = right, left
+	}
+	return &heapNode[T]{value: a.value, rank: rank(right) + 1, left: left, right: right}
+}
+
+// Push returns a queue that also holds value
+func (q PriorityQueue[T]) Push(value T) PriorityQueue[T] {
+	q.root = q.merge(q.root, &heapNode[T]{value: value, rank: 1})
+	q.size++
+	return q
+}
+
+// Pop returns the value with highest priority and the queue without it
+// Returns ErrEmpty, and q itself, if q is empty
+func (q PriorityQueue[T]) Pop() (T, PriorityQueue[T], error) {
+	if q.root == nil {
+		var zero T
+		return zero, q, ErrEmpty
+	}
+	value := q.root.value
+	q.root = q.merge(q.root.left, q.root.right)
+	q.size--
+	return value, q, nil
+}
+
+// Peek returns the value with highest priority
+// Returns ErrEmpty if q is empty
+func (q PriorityQueue[T]) Peek() (T, error) {
+	if q.root == nil {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return q.root.value, nil
+}
+
+// Merge returns a queue holding the values of both q and other, ordered by
+// q's compare function
+// The two heaps are melded as they are, so other must have been built with
+// the same compare function and direction as q; merging a min queue into a max
+// queue, or queues ordered by different functions, gives a heap whose pops
+// come out of order; push other's values into q to combine such queues
+func (q PriorityQueue[T]) Merge(other PriorityQueue[T]) PriorityQueue[T] {
+	q.root = q.merge(q.root, other.root)
+	q.size += other.size
+	return q
+}
+
+// Size returns the number of values
+func (q PriorityQueue[T]) Size() int {
+	return q.size
+}
+
+// IsEmpty returns true if q holds no values
+func (q PriorityQueue[T]) IsEmpty() bool {
+	return q.size == 0
+}
+
+// All returns an iterator over the values in priority order; each step pops
+// from a new version, so it costs O(log n) per value and leaves q intact
+func (q PriorityQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for cur := q; !cur.IsEmpty(); {
+			var v T
+			v, cur, _ = cur.Pop()
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Sorted returns the values in priority order
+func (q PriorityQueue[T]) Sorted() []T {
+	result := make([]T, 0, q.size)
+	for v := range q.All() {
+		result = append(result, v)
+	}
+	return result
+}
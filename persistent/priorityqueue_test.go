@@ -0,0 +1,135 @@
+package persistent
+
+import (
+	"cmp"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// checkLeftist verifies heap order and the leftist rank invariant below n
+func checkLeftist[T any](t *testing.T, q PriorityQueue[T], n *heapNode[T]) int {
+	t.Helper()
+	if n == nil {
+		return 0
+	}
+	for _, child := range []*heapNode[T]{n.left, n.right} {
+		if child != nil && q.compare(child.value, n.value) < 0 {
+			t.Fatalf("Child %v outranks parent %v", child.value, n.value)
+		}
+	}
+	if rank(n.left) < rank(n.right) || n.rank != rank(n.right)+1 {
+		t.Fatalf("Node %v breaks the leftist invariant", n.value)
+	}
+	return 1 + checkLeftist(t, q, n.left) + checkLeftist(t, q, n.right)
+}
+
+func TestPriorityQueue(t *testing.T) {
+	empty := NewMinPriorityQueue(cmp.Compare[int])
+	if _, err := empty.Peek(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from Peek, got %v", err)
+	}
+	if _, same, err := empty.Pop(); !errors.Is(err, ErrEmpty) || !same.IsEmpty() {
+		t.Errorf("Expected ErrEmpty and an empty queue from Pop, got %v", err)
+	}
+
+	q := empty.Push(5).Push(1).Push(3)
+	if top, _ := q.Peek(); top != 1 {
+		t.Errorf("Expected 1 at the top, got %d", top)
+	}
+
+	v, rest, err := q.Pop()
+	if err != nil || v != 1 {
+		t.Errorf("Expected (1, nil), got (%d, %v)", v, err)
+	}
+	if rest.Size() != 2 || q.Size() != 3 {
+		t.Errorf("Expected sizes 2 and 3, got %d and %d", rest.Size(), q.Size())
+	}
+	if !empty.IsEmpty() {
+		t.Errorf("Expected the empty version to stay empty, got size %d", empty.Size())
+	}
+	if got := q.Sorted(); !slices.Equal(got, []int{1, 3, 5}) {
+		t.Errorf("Expected the original to keep [1 3 5], got %v", got)
+	}
+
+	maxQueue := NewMaxPriorityQueue(cmp.Compare[int]).Push(2).Push(7).Push(4)
+	if got := maxQueue.Sorted(); !slices.Equal(got, []int{7, 4, 2}) {
+		t.Errorf("Expected [7 4 2], got %v", got)
+	}
+}
+
+func TestPriorityQueueVersions(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	q := NewMinPriorityQueue(cmp.Compare[int])
+	var versions []PriorityQueue[int]
+	var contents [][]int
+	var ref []int
+
+	for i := 0; i < 300; i++ {
+		if len(ref) > 0 && rng.Intn(3) == 0 {
+			var v int
+			v, q, _ = q.Pop()
+			slices.Sort(ref)
+			if v != ref[0] {
+				t.Fatalf("Step %d: expected %d, got %d", i, ref[0], v)
+			}
+			ref = ref[1:]
+		} else {
+			v := rng.Intn(100)
+			q = q.Push(v)
+			ref = append(ref, v)
+		}
+		versions = append(versions, q)
+		contents = append(contents, slices.Sorted(slices.Values(ref)))
+	}
+
+	// Every earlier version still holds exactly what it held when it was made
+	for i, version := range versions {
+		if n := checkLeftist(t, version, version.root); n != version.Size() {
+			t.Fatalf("Version %d: counted %d nodes, size says %d", i, n, version.Size())
+		}
+		if got := version.Sorted(); !slices.Equal(got, contents[i]) {
+			t.Fatalf("Version %d: expected %v, got %v", i, contents[i], got)
+		}
+	}
+}
+
+func TestPriorityQueueMerge(t *testing.T) {
+	a := NewMinPriorityQueue(cmp.Compare[int]).Push(4).Push(1).Push(9)
+	b := NewMinPriorityQueue(cmp.Compare[int]).Push(3).Push(8)
+
+	merged := a.Merge(b)
+	if merged.Size() != 5 {
+		t.Errorf("Expected size 5, got %d", merged.Size())
+	}
+	checkLeftist(t, merged, merged.root)
+	if got := merged.Sorted(); !slices.Equal(got, []int{1, 3, 4, 8, 9}) {
+		t.Errorf("Expected [1 3 4 8 9], got %v", got)
+	}
+	if a.Size() != 3 || b.Size() != 2 {
+		t.Errorf("Expected the inputs to be unchanged, got sizes %d and %d", a.Size(), b.Size())
+	}
+
+	for v := range merged.All() {
+		if v != 1 {
+			t.Errorf("Expected iteration to start at 1, got %d", v)
+		}
+		break
+	}
+}
+
+// Benchmark tests
+func BenchmarkPriorityQueuePushPop(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	q := NewMinPriorityQueue(cmp.Compare[int])
+	for i := 0; i < 1000; i++ {
+		q = q.Push(rng.Int())
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q = q.Push(rng.Int())
+		_, q, _ = q.Pop()
+	}
+}
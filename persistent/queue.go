@@ -0,0 +1,112 @@
+package persistent
+
+import "iter"
+
+// cons is a cell of an immutable singly linked list
+type cons[T any] struct {
+	value T
+	next  *cons[T]
+}
+
+// reverse returns a new list holding the values of l in reverse order
+func reverse[T any](l *cons[T]) *cons[T] {
+	var r *cons[T]
+	for ; l != nil; l = l.next {
+		r = &cons[T]{value: l.value, next: r}
+	}
+	return r
+}
+
+// Queue is an immutable FIFO queue in the two-list style of Okasaki's
+// batched queue: pushes go onto a rear list and pops come off a front list,
+// which is refilled by reversing the rear list when it runs out
+// Push and Pop return new queues and never modify the receiver. Both cost
+// amortized O(1) when each version is used once; popping the same old
+// version repeatedly can repeat an O(n) reversal
+// The zero value is an empty queue
+type Queue[T any] struct {
+	front *cons[T] // nil only when the queue is empty
+	rear  *cons[T] // newest value first
+	size  int
+}
+
+// NewQueue returns a queue holding values, the first of them at the front
+func NewQueue[T any](values ...T) Queue[T] {
+	var q Queue[T]
+	for _, v := range values {
+		q = q.Push(v)
+	}
+	return q
+}
+
+// Push returns a queue with value added at the rear
+func (q Queue[T]) Push(value T) Queue[T] {
+	if q.front == nil {
+		q.front = &cons[T]{value: value}
+	} else {
+		q.rear = &cons[T]{value: value, next: q.rear}
+	}
+	q.size++
+	return q
+}
+
+// Pop returns the front value and the queue without it
+// Returns ErrEmpty, and q itself, if q is empty
+func (q Queue[T]) Pop() (T, Queue[T], error) {
+	if q.front == nil {
+		var zero T
+		return zero, q, ErrEmpty
+	}
+	value := q.front.value
+	q.front = q.front.next
+	if q.front == nil {
+		q.front, q.rear = reverse(q.rear), nil
+	}
+	q.size--
+	return value, q, nil
+}
+
+// Peek returns the front value
+// Returns ErrEmpty if q is empty
+func (q Queue[T]) Peek() (T, error) {
+	if q.front == nil {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return q.front.value, nil
+}
+
+// Size returns the number of values
+func (q Queue[T]) Size() int {
+	return q.size
+}
+
+// IsEmpty returns true if q holds no values
+func (q Queue[T]) IsEmpty() bool {
+	return q.size == 0
+}
+
+// All returns an iterator over the values from front to rear
+func (q Queue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for l := q.front; l != nil; l = l.next {
+			if !yield(l.value) {
+				return
+			}
+		}
+		for l := reverse(q.rear); l != nil; l = l.next {
+			if !yield(l.value) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns the values from front to rear
+func (q Queue[T]) ToSlice() []T {
+	result := make([]T, 0, q.size)
+	for v := range q.All() {
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package persistent
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestQueue(t *testing.T) {
+	var empty Queue[int]
+	if _, err := empty.Peek(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+	if _, q, err := empty.Pop(); !errors.Is(err, ErrEmpty) || !q.IsEmpty() {
+		t.Errorf("Expected ErrEmpty and an empty queue, got %v", err)
+	}
+
+	q := NewQueue(1, 2, 3)
+	v, q2, _ := q.Pop()
+	q2 = q2.Push(4)
+	if v != 1 || !slices.Equal(q2.ToSlice(), []int{2, 3, 4}) {
+		t.Errorf("Expected 1 and [2 3 4], got %d and %v", v, q2.ToSlice())
+	}
+	if !slices.Equal(q.ToSlice(), []int{1, 2, 3}) {
+		t.Errorf("Expected the original to stay [1 2 3], got %v", q.ToSlice())
+	}
+}
+
+func TestQueueVersions(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	// Apply random operations to random old versions and compare each new
+	// version with a slice model
+	versions := []Queue[int]{{}}
+	models := [][]int{nil}
+	for i := 0; i < 2000; i++ {
+		k := rng.Intn(len(versions))
+		q, model := versions[k], models[k]
+
+		if rng.Intn(2) == 0 || len(model) == 0 {
+			q = q.Push(i)
+			model = append(slices.Clip(model), i)
+		} else {
+			v, next, err := q.Pop()
+			if err != nil || v != model[0] {
+				t.Fatalf("Step %d: expected %d, got %d (%v)", i, model[0], v, err)
+			}
+			q, model = next, model[1:]
+		}
+		if q.Size() != len(model) || !slices.Equal(q.ToSlice(), model) {
+			t.Fatalf("Step %d: expected %v, got %v", i, model, q.ToSlice())
+		}
+		versions = append(versions, q)
+		models = append(models, model)
+	}
+}
+
+// Benchmark tests
+func BenchmarkQueuePushPop(b *testing.B) {
+	var q Queue[int]
+	for i := 0; i < b.N; i++ {
+		q = q.Push(i)
+		if i%2 == 1 {
+			_, q, _ = q.Pop()
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package persistent
+
+import "iter"
+
+// Stack is an immutable LIFO stack: a cons list whose head is the top
+// Push and Pop cost O(1) and return new stacks sharing every node below the
+// top with the receiver, so keeping old versions around for backtracking or
+// snapshots costs nothing extra
+// The zero value is an empty stack
+type Stack[T any] struct {
+	top  *cons[T]
+	size int
+}
+
+// NewStack returns a stack holding values pushed in order, so the last of
+// them is on top
+func NewStack[T any](values ...T) Stack[T] {
+	var s Stack[T]
+	for _, v := range values {
+		s = s.Push(v)
+	}
+	return s
+}
+
+// Push returns a stack with value added on top
+func (s Stack[T]) Push(value T) Stack[T] {
+	return Stack[T]{top: &cons[T]{value: value, next: s.top}, size: s.size + 1}
+}
+
+// Pop returns the top value and the stack without it
+// Returns ErrEmpty, and s itself, if s is empty
+func (s Stack[T]) Pop() (T, Stack[T], error) {
+	if s.top == nil {
+		var zero T
+		return zero, s, ErrEmpty
+	}
+	return s.top.value, Stack[T]{top: s.top.next, size: s.size - 1}, nil
+}
+
+// Peek returns the top value
+// Returns ErrEmpty if s is empty
+func (s Stack[T]) Peek() (T, error) {
+	if s.top == nil {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return s.top.value, nil
+}
+
+// Size returns the number of values
+func (s Stack[T]) Size() int {
+	return s.size
+}
+
+// IsEmpty returns true if s holds no values
+func (s Stack[T]) IsEmpty() bool {
+	return s.size == 0
+}
+
+// All returns an iterator over the values from top to bottom
+func (s Stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for l := s.top; l != nil; l = l.next {
+			if !yield(l.value) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns the values from top to bottom
+func (s Stack[T]) ToSlice() []T {
+	result := make([]T, 0, s.size)
+	for v := range s.All() {
+		result = append(result, v)
+	}
+	return result
+}
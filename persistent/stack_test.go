@@ -0,0 +1,64 @@
+package persistent
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestStack(t *testing.T) {
+	var empty Stack[int]
+	if _, err := empty.Peek(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+	if _, s, err := empty.Pop(); !errors.Is(err, ErrEmpty) || !s.IsEmpty() {
+		t.Errorf("Expected ErrEmpty and an empty stack, got %v", err)
+	}
+
+	s := NewStack(1, 2, 3)
+	v, s2, _ := s.Pop()
+	s2 = s2.Push(4)
+	if v != 3 || !slices.Equal(s2.ToSlice(), []int{4, 2, 1}) {
+		t.Errorf("Expected 3 and [4 2 1], got %d and %v", v, s2.ToSlice())
+	}
+	if !slices.Equal(s.ToSlice(), []int{3, 2, 1}) || s.Size() != 3 {
+		t.Errorf("Expected the original to stay [3 2 1], got %v", s.ToSlice())
+	}
+	if top, _ := s2.Peek(); top != 4 || s2.Size() != 3 {
+		t.Errorf("Expected top 4 and size 3, got %d and %d", top, s2.Size())
+	}
+}
+
+func TestStackBacktracking(t *testing.T) {
+	// Enumerate subsets of {1, 2, 3}, keeping the path as a persistent stack
+	// so each branch extends its own version without undoing anything
+	var subsets [][]int
+	var walk func(i int, path Stack[int])
+	walk = func(i int, path Stack[int]) {
+		if i > 3 {
+			subsets = append(subsets, path.ToSlice())
+			return
+		}
+		walk(i+1, path)
+		walk(i+1, path.Push(i))
+	}
+	walk(1, Stack[int]{})
+
+	if len(subsets) != 8 {
+		t.Fatalf("Expected 8 subsets, got %d", len(subsets))
+	}
+	if !slices.Equal(subsets[7], []int{3, 2, 1}) || len(subsets[0]) != 0 {
+		t.Errorf("Unexpected subsets %v", subsets)
+	}
+}
+
+// Benchmark tests
+func BenchmarkStackPushPop(b *testing.B) {
+	var s Stack[int]
+	for i := 0; i < b.N; i++ {
+		s = s.Push(i)
+		if i%2 == 0 {
+			_, s, _ = s.Pop()
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/anwar-arif/golang-dsa/queue"
+)
+
+// newStageQueue creates the bounded blocking FIFO connecting two stages
+// A capacity below 1 is treated as 1
+func newStageQueue[T any](capacity int) *queue.BoundedQueue[T] {
+	return queue.NewBoundedQueue[T](max(capacity, 1), queue.WithFullPolicy(queue.BlockWhenFull))
+}
+
+// StageStats is a point-in-time view of one stage
+type StageStats struct {
+	Name      string
+	Workers   int
+	Depth     int   // items waiting in the stage's output queue
+	Capacity  int   // capacity of the stage's output queue
+	Processed int64 // items handled successfully
+	Failed    int64 // items whose handler returned an error
+}
+
+// stage holds the bookkeeping shared by every kind of stage
+type stage struct {
+	name      string
+	workers   int
+	capacity  int
+	depth     func() int
+	processed atomic.Int64
+	failed    atomic.Int64
+}
+
+// Pipeline owns a chain of stages and their goroutines
+// The first error returned by any stage cancels the whole pipeline
+type Pipeline struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	err      error
+	finished bool
+	stages   []*stage
+}
+
+// New creates an empty pipeline bound to ctx
+func New(ctx context.Context) *Pipeline {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Pipeline{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Context returns the pipeline context, cancelled on the first error
+func (p *Pipeline) Context() context.Context {
+	return p.ctx
+}
+
+// fail records the first error and cancels the pipeline
+func (p *Pipeline) fail(name string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err == nil {
+		p.err = fmt.Errorf("stage %q: %w", name, err)
+		p.cancel()
+	}
+}
+
+func (p *Pipeline) addStage(name string, workers, capacity int, depth func() int) *stage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s := &stage{name: name, workers: workers, capacity: capacity, depth: depth}
+	p.stages = append(p.stages, s)
+	return s
+}
+
+// Wait blocks until every stage has finished and returns the first error, if any
+// Cancellation of the parent context is reported as its error
+func (p *Pipeline) Wait() error {
+	p.wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.finished {
+		if p.err == nil {
+			p.err = p.ctx.Err()
+		}
+		p.finished = true
+		p.cancel()
+	}
+	return p.err
+}
+
+// Stats returns the current metrics of every stage in the order they were added
+func (p *Pipeline) Stats() []StageStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := make([]StageStats, 0, len(p.stages))
+	for _, s := range p.stages {
+		depth := 0
+		if s.depth != nil {
+			depth = s.depth()
+		}
+		stats = append(stats, StageStats{
+			Name:      s.name,
+			Workers:   s.workers,
+			Depth:     depth,
+			Capacity:  s.capacity,
+			Processed: s.processed.Load(),
+			Failed:    s.failed.Load(),
+		})
+	}
+	return stats
+}
+
+// Stream is the bounded output of a stage, consumed by the next stage
+type Stream[T any] struct {
+	p *Pipeline
+	q *queue.BoundedQueue[T]
+}
+
+// Source adds a producer stage; gen calls emit for each item and blocks while the output is full
+func Source[T any](p *Pipeline, name string, capacity int, gen func(ctx context.Context, emit func(T) error) error) *Stream[T] {
+	out := newStageQueue[T](capacity)
+	st := p.addStage(name, 1, out.Cap(), out.Size)
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer out.Close()
+
+		emit := func(v T) error {
+			if err := out.PushContext(p.ctx, v); err != nil {
+				return err
+			}
+			st.processed.Add(1)
+			return nil
+		}
+
+		if err := gen(p.ctx, emit); err != nil && p.ctx.Err() == nil {
+			st.failed.Add(1)
+			p.fail(name, err)
+		}
+	}()
+
+	return &Stream[T]{p: p, q: out}
+}
+
+// FromSlice adds a source stage that emits the given items in order
+func FromSlice[T any](p *Pipeline, name string, items []T) *Stream[T] {
+	return Source(p, name, max(len(items), 1), func(ctx context.Context, emit func(T) error) error {
+		for _, item := range items {
+			if err := emit(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Stage adds a processing stage with the given number of workers reading from in
+// Results go to a new stream holding at most capacity items; with more than
+// one worker the output order is not guaranteed
+func Stage[In, Out any](p *Pipeline, name string, in *Stream[In], workers, capacity int, fn func(ctx context.Context, v In) (Out, error)) *Stream[Out] {
+	if workers < 1 {
+		workers = 1
+	}
+
+	out := newStageQueue[Out](capacity)
+	st := p.addStage(name, workers, out.Cap(), out.Size)
+
+	var stageWG sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		stageWG.Add(1)
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			defer stageWG.Done()
+
+			for {
+				v, err := in.q.PopContext(p.ctx)
+				if err != nil {
+					return
+				}
+
+				result, err := fn(p.ctx, v)
+				if err != nil {
+					st.failed.Add(1)
+					p.fail(name, err)
+					return
+				}
+
+				if err := out.PushContext(p.ctx, result); err != nil {
+					return
+				}
+				st.processed.Add(1)
+			}
+		}()
+	}
+
+	// Close the output once every worker is done so the next stage can finish
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		stageWG.Wait()
+		out.Close()
+	}()
+
+	return &Stream[Out]{p: p, q: out}
+}
+
+// Sink adds a terminal stage with the given number of workers consuming in
+func Sink[T any](p *Pipeline, name string, in *Stream[T], workers int, fn func(ctx context.Context, v T) error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	st := p.addStage(name, workers, 0, nil)
+
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+
+			for {
+				v, err := in.q.PopContext(p.ctx)
+				if err != nil {
+					return
+				}
+
+				if err := fn(p.ctx, v); err != nil {
+					st.failed.Add(1)
+					p.fail(name, err)
+					return
+				}
+				st.processed.Add(1)
+			}
+		}()
+	}
+}
+
+// Collect drains in on the calling goroutine, waits for the pipeline and
+// returns everything received along with the pipeline error
+func Collect[T any](in *Stream[T]) ([]T, error) {
+	var items []T
+	for {
+		v, err := in.q.PopContext(in.p.ctx)
+		if err != nil {
+			break
+		}
+		items = append(items, v)
+	}
+	return items, in.p.Wait()
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Pipeline Examples ===")
+
+	// Example 1: Generate -> square (4 workers) -> sum
+	fmt.Println("1. Fan-out Squares:")
+	p := New(context.Background())
+
+	numbers := FromSlice(p, "numbers", []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	squares := Stage(p, "square", numbers, 4, 2, func(ctx context.Context, n int) (int, error) {
+		return n * n, nil
+	})
+
+	var sum int64
+	Sink(p, "sum", squares, 1, func(ctx context.Context, n int) error {
+		sum += int64(n)
+		return nil
+	})
+
+	if err := p.Wait(); err != nil {
+		fmt.Printf("  Pipeline error: %v\n", err)
+	}
+	fmt.Printf("  Sum of squares: %d\n", sum)
+
+	for _, s := range p.Stats() {
+		fmt.Printf("  Stage %-8s workers=%d processed=%d\n", s.Name, s.Workers, s.Processed)
+	}
+
+	// Example 2: Errors cancel every stage
+	fmt.Println("\n2. Error Propagation:")
+	p = New(context.Background())
+
+	inputs := FromSlice(p, "inputs", []string{"1", "2", "x", "4"})
+	parsed := Stage(p, "parse", inputs, 1, 1, func(ctx context.Context, s string) (int, error) {
+		var n int
+		if _, err := fmt.Sscanf(s, "%d", &n); err != nil {
+			return 0, fmt.Errorf("cannot parse %q", s)
+		}
+		return n, nil
+	})
+
+	_, err := Collect(parsed)
+	fmt.Printf("  Pipeline error: %v\n", err)
+}
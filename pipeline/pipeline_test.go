@@ -0,0 +1,257 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/anwar-arif/golang-dsa/queue"
+)
+
+func TestLinearPipeline(t *testing.T) {
+	p := New(context.Background())
+
+	src := FromSlice(p, "numbers", []int{1, 2, 3, 4, 5})
+	doubled := Stage(p, "double", src, 1, 2, func(ctx context.Context, n int) (int, error) {
+		return n * 2, nil
+	})
+	labels := Stage(p, "label", doubled, 1, 2, func(ctx context.Context, n int) (string, error) {
+		return string(rune('a' + n)), nil
+	})
+
+	result, err := Collect(labels)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Single workers keep the input order
+	expected := []string{"c", "e", "g", "i", "k"}
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d results, got %d", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if result[i] != v {
+			t.Errorf("Expected %s at position %d, got %s", v, i, result[i])
+		}
+	}
+}
+
+func TestFanOut(t *testing.T) {
+	p := New(context.Background())
+
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+
+	var active, peak atomic.Int32
+	src := FromSlice(p, "numbers", items)
+	squared := Stage(p, "square", src, 8, 4, func(ctx context.Context, n int) (int, error) {
+		cur := active.Add(1)
+		for {
+			old := peak.Load()
+			if cur <= old || peak.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		active.Add(-1)
+		return n * n, nil
+	})
+
+	result, err := Collect(squared)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	sort.Ints(result)
+	for i, v := range result {
+		if v != i*i {
+			t.Errorf("Expected %d at position %d, got %d", i*i, i, v)
+		}
+	}
+
+	if peak.Load() < 2 {
+		t.Errorf("Expected workers to run in parallel, peak was %d", peak.Load())
+	}
+	if peak.Load() > 8 {
+		t.Errorf("Expected at most 8 parallel workers, peak was %d", peak.Load())
+	}
+}
+
+func TestErrorPropagation(t *testing.T) {
+	p := New(context.Background())
+	boom := errors.New("boom")
+
+	src := Source(p, "endless", 1, func(ctx context.Context, emit func(int) error) error {
+		for i := 0; ; i++ {
+			if err := emit(i); err != nil {
+				return err
+			}
+		}
+	})
+	failing := Stage(p, "fail", src, 2, 1, func(ctx context.Context, n int) (int, error) {
+		if n == 10 {
+			return 0, boom
+		}
+		return n, nil
+	})
+
+	var sunk atomic.Int32
+	Sink(p, "sink", failing, 1, func(ctx context.Context, n int) error {
+		sunk.Add(1)
+		return nil
+	})
+
+	done := make(chan error)
+	go func() { done <- p.Wait() }()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, boom) {
+			t.Errorf("Expected boom error, got %v", err)
+		}
+		if err.Error() != `stage "fail": boom` {
+			t.Errorf("Expected stage name in error, got %q", err.Error())
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Pipeline did not stop after an error")
+	}
+
+	stats := p.Stats()
+	if stats[1].Failed != 1 {
+		t.Errorf("Expected 1 failure in stage fail, got %d", stats[1].Failed)
+	}
+}
+
+func TestSourceError(t *testing.T) {
+	p := New(context.Background())
+	bad := errors.New("bad input")
+
+	src := Source(p, "reader", 4, func(ctx context.Context, emit func(int) error) error {
+		emit(1)
+		return bad
+	})
+
+	_, err := Collect(src)
+	if !errors.Is(err, bad) {
+		t.Errorf("Expected source error, got %v", err)
+	}
+}
+
+func TestCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := New(ctx)
+
+	src := Source(p, "ticker", 1, func(ctx context.Context, emit func(int) error) error {
+		for i := 0; ; i++ {
+			if err := emit(i); err != nil {
+				return err
+			}
+		}
+	})
+	Sink(p, "slow", src, 1, func(ctx context.Context, n int) error {
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	if err := p.Wait(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	// Wait is idempotent
+	if err := p.Wait(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled on second Wait, got %v", err)
+	}
+}
+
+func TestStatsDepth(t *testing.T) {
+	p := New(context.Background())
+	release := make(chan struct{})
+
+	src := FromSlice(p, "numbers", []int{1, 2, 3, 4})
+	Sink(p, "blocked", src, 1, func(ctx context.Context, n int) error {
+		<-release
+		return nil
+	})
+
+	// Wait for the sink to take the first item
+	deadline := time.Now().Add(time.Second)
+	for p.Stats()[0].Depth != 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	stats := p.Stats()
+	if stats[0].Name != "numbers" || stats[0].Depth != 3 || stats[0].Capacity != 4 {
+		t.Errorf("Unexpected source stats: %+v", stats[0])
+	}
+	if stats[1].Name != "blocked" || stats[1].Workers != 1 {
+		t.Errorf("Unexpected sink stats: %+v", stats[1])
+	}
+
+	close(release)
+	if err := p.Wait(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	stats = p.Stats()
+	if stats[0].Processed != 4 || stats[1].Processed != 4 || stats[0].Depth != 0 {
+		t.Errorf("Unexpected final stats: %+v", stats)
+	}
+}
+
+func TestStageQueue(t *testing.T) {
+	ctx := context.Background()
+	if q := newStageQueue[int](0); q.Cap() != 1 {
+		t.Errorf("Expected capacity 0 to become 1, got %d", q.Cap())
+	}
+	q := newStageQueue[int](2)
+
+	q.PushContext(ctx, 1)
+	q.PushContext(ctx, 2)
+
+	// Full queue blocks until the context ends
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := q.PushContext(timeout, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+
+	q.Close()
+	if err := q.PushContext(ctx, 3); !errors.Is(err, queue.ErrClosed) {
+		t.Errorf("Expected queue.ErrClosed, got %v", err)
+	}
+
+	// Buffered values survive close
+	for _, expected := range []int{1, 2} {
+		v, err := q.PopContext(ctx)
+		if err != nil || v != expected {
+			t.Errorf("Expected %d, got %d (err=%v)", expected, v, err)
+		}
+	}
+
+	if _, err := q.PopContext(ctx); !errors.Is(err, queue.ErrClosed) {
+		t.Errorf("Expected closed and drained queue, got %v", err)
+	}
+}
+
+// Benchmark tests
+func BenchmarkPipeline(b *testing.B) {
+	items := make([]int, b.N)
+
+	b.ResetTimer()
+	p := New(context.Background())
+	src := FromSlice(p, "src", items)
+	inc := Stage(p, "inc", src, 4, 64, func(ctx context.Context, n int) (int, error) {
+		return n + 1, nil
+	})
+	Sink(p, "sink", inc, 1, func(ctx context.Context, n int) error {
+		return nil
+	})
+	p.Wait()
+}
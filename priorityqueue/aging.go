@@ -0,0 +1,127 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"time"
+)
+
+// AgingFunc returns how much a value's priority has grown after it has been
+// waiting for d; it should never decrease as d grows
+type AgingFunc func(waited time.Duration) float64
+
+// LinearAging returns an AgingFunc that adds perSecond for every second waited
+func LinearAging(perSecond float64) AgingFunc {
+	return func(waited time.Duration) float64 {
+		return perSecond * waited.Seconds()
+	}
+}
+
+// agingEntry is a queued value with its base priority and the effective
+// priority computed at the last refresh
+type agingEntry[T any] struct {
+	value     T
+	base      float64
+	enqueued  time.Time
+	effective float64
+}
+
+// AgingQueue is a max-priority queue in which waiting values gain priority,
+// so that a steady stream of urgent values cannot starve the rest forever
+// The effective priority of a value is its base priority plus aging of the
+// time it has waited. It is re-evaluated lazily on Pop and Peek, which
+// re-heapify in O(n); RefreshEvery trades accuracy for fewer re-evaluations
+type AgingQueue[T any] struct {
+	pq       *PriorityQueue[*agingEntry[T]]
+	priority func(T) float64
+	aging    AgingFunc
+	interval time.Duration
+	refresh  time.Time // time of the last re-evaluation
+	now      func() time.Time
+}
+
+// NewAgingQueue creates an aging queue that reads each value's base priority
+// with priority; higher values are served first
+func NewAgingQueue[T any](priority func(T) float64, aging AgingFunc, opts ...Option) *AgingQueue[T] {
+	return &AgingQueue[T]{
+		pq: NewMaxQueue(func(a, b *agingEntry[T]) int {
+			return cmp.Compare(a.effective, b.effective)
+		}, opts...),
+		priority: priority,
+		aging:    aging,
+		now:      time.Now,
+	}
+}
+
+// RefreshEvery re-evaluates priorities at most once per d instead of on every
+// Pop and Peek; a value may then be served up to d of aging late
+func (q *AgingQueue[T]) RefreshEvery(d time.Duration) {
+	q.interval = d
+}
+
+// Push adds value, which starts waiting now
+func (q *AgingQueue[T]) Push(value T) {
+	now := q.now()
+	base := q.priority(value)
+	q.pq.Push(&agingEntry[T]{
+		value:     value,
+		base:      base,
+		enqueued:  now,
+		effective: base + q.aging(0),
+	})
+}
+
+// Pop removes and returns the value with the highest effective priority
+// Returns ErrEmpty if the queue is empty
+func (q *AgingQueue[T]) Pop() (T, error) {
+	q.reevaluate()
+	e, err := q.pq.Pop()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return e.value, nil
+}
+
+// Peek returns the value with the highest effective priority without
+// removing it
+// Returns ErrEmpty if the queue is empty
+func (q *AgingQueue[T]) Peek() (T, error) {
+	q.reevaluate()
+	e, err := q.pq.Peek()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return e.value, nil
+}
+
+// reevaluate recomputes every effective priority and restores the heap,
+// unless the last refresh is more recent than the refresh interval
+func (q *AgingQueue[T]) reevaluate() {
+	now := q.now()
+	if q.pq.IsEmpty() || (q.interval > 0 && now.Sub(q.refresh) < q.interval) {
+		return
+	}
+	q.refresh = now
+	for _, item := range q.pq.heap.items {
+		e := item.Value
+		e.effective = e.base + q.aging(now.Sub(e.enqueued))
+	}
+	q.pq.heap.init()
+	q.pq.debugCheck()
+}
+
+// Size returns the number of queued values
+func (q *AgingQueue[T]) Size() int {
+	return q.pq.Size()
+}
+
+// IsEmpty returns true if the queue is empty
+func (q *AgingQueue[T]) IsEmpty() bool {
+	return q.pq.IsEmpty()
+}
+
+// Clear removes every value
+func (q *AgingQueue[T]) Clear() {
+	q.pq.Clear()
+}
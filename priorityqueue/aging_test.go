@@ -0,0 +1,96 @@
+package priorityqueue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anwar-arif/golang-dsa/internal/testclock"
+)
+
+func newTestAging(aging AgingFunc) (*AgingQueue[Task], *testclock.Clock) {
+	q := NewAgingQueue(func(task Task) float64 { return float64(task.Priority) }, aging)
+	clock := testclock.New()
+	q.now = clock.Now
+	return q, clock
+}
+
+func TestAgingQueue(t *testing.T) {
+	q, clock := newTestAging(LinearAging(1))
+	if _, err := q.Pop(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	q.Push(Task{Name: "low", Priority: 1})
+	q.Push(Task{Name: "high", Priority: 10})
+	if task, _ := q.Peek(); task.Name != "high" {
+		t.Errorf("Expected high first without aging, got %q", task.Name)
+	}
+
+	// After 20 seconds low has effective priority 21 while a fresh urgent
+	// task only has 15
+	clock.Advance(20 * time.Second)
+	q.Push(Task{Name: "urgent", Priority: 15})
+	for _, expected := range []string{"high", "low", "urgent"} {
+		if task, _ := q.Pop(); task.Name != expected {
+			t.Errorf("Expected %q, got %q", expected, task.Name)
+		}
+	}
+	if !q.IsEmpty() {
+		t.Errorf("Expected empty queue, got size %d", q.Size())
+	}
+}
+
+func TestAgingQueueBoundsStarvation(t *testing.T) {
+	q, clock := newTestAging(LinearAging(2))
+	q.Push(Task{Name: "background", Priority: 0})
+
+	// A new urgent task arrives every second and one task is served per
+	// second; background must still be served within 50 seconds
+	for second := 1; second <= 50; second++ {
+		clock.Advance(time.Second)
+		q.Push(Task{Name: "urgent", Priority: 50})
+		if task, _ := q.Pop(); task.Name == "background" {
+			return
+		}
+	}
+	t.Error("Expected background to be served despite a stream of urgent tasks")
+}
+
+func TestAgingQueueRefreshEvery(t *testing.T) {
+	q, clock := newTestAging(LinearAging(1))
+	q.RefreshEvery(time.Minute)
+	q.Push(Task{Name: "old", Priority: 1})
+	q.Peek()
+
+	// Until a minute has passed, old keeps the priority it had at the first
+	// Peek even though it has aged to 31 by now
+	clock.Advance(30 * time.Second)
+	q.Push(Task{Name: "new", Priority: 20})
+	if task, _ := q.Peek(); task.Name != "new" {
+		t.Errorf("Expected stale order to put new first, got %q", task.Name)
+	}
+	clock.Advance(31 * time.Second)
+	if task, _ := q.Peek(); task.Name != "old" {
+		t.Errorf("Expected old first after a refresh, got %q", task.Name)
+	}
+
+	q.Clear()
+	if !q.IsEmpty() {
+		t.Errorf("Expected empty queue after Clear, got size %d", q.Size())
+	}
+}
+
+// Benchmark tests
+func BenchmarkAgingQueuePop(b *testing.B) {
+	q := NewAgingQueue(func(v int) float64 { return float64(v) }, LinearAging(1))
+	for i := 0; i < 1000; i++ {
+		q.Push(i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v, _ := q.Pop()
+		q.Push(v)
+	}
+}
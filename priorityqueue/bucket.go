@@ -0,0 +1,120 @@
+package priorityqueue
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPriorityRange is returned when a priority falls outside the range a
+// queue was built for
+var ErrPriorityRange = errors.New("priority queue: priority out of range")
+
+// bucket is a FIFO of values sharing a priority
+type bucket[T any] struct {
+	values []T
+	head   int
+}
+
+// BucketQueue is a min-priority queue for small non-negative integer
+// priorities, as used by Dial's algorithm and weighted BFS
+// Every priority has its own bucket and a cursor remembers the lowest
+// non-empty one, so Push is O(1) and PopMin is amortized O(1) when priorities
+// are monotone: nothing is pushed below the last popped priority. Other
+// workloads stay correct but the cursor may have to rescan
+// Values of equal priority pop in the order they were pushed
+type BucketQueue[T any] struct {
+	buckets []bucket[T]
+	cursor  int // no bucket below cursor holds a value
+	size    int
+}
+
+// NewBucketQueue creates a queue for priorities 0 through maxPriority
+// Panics if maxPriority is negative
+func NewBucketQueue[T any](maxPriority int) *BucketQueue[T] {
+	if maxPriority < 0 {
+		panic(fmt.Sprintf("priorityqueue: maxPriority must not be negative, got %d", maxPriority))
+	}
+	return &BucketQueue[T]{buckets: make([]bucket[T], maxPriority+1)}
+}
+
+// Push adds value with priority
+// Returns ErrPriorityRange if priority is outside [0, maxPriority]
+func (q *BucketQueue[T]) Push(value T, priority int) error {
+	if priority < 0 || priority >= len(q.buckets) {
+		return fmt.Errorf("%w: %d not in [0, %d]", ErrPriorityRange, priority, len(q.buckets)-1)
+	}
+	b := &q.buckets[priority]
+	b.values = append(b.values, value)
+	q.cursor = min(q.cursor, priority)
+	q.size++
+	return nil
+}
+
+// PopMin removes and returns the value with the lowest priority along with
+// that priority
+// Returns ErrEmpty if the queue is empty
+func (q *BucketQueue[T]) PopMin() (T, int, error) {
+	var zero T
+	if !q.advance() {
+		return zero, 0, ErrEmpty
+	}
+	b := &q.buckets[q.cursor]
+	value := b.values[b.head]
+	b.values[b.head] = zero
+	b.head++
+	if b.head == len(b.values) {
+		// Reuse the bucket's storage from the start
+		b.values, b.head = b.values[:0], 0
+	}
+	q.size--
+	return value, q.cursor, nil
+}
+
+// PeekMin returns the value with the lowest priority and that priority
+// without removing it
+// Returns ErrEmpty if the queue is empty
+func (q *BucketQueue[T]) PeekMin() (T, int, error) {
+	if !q.advance() {
+		var zero T
+		return zero, 0, ErrEmpty
+	}
+	b := &q.buckets[q.cursor]
+	return b.values[b.head], q.cursor, nil
+}
+
+// advance moves the cursor to the lowest non-empty bucket and reports
+// whether there is one
+func (q *BucketQueue[T]) advance() bool {
+	if q.size == 0 {
+		return false
+	}
+	for len(q.buckets[q.cursor].values) == 0 {
+		q.cursor++
+	}
+	return true
+}
+
+// Size returns the number of queued values
+func (q *BucketQueue[T]) Size() int {
+	return q.size
+}
+
+// IsEmpty returns true if the queue is empty
+func (q *BucketQueue[T]) IsEmpty() bool {
+	return q.size == 0
+}
+
+// MaxPriority returns the highest priority the queue accepts
+func (q *BucketQueue[T]) MaxPriority() int {
+	return len(q.buckets) - 1
+}
+
+// Clear removes every value
+func (q *BucketQueue[T]) Clear() {
+	for i := range q.buckets {
+		b := &q.buckets[i]
+		clear(b.values)
+		b.values, b.head = b.values[:0], 0
+	}
+	q.cursor, q.size = 0, 0
+}
@@ -0,0 +1,102 @@
+package priorityqueue
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestBucketQueue(t *testing.T) {
+	q := NewBucketQueue[string](5)
+	if _, _, err := q.PopMin(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+	if err := q.Push("x", 6); !errors.Is(err, ErrPriorityRange) {
+		t.Errorf("Expected ErrPriorityRange, got %v", err)
+	}
+	if err := q.Push("x", -1); !errors.Is(err, ErrPriorityRange) {
+		t.Errorf("Expected ErrPriorityRange for a negative priority, got %v", err)
+	}
+
+	q.Push("c", 3)
+	q.Push("a1", 1)
+	q.Push("e", 5)
+	q.Push("a2", 1)
+	if v, p, _ := q.PeekMin(); v != "a1" || p != 1 {
+		t.Errorf("Expected a1 at 1, got %s at %d", v, p)
+	}
+
+	// Equal priorities pop first in, first out
+	expected := []struct {
+		value    string
+		priority int
+	}{{"a1", 1}, {"a2", 1}, {"c", 3}}
+	for _, e := range expected {
+		v, p, err := q.PopMin()
+		if err != nil || v != e.value || p != e.priority {
+			t.Errorf("Expected %s at %d, got %s at %d (%v)", e.value, e.priority, v, p, err)
+		}
+	}
+
+	// Pushing below the cursor is still handled
+	q.Push("z", 0)
+	if v, p, _ := q.PopMin(); v != "z" || p != 0 {
+		t.Errorf("Expected z at 0, got %s at %d", v, p)
+	}
+	if q.Size() != 1 || q.MaxPriority() != 5 {
+		t.Errorf("Expected size 1 and max priority 5, got %d and %d", q.Size(), q.MaxPriority())
+	}
+
+	q.Clear()
+	if !q.IsEmpty() {
+		t.Errorf("Expected empty queue after Clear, got size %d", q.Size())
+	}
+}
+
+func TestBucketQueueMonotone(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	q := NewBucketQueue[int](1000)
+	var ref []int
+	last := 0
+
+	// Dial's algorithm only pushes at or above the last popped priority
+	for step := 0; step < 5000; step++ {
+		if len(ref) > 0 && rng.Intn(2) == 0 {
+			_, p, _ := q.PopMin()
+			sort.Ints(ref)
+			if p != ref[0] {
+				t.Fatalf("Step %d: expected priority %d, got %d", step, ref[0], p)
+			}
+			ref, last = ref[1:], p
+			continue
+		}
+		p := min(last+rng.Intn(10), 1000)
+		q.Push(step, p)
+		ref = append(ref, p)
+	}
+	if q.Size() != len(ref) {
+		t.Errorf("Expected size %d, got %d", len(ref), q.Size())
+	}
+}
+
+func TestBucketQueuePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for a negative max priority")
+		}
+	}()
+	NewBucketQueue[int](-1)
+}
+
+// Benchmark tests
+func BenchmarkBucketQueue(b *testing.B) {
+	q := NewBucketQueue[int](1 << 16)
+	last := 0
+	for i := 0; i < b.N; i++ {
+		q.Push(i, min(last+i%8, 1<<16))
+		if i%2 == 1 {
+			_, last, _ = q.PopMin()
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package priorityqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/anwar-arif/golang-dsa/internal/wake"
+)
+
+// ErrClosed is returned when pushing to a closed ConcurrentQueue, or popping
+// from one that is closed and drained
+var ErrClosed = errors.New("priority queue is closed")
+
+// ConcurrentQueue is a PriorityQueue guarded by a mutex that many goroutines
+// can push to and pop from, with a Pop that blocks until a value arrives
+type ConcurrentQueue[T any] struct {
+	mu       sync.Mutex
+	pq       *PriorityQueue[T]
+	closed   bool
+	notEmpty wake.Signal   // wakes one blocked Pop
+	done     chan struct{} // closed by Close to release every blocked Pop
+}
+
+// NewConcurrentMinQueue creates a thread-safe min-priority queue
+func NewConcurrentMinQueue[T any](compare CompareFunc[T], opts ...Option) *ConcurrentQueue[T] {
+	return newConcurrent(NewMinQueue(compare, opts...))
+}
+
+// NewConcurrentMaxQueue creates a thread-safe max-priority queue
+func NewConcurrentMaxQueue[T any](compare CompareFunc[T], opts ...Option) *ConcurrentQueue[T] {
+	return newConcurrent(NewMaxQueue(compare, opts...))
+}
+
+func newConcurrent[T any](pq *PriorityQueue[T]) *ConcurrentQueue[T] {
+	return &ConcurrentQueue[T]{
+		pq:       pq,
+		notEmpty: wake.New(),
+		done:     make(chan struct{}),
+	}
+}
+
+// Push adds value to the queue
+// Returns ErrClosed once Close has been called
+func (q *ConcurrentQueue[T]) Push(value T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+	q.pq.Push(value)
+	q.notEmpty.Notify()
+	return nil
+}
+
+// TryPop removes and returns the value with highest priority without
+// blocking
+// Returns ErrEmpty if the queue is empty
+func (q *ConcurrentQueue[T]) TryPop() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.pq.Pop()
+}
+
+// Pop removes and returns the value with highest priority, blocking until
+// one is available
+// Returns ctx's error if it is done first, or ErrClosed once the queue is
+// closed and drained
+func (q *ConcurrentQueue[T]) Pop(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		if v, err := q.pq.Pop(); err == nil {
+			if !q.pq.IsEmpty() {
+				// Pass the wake-up on to the next waiter
+				q.notEmpty.Notify()
+			}
+			q.mu.Unlock()
+			return v, nil
+		}
+		closed := q.closed
+		q.mu.Unlock()
+
+		var zero T
+		if closed {
+			return zero, ErrClosed
+		}
+		select {
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		case <-q.done:
+		case <-q.notEmpty:
+		}
+	}
+}
+
+// Peek returns the value with highest priority without removing it
+// Returns ErrEmpty if the queue is empty
+func (q *ConcurrentQueue[T]) Peek() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.pq.Peek()
+}
+
+// Size returns the number of queued values
+func (q *ConcurrentQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.pq.Size()
+}
+
+// IsEmpty returns true if the queue is empty
+func (q *ConcurrentQueue[T]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+// Close stops the queue accepting values; values already queued can still
+// be popped, after which Pop returns ErrClosed
+func (q *ConcurrentQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.closed {
+		q.closed = true
+		close(q.done)
+	}
+}
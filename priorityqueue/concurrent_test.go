@@ -0,0 +1,84 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrentQueue(t *testing.T) {
+	q := NewConcurrentMaxQueue(cmp.Compare[int])
+	if _, err := q.TryPop(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	for _, v := range []int{2, 7, 4} {
+		q.Push(v)
+	}
+	if top, _ := q.Peek(); top != 7 {
+		t.Errorf("Expected 7 on top, got %d", top)
+	}
+	if v, _ := q.Pop(context.Background()); v != 7 {
+		t.Errorf("Expected 7, got %d", v)
+	}
+
+	q.Close()
+	if err := q.Push(1); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed from Push, got %v", err)
+	}
+	if v, _ := q.Pop(context.Background()); v != 4 {
+		t.Errorf("Expected queued values to survive Close, got %d", v)
+	}
+	q.TryPop()
+	if _, err := q.Pop(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed from a drained queue, got %v", err)
+	}
+}
+
+func TestConcurrentQueueBlockingPop(t *testing.T) {
+	q := NewConcurrentMinQueue(cmp.Compare[int])
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Pop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	const producers, perProducer = 4, 250
+	var wg sync.WaitGroup
+	for p := range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perProducer {
+				q.Push(p*perProducer + i)
+			}
+		}()
+	}
+
+	results := make(chan int)
+	for range 3 {
+		go func() {
+			for {
+				v, err := q.Pop(context.Background())
+				if err != nil {
+					return
+				}
+				results <- v
+			}
+		}()
+	}
+
+	seen := make(map[int]bool)
+	for range producers * perProducer {
+		seen[<-results] = true
+	}
+	wg.Wait()
+	q.Close()
+	if len(seen) != producers*perProducer {
+		t.Errorf("Expected %d distinct values, got %d", producers*perProducer, len(seen))
+	}
+}
@@ -0,0 +1,116 @@
+package priorityqueue
+
+import "fmt"
+
+// DefaultArity is the number of children per heap node unless WithArity is
+// passed
+const DefaultArity = 2
+
+// WithArity stores the queue as a d-ary heap, where every node has d
+// children instead of 2
+// A wider heap is shallower, so pushes sift through fewer levels while pops
+// compare more children per level; d = 4 tends to pay off when pushes
+// outnumber pops. Panics if d < 2
+func WithArity(d int) Option {
+	if d < 2 {
+		panic(fmt.Sprintf("priorityqueue: arity must be at least 2, got %d", d))
+	}
+	return func(o *options) { o.arity = d }
+}
+
+// The operations below mirror container/heap, generalised to d children
+// per node: the children of i are i*d+1 through i*d+d
+
+// parent returns the index of the parent of i
+func (h *priorityHeap[T]) parent(i int) int {
+	return (i - 1) / h.arity
+}
+
+// init establishes the heap invariants in O(n)
+func (h *priorityHeap[T]) init() {
+	n := len(h.items)
+	if n < 2 {
+		return
+	}
+	for i := h.parent(n - 1); i >= 0; i-- {
+		h.down(i, n)
+	}
+}
+
+// push adds item and sifts it up
+func (h *priorityHeap[T]) push(item *Item[T]) {
+	item.Index = len(h.items)
+	h.items = append(h.items, item)
+	h.up(item.Index)
+}
+
+// pop removes and returns the root
+func (h *priorityHeap[T]) pop() *Item[T] {
+	n := len(h.items) - 1
+	h.Swap(0, n)
+	h.down(0, n)
+	return h.removeLast()
+}
+
+// remove removes and returns the item at index i
+func (h *priorityHeap[T]) remove(i int) *Item[T] {
+	n := len(h.items) - 1
+	if n != i {
+		h.Swap(i, n)
+		if !h.down(i, n) {
+			h.up(i)
+		}
+	}
+	return h.removeLast()
+}
+
+// fix restores the heap after the value at index i changed
+func (h *priorityHeap[T]) fix(i int) {
+	if !h.down(i, len(h.items)) {
+		h.up(i)
+	}
+}
+
+func (h *priorityHeap[T]) removeLast() *Item[T] {
+	n := len(h.items) - 1
+	item := h.items[n]
+	h.items[n] = nil // avoid memory leak
+	item.Index = -1  // for safety
+	h.items = h.items[:n]
+	return item
+}
+
+func (h *priorityHeap[T]) up(j int) {
+	for j > 0 {
+		i := h.parent(j)
+		if !h.Less(j, i) {
+			break
+		}
+		h.Swap(i, j)
+		j = i
+	}
+}
+
+// down sifts the item at i0 towards the leaves of the first n items and
+// reports whether it moved
+func (h *priorityHeap[T]) down(i0, n int) bool {
+	i := i0
+	for {
+		first := i*h.arity + 1
+		if first >= n || first < 0 { // first < 0 after int overflow
+			break
+		}
+		best := first
+		for c := first + 1; c < first+h.arity && c < n; c++ {
+			if h.Less(c, best) {
+				best = c
+			}
+		}
+		if !h.Less(best, i) {
+			break
+		}
+		h.Swap(i, best)
+		i = best
+	}
+	return i > i0
+}
@@ -0,0 +1,99 @@
+package priorityqueue
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestWithArity(t *testing.T) {
+	for _, d := range []int{2, 3, 4, 8} {
+		t.Run(fmt.Sprintf("d=%d", d), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(int64(d)))
+			pq := NewMinQueue(IntCompare, WithArity(d))
+
+			var items []*Item[int]
+			for i := 0; i < 200; i++ {
+				item := NewItem(rng.Intn(1000))
+				pq.PushItem(item)
+				items = append(items, item)
+			}
+
+			// Remove every fifth item and lower every seventh
+			var want []int
+			for i, item := range items {
+				switch {
+				case i%5 == 0:
+					pq.Remove(item)
+					continue
+				case i%7 == 0:
+					item.Value -= 500
+					pq.UpdateItem(item)
+				}
+				want = append(want, item.Value)
+			}
+			if err := pq.Validate(); err != nil {
+				t.Fatalf("Unexpected invalid heap: %v", err)
+			}
+
+			slices.Sort(want)
+			for _, expected := range want {
+				if v, _ := pq.Pop(); v != expected {
+					t.Fatalf("Expected %d, got %d", expected, v)
+				}
+			}
+			if !pq.IsEmpty() {
+				t.Errorf("Expected empty queue, got size %d", pq.Size())
+			}
+		})
+	}
+}
+
+func TestWithArityFromSlice(t *testing.T) {
+	values := []int{9, 4, 7, 1, 8, 2, 6, 3, 5}
+	pq := NewMaxQueueFromSlice(IntCompare, values, WithArity(4))
+	if err := pq.Validate(); err != nil {
+		t.Fatalf("Unexpected invalid heap: %v", err)
+	}
+
+	// Clones keep the arity of the original
+	clone := pq.Clone()
+	clone.Push(10)
+	if err := clone.Validate(); err != nil {
+		t.Fatalf("Unexpected invalid clone: %v", err)
+	}
+
+	for expected := 9; expected >= 1; expected-- {
+		if v, _ := pq.Pop(); v != expected {
+			t.Errorf("Expected %d, got %d", expected, v)
+		}
+	}
+}
+
+func TestWithArityPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for arity 1")
+		}
+	}()
+	WithArity(1)
+}
+
+// Benchmark tests
+func BenchmarkArity(b *testing.B) {
+	for _, d := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("d=%d", d), func(b *testing.B) {
+			rng := rand.New(rand.NewSource(1))
+			pq := NewMinQueue(IntCompare, WithArity(d))
+
+			// Four pushes per pop, as in a best-first graph search
+			for i := 0; i < b.N; i++ {
+				pq.Push(rng.Int())
+				if i%4 == 3 {
+					pq.Pop()
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !dsadebug
+
+package priorityqueue
+
+// debugChecks enables validation after every mutation; build with
+// -tags dsadebug to turn it on
+const debugChecks = false
@@ -0,0 +1,6 @@
+//go:build dsadebug
+
+package priorityqueue
+
+// debugChecks enables validation after every mutation
+const debugChecks = true
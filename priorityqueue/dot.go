@@ -0,0 +1,27 @@
+package priorityqueue
+
+import "github.com/anwar-arif/golang-dsa/viz"
+
+// ToDOT renders the heap as a Graphviz DOT tree in array order, one node per
+// slot, so the picture matches the queue's arity and layout
+// Tombstoned values from MarkDeleted are shown with a "(deleted)" suffix
+// A nil label uses fmt.Sprint
+func (pq *PriorityQueue[T]) ToDOT(label func(T) string) string {
+	if label == nil {
+		label = viz.Sprint[T]
+	}
+	items := func(yield func(*Item[T]) bool) {
+		for _, item := range pq.heap.items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+	g := viz.DaryHeap("priorityqueue", items, pq.heap.arity, func(item *Item[T]) string {
+		if item.deleted {
+			return label(item.Value) + " (deleted)"
+		}
+		return label(item.Value)
+	})
+	return g.DOT()
+}
@@ -0,0 +1,47 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestToDOT(t *testing.T) {
+	pq := NewOrderedMinQueue[int]()
+	for _, v := range []int{3, 1, 2} {
+		pq.Push(v)
+	}
+
+	expected := `digraph "priorityqueue" {
+  n0 [label="#1", shape=circle];
+  n1 [label="#3", shape=circle];
+  n2 [label="#2", shape=circle];
+  n0 -> n1;
+  n0 -> n2;
+}
+`
+	if got := pq.ToDOT(func(v int) string { return "#" + strconv.Itoa(v) }); got != expected {
+		t.Errorf("Unexpected DOT output:\n%s", got)
+	}
+}
+
+func TestToDOTArityAndTombstones(t *testing.T) {
+	pq := NewMinQueue(cmp.Compare[int], WithArity(4))
+	var last *Item[int]
+	for v := range 10 {
+		last = NewItem(v)
+		pq.PushItem(last)
+	}
+	pq.MarkDeleted(last)
+
+	dot := pq.ToDOT(nil)
+	for _, edge := range []string{"n0 -> n4;", "n1 -> n5;", "n2 -> n9;"} {
+		if !strings.Contains(dot, edge) {
+			t.Errorf("Expected edge %q in:\n%s", edge, dot)
+		}
+	}
+	if !strings.Contains(dot, `label="9 (deleted)"`) {
+		t.Errorf("Expected tombstone marker in:\n%s", dot)
+	}
+}
@@ -0,0 +1,70 @@
+package priorityqueue
+
+import (
+	"iter"
+	"slices"
+)
+
+// Equal reports whether pq and other hold the same values as multisets,
+// regardless of heap layout or pop order among ties
+// Values of both queues are sorted with pq's compare function and matched
+// with eq within each run of values that compare as equal, so eq must only
+// report true for values that compare as equal. O(n log n) when ties are few
+func (pq *PriorityQueue[T]) Equal(other *PriorityQueue[T], eq func(a, b T) bool) bool {
+	if pq.Size() != other.Size() {
+		return false
+	}
+	a := slices.SortedFunc(pq.All(), pq.heap.compare)
+	b := slices.SortedFunc(other.All(), pq.heap.compare)
+
+	for start := 0; start < len(a); {
+		end := start + 1
+		for end < len(a) && pq.heap.compare(a[start], a[end]) == 0 {
+			end++
+		}
+		if end > len(b) || pq.heap.compare(a[start], b[end-1]) != 0 ||
+			(end < len(b) && pq.heap.compare(a[start], b[end]) == 0) {
+			return false
+		}
+		if !matchAll(a[start:end], b[start:end], eq) {
+			return false
+		}
+		start = end
+	}
+	return true
+}
+
+// matchAll reports whether every value of a can be paired with a distinct
+// value of b under eq, where both hold the same number of values
+func matchAll[T any](a, b []T, eq func(a, b T) bool) bool {
+	used := make([]bool, len(b))
+next:
+	for _, x := range a {
+		for j, y := range b {
+			if !used[j] && eq(x, y) {
+				used[j] = true
+				continue next
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// EqualOrdered reports whether pq and other pop the same values in the same
+// order, each following its own compare function; values that tie may pop in
+// either order, so prefer Equal unless the order itself is under test
+func (pq *PriorityQueue[T]) EqualOrdered(other *PriorityQueue[T], eq func(a, b T) bool) bool {
+	if pq.Size() != other.Size() {
+		return false
+	}
+	next, stop := iter.Pull(other.Ordered())
+	defer stop()
+	for v := range pq.Ordered() {
+		w, _ := next()
+		if !eq(v, w) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package priorityqueue
+
+import (
+	"slices"
+	"testing"
+)
+
+func sameTask(a, b Task) bool { return a == b }
+
+func TestEqual(t *testing.T) {
+	tasks := []Task{
+		{ID: 1, Name: "a", Priority: 2},
+		{ID: 2, Name: "b", Priority: 1},
+		{ID: 3, Name: "c", Priority: 2},
+		{ID: 4, Name: "d", Priority: 3},
+	}
+	pq := NewMinQueueFromSlice(TaskByPriority, tasks)
+
+	other := NewMinQueue(TaskByPriority)
+	for i := len(tasks) - 1; i >= 0; i-- {
+		other.Push(tasks[i])
+	}
+	if !pq.Equal(other, sameTask) || !other.Equal(pq, sameTask) {
+		t.Error("Expected queues with the same tasks to be equal")
+	}
+
+	// Same priorities, but a different task within the tie at priority 2
+	swapped := slices.Clone(tasks)
+	swapped[2] = Task{ID: 5, Name: "e", Priority: 2}
+	if pq.Equal(NewMinQueueFromSlice(TaskByPriority, swapped), sameTask) {
+		t.Error("Expected queues with different tasks to differ")
+	}
+
+	other.Pop()
+	if pq.Equal(other, sameTask) {
+		t.Error("Expected queues of different sizes to differ")
+	}
+}
+
+func TestEqualTieCounts(t *testing.T) {
+	pq := NewMinQueueFromSlice(IntCompare, []int{1, 1, 2})
+	other := NewMinQueueFromSlice(IntCompare, []int{1, 2, 2})
+	if pq.Equal(other, func(a, b int) bool { return a == b }) {
+		t.Error("Expected different tie counts to differ")
+	}
+}
+
+func TestEqualOrdered(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+	minQueue := NewMinQueueFromSlice(IntCompare, []int{3, 1, 2})
+	other := NewMinQueueFromSlice(IntCompare, []int{2, 3, 1})
+	maxQueue := NewMaxQueueFromSlice(IntCompare, []int{3, 1, 2})
+
+	if !minQueue.EqualOrdered(other, eq) {
+		t.Error("Expected equal pop orders")
+	}
+	if minQueue.EqualOrdered(maxQueue, eq) {
+		t.Error("Expected min and max pop orders to differ")
+	}
+	if !minQueue.Equal(maxQueue, eq) {
+		t.Error("Expected the same multiset regardless of order")
+	}
+	if minQueue.Size() != 3 || other.Size() != 3 {
+		t.Error("Expected EqualOrdered not to drain the queues")
+	}
+}
@@ -0,0 +1,116 @@
+package priorityqueue
+
+import "time"
+
+// expiringEntry is a value with the time after which it is no longer valid
+type expiringEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+// expired reports whether e is no longer valid at now
+func (e expiringEntry[T]) expired(now time.Time) bool {
+	return !now.Before(e.expires)
+}
+
+// ExpiringQueue is a priority queue whose values carry a deadline
+// Expired values are dropped when they reach the top, so Pop and Peek only
+// ever return live values; PruneExpired drops the rest in one pass
+type ExpiringQueue[T any] struct {
+	pq  *PriorityQueue[expiringEntry[T]]
+	now func() time.Time
+}
+
+// NewExpiringMinQueue creates an expiring queue that pops the smallest live
+// value first
+func NewExpiringMinQueue[T any](compare CompareFunc[T], opts ...Option) *ExpiringQueue[T] {
+	return &ExpiringQueue[T]{
+		pq:  NewMinQueue(byExpiringValue(compare), opts...),
+		now: time.Now,
+	}
+}
+
+// NewExpiringMaxQueue creates an expiring queue that pops the largest live
+// value first
+func NewExpiringMaxQueue[T any](compare CompareFunc[T], opts ...Option) *ExpiringQueue[T] {
+	return &ExpiringQueue[T]{
+		pq:  NewMaxQueue(byExpiringValue(compare), opts...),
+		now: time.Now,
+	}
+}
+
+func byExpiringValue[T any](compare CompareFunc[T]) CompareFunc[expiringEntry[T]] {
+	return func(a, b expiringEntry[T]) int { return compare(a.value, b.value) }
+}
+
+// Push adds value, valid for ttl from now
+func (q *ExpiringQueue[T]) Push(value T, ttl time.Duration) {
+	q.PushUntil(value, q.now().Add(ttl))
+}
+
+// PushUntil adds value, valid until deadline
+func (q *ExpiringQueue[T]) PushUntil(value T, deadline time.Time) {
+	q.pq.Push(expiringEntry[T]{value: value, expires: deadline})
+}
+
+// Pop removes and returns the live value with highest priority, dropping any
+// expired values ranked above it
+// Returns ErrEmpty if no live value is left
+func (q *ExpiringQueue[T]) Pop() (T, error) {
+	var zero T
+	if !q.dropExpired() {
+		return zero, ErrEmpty
+	}
+	e, _ := q.pq.Pop()
+	return e.value, nil
+}
+
+// Peek returns the live value with highest priority without removing it;
+// expired values ranked above it are dropped
+// Returns ErrEmpty if no live value is left
+func (q *ExpiringQueue[T]) Peek() (T, error) {
+	var zero T
+	if !q.dropExpired() {
+		return zero, ErrEmpty
+	}
+	e, _ := q.pq.Peek()
+	return e.value, nil
+}
+
+// dropExpired pops expired entries off the top and reports whether a live
+// entry is left there
+func (q *ExpiringQueue[T]) dropExpired() bool {
+	now := q.now()
+	for {
+		e, err := q.pq.Peek()
+		if err != nil {
+			return false
+		}
+		if !e.expired(now) {
+			return true
+		}
+		q.pq.Pop()
+	}
+}
+
+// PruneExpired removes every value that has expired at now and returns how
+// many were removed
+func (q *ExpiringQueue[T]) PruneExpired(now time.Time) int {
+	return q.pq.RemoveFunc(func(e expiringEntry[T]) bool { return e.expired(now) })
+}
+
+// Size returns the number of queued values, including expired values that
+// have not been dropped yet
+func (q *ExpiringQueue[T]) Size() int {
+	return q.pq.Size()
+}
+
+// IsEmpty returns true if the queue holds no values, live or expired
+func (q *ExpiringQueue[T]) IsEmpty() bool {
+	return q.pq.IsEmpty()
+}
+
+// Clear removes every value
+func (q *ExpiringQueue[T]) Clear() {
+	q.pq.Clear()
+}
@@ -0,0 +1,98 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anwar-arif/golang-dsa/internal/testclock"
+)
+
+func newTestExpiring() (*ExpiringQueue[string], *testclock.Clock) {
+	q := NewExpiringMinQueue(cmp.Compare[string])
+	clock := testclock.New()
+	q.now = clock.Now
+	return q, clock
+}
+
+func TestExpiringQueue(t *testing.T) {
+	q, clock := newTestExpiring()
+	q.Push("a", time.Second)
+	q.Push("b", time.Minute)
+	q.Push("c", time.Hour)
+
+	clock.Advance(30 * time.Second)
+
+	// a has expired and is dropped on the way to b
+	if v, _ := q.Peek(); v != "b" {
+		t.Errorf("Expected b, got %q", v)
+	}
+	if q.Size() != 2 {
+		t.Errorf("Expected the expired value to be dropped, got size %d", q.Size())
+	}
+	if v, _ := q.Pop(); v != "b" {
+		t.Errorf("Expected b, got %q", v)
+	}
+
+	clock.Advance(time.Hour)
+	if _, err := q.Pop(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty once everything expired, got %v", err)
+	}
+	if !q.IsEmpty() {
+		t.Errorf("Expected empty queue, got size %d", q.Size())
+	}
+}
+
+func TestExpiringQueueDeadline(t *testing.T) {
+	q, clock := newTestExpiring()
+	q.PushUntil("due", clock.Now())
+	if _, err := q.Peek(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected a value to expire at its deadline, got %v", err)
+	}
+
+	maxQueue := NewExpiringMaxQueue(cmp.Compare[int])
+	maxQueue.PushUntil(1, time.Now().Add(time.Hour))
+	maxQueue.PushUntil(2, time.Now().Add(time.Hour))
+	if v, _ := maxQueue.Pop(); v != 2 {
+		t.Errorf("Expected 2 from the max queue, got %d", v)
+	}
+}
+
+func TestPruneExpired(t *testing.T) {
+	q, clock := newTestExpiring()
+	q.Push("z", time.Second)
+	q.Push("y", time.Hour)
+	q.Push("x", time.Second)
+	q.Push("a", time.Hour)
+
+	// Expired values below the top are only found by pruning
+	if n := q.PruneExpired(clock.Now().Add(time.Minute)); n != 2 {
+		t.Errorf("Expected 2 pruned, got %d", n)
+	}
+	if q.Size() != 2 {
+		t.Errorf("Expected size 2, got %d", q.Size())
+	}
+	for _, expected := range []string{"a", "y"} {
+		if v, _ := q.Pop(); v != expected {
+			t.Errorf("Expected %q, got %q", expected, v)
+		}
+	}
+
+	q.Push("b", time.Hour)
+	q.Clear()
+	if q.PruneExpired(clock.Now().Add(2*time.Hour)) != 0 || !q.IsEmpty() {
+		t.Errorf("Expected Clear to remove everything, got size %d", q.Size())
+	}
+}
+
+// Benchmark tests
+func BenchmarkExpiringQueuePushPop(b *testing.B) {
+	q := NewExpiringMinQueue(cmp.Compare[int])
+	for i := 0; i < b.N; i++ {
+		q.Push(i, time.Minute)
+		if i%2 == 1 {
+			q.Pop()
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package priorityqueue
+
+import (
+	"container/heap"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// indexedEntry is a key with its current priority value
+type indexedEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// indexedHeap is a heap of entries that tracks where each key lives
+type indexedHeap[K comparable, V any] struct {
+	entries   []indexedEntry[K, V]
+	pos       map[K]int
+	compare   CompareFunc[V]
+	isMaxHeap bool
+}
+
+func (h *indexedHeap[K, V]) Len() int { return len(h.entries) }
+
+func (h *indexedHeap[K, V]) Less(i, j int) bool {
+	cmp := h.compare(h.entries[i].value, h.entries[j].value)
+	if h.isMaxHeap {
+		return cmp > 0
+	}
+	return cmp < 0
+}
+
+func (h *indexedHeap[K, V]) Swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+	h.pos[h.entries[i].key] = i
+	h.pos[h.entries[j].key] = j
+}
+
+func (h *indexedHeap[K, V]) Push(x interface{}) {
+	e := x.(indexedEntry[K, V])
+	h.pos[e.key] = len(h.entries)
+	h.entries = append(h.entries, e)
+}
+
+func (h *indexedHeap[K, V]) Pop() interface{} {
+	n := len(h.entries)
+	e := h.entries[n-1]
+	h.entries[n-1] = indexedEntry[K, V]{} // avoid memory leak
+	h.entries = h.entries[:n-1]
+	delete(h.pos, e.key)
+	return e
+}
+
+// IndexedPriorityQueue is a priority queue of unique keys, each with a
+// priority value that can be changed or removed by key in O(log n)
+// It suits algorithms such as Dijkstra and Prim that decrease the priority of
+// a known node, without holding on to *Item pointers
+type IndexedPriorityQueue[K comparable, V any] struct {
+	heap *indexedHeap[K, V]
+}
+
+func newIndexed[K comparable, V any](compare CompareFunc[V], isMaxHeap bool) *IndexedPriorityQueue[K, V] {
+	return &IndexedPriorityQueue[K, V]{heap: &indexedHeap[K, V]{
+		pos:       make(map[K]int),
+		compare:   compare,
+		isMaxHeap: isMaxHeap,
+	}}
+}
+
+// NewIndexedMinQueue creates an indexed queue that pops the smallest value first
+func NewIndexedMinQueue[K comparable, V any](compare CompareFunc[V]) *IndexedPriorityQueue[K, V] {
+	return newIndexed[K](compare, false)
+}
+
+// NewIndexedMaxQueue creates an indexed queue that pops the largest value first
+func NewIndexedMaxQueue[K comparable, V any](compare CompareFunc[V]) *IndexedPriorityQueue[K, V] {
+	return newIndexed[K](compare, true)
+}
+
+// Push adds key with priority value
+// It returns an error wrapping ErrKeyExists if key is already queued; use
+// Update or Set instead
+func (pq *IndexedPriorityQueue[K, V]) Push(key K, value V) error {
+	if pq.Contains(key) {
+		return fmt.Errorf("%w: %v", ErrKeyExists, key)
+	}
+	heap.Push(pq.heap, indexedEntry[K, V]{key: key, value: value})
+	pq.debugCheck()
+	return nil
+}
+
+// Update changes the priority of a queued key
+// It returns an error wrapping ErrKeyNotFound if key is not queued
+func (pq *IndexedPriorityQueue[K, V]) Update(key K, value V) error {
+	i, ok := pq.heap.pos[key]
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+	}
+	pq.heap.entries[i].value = value
+	heap.Fix(pq.heap, i)
+	pq.debugCheck()
+	return nil
+}
+
+// Set pushes key or, if it is already queued, updates its priority in O(log n)
+// Reports whether key was inserted rather than updated
+func (pq *IndexedPriorityQueue[K, V]) Set(key K, value V) (inserted bool) {
+	if pq.Update(key, value) == nil {
+		return false
+	}
+	heap.Push(pq.heap, indexedEntry[K, V]{key: key, value: value})
+	pq.debugCheck()
+	return true
+}
+
+// Remove removes key and reports whether it was queued
+func (pq *IndexedPriorityQueue[K, V]) Remove(key K) bool {
+	i, ok := pq.heap.pos[key]
+	if !ok {
+		return false
+	}
+	heap.Remove(pq.heap, i)
+	pq.debugCheck()
+	return true
+}
+
+// Contains reports whether key is queued
+func (pq *IndexedPriorityQueue[K, V]) Contains(key K) bool {
+	_, ok := pq.heap.pos[key]
+	return ok
+}
+
+// Get returns the priority of a queued key
+func (pq *IndexedPriorityQueue[K, V]) Get(key K) (V, bool) {
+	if i, ok := pq.heap.pos[key]; ok {
+		return pq.heap.entries[i].value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Pop removes and returns the key with the highest priority and its value
+func (pq *IndexedPriorityQueue[K, V]) Pop() (K, V, error) {
+	if pq.IsEmpty() {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, ErrEmpty
+	}
+	e := heap.Pop(pq.heap).(indexedEntry[K, V])
+	pq.debugCheck()
+	return e.key, e.value, nil
+}
+
+// Peek returns the key with the highest priority and its value without removing it
+func (pq *IndexedPriorityQueue[K, V]) Peek() (K, V, error) {
+	if pq.IsEmpty() {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, ErrEmpty
+	}
+	e := pq.heap.entries[0]
+	return e.key, e.value, nil
+}
+
+// IsEmpty returns true if the queue is empty
+func (pq *IndexedPriorityQueue[K, V]) IsEmpty() bool {
+	return pq.heap.Len() == 0
+}
+
+// Size returns the number of queued keys
+func (pq *IndexedPriorityQueue[K, V]) Size() int {
+	return pq.heap.Len()
+}
+
+// Clear removes every key
+func (pq *IndexedPriorityQueue[K, V]) Clear() {
+	clear(pq.heap.entries)
+	pq.heap.entries = pq.heap.entries[:0]
+	clear(pq.heap.pos)
+}
+
+// Clone returns a copy of the queue with the same compare function in O(n)
+func (pq *IndexedPriorityQueue[K, V]) Clone() *IndexedPriorityQueue[K, V] {
+	h := *pq.heap
+	h.entries = slices.Clone(pq.heap.entries)
+	h.pos = maps.Clone(pq.heap.pos)
+	return &IndexedPriorityQueue[K, V]{heap: &h}
+}
+
+// All returns an iterator over the keys and values in heap order (not sorted)
+func (pq *IndexedPriorityQueue[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, e := range pq.heap.entries {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// String returns a string representation of the queue
+func (pq *IndexedPriorityQueue[K, V]) String() string {
+	return fmt.Sprintf("IndexedPriorityQueue{size: %d}", pq.Size())
+}
@@ -0,0 +1,151 @@
+package priorityqueue
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestIndexedBasics(t *testing.T) {
+	pq := NewIndexedMinQueue[string](IntCompare)
+
+	if _, _, err := pq.Pop(); err == nil {
+		t.Error("Expected error popping an empty queue")
+	}
+
+	pq.Push("a", 5)
+	pq.Push("b", 3)
+	pq.Push("c", 8)
+	if err := pq.Push("a", 1); !errors.Is(err, ErrKeyExists) {
+		t.Errorf("Expected ErrKeyExists pushing a duplicate key, got %v", err)
+	}
+	if err := pq.Update("missing", 1); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected ErrKeyNotFound updating a missing key, got %v", err)
+	}
+
+	// Decrease and increase keys
+	pq.Update("c", 1)
+	pq.Update("b", 10)
+	if v, ok := pq.Get("b"); !ok || v != 10 {
+		t.Errorf("Expected b=10, got %d", v)
+	}
+
+	if k, v, _ := pq.Peek(); k != "c" || v != 1 {
+		t.Errorf("Expected c=1 at the top, got %s=%d", k, v)
+	}
+
+	if !pq.Remove("a") || pq.Remove("a") || pq.Contains("a") {
+		t.Error("Unexpected Remove result")
+	}
+
+	pq.Set("d", 7)
+	pq.Set("d", 2)
+
+	var order []string
+	for !pq.IsEmpty() {
+		k, _, _ := pq.Pop()
+		order = append(order, k)
+	}
+	if !slices.Equal(order, []string{"c", "d", "b"}) {
+		t.Errorf("Expected [c d b], got %v", order)
+	}
+	if pq.Contains("c") {
+		t.Error("Expected popped keys to be forgotten")
+	}
+}
+
+func TestIndexedMaxQueue(t *testing.T) {
+	pq := NewIndexedMaxQueue[int](IntCompare)
+	for i := 0; i < 5; i++ {
+		pq.Push(i, i*10)
+	}
+	pq.Update(0, 100)
+
+	if k, v, _ := pq.Pop(); k != 0 || v != 100 {
+		t.Errorf("Expected 0=100, got %d=%d", k, v)
+	}
+
+	pq.Clear()
+	if !pq.IsEmpty() || pq.Contains(1) {
+		t.Error("Expected an empty queue after Clear")
+	}
+}
+
+func TestIndexedMatchesReference(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	pq := NewIndexedMinQueue[int](IntCompare)
+	ref := make(map[int]int)
+
+	for step := 0; step < 5000; step++ {
+		k := rng.Intn(50)
+		switch rng.Intn(4) {
+		case 0:
+			if pq.Remove(k) != (ref[k] != 0) {
+				t.Fatalf("Step %d: Remove(%d) disagreed", step, k)
+			}
+			delete(ref, k)
+		case 1:
+			if len(ref) == 0 {
+				continue
+			}
+			key, v, _ := pq.Pop()
+			for _, other := range ref {
+				if other < v {
+					t.Fatalf("Step %d: popped %d but %d is smaller", step, v, other)
+				}
+			}
+			if ref[key] != v {
+				t.Fatalf("Step %d: popped %d=%d, expected value %d", step, key, v, ref[key])
+			}
+			delete(ref, key)
+		default:
+			v := rng.Intn(1000) + 1
+			pq.Set(k, v)
+			ref[k] = v
+		}
+
+		if pq.Size() != len(ref) {
+			t.Fatalf("Step %d: expected size %d, got %d", step, len(ref), pq.Size())
+		}
+	}
+
+	for k, v := range pq.All() {
+		if ref[k] != v {
+			t.Errorf("Iteration yielded %d=%d, expected %d", k, v, ref[k])
+		}
+	}
+}
+
+func TestIndexedClone(t *testing.T) {
+	pq := NewIndexedMaxQueue[string](IntCompare)
+	pq.Push("a", 1)
+	pq.Push("b", 5)
+
+	clone := pq.Clone()
+	clone.Update("a", 9)
+	clone.Push("c", 3)
+	pq.Remove("b")
+
+	if key, v, _ := pq.Peek(); key != "a" || v != 1 || pq.Size() != 1 {
+		t.Errorf("Expected original to hold only a=1, got %s=%d and size %d", key, v, pq.Size())
+	}
+	for _, expected := range []string{"a", "b", "c"} {
+		if key, _, _ := clone.Pop(); key != expected {
+			t.Errorf("Expected %s from clone, got %s", expected, key)
+		}
+	}
+}
+
+// Benchmark tests
+func BenchmarkIndexedDecreaseKey(b *testing.B) {
+	pq := NewIndexedMinQueue[int](IntCompare)
+	for i := 0; i < 10000; i++ {
+		pq.Push(i, 1<<30)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pq.Update(i%10000, (1<<30)-i)
+	}
+}
@@ -0,0 +1,13 @@
+package priorityqueue
+
+// KeyedQueue is a priority queue of unique keys with upsert semantics:
+// Set inserts a key or re-prioritizes it in place, so a key is never queued
+// twice, as rate-limited schedulers and priority caches need
+// It is another name for IndexedPriorityQueue
+type KeyedQueue[K comparable, V any] = IndexedPriorityQueue[K, V]
+
+// NewKeyedQueue creates an empty keyed queue that pops the smallest value first
+// Wrap compare with ReverseCompare to pop the largest value first
+func NewKeyedQueue[K comparable, V any](compare CompareFunc[V]) *KeyedQueue[K, V] {
+	return NewIndexedMinQueue[K](compare)
+}
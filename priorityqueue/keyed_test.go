@@ -0,0 +1,60 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestKeyedQueue(t *testing.T) {
+	q := NewKeyedQueue[string](cmp.Compare[int])
+	if _, _, err := q.Pop(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	if !q.Set("a", 5) || !q.Set("b", 3) || !q.Set("c", 8) {
+		t.Error("Expected new keys to be inserted")
+	}
+	if q.Set("c", 1) {
+		t.Error("Expected existing key to be updated")
+	}
+	if q.Size() != 3 {
+		t.Errorf("Expected 3 keys after upsert, got %d", q.Size())
+	}
+	if v, ok := q.Get("c"); !ok || v != 1 {
+		t.Errorf("Expected c = 1, got %d, %v", v, ok)
+	}
+
+	if k, v, _ := q.Pop(); k != "c" || v != 1 {
+		t.Errorf("Expected (c, 1), got (%s, %d)", k, v)
+	}
+	q.Set("a", 2)
+	if k, _, _ := q.Peek(); k != "a" {
+		t.Errorf("Expected a on top after lowering it, got %s", k)
+	}
+	if !q.Remove("a") || q.Remove("a") || q.Contains("a") {
+		t.Error("Expected a to be deleted exactly once")
+	}
+	if k, v, _ := q.Pop(); k != "b" || v != 3 {
+		t.Errorf("Expected (b, 3), got (%s, %d)", k, v)
+	}
+	if !q.IsEmpty() {
+		t.Errorf("Expected empty queue, got size %d", q.Size())
+	}
+}
+
+// Benchmark tests
+func BenchmarkKeyedQueueSet(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	q := NewKeyedQueue[string](cmp.Compare[int])
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = fmt.Sprint(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.Set(keys[rng.Intn(len(keys))], rng.Int())
+	}
+}
@@ -0,0 +1,63 @@
+package priorityqueue
+
+import "github.com/anwar-arif/golang-dsa/collections"
+
+// MarkDeleted removes item from the queue in O(1) by leaving it in the heap
+// as a tombstone; Pop and Peek skip tombstones when they reach the top
+// The heap is compacted once tombstones make up more than half of it
+// Returns false if item is not in the queue or is already marked
+func (pq *PriorityQueue[T]) MarkDeleted(item *Item[T]) bool {
+	i := item.Index
+	if i < 0 || i >= len(pq.heap.items) || pq.heap.items[i] != item || item.deleted {
+		return false
+	}
+	item.deleted = true
+	pq.tombstones++
+	if pq.tombstones*2 > len(pq.heap.items) {
+		pq.compact()
+	}
+	pq.debugCheck()
+	pq.record("remove")
+	pq.observers.Notify(collections.OpRemove, item.Value)
+	return true
+}
+
+// Tombstones returns the number of items marked by MarkDeleted that are still
+// held by the heap
+func (pq *PriorityQueue[T]) Tombstones() int {
+	return pq.tombstones
+}
+
+// Compact drops every tombstone from the heap in O(n)
+func (pq *PriorityQueue[T]) Compact() {
+	pq.compact()
+	pq.debugCheck()
+}
+
+func (pq *PriorityQueue[T]) compact() {
+	if pq.tombstones == 0 {
+		return
+	}
+	items := pq.heap.items
+	live := items[:0]
+	for _, item := range items {
+		if item.deleted {
+			item.Index = -1
+			continue
+		}
+		item.Index = len(live)
+		live = append(live, item)
+	}
+	clear(items[len(live):])
+	pq.heap.items = live
+	pq.tombstones = 0
+	pq.heap.init()
+}
+
+// skipDeleted pops tombstones off the top of the heap so the root is live
+func (pq *PriorityQueue[T]) skipDeleted() {
+	for pq.tombstones > 0 && pq.heap.items[0].deleted {
+		pq.heap.pop()
+		pq.tombstones--
+	}
+}
@@ -0,0 +1,158 @@
+package priorityqueue
+
+import (
+	"testing"
+
+	"github.com/anwar-arif/golang-dsa/collections"
+)
+
+func TestMarkDeleted(t *testing.T) {
+	pq := NewMinQueue(IntCompare)
+	items := make(map[int]*Item[int])
+	for _, v := range []int{5, 1, 8, 3, 9, 2} {
+		items[v] = NewItem(v)
+		pq.PushItem(items[v])
+	}
+
+	if !pq.MarkDeleted(items[1]) {
+		t.Fatal("Expected item 1 to be marked")
+	}
+	if pq.MarkDeleted(items[1]) {
+		t.Error("Expected second mark of item 1 to fail")
+	}
+	pq.MarkDeleted(items[3])
+
+	if pq.Size() != 4 {
+		t.Errorf("Expected size 4, got %d", pq.Size())
+	}
+	if pq.Tombstones() != 2 {
+		t.Errorf("Expected 2 tombstones, got %d", pq.Tombstones())
+	}
+	if err := pq.Validate(); err != nil {
+		t.Fatalf("Unexpected invalid heap: %v", err)
+	}
+
+	// Tombstones at the top are skipped
+	if top, _ := pq.Peek(); top != 2 {
+		t.Errorf("Expected 2 at the top, got %d", top)
+	}
+	for _, expected := range []int{2, 5, 8, 9} {
+		if v, _ := pq.Pop(); v != expected {
+			t.Errorf("Expected %d, got %d", expected, v)
+		}
+	}
+	if !pq.IsEmpty() || pq.Tombstones() != 0 {
+		t.Errorf("Expected empty queue without tombstones, got size %d and %d tombstones", pq.Size(), pq.Tombstones())
+	}
+	if _, err := pq.Pop(); err == nil {
+		t.Error("Expected error when popping empty queue")
+	}
+
+	// Items that have left the queue cannot be marked
+	if pq.MarkDeleted(items[5]) {
+		t.Error("Expected marking a popped item to fail")
+	}
+}
+
+func TestMarkDeletedCompacts(t *testing.T) {
+	pq := NewMinQueue(IntCompare)
+	var items []*Item[int]
+	for i := 0; i < 10; i++ {
+		item := NewItem(i)
+		pq.PushItem(item)
+		items = append(items, item)
+	}
+
+	for _, item := range items[5:] {
+		pq.MarkDeleted(item)
+	}
+	if pq.Tombstones() != 5 {
+		t.Errorf("Expected 5 tombstones at the threshold, got %d", pq.Tombstones())
+	}
+
+	// The sixth tombstone tips the balance
+	pq.MarkDeleted(items[4])
+	if pq.Tombstones() != 0 {
+		t.Errorf("Expected compaction, got %d tombstones", pq.Tombstones())
+	}
+	if pq.Size() != 4 || len(pq.ToSlice()) != 4 {
+		t.Errorf("Expected 4 items, got size %d", pq.Size())
+	}
+	if items[4].Index != -1 {
+		t.Errorf("Expected compacted item to be detached, got index %d", items[4].Index)
+	}
+	if err := pq.Validate(); err != nil {
+		t.Fatalf("Unexpected invalid heap: %v", err)
+	}
+}
+
+func TestTombstonesAreHidden(t *testing.T) {
+	pq := NewMaxQueue(IntCompare)
+	var items []*Item[int]
+	for _, v := range []int{4, 7, 1, 6} {
+		item := NewItem(v)
+		pq.PushItem(item)
+		items = append(items, item)
+	}
+	pq.MarkDeleted(items[1])
+
+	for v := range pq.All() {
+		if v == 7 {
+			t.Error("Expected All to skip tombstones")
+		}
+	}
+	for _, item := range pq.ToSlice() {
+		if item.Value == 7 {
+			t.Error("Expected ToSlice to skip tombstones")
+		}
+	}
+
+	clone := pq.Clone()
+	if clone.Size() != 3 || clone.Tombstones() != 0 {
+		t.Errorf("Expected clone with 3 live items, got size %d and %d tombstones", clone.Size(), clone.Tombstones())
+	}
+	if top, _ := clone.Peek(); top != 6 {
+		t.Errorf("Expected 6 at the top of the clone, got %d", top)
+	}
+
+	// Removing a tombstone drops it without changing the size
+	pq.Remove(items[1])
+	if pq.Size() != 3 || pq.Tombstones() != 0 {
+		t.Errorf("Expected size 3 and no tombstones, got %d and %d", pq.Size(), pq.Tombstones())
+	}
+	if err := pq.Validate(); err != nil {
+		t.Fatalf("Unexpected invalid heap: %v", err)
+	}
+}
+
+func TestMarkDeletedNotifiesObservers(t *testing.T) {
+	pq := NewMinQueue(IntCompare)
+	item := NewItem(3)
+	pq.PushItem(item)
+	pq.Push(5)
+
+	var ops []collections.Op
+	pq.OnMutate(func(op collections.Op, v int) { ops = append(ops, op) })
+
+	pq.MarkDeleted(item)
+	pq.Pop()
+
+	if len(ops) != 2 || ops[0] != collections.OpRemove || ops[1] != collections.OpPop {
+		t.Errorf("Expected remove then pop, got %v", ops)
+	}
+}
+
+// Benchmark tests
+func BenchmarkMarkDeleted(b *testing.B) {
+	pq := NewMinQueue(IntCompare)
+	items := make([]*Item[int], b.N)
+	for i := range items {
+		items[i] = NewItem(i)
+		pq.PushItem(items[i])
+	}
+
+	b.ResetTimer()
+	for _, item := range items {
+		pq.MarkDeleted(item)
+	}
+}
@@ -0,0 +1,71 @@
+package priorityqueue
+
+// MedianTracker keeps the running median of a stream of values
+// The lower half lives in a max-queue and the upper half in a min-queue,
+// with the lower half holding the extra value when the count is odd, so Add
+// is O(log n) and the medians sit at the two tops
+type MedianTracker[T any] struct {
+	low     *PriorityQueue[T] // max-queue of the lower half
+	high    *PriorityQueue[T] // min-queue of the upper half
+	compare CompareFunc[T]
+}
+
+// NewMedianTracker creates an empty tracker ordering values with compare
+func NewMedianTracker[T any](compare CompareFunc[T]) *MedianTracker[T] {
+	return &MedianTracker[T]{
+		low:     NewMaxQueue(compare),
+		high:    NewMinQueue(compare),
+		compare: compare,
+	}
+}
+
+// Add records value
+func (m *MedianTracker[T]) Add(value T) {
+	if top, err := m.low.Peek(); err != nil || m.compare(value, top) <= 0 {
+		m.low.Push(value)
+	} else {
+		m.high.Push(value)
+	}
+
+	// Rebalance so that low has as many values as high, or one more
+	if m.low.Size() > m.high.Size()+1 {
+		v, _ := m.low.Pop()
+		m.high.Push(v)
+	} else if m.high.Size() > m.low.Size() {
+		v, _ := m.high.Pop()
+		m.low.Push(v)
+	}
+}
+
+// Median returns the median, or the lower of the two middle values when the
+// count is even
+// Returns ErrEmpty if nothing has been added
+func (m *MedianTracker[T]) Median() (T, error) {
+	return m.low.Peek()
+}
+
+// Medians returns the two middle values, which are the same value when the
+// count is odd; numeric callers can average them
+// Returns ErrEmpty if nothing has been added
+func (m *MedianTracker[T]) Medians() (low, high T, err error) {
+	low, err = m.low.Peek()
+	if err != nil {
+		return low, high, err
+	}
+	if m.low.Size() > m.high.Size() {
+		return low, low, nil
+	}
+	high, _ = m.high.Peek()
+	return low, high, nil
+}
+
+// Size returns the number of values added
+func (m *MedianTracker[T]) Size() int {
+	return m.low.Size() + m.high.Size()
+}
+
+// Clear forgets every value
+func (m *MedianTracker[T]) Clear() {
+	m.low.Clear()
+	m.high.Clear()
+}
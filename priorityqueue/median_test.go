@@ -0,0 +1,76 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestMedianTracker(t *testing.T) {
+	m := NewMedianTracker(cmp.Compare[int])
+	if _, err := m.Median(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+	if _, _, err := m.Medians(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from Medians, got %v", err)
+	}
+
+	steps := []struct {
+		add       int
+		low, high int
+	}{
+		{5, 5, 5},
+		{1, 1, 5},
+		{9, 5, 5},
+		{3, 3, 5},
+		{7, 5, 5},
+	}
+	for _, step := range steps {
+		m.Add(step.add)
+		low, high, _ := m.Medians()
+		if low != step.low || high != step.high {
+			t.Errorf("After adding %d: expected medians (%d, %d), got (%d, %d)", step.add, step.low, step.high, low, high)
+		}
+		if median, _ := m.Median(); median != step.low {
+			t.Errorf("After adding %d: expected median %d, got %d", step.add, step.low, median)
+		}
+	}
+
+	m.Clear()
+	if m.Size() != 0 {
+		t.Errorf("Expected size 0 after Clear, got %d", m.Size())
+	}
+}
+
+func TestMedianTrackerMatchesSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	m := NewMedianTracker(cmp.Compare[int])
+	var seen []int
+
+	for i := 0; i < 500; i++ {
+		v := rng.Intn(100)
+		m.Add(v)
+		seen = append(seen, v)
+
+		sorted := slices.Sorted(slices.Values(seen))
+		n := len(sorted)
+		low, high, _ := m.Medians()
+		if low != sorted[(n-1)/2] || high != sorted[n/2] {
+			t.Fatalf("Step %d: expected medians (%d, %d), got (%d, %d)", i, sorted[(n-1)/2], sorted[n/2], low, high)
+		}
+	}
+	if m.Size() != 500 {
+		t.Errorf("Expected size 500, got %d", m.Size())
+	}
+}
+
+// Benchmark tests
+func BenchmarkMedianTrackerAdd(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	m := NewMedianTracker(cmp.Compare[int])
+	for i := 0; i < b.N; i++ {
+		m.Add(rng.Int())
+	}
+}
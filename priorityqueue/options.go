@@ -0,0 +1,37 @@
+package priorityqueue
+
+import "fmt"
+
+// Option configures a PriorityQueue at construction
+type Option func(*options)
+
+type options struct {
+	arity    int
+	capacity int
+	validate bool
+}
+
+func buildOptions(opts []Option) options {
+	o := options{arity: DefaultArity}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithCapacity pre-sizes the queue to hold n items before it has to grow
+// Panics if n is negative
+func WithCapacity(n int) Option {
+	if n < 0 {
+		panic(fmt.Sprintf("priorityqueue: capacity must not be negative, got %d", n))
+	}
+	return func(o *options) { o.capacity = n }
+}
+
+// WithValidation checks the queue with Validate after every mutation and
+// panics on the first failure, as building with -tags dsadebug does for
+// every queue; it costs O(n) per mutation and is meant for tests that
+// exercise a custom compare function
+func WithValidation() Option {
+	return func(o *options) { o.validate = true }
+}
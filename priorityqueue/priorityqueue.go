@@ -1,22 +1,42 @@
 package priorityqueue
 
 import (
+	"bytes"
+	"cmp"
 	"container/heap"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"iter"
+	"slices"
+
+	"github.com/anwar-arif/golang-dsa/codec"
+	"github.com/anwar-arif/golang-dsa/collections"
+	"github.com/anwar-arif/golang-dsa/compare"
+	"github.com/anwar-arif/golang-dsa/metrics"
 )
 
-// CompareFunc defines a comparison function type
-// Returns:
-//
-//	-1 if a < b
-//	 0 if a == b
-//	 1 if a > b
-type CompareFunc[T any] func(a, b T) int
+var (
+	// ErrEmpty is returned when reading from an empty priority queue
+	ErrEmpty = errors.New("priority queue is empty")
+	// ErrFull is returned when adding to a bounded priority queue that has no room left
+	ErrFull = errors.New("priority queue is full")
+	// ErrKeyExists is returned when pushing a key an indexed priority queue already holds
+	ErrKeyExists = errors.New("key is already in the priority queue")
+	// ErrKeyNotFound is returned when updating a key an indexed priority queue does not hold
+	ErrKeyNotFound = errors.New("key is not in the priority queue")
+)
+
+// CompareFunc defines a comparison function type; see compare.CompareFunc
+type CompareFunc[T any] = compare.CompareFunc[T]
 
 // Item represents an item in the priority queue
 type Item[T any] struct {
 	Value T
 	Index int // internal index for heap operations
+
+	deleted bool // set by MarkDeleted until the item leaves the heap
 }
 
 // NewItem creates a new item with value
@@ -31,12 +51,18 @@ type priorityHeap[T any] struct {
 	items     []*Item[T]
 	compare   CompareFunc[T]
 	isMaxHeap bool
+	arity     int
 }
 
 func (h *priorityHeap[T]) Len() int { return len(h.items) }
 
 func (h *priorityHeap[T]) Less(i, j int) bool {
-	cmp := h.compare(h.items[i].Value, h.items[j].Value)
+	return h.before(h.items[i].Value, h.items[j].Value)
+}
+
+// before reports whether a has strictly higher priority than b
+func (h *priorityHeap[T]) before(a, b T) bool {
+	cmp := h.compare(a, b)
 	if h.isMaxHeap {
 		return cmp > 0 // For max-heap, reverse the comparison
 	}
@@ -49,108 +75,616 @@ func (h *priorityHeap[T]) Swap(i, j int) {
 	h.items[j].Index = j
 }
 
-func (h *priorityHeap[T]) Push(x interface{}) {
-	item := x.(*Item[T])
-	item.Index = len(h.items)
-	h.items = append(h.items, item)
-}
-
-func (h *priorityHeap[T]) Pop() interface{} {
-	old := h.items
-	n := len(old)
-	item := old[n-1]
-	old[n-1] = nil  // avoid memory leak
-	item.Index = -1 // for safety
-	h.items = old[0 : n-1]
-	return item
-}
-
 // PriorityQueue represents a priority queue with custom comparison
 type PriorityQueue[T any] struct {
-	heap *priorityHeap[T]
-}
+	heap       *priorityHeap[T]
+	tombstones int              // items marked by MarkDeleted but still in heap
+	validate   bool             // see WithValidation
+	rec        metrics.Recorder // optional, see SetRecorder
+
+	observers collections.Observers[T]
+	stats     collections.StatsCounter
+}
+
+// PriorityQueue satisfies the shared collection interfaces
+var (
+	_ collections.Collection[int]             = (*PriorityQueue[int])(nil)
+	_ collections.Serializable                = (*PriorityQueue[int])(nil)
+	_ json.Marshaler                          = (*PriorityQueue[int])(nil)
+	_ json.Unmarshaler                        = (*PriorityQueue[int])(nil)
+	_ collections.Cloner[*PriorityQueue[int]] = (*PriorityQueue[int])(nil)
+	_ collections.StatsReporter               = (*PriorityQueue[int])(nil)
+)
 
 // NewMinQueue creates a new min-priority queue using the provided compare function
 // Items that compare as "less" will have higher priority
-func NewMinQueue[T any](compare CompareFunc[T]) *PriorityQueue[T] {
-	h := &priorityHeap[T]{
-		items:     make([]*Item[T], 0),
-		compare:   compare,
-		isMaxHeap: false,
-	}
-	heap.Init(h)
-	return &PriorityQueue[T]{heap: h}
+func NewMinQueue[T any](compare CompareFunc[T], opts ...Option) *PriorityQueue[T] {
+	return newQueue(compare, false, opts)
 }
 
 // NewMaxQueue creates a new max-priority queue using the provided compare function
 // Items that compare as "greater" will have higher priority
-func NewMaxQueue[T any](compare CompareFunc[T]) *PriorityQueue[T] {
+func NewMaxQueue[T any](compare CompareFunc[T], opts ...Option) *PriorityQueue[T] {
+	return newQueue(compare, true, opts)
+}
+
+// NewOrderedMinQueue creates a min-priority queue of an ordered type, using
+// cmp.Compare so no compare function is needed
+func NewOrderedMinQueue[T cmp.Ordered](opts ...Option) *PriorityQueue[T] {
+	return NewMinQueue(cmp.Compare[T], opts...)
+}
+
+// NewOrderedMaxQueue creates a max-priority queue of an ordered type, using
+// cmp.Compare so no compare function is needed
+func NewOrderedMaxQueue[T cmp.Ordered](opts ...Option) *PriorityQueue[T] {
+	return NewMaxQueue(cmp.Compare[T], opts...)
+}
+
+func newQueue[T any](compare CompareFunc[T], isMaxHeap bool, opts []Option) *PriorityQueue[T] {
+	o := buildOptions(opts)
 	h := &priorityHeap[T]{
-		items:     make([]*Item[T], 0),
+		items:     make([]*Item[T], 0, o.capacity),
 		compare:   compare,
-		isMaxHeap: true,
+		isMaxHeap: isMaxHeap,
+		arity:     o.arity,
+	}
+	return &PriorityQueue[T]{heap: h, validate: o.validate}
+}
+
+// NewMinQueueFromSlice creates a min-priority queue holding values
+// The heap is built in one O(n) pass instead of n O(log n) pushes; values is
+// not retained, so the caller may reuse it
+func NewMinQueueFromSlice[T any](compare CompareFunc[T], values []T, opts ...Option) *PriorityQueue[T] {
+	pq := NewMinQueue(compare, opts...)
+	pq.heapify(values)
+	return pq
+}
+
+// NewMaxQueueFromSlice creates a max-priority queue holding values
+// The heap is built in one O(n) pass instead of n O(log n) pushes; values is
+// not retained, so the caller may reuse it
+func NewMaxQueueFromSlice[T any](compare CompareFunc[T], values []T, opts ...Option) *PriorityQueue[T] {
+	pq := NewMaxQueue(compare, opts...)
+	pq.heapify(values)
+	return pq
+}
+
+// heapify replaces the contents of the queue with values in O(n)
+func (pq *PriorityQueue[T]) heapify(values []T) {
+	clear(pq.heap.items)
+	pq.heap.items = pq.heap.items[:0]
+	pq.tombstones = 0
+	for i, v := range values {
+		pq.heap.items = append(pq.heap.items, &Item[T]{Value: v, Index: i})
 	}
-	heap.Init(h)
-	return &PriorityQueue[T]{heap: h}
+	pq.stats.Alloc(len(values))
+	pq.stats.Push(len(values), len(values))
+	pq.heap.init()
+	pq.debugCheck()
 }
 
 // Push adds an item to the priority queue
 func (pq *PriorityQueue[T]) Push(value T) {
-	item := NewItem(value)
-	heap.Push(pq.heap, item)
+	pq.stats.Alloc(1)
+	pq.PushItem(NewItem(value))
+}
+
+// PushItem adds an item and keeps the caller's handle to it, so the item can
+// later be passed to UpdateItem or Remove without searching ToSlice
+func (pq *PriorityQueue[T]) PushItem(item *Item[T]) {
+	item.deleted = false
+	pq.heap.push(item)
+	pq.debugCheck()
+	pq.stats.Push(1, pq.Size())
+	pq.record("push")
+	pq.observers.Notify(collections.OpPush, item.Value)
 }
 
 // Pop removes and returns the item with highest priority
 func (pq *PriorityQueue[T]) Pop() (T, error) {
 	var zero T
 	if pq.IsEmpty() {
-		return zero, fmt.Errorf("priority queue is empty")
+		return zero, ErrEmpty
 	}
-	item := heap.Pop(pq.heap).(*Item[T])
+	pq.skipDeleted()
+	item := pq.heap.pop()
+	pq.debugCheck()
+	pq.stats.Pop(1)
+	pq.record("pop")
+	pq.observers.Notify(collections.OpPop, item.Value)
 	return item.Value, nil
 }
 
+// PushPop pushes value and then pops the highest-priority item with a single
+// sift, like heapq.heappushpop; value itself is returned straight away when
+// nothing in the queue outranks it
+func (pq *PriorityQueue[T]) PushPop(value T) T {
+	result := value
+	pq.skipDeleted()
+	if !pq.IsEmpty() && pq.heap.before(pq.heap.items[0].Value, value) {
+		result = pq.replaceRoot(value)
+	}
+	pq.stats.Push(1, pq.Size()+1)
+	pq.stats.Pop(1)
+	pq.record("push")
+	pq.record("pop")
+	pq.observers.Notify(collections.OpPush, value)
+	pq.observers.Notify(collections.OpPop, result)
+	return result
+}
+
+// Replace pops the highest-priority item and then pushes value with a single
+// sift, like heapq.heapreplace; the returned item may rank below value
+// Returns an error, and pushes nothing, when the queue is empty
+func (pq *PriorityQueue[T]) Replace(value T) (T, error) {
+	var zero T
+	if pq.IsEmpty() {
+		return zero, ErrEmpty
+	}
+	pq.skipDeleted()
+	result := pq.replaceRoot(value)
+	pq.stats.Pop(1)
+	pq.stats.Push(1, pq.Size())
+	pq.record("pop")
+	pq.record("push")
+	pq.observers.Notify(collections.OpPop, result)
+	pq.observers.Notify(collections.OpPush, value)
+	return result, nil
+}
+
+// replaceRoot swaps a fresh item holding value in for the root, restores
+// the heap and returns the old root's value
+// The root item is replaced rather than reused since callers of PushItem may
+// still hold a handle to it
+func (pq *PriorityQueue[T]) replaceRoot(value T) T {
+	old := pq.heap.items[0]
+	old.Index = -1
+	pq.heap.items[0] = &Item[T]{Value: value, Index: 0}
+	pq.stats.Alloc(1)
+	pq.heap.fix(0)
+	pq.debugCheck()
+	return old.Value
+}
+
 // Peek returns the item with highest priority without removing it
 func (pq *PriorityQueue[T]) Peek() (T, error) {
 	var zero T
 	if pq.IsEmpty() {
-		return zero, fmt.Errorf("priority queue is empty")
+		return zero, ErrEmpty
 	}
+	pq.skipDeleted()
 	return pq.heap.items[0].Value, nil
 }
 
+// PeekN returns up to n values in priority order without modifying the queue
+// Only the part of the heap above the n-th value is visited, so this costs
+// O(n log n) regardless of the queue's size
+func (pq *PriorityQueue[T]) PeekN(n int) []T {
+	n = min(n, pq.Size())
+	if n <= 0 {
+		return []T{}
+	}
+
+	// frontier holds the heap indexes whose parents have been taken; the
+	// best of them is always the next value in priority order
+	h := pq.heap
+	frontier := &indexHeap{less: h.Less, indexes: []int{0}}
+	result := make([]T, 0, n)
+	for len(result) < n {
+		i := heap.Pop(frontier).(int)
+		if item := h.items[i]; !item.deleted {
+			result = append(result, item.Value)
+		}
+		first := i*h.arity + 1
+		for c := first; c < first+h.arity && c < len(h.items); c++ {
+			heap.Push(frontier, c)
+		}
+	}
+	return result
+}
+
+// PopN removes and returns up to n values in priority order
+// The queue is validated and its metrics reported once for the whole batch
+func (pq *PriorityQueue[T]) PopN(n int) []T {
+	n = min(n, pq.Size())
+	if n <= 0 {
+		return []T{}
+	}
+
+	result := make([]T, n)
+	for i := range result {
+		pq.skipDeleted()
+		result[i] = pq.heap.pop().Value
+	}
+	pq.debugCheck()
+	pq.stats.Pop(n)
+	if pq.rec != nil {
+		pq.rec.Count("pop", int64(n))
+		pq.record("")
+	}
+	for _, v := range result {
+		pq.observers.Notify(collections.OpPop, v)
+	}
+	return result
+}
+
+// indexHeap is a heap of positions in a priorityHeap, used by PeekN
+type indexHeap struct {
+	indexes []int
+	less    func(i, j int) bool
+}
+
+func (h *indexHeap) Len() int           { return len(h.indexes) }
+func (h *indexHeap) Less(i, j int) bool { return h.less(h.indexes[i], h.indexes[j]) }
+func (h *indexHeap) Swap(i, j int)      { h.indexes[i], h.indexes[j] = h.indexes[j], h.indexes[i] }
+func (h *indexHeap) Push(x any)         { h.indexes = append(h.indexes, x.(int)) }
+func (h *indexHeap) Pop() any {
+	n := len(h.indexes) - 1
+	i := h.indexes[n]
+	h.indexes = h.indexes[:n]
+	return i
+}
+
 // IsEmpty returns true if the priority queue is empty
 func (pq *PriorityQueue[T]) IsEmpty() bool {
-	return pq.heap.Len() == 0
+	return pq.Size() == 0
 }
 
-// Size returns the number of items in the priority queue
+// Size returns the number of items in the priority queue, not counting
+// items marked by MarkDeleted
 func (pq *PriorityQueue[T]) Size() int {
-	return pq.heap.Len()
+	return pq.heap.Len() - pq.tombstones
+}
+
+// Cap returns the number of items the queue can hold before it has to grow,
+// including room taken by tombstones
+func (pq *PriorityQueue[T]) Cap() int {
+	return cap(pq.heap.items)
+}
+
+// Shrink drops tombstones and releases unused capacity, so that Cap equals
+// Size
+func (pq *PriorityQueue[T]) Shrink() {
+	pq.compact()
+	pq.heap.items = slices.Clip(slices.Clone(pq.heap.items))
+	pq.debugCheck()
+}
+
+// SetCompare switches the queue to a new compare function and rebuilds the
+// heap in O(n), keeping min or max order and every *Item handle valid
+func (pq *PriorityQueue[T]) SetCompare(compare CompareFunc[T]) {
+	pq.heap.compare = compare
+	pq.heap.init()
+	pq.debugCheck()
 }
 
 // UpdateItem triggers a re-heapify for an item after it has been modified
 // You should modify the item externally, then call this method
 func (pq *PriorityQueue[T]) UpdateItem(item *Item[T]) {
-	heap.Fix(pq.heap, item.Index)
+	pq.heap.fix(item.Index)
+	pq.debugCheck()
+	if item.deleted {
+		return
+	}
+	pq.record("update")
+	pq.observers.Notify(collections.OpUpdate, item.Value)
 }
 
 // Remove removes an item from the priority queue
+// An item already marked by MarkDeleted is dropped without being reported
+// again
 func (pq *PriorityQueue[T]) Remove(item *Item[T]) {
-	heap.Remove(pq.heap, item.Index)
+	pq.heap.remove(item.Index)
+	if item.deleted {
+		pq.tombstones--
+		pq.debugCheck()
+		return
+	}
+	pq.debugCheck()
+	pq.record("remove")
+	pq.observers.Notify(collections.OpRemove, item.Value)
+}
+
+// Find returns the first item, in heap order, whose value satisfies pred
+// The item can be passed to UpdateItem or Remove
+func (pq *PriorityQueue[T]) Find(pred func(T) bool) (*Item[T], bool) {
+	for _, item := range pq.heap.items {
+		if !item.deleted && pred(item.Value) {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+// RemoveFunc removes every item whose value satisfies pred and returns how
+// many were removed
+// The heap is rebuilt once in O(n) rather than removing items one by one;
+// tombstones left by MarkDeleted are dropped along the way
+func (pq *PriorityQueue[T]) RemoveFunc(pred func(T) bool) int {
+	items := pq.heap.items
+	kept := items[:0]
+	var removed []T
+	for _, item := range items {
+		switch {
+		case item.deleted:
+			item.Index = -1
+		case pred(item.Value):
+			item.Index = -1
+			removed = append(removed, item.Value)
+		default:
+			item.Index = len(kept)
+			kept = append(kept, item)
+		}
+	}
+	if len(kept) == len(items) {
+		return 0
+	}
+	clear(items[len(kept):])
+	pq.heap.items = kept
+	pq.tombstones = 0
+	pq.heap.init()
+	pq.debugCheck()
+
+	if len(removed) > 0 && pq.rec != nil {
+		pq.rec.Count("remove", int64(len(removed)))
+	}
+	pq.record("")
+	for _, v := range removed {
+		pq.observers.Notify(collections.OpRemove, v)
+	}
+	return len(removed)
 }
 
-// ToSlice returns all items as a slice (does not modify the queue)
+// ToSlice returns all items as a slice in heap order (does not modify the
+// queue); use SortedSlice for priority order
 func (pq *PriorityQueue[T]) ToSlice() []*Item[T] {
-	result := make([]*Item[T], len(pq.heap.items))
-	copy(result, pq.heap.items)
+	result := make([]*Item[T], 0, pq.Size())
+	for _, item := range pq.heap.items {
+		if !item.deleted {
+			result = append(result, item)
+		}
+	}
 	return result
 }
 
 // Clear removes all items from the priority queue
 func (pq *PriorityQueue[T]) Clear() {
+	clear(pq.heap.items)
 	pq.heap.items = pq.heap.items[:0]
-	heap.Init(pq.heap)
+	pq.tombstones = 0
+	pq.record("")
+
+	var zero T
+	pq.observers.Notify(collections.OpClear, zero)
+}
+
+// OnMutate registers fn to be called after every push, pop, update, remove
+// and clear, and returns a function that unregisters it
+// fn runs synchronously and must not modify the queue
+func (pq *PriorityQueue[T]) OnMutate(fn func(op collections.Op, value T)) (remove func()) {
+	return pq.observers.Add(fn)
+}
+
+// OnPush registers fn to be called with every value pushed, for example to
+// count pushes, and returns a function that unregisters it
+func (pq *PriorityQueue[T]) OnPush(fn func(value T)) (remove func()) {
+	return pq.observers.AddOp(collections.OpPush, fn)
+}
+
+// OnPop registers fn to be called with every value popped and returns a
+// function that unregisters it
+func (pq *PriorityQueue[T]) OnPop(fn func(value T)) (remove func()) {
+	return pq.observers.AddOp(collections.OpPop, fn)
+}
+
+// Stats returns the queue's size and activity counters; Allocs counts the
+// items the queue created itself, which excludes items passed to PushItem
+func (pq *PriorityQueue[T]) Stats() collections.Stats {
+	return pq.stats.Snapshot(pq.Size())
+}
+
+// SetRecorder reports queue activity to r: "push", "pop", "update" and
+// "remove" counters and a "depth" gauge; use metrics.WithPrefix to tell
+// several queues apart
+// Passing nil turns reporting off
+func (pq *PriorityQueue[T]) SetRecorder(r metrics.Recorder) {
+	pq.rec = r
+	pq.record("")
+}
+
+// record counts op, if any, and updates the depth gauge
+func (pq *PriorityQueue[T]) record(op string) {
+	if pq.rec == nil {
+		return
+	}
+	if op != "" {
+		pq.rec.Count(op, 1)
+	}
+	pq.rec.Gauge("depth", float64(pq.Size()))
+}
+
+// Clone returns a copy of the priority queue with the same compare function
+// Item handles of the original do not refer to items of the copy
+func (pq *PriorityQueue[T]) Clone() *PriorityQueue[T] {
+	return pq.CloneWith(func(v T) T { return v })
+}
+
+// CloneWith returns a copy of the priority queue with every value passed
+// through copyFn, for values that need a deep copy
+// copyFn must not change how values compare
+func (pq *PriorityQueue[T]) CloneWith(copyFn func(T) T) *PriorityQueue[T] {
+	h := &priorityHeap[T]{
+		items:     make([]*Item[T], 0, pq.Size()),
+		compare:   pq.heap.compare,
+		isMaxHeap: pq.heap.isMaxHeap,
+		arity:     pq.heap.arity,
+	}
+	for _, item := range pq.heap.items {
+		if !item.deleted {
+			h.items = append(h.items, &Item[T]{Value: copyFn(item.Value), Index: len(h.items)})
+		}
+	}
+	if pq.tombstones > 0 {
+		// Dropping tombstones leaves holes in the heap order
+		h.init()
+	}
+	return &PriorityQueue[T]{heap: h, validate: pq.validate}
+}
+
+// All returns an iterator over the values in heap order, which is not
+// priority order; use Ordered to visit values by priority
+func (pq *PriorityQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range pq.heap.items {
+			if item.deleted {
+				continue
+			}
+			if !yield(item.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Ordered returns an iterator over the values in priority order without
+// draining the queue; it pops a copy of the heap, so stopping early after k
+// values costs O(n + k log n)
+func (pq *PriorityQueue[T]) Ordered() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		c := pq.Clone()
+		for c.heap.Len() > 0 {
+			if !yield(c.heap.pop().Value) {
+				return
+			}
+		}
+	}
+}
+
+// Ordered2 is like Ordered but pairs every value with its rank, where the
+// highest-priority value is 0
+func (pq *PriorityQueue[T]) Ordered2() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range pq.Ordered() {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// SortedSlice returns the values in priority order without draining the queue
+func (pq *PriorityQueue[T]) SortedSlice() []T {
+	result := make([]T, 0, pq.Size())
+	for v := range pq.Ordered() {
+		result = append(result, v)
+	}
+	return result
+}
+
+// Save writes the values to w with enc, in heap order
+// The compare function is not saved
+func (pq *PriorityQueue[T]) Save(w io.Writer, enc codec.Encoder[[]T]) error {
+	values := make([]T, 0, pq.Size())
+	for v := range pq.All() {
+		values = append(values, v)
+	}
+	return enc.Encode(w, values)
+}
+
+// Load replaces the contents of the queue with values read from r by dec,
+// ordering them with the queue's own compare function
+// The queue must come from a constructor, since a zero PriorityQueue has no
+// compare function
+func (pq *PriorityQueue[T]) Load(r io.Reader, dec codec.Decoder[[]T]) error {
+	if err := pq.needCompare("Load"); err != nil {
+		return err
+	}
+	values, err := dec.Decode(r)
+	if err != nil {
+		return err
+	}
+
+	pq.heapify(values)
+	pq.record("")
+
+	// Report the load as a clear followed by a push of every value
+	var zero T
+	pq.observers.Notify(collections.OpClear, zero)
+	for _, v := range values {
+		pq.observers.Notify(collections.OpPush, v)
+	}
+	return nil
+}
+
+// MarshalBinary encodes the values with codec.Gob
+func (pq *PriorityQueue[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pq.Save(&buf, codec.Gob[[]T]()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// needCompare returns an error naming op if the queue is a zero value with no
+// compare function to order loaded values by
+func (pq *PriorityQueue[T]) needCompare(op string) error {
+	if pq.heap == nil {
+		return fmt.Errorf("priority queue: %s needs a queue built with a compare function", op)
+	}
+	return nil
+}
+
+// UnmarshalBinary replaces the contents of the queue with values encoded by MarshalBinary
+// Like UnmarshalJSON it needs a queue that came from a constructor
+func (pq *PriorityQueue[T]) UnmarshalBinary(data []byte) error {
+	if err := pq.needCompare("UnmarshalBinary"); err != nil {
+		return err
+	}
+	return pq.Load(bytes.NewReader(data), codec.Gob[[]T]())
+}
+
+// MarshalJSON encodes the values as a JSON array with codec.JSON, in heap order
+// The compare function is not saved
+func (pq *PriorityQueue[T]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pq.Save(&buf, codec.JSON[[]T]()); err != nil {
+		return nil, err
+	}
+	// json.Encoder ends each document with a newline
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// UnmarshalJSON replaces the contents of the queue with values encoded by
+// MarshalJSON and re-heapifies them with the queue's own compare function
+// The queue must come from a constructor, since a zero PriorityQueue has no
+// compare function; see NewMinQueueFromJSON
+func (pq *PriorityQueue[T]) UnmarshalJSON(data []byte) error {
+	if err := pq.needCompare("UnmarshalJSON"); err != nil {
+		return err
+	}
+	return pq.Load(bytes.NewReader(data), codec.JSON[[]T]())
+}
+
+// NewMinQueueFromJSON creates a min-priority queue from values encoded by
+// MarshalJSON
+func NewMinQueueFromJSON[T any](data []byte, compare CompareFunc[T], opts ...Option) (*PriorityQueue[T], error) {
+	pq := NewMinQueue(compare, opts...)
+	if err := pq.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return pq, nil
+}
+
+// NewMaxQueueFromJSON creates a max-priority queue from values encoded by
+// MarshalJSON
+func NewMaxQueueFromJSON[T any](data []byte, compare CompareFunc[T], opts ...Option) (*PriorityQueue[T], error) {
+	pq := NewMaxQueue(compare, opts...)
+	if err := pq.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return pq, nil
 }
 
 // String returns a string representation of the priority queue
@@ -161,6 +695,9 @@ func (pq *PriorityQueue[T]) String() string {
 // Common comparison functions
 
 // IntCompare compares two integers
+//
+// Deprecated: use NewOrderedMinQueue or NewOrderedMaxQueue, or cmp.Compare[int]
+// where a compare function is still needed
 func IntCompare(a, b int) int {
 	if a < b {
 		return -1
@@ -171,6 +708,9 @@ func IntCompare(a, b int) int {
 }
 
 // StringCompare compares two strings lexicographically
+//
+// Deprecated: use NewOrderedMinQueue or NewOrderedMaxQueue, or
+// cmp.Compare[string] where a compare function is still needed
 func StringCompare(a, b string) int {
 	if a < b {
 		return -1
@@ -181,6 +721,10 @@ func StringCompare(a, b string) int {
 }
 
 // Float64Compare compares two float64 values
+//
+// Deprecated: use NewOrderedMinQueue or NewOrderedMaxQueue, or
+// cmp.Compare[float64] where a compare function is still needed; unlike
+// cmp.Compare, Float64Compare treats NaN as equal to every value
 func Float64Compare(a, b float64) int {
 	if a < b {
 		return -1
@@ -191,10 +735,8 @@ func Float64Compare(a, b float64) int {
 }
 
 // ReverseCompare reverses any comparison function
-func ReverseCompare[T any](compare CompareFunc[T]) CompareFunc[T] {
-	return func(a, b T) int {
-		return -compare(a, b)
-	}
+func ReverseCompare[T any](c CompareFunc[T]) CompareFunc[T] {
+	return compare.Reverse(c)
 }
 
 // Example types and their compare functions
@@ -208,12 +750,12 @@ type Task struct {
 
 // TaskByPriority compares tasks by their priority (lower number = higher priority)
 func TaskByPriority(a, b Task) int {
-	return IntCompare(a.Priority, b.Priority)
+	return cmp.Compare(a.Priority, b.Priority)
 }
 
 // TaskByID compares tasks by their ID
 func TaskByID(a, b Task) int {
-	return IntCompare(a.ID, b.ID)
+	return cmp.Compare(a.ID, b.ID)
 }
 
 // Node represents a graph node with distance
@@ -224,7 +766,7 @@ type Node struct {
 
 // NodeByDistance compares nodes by their distance
 func NodeByDistance(a, b Node) int {
-	return IntCompare(a.Distance, b.Distance)
+	return cmp.Compare(a.Distance, b.Distance)
 }
 
 // Patient represents a hospital patient
@@ -236,7 +778,7 @@ type Patient struct {
 
 // PatientByUrgency compares patients by urgency level (higher = more urgent)
 func PatientByUrgency(a, b Patient) int {
-	return IntCompare(a.UrgencyLevel, b.UrgencyLevel)
+	return cmp.Compare(a.UrgencyLevel, b.UrgencyLevel)
 }
 
 // Score represents a game score
@@ -247,7 +789,7 @@ type Score struct {
 
 // ScoreByPoints compares scores by points
 func ScoreByPoints(a, b Score) int {
-	return IntCompare(a.Points, b.Points)
+	return cmp.Compare(a.Points, b.Points)
 }
 
 // Example usage demonstrating different scenarios
@@ -256,7 +798,7 @@ func ExampleUsage() {
 
 	// Example 1: Integer min-heap
 	fmt.Println("1. Integer Min-Heap:")
-	intMinQueue := NewMinQueue(IntCompare)
+	intMinQueue := NewOrderedMinQueue[int]()
 
 	intMinQueue.Push(30)
 	intMinQueue.Push(10)
@@ -269,7 +811,7 @@ func ExampleUsage() {
 
 	// Example 2: String max-heap
 	fmt.Println("\n2. String Max-Heap:")
-	stringMaxQueue := NewMaxQueue(StringCompare)
+	stringMaxQueue := NewOrderedMaxQueue[string]()
 
 	stringMaxQueue.Push("apple")
 	stringMaxQueue.Push("zebra")
@@ -340,7 +882,7 @@ func ExampleUsage() {
 	// Example 7: Custom comparison with lambda-like function
 	fmt.Println("\n7. Custom Comparison (Tasks by Name Length):")
 	taskByNameLengthQueue := NewMinQueue(func(a, b Task) int {
-		return IntCompare(len(a.Name), len(b.Name))
+		return cmp.Compare(len(a.Name), len(b.Name))
 	})
 
 	taskByNameLengthQueue.Push(Task{Name: "A", Priority: 1})
@@ -354,7 +896,7 @@ func ExampleUsage() {
 
 	// Example 8: Using ReverseCompare
 	fmt.Println("\n8. Reverse Integer Comparison (Max-Heap using Min comparator):")
-	reverseIntQueue := NewMinQueue(ReverseCompare(IntCompare))
+	reverseIntQueue := NewMinQueue(ReverseCompare(cmp.Compare[int]))
 
 	reverseIntQueue.Push(10)
 	reverseIntQueue.Push(30)
@@ -364,4 +906,27 @@ func ExampleUsage() {
 		val, _ := reverseIntQueue.Pop()
 		fmt.Printf("  Popped: %d\n", val)
 	}
+
+	// Example 9: Decrease-key by node ID
+	fmt.Println("\n9. Indexed Priority Queue (Decrease-Key):")
+	distances := NewIndexedMinQueue[string](cmp.Compare[int])
+	distances.Push("A", 7)
+	distances.Push("B", 3)
+	distances.Push("C", 9)
+	distances.Update("C", 1) // found a shorter path to C
+
+	for !distances.IsEmpty() {
+		node, d, _ := distances.Pop()
+		fmt.Printf("  Popped: %s (distance %d)\n", node, d)
+	}
+
+	// Example 10: Keep the three largest values with a bounded min-heap
+	fmt.Println("\n10. Top-3 with PushPop:")
+	top := NewMinQueueFromSlice(cmp.Compare[int], []int{4, 1, 7})
+	for _, v := range []int{9, 2, 8, 5} {
+		dropped := top.PushPop(v)
+		fmt.Printf("  Offered %d, dropped %d\n", v, dropped)
+	}
+	smallest, _ := top.Peek()
+	fmt.Printf("  Smallest of the top 3: %d\n", smallest)
 }
@@ -1,7 +1,21 @@
 package priorityqueue
 
 import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+
+	"github.com/anwar-arif/golang-dsa/codec"
+	"github.com/anwar-arif/golang-dsa/collections"
+	"github.com/anwar-arif/golang-dsa/metrics"
 )
 
 func TestMinQueueInts(t *testing.T) {
@@ -402,6 +416,29 @@ func TestUpdateItem(t *testing.T) {
 	}
 }
 
+func TestPushItem(t *testing.T) {
+	pq := NewMinQueue(IntCompare)
+
+	handles := make([]*Item[int], 0, 3)
+	for _, v := range []int{5, 3, 8} {
+		item := NewItem(v)
+		pq.PushItem(item)
+		handles = append(handles, item)
+	}
+
+	// Handles stay valid while the heap reorders
+	handles[2].Value = 1
+	pq.UpdateItem(handles[2])
+	pq.Remove(handles[1])
+
+	if v, _ := pq.Pop(); v != 1 {
+		t.Errorf("Expected 1, got %d", v)
+	}
+	if v, _ := pq.Pop(); v != 5 || !pq.IsEmpty() {
+		t.Errorf("Expected 5 and an empty queue, got %d", v)
+	}
+}
+
 func TestLargeDataset(t *testing.T) {
 	pq := NewMinQueue(IntCompare)
 
@@ -491,6 +528,855 @@ func TestEdgeCases(t *testing.T) {
 	})
 }
 
+func TestAll(t *testing.T) {
+	pq := NewMinQueue(IntCompare)
+	for _, v := range []int{5, 3, 8, 1} {
+		pq.Push(v)
+	}
+
+	sum := 0
+	count := 0
+	for v := range pq.All() {
+		sum += v
+		count++
+	}
+
+	if count != 4 || sum != 17 {
+		t.Errorf("Expected 4 values summing to 17, got %d values summing to %d", count, sum)
+	}
+	if pq.Size() != 4 {
+		t.Errorf("Expected All to leave the queue unchanged, got size %d", pq.Size())
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	pq := NewMinQueue(TaskByPriority)
+	pq.Push(Task{ID: 1, Name: "deploy", Priority: 2})
+	pq.Push(Task{ID: 2, Name: "hotfix", Priority: 1})
+	pq.Push(Task{ID: 3, Name: "docs", Priority: 3})
+
+	data, err := json.Marshal(pq)
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+
+	// MarshalJSON returns a bare array, without the newline codec.JSON writes
+	if empty, _ := NewMinQueue(IntCompare).MarshalJSON(); string(empty) != "[]" {
+		t.Errorf("Expected [], got %q", empty)
+	}
+
+	restored, err := NewMinQueueFromJSON(data, TaskByPriority)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, expected := range []string{"hotfix", "deploy", "docs"} {
+		if task, _ := restored.Pop(); task.Name != expected {
+			t.Errorf("Expected %q, got %q", expected, task.Name)
+		}
+	}
+
+	// The restored order follows the new compare function, not the saved one
+	maxQueue, err := NewMaxQueueFromJSON(data, TaskByPriority)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if top, _ := maxQueue.Peek(); top.Name != "docs" {
+		t.Errorf("Expected docs at the top, got %q", top.Name)
+	}
+
+	if _, err := NewMinQueueFromJSON([]byte("{"), TaskByPriority); err == nil {
+		t.Error("Expected error for malformed JSON")
+	}
+
+	// A zero queue has no compare function to order by
+	var zero PriorityQueue[int]
+	if err := json.Unmarshal([]byte("[1,2]"), &zero); err == nil {
+		t.Error("Expected error when unmarshaling into a zero queue")
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	pq := NewMaxQueue(IntCompare)
+	for _, v := range []int{5, 3, 8, 1} {
+		pq.Push(v)
+	}
+
+	data, err := pq.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+
+	// The restored queue keeps its own ordering
+	restored := NewMinQueue(IntCompare)
+	restored.Push(100)
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+
+	for _, expected := range []int{1, 3, 5, 8} {
+		if v, _ := restored.Pop(); v != expected {
+			t.Errorf("Expected %d, got %d", expected, v)
+		}
+	}
+	if !restored.IsEmpty() {
+		t.Errorf("Expected empty queue, got size %d", restored.Size())
+	}
+}
+
+func TestUnmarshalZeroQueue(t *testing.T) {
+	pq := NewMinQueue(IntCompare)
+	pq.Push(2)
+	pq.Push(1)
+	data, err := pq.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+
+	// A zero queue has no compare function, so it must refuse rather than panic
+	var zero PriorityQueue[int]
+	if err := zero.UnmarshalBinary(data); err == nil {
+		t.Error("Expected error from UnmarshalBinary on a zero queue")
+	}
+	if err := zero.UnmarshalJSON([]byte("[1,2]")); err == nil {
+		t.Error("Expected error from UnmarshalJSON on a zero queue")
+	}
+
+	// The same holds when gob decodes into a struct field
+	type holder struct{ Queue *PriorityQueue[int] }
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(holder{Queue: pq}); err != nil {
+		t.Fatalf("Unexpected encode error: %v", err)
+	}
+	var decoded holder
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err == nil {
+		t.Error("Expected error decoding into a zero queue field")
+	}
+}
+
+func TestFromCSV(t *testing.T) {
+	input := `3,write docs
+1,fix outage
+2,review PR
+`
+	parse := func(record []string) (Task, error) {
+		p, err := strconv.Atoi(record[0])
+		if err != nil {
+			return Task{}, err
+		}
+		return Task{Name: record[1], Priority: p}, nil
+	}
+
+	pq, err := FromCSV(strings.NewReader(input), parse, TaskByPriority)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, expected := range []string{"fix outage", "review PR", "write docs"} {
+		if task, _ := pq.Pop(); task.Name != expected {
+			t.Errorf("Expected %q, got %q", expected, task.Name)
+		}
+	}
+
+	maxQueue, _ := FromCSV(strings.NewReader(input), parse, ReverseCompare(TaskByPriority))
+	if top, _ := maxQueue.Peek(); top.Priority != 3 {
+		t.Errorf("Expected priority 3 first with a reversed comparison, got %d", top.Priority)
+	}
+
+	_, err = FromCSV(strings.NewReader("1,a\nx,b\n"), parse, TaskByPriority)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected an error naming line 2, got %v", err)
+	}
+
+	if _, err := FromCSV(strings.NewReader("1,\"unterminated\n"), parse, TaskByPriority); err == nil {
+		t.Error("Expected a CSV syntax error")
+	}
+}
+
+func TestDrain(t *testing.T) {
+	pq := NewMinQueueFromSlice(IntCompare, []int{4, 1, 3, 2})
+
+	var got []int
+	for v := range pq.Drain(context.Background()) {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected [1 2 3 4], got %v", got)
+	}
+	if !pq.IsEmpty() {
+		t.Errorf("Expected empty queue after Drain, got size %d", pq.Size())
+	}
+}
+
+func TestDrainCancel(t *testing.T) {
+	pq := NewMinQueueFromSlice(IntCompare, []int{4, 1, 3, 2})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch := pq.Drain(ctx)
+	if v := <-ch; v != 1 {
+		t.Errorf("Expected 1, got %d", v)
+	}
+	cancel()
+	for range ch {
+		// A send may still win the race with cancellation
+	}
+
+	// Values that were not received stay queued
+	if size := pq.Size(); size < 2 || size > 3 {
+		t.Errorf("Expected 2 or 3 values left, got %d", size)
+	}
+	if top, _ := pq.Peek(); top != 5-pq.Size() {
+		t.Errorf("Expected %d on top, got %d", 5-pq.Size(), top)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	pq := NewMinQueue(TaskByPriority)
+	pq.Push(Task{ID: 1, Name: "low", Priority: 5})
+	pq.Push(Task{ID: 2, Name: "high", Priority: 1})
+
+	var buf bytes.Buffer
+	if err := pq.Save(&buf, codec.JSON[[]Task]()); err != nil {
+		t.Fatalf("Unexpected save error: %v", err)
+	}
+
+	restored := NewMinQueue(TaskByPriority)
+	if err := restored.Load(&buf, codec.JSON[[]Task]()); err != nil {
+		t.Fatalf("Unexpected load error: %v", err)
+	}
+	if top, _ := restored.Pop(); top.Name != "high" {
+		t.Errorf("Expected high first, got %s", top.Name)
+	}
+}
+
+func TestClone(t *testing.T) {
+	pq := NewMaxQueue(IntCompare)
+	for _, v := range []int{3, 9, 1} {
+		pq.Push(v)
+	}
+
+	clone := pq.Clone()
+	clone.Push(10)
+	pq.Pop()
+
+	if pq.Size() != 2 || clone.Size() != 4 {
+		t.Fatalf("Expected sizes 2 and 4, got %d and %d", pq.Size(), clone.Size())
+	}
+	for _, expected := range []int{10, 9, 3, 1} {
+		if v, _ := clone.Pop(); v != expected {
+			t.Errorf("Expected %d from clone, got %d", expected, v)
+		}
+	}
+
+	scaled := pq.CloneWith(func(v int) int { return v * 10 })
+	if v, _ := scaled.Peek(); v != 30 {
+		t.Errorf("Expected 30, got %d", v)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	pq := NewMinQueue(IntCompare)
+	for _, v := range []int{5, 3, 8, 1, 9, 2} {
+		pq.Push(v)
+	}
+	if err := pq.Validate(); err != nil {
+		t.Fatalf("Unexpected error on a valid queue: %v", err)
+	}
+
+	// Changing a value without UpdateItem breaks the heap
+	items := pq.ToSlice()
+	items[len(items)-1].Value = -1
+	if err := pq.Validate(); err == nil {
+		t.Error("Expected error for a value changed without UpdateItem")
+	}
+	pq.UpdateItem(items[len(items)-1])
+	if err := pq.Validate(); err != nil {
+		t.Errorf("Expected UpdateItem to restore the heap, got %v", err)
+	}
+
+	items[0].Index = 3
+	if err := pq.Validate(); err == nil {
+		t.Error("Expected error for a wrong index")
+	}
+}
+
+func TestSetCompare(t *testing.T) {
+	pq := NewMinQueue(TaskByPriority)
+	tasks := []Task{
+		{ID: 3, Name: "c", Priority: 1},
+		{ID: 1, Name: "a", Priority: 3},
+		{ID: 2, Name: "b", Priority: 2},
+	}
+	items := make([]*Item[Task], len(tasks))
+	for i, task := range tasks {
+		items[i] = NewItem(task)
+		pq.PushItem(items[i])
+	}
+
+	pq.SetCompare(TaskByID)
+	if err := pq.Validate(); err != nil {
+		t.Fatalf("Unexpected invalid heap: %v", err)
+	}
+	if top, _ := pq.Peek(); top.ID != 1 {
+		t.Errorf("Expected task 1 first by ID, got %d", top.ID)
+	}
+
+	// Handles stay usable after the rebuild
+	pq.Remove(items[1])
+	if top, _ := pq.Pop(); top.ID != 2 {
+		t.Errorf("Expected task 2 after removing task 1, got %d", top.ID)
+	}
+}
+
+func TestValidateCompare(t *testing.T) {
+	pq := NewMinQueue(IntCompare)
+	pq.Push(1)
+	pq.Push(2)
+
+	// A comparator that claims every pair is ordered both ways; swapped in
+	// directly so that builds with -tags dsadebug do not panic first
+	pq.heap.compare = func(a, b int) int { return -1 }
+	if err := pq.Validate(); err == nil || !strings.Contains(err.Error(), "compare") {
+		t.Errorf("Expected a compare error, got %v", err)
+	}
+}
+
+func TestWithValidation(t *testing.T) {
+	pq := NewMinQueue(IntCompare, WithValidation())
+	for _, v := range []int{4, 2, 6} {
+		pq.Push(v)
+	}
+	pq.Pop()
+
+	// Clones keep validating
+	clone := pq.Clone()
+	clone.Push(1)
+
+	broken := NewMinQueue(func(a, b int) int { return -1 }, WithValidation())
+	broken.Push(1)
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic from a validated queue with a broken comparator")
+		}
+	}()
+	broken.Push(2)
+}
+
+func TestIndexedValidate(t *testing.T) {
+	pq := NewIndexedMinQueue[string](IntCompare)
+	pq.Push("a", 3)
+	pq.Push("b", 1)
+	pq.Push("c", 2)
+	if err := pq.Validate(); err != nil {
+		t.Fatalf("Unexpected error on a valid queue: %v", err)
+	}
+
+	pq.heap.pos["a"] = 2
+	if err := pq.Validate(); err == nil {
+		t.Error("Expected error for a wrong key index")
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	m := metrics.NewMemory()
+	pq := NewMinQueue(IntCompare)
+	pq.SetRecorder(m)
+
+	pq.Push(3)
+	pq.Push(1)
+	pq.Push(2)
+	pq.Pop()
+	items := pq.ToSlice()
+	pq.Remove(items[0])
+
+	if m.Counter("push") != 3 || m.Counter("pop") != 1 || m.Counter("remove") != 1 {
+		t.Errorf("Unexpected counters: %v", m)
+	}
+	if depth, _ := m.GaugeValue("depth"); depth != 1 {
+		t.Errorf("Expected depth 1, got %v", depth)
+	}
+}
+
+func TestOnMutate(t *testing.T) {
+	pq := NewMinQueue(IntCompare)
+
+	var ops []collections.Op
+	sum := 0
+	pq.OnMutate(func(op collections.Op, value int) {
+		ops = append(ops, op)
+		switch op {
+		case collections.OpPush:
+			sum += value
+		case collections.OpPop, collections.OpRemove:
+			sum -= value
+		}
+	})
+
+	pq.Push(5)
+	pq.Push(1)
+	pq.Push(9)
+	pq.Pop()
+	items := pq.ToSlice()
+	items[0].Value = 4
+	pq.UpdateItem(items[0])
+
+	expected := []collections.Op{collections.OpPush, collections.OpPush, collections.OpPush, collections.OpPop, collections.OpUpdate}
+	if fmt.Sprint(ops) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v, got %v", expected, ops)
+	}
+	if sum != 14 {
+		t.Errorf("Expected running sum 14, got %d", sum)
+	}
+
+	data, _ := pq.MarshalBinary()
+	ops = nil
+	pq.UnmarshalBinary(data)
+	if len(ops) != 3 || ops[0] != collections.OpClear {
+		t.Errorf("Expected load to report clear and two pushes, got %v", ops)
+	}
+}
+
+func TestOrderedQueues(t *testing.T) {
+	minQueue := NewOrderedMinQueue[string]()
+	for _, v := range []string{"pear", "apple", "fig"} {
+		minQueue.Push(v)
+	}
+	if got := minQueue.SortedSlice(); !slices.Equal(got, []string{"apple", "fig", "pear"}) {
+		t.Errorf("Expected [apple fig pear], got %v", got)
+	}
+
+	maxQueue := NewOrderedMaxQueue[float64](WithArity(4))
+	for _, v := range []float64{1.5, -2, 3.25} {
+		maxQueue.Push(v)
+	}
+	if got := maxQueue.SortedSlice(); !slices.Equal(got, []float64{3.25, 1.5, -2}) {
+		t.Errorf("Expected [3.25 1.5 -2], got %v", got)
+	}
+}
+
+func TestWithCapacity(t *testing.T) {
+	pq := NewMinQueue(IntCompare, WithCapacity(100))
+	if pq.Cap() != 100 {
+		t.Fatalf("Expected capacity 100, got %d", pq.Cap())
+	}
+	for i := 0; i < 100; i++ {
+		pq.Push(i)
+	}
+	if pq.Cap() != 100 {
+		t.Errorf("Expected no growth within capacity, got %d", pq.Cap())
+	}
+
+	items := pq.ToSlice()
+	pq.PopN(60)
+	pq.MarkDeleted(items[len(items)-1])
+	pq.Shrink()
+	if pq.Cap() != pq.Size() || pq.Size() != 39 {
+		t.Errorf("Expected capacity to match size 39, got capacity %d and size %d", pq.Cap(), pq.Size())
+	}
+	if pq.Tombstones() != 0 {
+		t.Errorf("Expected Shrink to drop tombstones, got %d", pq.Tombstones())
+	}
+	if err := pq.Validate(); err != nil {
+		t.Fatalf("Unexpected invalid heap: %v", err)
+	}
+	if top, _ := pq.Peek(); top != 60 {
+		t.Errorf("Expected 60 at the top, got %d", top)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for a negative capacity")
+		}
+	}()
+	WithCapacity(-1)
+}
+
+func TestNewQueueFromSlice(t *testing.T) {
+	values := []int{5, 3, 8, 1, 9, 2}
+
+	minQueue := NewMinQueueFromSlice(IntCompare, values)
+	if minQueue.Size() != len(values) {
+		t.Fatalf("Expected size %d, got %d", len(values), minQueue.Size())
+	}
+	for _, expected := range []int{1, 2, 3, 5, 8, 9} {
+		if v, _ := minQueue.Pop(); v != expected {
+			t.Errorf("Expected %d, got %d", expected, v)
+		}
+	}
+
+	maxQueue := NewMaxQueueFromSlice(IntCompare, values)
+	for _, expected := range []int{9, 8, 5, 3, 2, 1} {
+		if v, _ := maxQueue.Pop(); v != expected {
+			t.Errorf("Expected %d, got %d", expected, v)
+		}
+	}
+
+	// The input slice is not reordered by heapify
+	if values[0] != 5 || values[5] != 2 {
+		t.Errorf("Expected input slice to be unchanged, got %v", values)
+	}
+
+	empty := NewMinQueueFromSlice[int](IntCompare, nil)
+	if !empty.IsEmpty() {
+		t.Errorf("Expected empty queue, got size %d", empty.Size())
+	}
+}
+
+func TestErrEmpty(t *testing.T) {
+	pq := NewOrderedMinQueue[int]()
+	if _, err := pq.Pop(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from Pop, got %v", err)
+	}
+	if _, err := pq.Peek(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from Peek, got %v", err)
+	}
+	if _, err := pq.Replace(1); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from Replace, got %v", err)
+	}
+
+	indexed := NewIndexedMinQueue[string](IntCompare)
+	if _, _, err := indexed.Pop(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from indexed Pop, got %v", err)
+	}
+	if _, _, err := indexed.Peek(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from indexed Peek, got %v", err)
+	}
+}
+
+func TestPushPop(t *testing.T) {
+	pq := NewMinQueue(IntCompare)
+
+	// An empty queue hands the value straight back
+	if v := pq.PushPop(5); v != 5 {
+		t.Errorf("Expected 5 from an empty queue, got %d", v)
+	}
+	if !pq.IsEmpty() {
+		t.Fatalf("Expected queue to stay empty, got size %d", pq.Size())
+	}
+
+	pq.Push(3)
+	pq.Push(6)
+	pq.Push(9)
+
+	// Nothing outranks 2, so it is returned without touching the heap
+	if v := pq.PushPop(2); v != 2 {
+		t.Errorf("Expected 2, got %d", v)
+	}
+	// Ties go back to the caller as well
+	if v := pq.PushPop(3); v != 3 {
+		t.Errorf("Expected 3, got %d", v)
+	}
+	// 7 displaces the root
+	if v := pq.PushPop(7); v != 3 {
+		t.Errorf("Expected 3, got %d", v)
+	}
+	if pq.Size() != 3 {
+		t.Errorf("Expected size 3, got %d", pq.Size())
+	}
+	for _, expected := range []int{6, 7, 9} {
+		if v, _ := pq.Pop(); v != expected {
+			t.Errorf("Expected %d, got %d", expected, v)
+		}
+	}
+
+	maxQueue := NewMaxQueueFromSlice(IntCompare, []int{1, 4, 2})
+	if v := maxQueue.PushPop(3); v != 4 {
+		t.Errorf("Expected 4 from max queue, got %d", v)
+	}
+	if top, _ := maxQueue.Peek(); top != 3 {
+		t.Errorf("Expected 3 at the top, got %d", top)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	pq := NewMinQueue(IntCompare)
+	if _, err := pq.Replace(1); err == nil {
+		t.Error("Expected error when replacing in an empty queue")
+	}
+	if !pq.IsEmpty() {
+		t.Errorf("Expected nothing to be pushed, got size %d", pq.Size())
+	}
+
+	pq.Push(3)
+	pq.Push(6)
+
+	// Unlike PushPop, the root is returned even when value ranks higher
+	v, err := pq.Replace(1)
+	if err != nil || v != 3 {
+		t.Errorf("Expected (3, nil), got (%d, %v)", v, err)
+	}
+	if v, _ = pq.Replace(8); v != 1 {
+		t.Errorf("Expected 1, got %d", v)
+	}
+	for _, expected := range []int{6, 8} {
+		if v, _ := pq.Pop(); v != expected {
+			t.Errorf("Expected %d, got %d", expected, v)
+		}
+	}
+}
+
+func TestReplaceKeepsItemHandles(t *testing.T) {
+	pq := NewMinQueue(IntCompare)
+	root := NewItem(1)
+	pq.PushItem(root)
+	other := NewItem(5)
+	pq.PushItem(other)
+
+	pq.Replace(3)
+	if root.Index != -1 {
+		t.Errorf("Expected replaced item to be detached, got index %d", root.Index)
+	}
+
+	// Handles to items still in the queue remain usable
+	other.Value = 0
+	pq.UpdateItem(other)
+	if top, _ := pq.Peek(); top != 0 {
+		t.Errorf("Expected 0 at the top, got %d", top)
+	}
+}
+
+func TestOnPushOnPop(t *testing.T) {
+	pq := NewMinQueue(IntCompare)
+	var pushed, popped []int
+	pq.OnPush(func(v int) { pushed = append(pushed, v) })
+	pq.OnPop(func(v int) { popped = append(popped, v) })
+
+	pq.Push(3)
+	pq.Push(1)
+	pq.PushPop(2)
+	pq.Pop()
+
+	if !slices.Equal(pushed, []int{3, 1, 2}) {
+		t.Errorf("Expected pushes [3 1 2], got %v", pushed)
+	}
+	if !slices.Equal(popped, []int{1, 2}) {
+		t.Errorf("Expected pops [1 2], got %v", popped)
+	}
+}
+
+func TestStats(t *testing.T) {
+	pq := NewMinQueueFromSlice(IntCompare, []int{5, 3})
+	pq.Push(1)
+	pq.PushItem(NewItem(4))
+	pq.PushPop(2)
+	pq.PopN(2)
+
+	expected := collections.Stats{Size: 2, PeakSize: 5, Pushes: 5, Pops: 3, Allocs: 4}
+	if got := pq.Stats(); got != expected {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestPushPopNotifiesObservers(t *testing.T) {
+	pq := NewMinQueueFromSlice(IntCompare, []int{3})
+	var ops []collections.Op
+	var values []int
+	pq.OnMutate(func(op collections.Op, v int) {
+		ops = append(ops, op)
+		values = append(values, v)
+	})
+
+	pq.PushPop(5)
+	pq.Replace(1)
+
+	wantOps := []collections.Op{collections.OpPush, collections.OpPop, collections.OpPop, collections.OpPush}
+	wantValues := []int{5, 3, 5, 1}
+	if len(ops) != len(wantOps) {
+		t.Fatalf("Expected %d notifications, got %v", len(wantOps), ops)
+	}
+	for i := range wantOps {
+		if ops[i] != wantOps[i] || values[i] != wantValues[i] {
+			t.Errorf("Notification %d: expected (%v, %d), got (%v, %d)", i, wantOps[i], wantValues[i], ops[i], values[i])
+		}
+	}
+}
+
+func TestOrdered(t *testing.T) {
+	pq := NewMaxQueueFromSlice(IntCompare, []int{4, 9, 1, 7, 3})
+
+	got := pq.SortedSlice()
+	expected := []int{9, 7, 4, 3, 1}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, got)
+			break
+		}
+	}
+
+	// Stopping early leaves the queue as it was
+	var first []int
+	for v := range pq.Ordered() {
+		first = append(first, v)
+		if len(first) == 2 {
+			break
+		}
+	}
+	if len(first) != 2 || first[0] != 9 || first[1] != 7 {
+		t.Errorf("Expected [9 7], got %v", first)
+	}
+	if pq.Size() != 5 {
+		t.Errorf("Expected size 5 after iterating, got %d", pq.Size())
+	}
+	if top, _ := pq.Peek(); top != 9 {
+		t.Errorf("Expected 9 at the top, got %d", top)
+	}
+
+	for rank, v := range pq.Ordered2() {
+		if v != expected[rank] {
+			t.Errorf("Expected %d at rank %d, got %d", expected[rank], rank, v)
+		}
+	}
+
+	if empty := NewMinQueue(IntCompare).SortedSlice(); len(empty) != 0 {
+		t.Errorf("Expected empty slice, got %v", empty)
+	}
+}
+
+func TestPeekN(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, d := range []int{2, 4} {
+		pq := NewMinQueue(IntCompare, WithArity(d))
+		var items []*Item[int]
+		for i := 0; i < 100; i++ {
+			item := NewItem(rng.Intn(50))
+			pq.PushItem(item)
+			items = append(items, item)
+		}
+		// Tombstones are skipped but their children are still reached
+		for _, item := range items[:20] {
+			pq.MarkDeleted(item)
+		}
+
+		sorted := pq.SortedSlice()
+		for _, n := range []int{1, 10, 80} {
+			if got := pq.PeekN(n); !slices.Equal(got, sorted[:n]) {
+				t.Errorf("d=%d: PeekN(%d) = %v, expected %v", d, n, got, sorted[:n])
+			}
+		}
+		if pq.Size() != 80 {
+			t.Errorf("d=%d: Expected PeekN to leave size 80, got %d", d, pq.Size())
+		}
+	}
+
+	pq := NewMaxQueueFromSlice(IntCompare, []int{3, 1, 2})
+	if got := pq.PeekN(10); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("Expected every value when n > Size, got %v", got)
+	}
+	if got := pq.PeekN(0); got == nil || len(got) != 0 {
+		t.Errorf("Expected an empty slice for n = 0, got %v", got)
+	}
+	if got := NewMinQueue(IntCompare).PeekN(3); len(got) != 0 {
+		t.Errorf("Expected an empty slice from an empty queue, got %v", got)
+	}
+}
+
+func TestPopN(t *testing.T) {
+	pq := NewMinQueueFromSlice(IntCompare, []int{7, 3, 9, 1, 5})
+	m := metrics.NewMemory()
+	pq.SetRecorder(m)
+	var popped []int
+	pq.OnMutate(func(op collections.Op, v int) {
+		if op == collections.OpPop {
+			popped = append(popped, v)
+		}
+	})
+
+	if got := pq.PopN(3); !slices.Equal(got, []int{1, 3, 5}) {
+		t.Errorf("Expected [1 3 5], got %v", got)
+	}
+	if pq.Size() != 2 {
+		t.Errorf("Expected size 2, got %d", pq.Size())
+	}
+	if !slices.Equal(popped, []int{1, 3, 5}) {
+		t.Errorf("Expected a pop notification per value, got %v", popped)
+	}
+	if m.Counter("pop") != 3 {
+		t.Errorf("Expected pop counter 3, got %d", m.Counter("pop"))
+	}
+	if depth, _ := m.GaugeValue("depth"); depth != 2 {
+		t.Errorf("Expected depth 2, got %v", depth)
+	}
+
+	// Asking for more than the queue holds drains it
+	if got := pq.PopN(10); !slices.Equal(got, []int{7, 9}) {
+		t.Errorf("Expected [7 9], got %v", got)
+	}
+	if !pq.IsEmpty() {
+		t.Errorf("Expected empty queue, got size %d", pq.Size())
+	}
+	if got := pq.PopN(1); len(got) != 0 {
+		t.Errorf("Expected an empty slice from an empty queue, got %v", got)
+	}
+}
+
+func TestFind(t *testing.T) {
+	pq := NewMinQueue(TaskByPriority)
+	pq.Push(Task{ID: 1, Name: "build", Priority: 2})
+	pq.Push(Task{ID: 2, Name: "test", Priority: 3})
+	pq.Push(Task{ID: 3, Name: "deploy", Priority: 1})
+
+	item, ok := pq.Find(func(task Task) bool { return task.ID == 2 })
+	if !ok || item.Value.Name != "test" {
+		t.Fatalf("Expected to find task 2, got %v, %v", item, ok)
+	}
+
+	// The found item is a live handle
+	item.Value.Priority = 0
+	pq.UpdateItem(item)
+	if top, _ := pq.Peek(); top.ID != 2 {
+		t.Errorf("Expected task 2 at the top, got %d", top.ID)
+	}
+
+	if _, ok := pq.Find(func(task Task) bool { return task.ID == 42 }); ok {
+		t.Error("Expected no task 42")
+	}
+
+	pq.MarkDeleted(item)
+	if _, ok := pq.Find(func(task Task) bool { return task.ID == 2 }); ok {
+		t.Error("Expected Find to skip tombstones")
+	}
+}
+
+func TestRemoveFunc(t *testing.T) {
+	pq := NewMinQueueFromSlice(IntCompare, []int{8, 3, 6, 1, 4, 7, 2, 5})
+	var removed []int
+	pq.OnMutate(func(op collections.Op, v int) {
+		if op == collections.OpRemove {
+			removed = append(removed, v)
+		}
+	})
+
+	isEven := func(v int) bool { return v%2 == 0 }
+	if n := pq.RemoveFunc(isEven); n != 4 {
+		t.Errorf("Expected 4 removals, got %d", n)
+	}
+	slices.Sort(removed)
+	if !slices.Equal(removed, []int{2, 4, 6, 8}) {
+		t.Errorf("Expected a remove notification per value, got %v", removed)
+	}
+	if err := pq.Validate(); err != nil {
+		t.Fatalf("Unexpected invalid heap: %v", err)
+	}
+	if got := pq.SortedSlice(); !slices.Equal(got, []int{1, 3, 5, 7}) {
+		t.Errorf("Expected [1 3 5 7], got %v", got)
+	}
+
+	if n := pq.RemoveFunc(isEven); n != 0 {
+		t.Errorf("Expected no removals, got %d", n)
+	}
+
+	// Tombstones are dropped but not counted
+	item, _ := pq.Find(func(v int) bool { return v == 5 })
+	pq.MarkDeleted(item)
+	if n := pq.RemoveFunc(func(v int) bool { return v > 4 }); n != 1 {
+		t.Errorf("Expected 1 removal, got %d", n)
+	}
+	if pq.Tombstones() != 0 || pq.Size() != 2 {
+		t.Errorf("Expected 2 items and no tombstones, got size %d and %d tombstones", pq.Size(), pq.Tombstones())
+	}
+}
+
 // Benchmark tests
 func BenchmarkPushMinQueue(b *testing.B) {
 	pq := NewMinQueue(IntCompare)
@@ -525,6 +1411,72 @@ func BenchmarkPeekMinQueue(b *testing.B) {
 	}
 }
 
+func BenchmarkNewMinQueueFromSlice(b *testing.B) {
+	values := make([]int, 10000)
+	for i := range values {
+		values[i] = len(values) - i
+	}
+
+	b.Run("Heapify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewMinQueueFromSlice(IntCompare, values)
+		}
+	})
+	b.Run("Push", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			pq := NewMinQueue(IntCompare)
+			for _, v := range values {
+				pq.Push(v)
+			}
+		}
+	})
+}
+
+func BenchmarkPushPop(b *testing.B) {
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = i
+	}
+
+	b.Run("PushPop", func(b *testing.B) {
+		pq := NewMinQueueFromSlice(IntCompare, values)
+		for i := 0; i < b.N; i++ {
+			pq.PushPop(i)
+		}
+	})
+	b.Run("PushThenPop", func(b *testing.B) {
+		pq := NewMinQueueFromSlice(IntCompare, values)
+		for i := 0; i < b.N; i++ {
+			pq.Push(i)
+			pq.Pop()
+		}
+	})
+}
+
+func BenchmarkPeekN(b *testing.B) {
+	values := make([]int, 100000)
+	for i := range values {
+		values[i] = i
+	}
+	pq := NewMinQueueFromSlice(IntCompare, values)
+
+	b.Run("PeekN", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			pq.PeekN(10)
+		}
+	})
+	b.Run("Ordered", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			n := 0
+			for range pq.Ordered() {
+				if n++; n == 10 {
+					break
+				}
+			}
+		}
+	})
+}
+
 func BenchmarkStringQueue(b *testing.B) {
 	pq := NewMinQueue(StringCompare)
 
@@ -544,7 +1496,7 @@ func ExampleNewMinQueue() {
 
 	for !pq.IsEmpty() {
 		val, _ := pq.Pop()
-		println("Value:", val)
+		fmt.Println("Value:", val)
 	}
 	// Output:
 	// Value: 10
@@ -561,7 +1513,7 @@ func ExampleNewMaxQueue() {
 
 	for !pq.IsEmpty() {
 		val, _ := pq.Pop()
-		println("Value:", val)
+		fmt.Println("Value:", val)
 	}
 	// Output:
 	// Value: zebra
@@ -569,7 +1521,7 @@ func ExampleNewMaxQueue() {
 	// Value: apple
 }
 
-func ExampleCustomComparison() {
+func ExampleNewMinQueue_customComparison() {
 	// Custom comparison by string length
 	lengthCompare := func(a, b string) int {
 		return IntCompare(len(a), len(b))
@@ -583,7 +1535,7 @@ func ExampleCustomComparison() {
 
 	for !pq.IsEmpty() {
 		val, _ := pq.Pop()
-		println("Value:", val, "Length:", len(val))
+		fmt.Println("Value:", val, "Length:", len(val))
 	}
 	// Output:
 	// Value: hi Length: 2
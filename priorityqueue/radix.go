@@ -0,0 +1,142 @@
+package priorityqueue
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// ErrNotMonotone is returned when a key is pushed below the last popped key of
+// a queue that requires monotone keys
+var ErrNotMonotone = errors.New("priority queue: key below last popped key")
+
+// radixEntry is a value with its key
+type radixEntry[T any] struct {
+	key   uint64
+	value T
+}
+
+// RadixHeap is a min-priority queue for uint64 keys that never go below the
+// last popped key, which holds for Dijkstra with non-negative weights
+// Entries are kept in 65 buckets by the highest bit in which their key
+// differs from the last popped key; each entry only ever moves to a lower
+// bucket, so a push and pop together cost amortized O(log C) for keys up to
+// C, with no comparisons between values
+type RadixHeap[T any] struct {
+	buckets [65][]radixEntry[T]
+	last    uint64 // last popped key
+	size    int
+}
+
+// NewRadixHeap creates an empty radix heap
+func NewRadixHeap[T any]() *RadixHeap[T] {
+	return &RadixHeap[T]{}
+}
+
+// bucketFor returns the bucket of key: 0 for keys equal to last, otherwise
+// one past the highest bit in which key and last differ
+func (h *RadixHeap[T]) bucketFor(key uint64) int {
+	return bits.Len64(key ^ h.last)
+}
+
+// Push adds value with key
+// Returns ErrNotMonotone if key is below the last popped key
+func (h *RadixHeap[T]) Push(value T, key uint64) error {
+	if key < h.last {
+		return fmt.Errorf("%w: %d < %d", ErrNotMonotone, key, h.last)
+	}
+	i := h.bucketFor(key)
+	h.buckets[i] = append(h.buckets[i], radixEntry[T]{key: key, value: value})
+	h.size++
+	return nil
+}
+
+// PopMin removes and returns a value with the lowest key along with the key
+// Returns ErrEmpty if the heap is empty
+func (h *RadixHeap[T]) PopMin() (T, uint64, error) {
+	var zero T
+	if !h.settle() {
+		return zero, 0, ErrEmpty
+	}
+	b := h.buckets[0]
+	e := b[len(b)-1]
+	b[len(b)-1] = radixEntry[T]{}
+	h.buckets[0] = b[:len(b)-1]
+	h.size--
+	return e.value, e.key, nil
+}
+
+// PeekMin returns a value with the lowest key and the key without removing it
+// Returns ErrEmpty if the heap is empty
+// Unlike PopMin it does not redistribute, so Last and the keys Push accepts
+// stay the same
+func (h *RadixHeap[T]) PeekMin() (T, uint64, error) {
+	if h.size == 0 {
+		var zero T
+		return zero, 0, ErrEmpty
+	}
+	i := 0
+	for len(h.buckets[i]) == 0 {
+		i++
+	}
+	b := h.buckets[i]
+	best := len(b) - 1
+	for j, e := range b {
+		if e.key < b[best].key {
+			best = j
+		}
+	}
+	return b[best].value, b[best].key, nil
+}
+
+// settle makes bucket 0 non-empty, if the heap is, by moving last up to the
+// smallest key in the lowest non-empty bucket and redistributing that bucket
+func (h *RadixHeap[T]) settle() bool {
+	if h.size == 0 {
+		return false
+	}
+	if len(h.buckets[0]) > 0 {
+		return true
+	}
+
+	i := 1
+	for len(h.buckets[i]) == 0 {
+		i++
+	}
+	b := h.buckets[i]
+	h.last = b[0].key
+	for _, e := range b[1:] {
+		h.last = min(h.last, e.key)
+	}
+	for _, e := range b {
+		j := h.bucketFor(e.key)
+		h.buckets[j] = append(h.buckets[j], e)
+	}
+	clear(b)
+	h.buckets[i] = b[:0]
+	return true
+}
+
+// Last returns the last popped key, the lowest key Push accepts
+func (h *RadixHeap[T]) Last() uint64 {
+	return h.last
+}
+
+// Size returns the number of queued values
+func (h *RadixHeap[T]) Size() int {
+	return h.size
+}
+
+// IsEmpty returns true if the heap is empty
+func (h *RadixHeap[T]) IsEmpty() bool {
+	return h.size == 0
+}
+
+// Clear removes every value and resets the last popped key to 0
+func (h *RadixHeap[T]) Clear() {
+	for i := range h.buckets {
+		clear(h.buckets[i])
+		h.buckets[i] = h.buckets[i][:0]
+	}
+	h.last, h.size = 0, 0
+}
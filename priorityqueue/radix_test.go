@@ -0,0 +1,109 @@
+package priorityqueue
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestRadixHeap(t *testing.T) {
+	h := NewRadixHeap[string]()
+	if _, _, err := h.PopMin(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	h.Push("c", 30)
+	h.Push("a", 10)
+	h.Push("d", 1<<40)
+	h.Push("b", 20)
+
+	if v, k, _ := h.PeekMin(); v != "a" || k != 10 {
+		t.Errorf("Expected a at 10, got %s at %d", v, k)
+	}
+	if h.Last() != 0 {
+		t.Errorf("Expected PeekMin to leave Last at 0, got %d", h.Last())
+	}
+	if err := h.Push("zero", 0); err != nil {
+		t.Errorf("Expected a push at 0 to be accepted after PeekMin, got %v", err)
+	}
+
+	for _, expected := range []string{"zero", "a", "b"} {
+		if v, _, _ := h.PopMin(); v != expected {
+			t.Errorf("Expected %s, got %s", expected, v)
+		}
+	}
+	if h.Last() != 20 {
+		t.Errorf("Expected Last 20, got %d", h.Last())
+	}
+	if err := h.Push("late", 19); !errors.Is(err, ErrNotMonotone) {
+		t.Errorf("Expected ErrNotMonotone, got %v", err)
+	}
+	if err := h.Push("tie", 20); err != nil {
+		t.Errorf("Expected a push at the last key to be accepted, got %v", err)
+	}
+
+	for _, expected := range []uint64{20, 30, 1 << 40} {
+		if _, k, _ := h.PopMin(); k != expected {
+			t.Errorf("Expected key %d, got %d", expected, k)
+		}
+	}
+	if !h.IsEmpty() {
+		t.Errorf("Expected empty heap, got size %d", h.Size())
+	}
+
+	h.Push("x", 1<<50)
+	h.Clear()
+	if h.Size() != 0 || h.Last() != 0 {
+		t.Errorf("Expected Clear to reset the heap, got size %d and last %d", h.Size(), h.Last())
+	}
+}
+
+func TestRadixHeapMatchesSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	h := NewRadixHeap[int]()
+	var ref []uint64
+
+	for step := 0; step < 5000; step++ {
+		if len(ref) > 0 && rng.Intn(2) == 0 {
+			_, k, _ := h.PopMin()
+			slices.Sort(ref)
+			if k != ref[0] {
+				t.Fatalf("Step %d: expected key %d, got %d", step, ref[0], k)
+			}
+			ref = ref[1:]
+			continue
+		}
+		k := h.Last() + uint64(rng.Int63n(1<<20))
+		h.Push(step, k)
+		ref = append(ref, k)
+	}
+	if h.Size() != len(ref) {
+		t.Errorf("Expected size %d, got %d", len(ref), h.Size())
+	}
+}
+
+// Benchmark tests
+func BenchmarkRadixHeap(b *testing.B) {
+	b.Run("Radix", func(b *testing.B) {
+		rng := rand.New(rand.NewSource(1))
+		h := NewRadixHeap[int]()
+		for i := 0; i < b.N; i++ {
+			h.Push(i, h.Last()+uint64(rng.Intn(1000)))
+			if i%2 == 1 {
+				h.PopMin()
+			}
+		}
+	})
+	b.Run("Binary", func(b *testing.B) {
+		rng := rand.New(rand.NewSource(1))
+		pq := NewOrderedMinQueue[uint64]()
+		last := uint64(0)
+		for i := 0; i < b.N; i++ {
+			pq.Push(last + uint64(rng.Intn(1000)))
+			if i%2 == 1 {
+				last, _ = pq.Pop()
+			}
+		}
+	})
+}
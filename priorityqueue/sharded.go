@@ -0,0 +1,114 @@
+package priorityqueue
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// shard is one independently locked heap of a ShardedQueue
+type shard[T any] struct {
+	mu sync.Mutex
+	pq *PriorityQueue[T]
+	_  [64]byte // keep neighbouring locks off the same cache line
+}
+
+// ShardedQueue is a thread-safe priority queue that spreads values over
+// several independently locked heaps, so concurrent producers rarely contend
+// Push goes to the next shard in round-robin order and TryPop takes the best
+// of the shard heads. Ordering is approximate under concurrency: a value
+// pushed while a pop is scanning may be missed, and a head taken by another
+// goroutine makes the pop settle for the next best shard. With no concurrent
+// pushes, pops come out in exact priority order
+type ShardedQueue[T any] struct {
+	shards []shard[T]
+	next   atomic.Uint64
+	before func(a, b T) bool
+}
+
+// NewShardedMinQueue creates a sharded min-priority queue with n shards
+// Panics if n < 1
+func NewShardedMinQueue[T any](compare CompareFunc[T], n int, opts ...Option) *ShardedQueue[T] {
+	return newSharded(compare, false, n, opts)
+}
+
+// NewShardedMaxQueue creates a sharded max-priority queue with n shards
+// Panics if n < 1
+func NewShardedMaxQueue[T any](compare CompareFunc[T], n int, opts ...Option) *ShardedQueue[T] {
+	return newSharded(compare, true, n, opts)
+}
+
+func newSharded[T any](compare CompareFunc[T], isMaxHeap bool, n int, opts []Option) *ShardedQueue[T] {
+	if n < 1 {
+		panic(fmt.Sprintf("priorityqueue: need at least 1 shard, got %d", n))
+	}
+	q := &ShardedQueue[T]{shards: make([]shard[T], n)}
+	for i := range q.shards {
+		q.shards[i].pq = newQueue(compare, isMaxHeap, opts)
+	}
+	q.before = q.shards[0].pq.heap.before
+	return q
+}
+
+// Push adds value to the next shard
+func (q *ShardedQueue[T]) Push(value T) {
+	s := &q.shards[q.next.Add(1)%uint64(len(q.shards))]
+	s.mu.Lock()
+	s.pq.Push(value)
+	s.mu.Unlock()
+}
+
+// TryPop removes and returns the best value among the shard heads without
+// blocking
+// Returns ErrEmpty if every shard is empty
+func (q *ShardedQueue[T]) TryPop() (T, error) {
+	for {
+		best := -1
+		var bestValue T
+		for i := range q.shards {
+			s := &q.shards[i]
+			s.mu.Lock()
+			v, err := s.pq.Peek()
+			s.mu.Unlock()
+			if err == nil && (best < 0 || q.before(v, bestValue)) {
+				best, bestValue = i, v
+			}
+		}
+		if best < 0 {
+			var zero T
+			return zero, ErrEmpty
+		}
+
+		s := &q.shards[best]
+		s.mu.Lock()
+		v, err := s.pq.Pop()
+		s.mu.Unlock()
+		if err == nil {
+			return v, nil
+		}
+		// Another goroutine emptied the shard since the scan; look again
+	}
+}
+
+// Size returns the number of queued values; under concurrent use the
+// result is only a snapshot
+func (q *ShardedQueue[T]) Size() int {
+	n := 0
+	for i := range q.shards {
+		s := &q.shards[i]
+		s.mu.Lock()
+		n += s.pq.Size()
+		s.mu.Unlock()
+	}
+	return n
+}
+
+// IsEmpty returns true if every shard is empty
+func (q *ShardedQueue[T]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+// Shards returns the number of shards
+func (q *ShardedQueue[T]) Shards() int {
+	return len(q.shards)
+}
@@ -0,0 +1,110 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"errors"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestShardedQueueOrder(t *testing.T) {
+	q := NewShardedMinQueue(cmp.Compare[int], 4)
+	if _, err := q.TryPop(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	var want []int
+	for i := 0; i < 200; i++ {
+		v := rng.Intn(1000)
+		q.Push(v)
+		want = append(want, v)
+	}
+	slices.Sort(want)
+
+	// Without concurrent pushes the order is exact
+	var got []int
+	for !q.IsEmpty() {
+		v, _ := q.TryPop()
+		got = append(got, v)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Values popped out of order: %v", got)
+	}
+}
+
+func TestShardedQueueConcurrent(t *testing.T) {
+	q := NewShardedMaxQueue(cmp.Compare[int], 8)
+	const producers, perProducer = 16, 500
+
+	var wg sync.WaitGroup
+	for p := range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perProducer {
+				q.Push(p*perProducer + i)
+			}
+		}()
+	}
+
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range producers * perProducer / 8 {
+				if v, err := q.TryPop(); err == nil {
+					mu.Lock()
+					seen[v] = true
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for !q.IsEmpty() {
+		v, _ := q.TryPop()
+		seen[v] = true
+	}
+	if len(seen) != producers*perProducer {
+		t.Errorf("Expected %d distinct values, got %d", producers*perProducer, len(seen))
+	}
+}
+
+// Benchmark tests
+
+// benchmarkProducers runs 32 goroutines per CPU that push, popping once for
+// every popEvery pushes when popEvery > 0
+func benchmarkProducers(b *testing.B, push func(int), pop func(), popEvery int) {
+	b.SetParallelism(32)
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 1; pb.Next(); i++ {
+			push(i)
+			if popEvery > 0 && i%popEvery == 0 {
+				pop()
+			}
+		}
+	})
+}
+
+func BenchmarkShardedVsLocked(b *testing.B) {
+	for _, popEvery := range []int{0, 4} {
+		name := "push"
+		if popEvery > 0 {
+			name = "push+pop"
+		}
+		b.Run(name+"/locked", func(b *testing.B) {
+			q := NewConcurrentMinQueue(cmp.Compare[int])
+			benchmarkProducers(b, func(v int) { q.Push(v) }, func() { q.TryPop() }, popEvery)
+		})
+		b.Run(name+"/sharded", func(b *testing.B) {
+			q := NewShardedMinQueue(cmp.Compare[int], 16)
+			benchmarkProducers(b, q.Push, func() { q.TryPop() }, popEvery)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package priorityqueue
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// FromCSV builds a min-priority queue from CSV records, parsing and pushing
+// one record at a time so the input is never held in memory as a whole
+// parse receives the fields of each record; the slice is reused between
+// calls and must not be retained. Wrap compare with ReverseCompare for a
+// max-priority order
+// An error names the failing line
+func FromCSV[T any](r io.Reader, parse func(record []string) (T, error), compare CompareFunc[T]) (*PriorityQueue[T], error) {
+	pq := NewMinQueue(compare)
+
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	cr.FieldsPerRecord = -1
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return pq, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := parse(record)
+		if err != nil {
+			line, _ := cr.FieldPos(0)
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		pq.Push(v)
+	}
+}
+
+// Drain pops values in priority order and sends them on the returned
+// channel, which is closed once the queue is empty or ctx is cancelled
+// A value leaves the queue only after it has been received, so cancelling
+// keeps every unsent value queued. The queue belongs to the draining
+// goroutine until the channel is closed and must not be used meanwhile
+func (pq *PriorityQueue[T]) Drain(ctx context.Context) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for {
+			v, err := pq.Peek()
+			if err != nil {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- v:
+				pq.Pop()
+			}
+		}
+	}()
+	return ch
+}
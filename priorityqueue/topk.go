@@ -0,0 +1,66 @@
+package priorityqueue
+
+import (
+	"fmt"
+	"slices"
+)
+
+// TopK tracks the k largest values of a stream in O(k) memory
+// Values are kept in a min-queue of size k whose top is the smallest value
+// still in the top k, so each Offer costs at most one sift. Wrap compare with
+// ReverseCompare to track the k smallest values instead
+type TopK[T any] struct {
+	pq *PriorityQueue[T]
+	k  int
+}
+
+// NewTopK creates a tracker for the k largest values under compare
+// Panics if k < 1
+func NewTopK[T any](k int, compare CompareFunc[T]) *TopK[T] {
+	if k < 1 {
+		panic(fmt.Sprintf("priorityqueue: TopK needs k >= 1, got %d", k))
+	}
+	return &TopK[T]{pq: NewMinQueue(compare, WithCapacity(k)), k: k}
+}
+
+// Offer considers v for the top k and reports whether it was kept
+// Once k values are held, v must outrank the smallest of them to get in
+func (t *TopK[T]) Offer(v T) bool {
+	if t.pq.Size() < t.k {
+		t.pq.Push(v)
+		return true
+	}
+	if top, _ := t.pq.Peek(); !t.pq.heap.before(top, v) {
+		return false
+	}
+	t.pq.Replace(v)
+	return true
+}
+
+// Min returns the smallest value in the top k, which a new value has to beat
+// Returns ErrEmpty if nothing has been offered
+func (t *TopK[T]) Min() (T, error) {
+	return t.pq.Peek()
+}
+
+// Values returns the tracked values from largest to smallest
+func (t *TopK[T]) Values() []T {
+	values := t.pq.SortedSlice()
+	slices.Reverse(values)
+	return values
+}
+
+// Size returns the number of values held, at most k
+func (t *TopK[T]) Size() int {
+	return t.pq.Size()
+}
+
+// K returns the number of values tracked
+func (t *TopK[T]) K() int {
+	return t.k
+}
+
+// Reset forgets every value
+func (t *TopK[T]) Reset() {
+	t.pq.Clear()
+}
@@ -0,0 +1,76 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestTopK(t *testing.T) {
+	top := NewTopK(3, cmp.Compare[int])
+	if _, err := top.Min(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	for _, v := range []int{5, 1, 8} {
+		if !top.Offer(v) {
+			t.Errorf("Expected %d to be kept while filling up", v)
+		}
+	}
+	if top.Offer(1) {
+		t.Error("Expected a tie with the minimum to be rejected")
+	}
+	if !top.Offer(6) {
+		t.Error("Expected 6 to be kept")
+	}
+	if lowest, _ := top.Min(); lowest != 5 {
+		t.Errorf("Expected minimum 5, got %d", lowest)
+	}
+	if got := top.Values(); !slices.Equal(got, []int{8, 6, 5}) {
+		t.Errorf("Expected [8 6 5], got %v", got)
+	}
+	if top.Size() != 3 || top.K() != 3 {
+		t.Errorf("Expected size and k of 3, got %d and %d", top.Size(), top.K())
+	}
+
+	top.Reset()
+	if top.Size() != 0 || len(top.Values()) != 0 {
+		t.Errorf("Expected an empty tracker after Reset, got %v", top.Values())
+	}
+}
+
+func TestTopKMatchesSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	smallest := NewTopK(10, ReverseCompare(cmp.Compare[int]))
+	var all []int
+	for i := 0; i < 1000; i++ {
+		v := rng.Intn(10000)
+		smallest.Offer(v)
+		all = append(all, v)
+	}
+
+	slices.Sort(all)
+	if got := smallest.Values(); !slices.Equal(got, all[:10]) {
+		t.Errorf("Expected the 10 smallest %v, got %v", all[:10], got)
+	}
+}
+
+func TestTopKPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for k = 0")
+		}
+	}()
+	NewTopK(0, cmp.Compare[int])
+}
+
+// Benchmark tests
+func BenchmarkTopKOffer(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	top := NewTopK(100, cmp.Compare[int])
+	for i := 0; i < b.N; i++ {
+		top.Offer(rng.Int())
+	}
+}
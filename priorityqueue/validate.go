@@ -0,0 +1,108 @@
+package priorityqueue
+
+import "fmt"
+
+// Validate checks the heap invariants: every item knows its own index, no
+// child has higher priority than its parent and the compare function gives
+// opposite answers when the arguments of each parent/child pair are swapped
+// A failure usually means the compare function is inconsistent or an item's
+// value was changed without calling UpdateItem
+func (pq *PriorityQueue[T]) Validate() error {
+	h := pq.heap
+	deleted := 0
+	for i, item := range h.items {
+		if item == nil {
+			return fmt.Errorf("priority queue: nil item at index %d", i)
+		}
+		if item.deleted {
+			deleted++
+		}
+		if item.Index != i {
+			return fmt.Errorf("priority queue: item at index %d records index %d", i, item.Index)
+		}
+		if i > 0 {
+			// A broken compare function is the likelier root cause, so
+			// report it ahead of the heap order it breaks
+			parent := h.parent(i)
+			if err := checkAntisymmetric(h.compare, h.items[parent].Value, item.Value); err != nil {
+				return err
+			}
+			if h.Less(i, parent) {
+				return fmt.Errorf("priority queue: item %v at index %d outranks its parent %v at index %d",
+					item.Value, i, h.items[parent].Value, parent)
+			}
+		}
+	}
+	if deleted != pq.tombstones {
+		return fmt.Errorf("priority queue: %d tombstones in heap, %d recorded", deleted, pq.tombstones)
+	}
+	return nil
+}
+
+// debugCheck panics if the queue is invalid; it does nothing unless built
+// with the dsadebug tag or the queue was made WithValidation
+func (pq *PriorityQueue[T]) debugCheck() {
+	if !debugChecks && !pq.validate {
+		return
+	}
+	if err := pq.Validate(); err != nil {
+		panic(err)
+	}
+}
+
+// checkAntisymmetric reports an error if compare(a, b) and compare(b, a) do
+// not have opposite signs
+func checkAntisymmetric[T any](compare CompareFunc[T], a, b T) error {
+	if sign(compare(a, b)) != -sign(compare(b, a)) {
+		return fmt.Errorf("priority queue: compare(%v, %v) = %d but compare(%v, %v) = %d",
+			a, b, compare(a, b), b, a, compare(b, a))
+	}
+	return nil
+}
+
+func sign(c int) int {
+	switch {
+	case c < 0:
+		return -1
+	case c > 0:
+		return 1
+	}
+	return 0
+}
+
+// Validate checks the heap invariants of an indexed queue: the key index
+// agrees with the heap, no child has higher priority than its parent and
+// the compare function is antisymmetric on each parent/child pair
+func (pq *IndexedPriorityQueue[K, V]) Validate() error {
+	h := pq.heap
+	if len(h.pos) != len(h.entries) {
+		return fmt.Errorf("priority queue: %d keys indexed for %d entries", len(h.pos), len(h.entries))
+	}
+	for i, e := range h.entries {
+		if j, ok := h.pos[e.key]; !ok || j != i {
+			return fmt.Errorf("priority queue: key %v at index %d is indexed at %d", e.key, i, j)
+		}
+		if i > 0 {
+			parent := (i - 1) / 2
+			if err := checkAntisymmetric(h.compare, h.entries[parent].value, e.value); err != nil {
+				return err
+			}
+			if h.Less(i, parent) {
+				return fmt.Errorf("priority queue: key %v at index %d outranks its parent %v at index %d",
+					e.key, i, h.entries[parent].key, parent)
+			}
+		}
+	}
+	return nil
+}
+
+// debugCheck panics if the indexed queue is invalid; it does nothing unless
+// built with the dsadebug tag
+func (pq *IndexedPriorityQueue[K, V]) debugCheck() {
+	if !debugChecks {
+		return
+	}
+	if err := pq.Validate(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,203 @@
+package priorityqueue
+
+import (
+	"fmt"
+	"iter"
+)
+
+// ValueQueue is a priority queue that stores values directly in its heap
+// slice instead of behind an *Item, so a Push allocates nothing once the
+// slice has room, which matters for int or float workloads
+// It takes the same options as PriorityQueue but has no item handles, so
+// there is no UpdateItem, Remove or MarkDeleted
+type ValueQueue[T any] struct {
+	values    []T
+	compare   CompareFunc[T]
+	isMaxHeap bool
+	arity     int
+	validate  bool // see WithValidation
+}
+
+// NewMinValueQueue creates a min-priority value queue using compare
+func NewMinValueQueue[T any](compare CompareFunc[T], opts ...Option) *ValueQueue[T] {
+	return newValueQueue(compare, false, opts)
+}
+
+// NewMaxValueQueue creates a max-priority value queue using compare
+func NewMaxValueQueue[T any](compare CompareFunc[T], opts ...Option) *ValueQueue[T] {
+	return newValueQueue(compare, true, opts)
+}
+
+func newValueQueue[T any](compare CompareFunc[T], isMaxHeap bool, opts []Option) *ValueQueue[T] {
+	o := buildOptions(opts)
+	return &ValueQueue[T]{
+		values:    make([]T, 0, o.capacity),
+		compare:   compare,
+		isMaxHeap: isMaxHeap,
+		arity:     o.arity,
+		validate:  o.validate,
+	}
+}
+
+// less reports whether the value at i has strictly higher priority than the
+// value at j
+func (q *ValueQueue[T]) less(i, j int) bool {
+	c := q.compare(q.values[i], q.values[j])
+	if q.isMaxHeap {
+		return c > 0
+	}
+	return c < 0
+}
+
+// Push adds value to the queue
+func (q *ValueQueue[T]) Push(value T) {
+	q.values = append(q.values, value)
+	q.up(len(q.values) - 1)
+	q.debugCheck()
+}
+
+// Pop removes and returns the value with highest priority
+// Returns ErrEmpty if the queue is empty
+func (q *ValueQueue[T]) Pop() (T, error) {
+	var zero T
+	n := len(q.values) - 1
+	if n < 0 {
+		return zero, ErrEmpty
+	}
+	top := q.values[0]
+	q.values[0] = q.values[n]
+	q.values[n] = zero
+	q.values = q.values[:n]
+	q.down(0)
+	q.debugCheck()
+	return top, nil
+}
+
+// Peek returns the value with highest priority without removing it
+// Returns ErrEmpty if the queue is empty
+func (q *ValueQueue[T]) Peek() (T, error) {
+	if len(q.values) == 0 {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return q.values[0], nil
+}
+
+// PushPop pushes value and then pops the highest-priority value with a
+// single sift; value itself is returned when nothing in the queue outranks it
+func (q *ValueQueue[T]) PushPop(value T) T {
+	if len(q.values) == 0 {
+		return value
+	}
+	c := q.compare(q.values[0], value)
+	if (q.isMaxHeap && c <= 0) || (!q.isMaxHeap && c >= 0) {
+		return value
+	}
+	top := q.values[0]
+	q.values[0] = value
+	q.down(0)
+	q.debugCheck()
+	return top
+}
+
+// Size returns the number of values in the queue
+func (q *ValueQueue[T]) Size() int {
+	return len(q.values)
+}
+
+// IsEmpty returns true if the queue is empty
+func (q *ValueQueue[T]) IsEmpty() bool {
+	return len(q.values) == 0
+}
+
+// SetCompare switches the queue to a new compare function and rebuilds the
+// heap in O(n)
+func (q *ValueQueue[T]) SetCompare(compare CompareFunc[T]) {
+	q.compare = compare
+	if n := len(q.values); n > 1 {
+		for i := (n - 2) / q.arity; i >= 0; i-- {
+			q.down(i)
+		}
+	}
+	q.debugCheck()
+}
+
+// Cap returns the number of values the queue can hold before it has to grow
+func (q *ValueQueue[T]) Cap() int {
+	return cap(q.values)
+}
+
+// Clear removes every value, keeping the allocated capacity
+func (q *ValueQueue[T]) Clear() {
+	clear(q.values)
+	q.values = q.values[:0]
+}
+
+// All returns an iterator over the values in heap order, not priority order
+func (q *ValueQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range q.values {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Validate checks that no value has higher priority than its parent and
+// that compare is antisymmetric on each parent/child pair
+func (q *ValueQueue[T]) Validate() error {
+	for i := 1; i < len(q.values); i++ {
+		parent := (i - 1) / q.arity
+		if err := checkAntisymmetric(q.compare, q.values[parent], q.values[i]); err != nil {
+			return err
+		}
+		if q.less(i, parent) {
+			return fmt.Errorf("priority queue: value %v at index %d outranks its parent %v at index %d",
+				q.values[i], i, q.values[parent], parent)
+		}
+	}
+	return nil
+}
+
+// debugCheck panics if the queue is invalid; see PriorityQueue.debugCheck
+func (q *ValueQueue[T]) debugCheck() {
+	if !debugChecks && !q.validate {
+		return
+	}
+	if err := q.Validate(); err != nil {
+		panic(err)
+	}
+}
+
+func (q *ValueQueue[T]) up(j int) {
+	for j > 0 {
+		i := (j - 1) / q.arity
+		if !q.less(j, i) {
+			break
+		}
+		q.values[i], q.values[j] = q.values[j], q.values[i]
+		j = i
+	}
+}
+
+func (q *ValueQueue[T]) down(i int) {
+	n := len(q.values)
+	for {
+		first := i*q.arity + 1
+		if first >= n || first < 0 { // first < 0 after int overflow
+			return
+		}
+		best := first
+		for c := first + 1; c < first+q.arity && c < n; c++ {
+			if q.less(c, best) {
+				best = c
+			}
+		}
+		if !q.less(best, i) {
+			return
+		}
+		q.values[i], q.values[best] = q.values[best], q.values[i]
+		i = best
+	}
+}
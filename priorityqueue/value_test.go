@@ -0,0 +1,103 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestValueQueue(t *testing.T) {
+	for _, d := range []int{2, 4} {
+		t.Run(fmt.Sprintf("d=%d", d), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(int64(d)))
+			q := NewMinValueQueue(cmp.Compare[int], WithArity(d), WithValidation())
+			if _, err := q.Pop(); !errors.Is(err, ErrEmpty) {
+				t.Errorf("Expected ErrEmpty, got %v", err)
+			}
+
+			var want []int
+			for i := 0; i < 200; i++ {
+				v := rng.Intn(1000)
+				q.Push(v)
+				want = append(want, v)
+			}
+			slices.Sort(want)
+
+			if top, _ := q.Peek(); top != want[0] {
+				t.Errorf("Expected peek %d, got %d", want[0], top)
+			}
+			var got []int
+			for !q.IsEmpty() {
+				v, _ := q.Pop()
+				got = append(got, v)
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("Values popped out of order: %v", got)
+			}
+		})
+	}
+}
+
+func TestValueQueuePushPop(t *testing.T) {
+	q := NewMaxValueQueue(cmp.Compare[int])
+	if got := q.PushPop(4); got != 4 {
+		t.Errorf("Expected 4 from empty queue, got %d", got)
+	}
+	for _, v := range []int{3, 9, 5} {
+		q.Push(v)
+	}
+	if got := q.PushPop(7); got != 9 {
+		t.Errorf("Expected 9, got %d", got)
+	}
+	if got := q.PushPop(10); got != 10 {
+		t.Errorf("Expected 10, got %d", got)
+	}
+	if got := slices.Sorted(q.All()); !slices.Equal(got, []int{3, 5, 7}) {
+		t.Errorf("Expected [3 5 7], got %v", got)
+	}
+
+	q.Clear()
+	if q.Size() != 0 || q.Cap() == 0 {
+		t.Errorf("Expected empty queue with capacity kept, got size %d cap %d", q.Size(), q.Cap())
+	}
+}
+
+func TestValueQueueSetCompare(t *testing.T) {
+	q := NewMinValueQueue(cmp.Compare[int], WithArity(3), WithValidation())
+	for v := range 20 {
+		q.Push(v)
+	}
+	q.SetCompare(ReverseCompare(cmp.Compare[int]))
+	if top, _ := q.Pop(); top != 19 {
+		t.Errorf("Expected 19 after reversing the order, got %d", top)
+	}
+}
+
+// Benchmark tests
+func BenchmarkBackend(b *testing.B) {
+	b.Run("items", func(b *testing.B) {
+		rng := rand.New(rand.NewSource(1))
+		pq := NewOrderedMinQueue[int]()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			pq.Push(rng.Int())
+			if i%4 == 3 {
+				pq.Pop()
+			}
+		}
+	})
+	b.Run("values", func(b *testing.B) {
+		rng := rand.New(rand.NewSource(1))
+		q := NewMinValueQueue(cmp.Compare[int])
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			q.Push(rng.Int())
+			if i%4 == 3 {
+				q.Pop()
+			}
+		}
+	})
+}
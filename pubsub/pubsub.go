@@ -0,0 +1,329 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/anwar-arif/golang-dsa/queue"
+)
+
+var (
+	// ErrClosed is returned when receiving from or delivering to a closed subscription
+	ErrClosed = errors.New("subscription closed")
+	// ErrBrokerClosed is returned when using a broker after Close
+	ErrBrokerClosed = errors.New("broker closed")
+	// ErrInvalidPattern is returned for malformed subscription patterns
+	ErrInvalidPattern = errors.New("invalid subscription pattern")
+	// ErrInvalidTopic is returned when publishing to an empty or wildcard topic
+	ErrInvalidTopic = errors.New("invalid topic")
+)
+
+// Overflow decides what happens when a message arrives for a full subscription
+type Overflow int
+
+const (
+	// DropOldest evicts the oldest buffered message to make room
+	DropOldest Overflow = iota
+	// DropNewest discards the incoming message
+	DropNewest
+	// Block makes the publisher wait until the subscriber frees space
+	Block
+)
+
+// String returns the name of the overflow policy
+func (o Overflow) String() string {
+	switch o {
+	case DropOldest:
+		return "DropOldest"
+	case DropNewest:
+		return "DropNewest"
+	case Block:
+		return "Block"
+	}
+	return fmt.Sprintf("Overflow(%d)", int(o))
+}
+
+// Message is a payload delivered on a topic
+type Message[T any] struct {
+	Topic   string
+	Payload T
+}
+
+// Subscription is a bounded buffer of messages for one subscriber
+type Subscription[T any] struct {
+	id      uint64
+	broker  *Broker[T]
+	pattern []string
+
+	items     *queue.BoundedQueue[Message[T]]
+	discarded atomic.Uint64 // incoming messages dropped under DropNewest
+}
+
+// fullPolicy maps an overflow policy onto the queue's full policy; DropNewest
+// is a rejected push that the subscription counts as dropped
+func (o Overflow) fullPolicy() queue.FullPolicy {
+	switch o {
+	case DropOldest:
+		return queue.DropOldest
+	case DropNewest:
+		return queue.RejectWhenFull
+	}
+	return queue.BlockWhenFull
+}
+
+// deliver buffers a message according to the overflow policy
+// It reports whether the message was buffered
+func (s *Subscription[T]) deliver(ctx context.Context, msg Message[T]) (bool, error) {
+	switch err := s.items.PushContext(ctx, msg); {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, queue.ErrFull):
+		s.discarded.Add(1)
+		return false, nil
+	case errors.Is(err, queue.ErrClosed):
+		return false, ErrClosed
+	default:
+		return false, err
+	}
+}
+
+// Receive blocks until a message is available, the context is done or the
+// subscription is closed; buffered messages are still returned after Unsubscribe
+func (s *Subscription[T]) Receive(ctx context.Context) (Message[T], error) {
+	msg, err := s.items.PopContext(ctx)
+	if errors.Is(err, queue.ErrClosed) {
+		return msg, ErrClosed
+	}
+	return msg, err
+}
+
+// TryReceive returns a buffered message without blocking
+func (s *Subscription[T]) TryReceive() (Message[T], bool) {
+	msg, err := s.items.Pop()
+	return msg, err == nil
+}
+
+// Unsubscribe detaches the subscription from its broker
+// Blocked publishers are released and Receive returns ErrClosed once the buffer is drained
+func (s *Subscription[T]) Unsubscribe() {
+	s.broker.remove(s.id)
+	s.close()
+}
+
+func (s *Subscription[T]) close() {
+	s.items.Close()
+}
+
+// Len returns the number of buffered messages
+func (s *Subscription[T]) Len() int {
+	return s.items.Size()
+}
+
+// Dropped returns the number of messages lost to the overflow policy
+func (s *Subscription[T]) Dropped() uint64 {
+	return uint64(s.items.Dropped()) + s.discarded.Load()
+}
+
+// Pattern returns the topic pattern the subscription was created with
+func (s *Subscription[T]) Pattern() string {
+	return strings.Join(s.pattern, ".")
+}
+
+// Broker routes published messages to every subscription whose pattern matches
+// Topics are dot separated; in patterns "*" matches exactly one segment and a
+// trailing ">" matches one or more remaining segments
+type Broker[T any] struct {
+	mu     sync.RWMutex
+	subs   map[uint64]*Subscription[T]
+	nextID uint64
+	closed bool
+}
+
+// NewBroker creates a new broker with no subscriptions
+func NewBroker[T any]() *Broker[T] {
+	return &Broker[T]{
+		subs: make(map[uint64]*Subscription[T]),
+	}
+}
+
+// Subscribe registers a subscription for pattern that buffers up to capacity
+// messages and applies overflow when full
+func (b *Broker[T]) Subscribe(pattern string, capacity int, overflow Overflow) (*Subscription[T], error) {
+	segments, err := parsePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if capacity <= 0 {
+		return nil, fmt.Errorf("capacity must be positive, got %d", capacity)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, ErrBrokerClosed
+	}
+
+	b.nextID++
+	s := &Subscription[T]{
+		id:      b.nextID,
+		broker:  b,
+		pattern: segments,
+		items:   queue.NewBoundedQueue[Message[T]](capacity, queue.WithFullPolicy(overflow.fullPolicy())),
+	}
+	b.subs[s.id] = s
+
+	return s, nil
+}
+
+// Publish delivers payload to every matching subscription and returns how many buffered it
+// Only subscriptions with the Block policy can make Publish wait; if ctx ends
+// first the remaining subscriptions are skipped and the context error is returned
+func (b *Broker[T]) Publish(ctx context.Context, topic string, payload T) (int, error) {
+	if topic == "" || strings.ContainsAny(topic, "*>") {
+		return 0, ErrInvalidTopic
+	}
+	segments := strings.Split(topic, ".")
+
+	// Snapshot the matching subscriptions so a blocked delivery never holds the broker lock
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return 0, ErrBrokerClosed
+	}
+	targets := make([]*Subscription[T], 0)
+	for _, s := range b.subs {
+		if match(s.pattern, segments) {
+			targets = append(targets, s)
+		}
+	}
+	b.mu.RUnlock()
+
+	msg := Message[T]{Topic: topic, Payload: payload}
+	delivered := 0
+
+	for _, s := range targets {
+		ok, err := s.deliver(ctx, msg)
+		if errors.Is(err, ErrClosed) {
+			continue
+		}
+		if err != nil {
+			return delivered, err
+		}
+		if ok {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+// Subscribers returns the number of active subscriptions
+func (b *Broker[T]) Subscribers() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.subs)
+}
+
+// Close unsubscribes everyone and rejects further use of the broker
+func (b *Broker[T]) Close() {
+	b.mu.Lock()
+	subs := b.subs
+	b.subs = make(map[uint64]*Subscription[T])
+	b.closed = true
+	b.mu.Unlock()
+
+	for _, s := range subs {
+		s.close()
+	}
+}
+
+func (b *Broker[T]) remove(id uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subs, id)
+}
+
+// parsePattern splits and validates a subscription pattern
+func parsePattern(pattern string) ([]string, error) {
+	if pattern == "" {
+		return nil, ErrInvalidPattern
+	}
+
+	segments := strings.Split(pattern, ".")
+	for i, seg := range segments {
+		switch {
+		case seg == "":
+			return nil, ErrInvalidPattern
+		case seg == ">" && i != len(segments)-1:
+			return nil, ErrInvalidPattern
+		case seg != "*" && seg != ">" && strings.ContainsAny(seg, "*>"):
+			return nil, ErrInvalidPattern
+		}
+	}
+
+	return segments, nil
+}
+
+// match reports whether a topic matches a pattern
+func match(pattern, topic []string) bool {
+	for i, seg := range pattern {
+		if seg == ">" {
+			return len(topic) > i
+		}
+		if i >= len(topic) {
+			return false
+		}
+		if seg != "*" && seg != topic[i] {
+			return false
+		}
+	}
+	return len(pattern) == len(topic)
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Pub/Sub Examples ===")
+
+	ctx := context.Background()
+	broker := NewBroker[string]()
+	defer broker.Close()
+
+	// Example 1: Exact and wildcard subscriptions
+	fmt.Println("1. Topic Wildcards:")
+	orders, _ := broker.Subscribe("orders.created", 10, Block)
+	all, _ := broker.Subscribe("orders.>", 10, Block)
+	anyCreated, _ := broker.Subscribe("*.created", 10, Block)
+
+	broker.Publish(ctx, "orders.created", "order #1")
+	broker.Publish(ctx, "orders.shipped", "order #1")
+	broker.Publish(ctx, "users.created", "alice")
+
+	fmt.Printf("  orders.created received %d\n", orders.Len())
+	fmt.Printf("  orders.> received %d\n", all.Len())
+	fmt.Printf("  *.created received %d\n", anyCreated.Len())
+
+	// Example 2: Drop-oldest keeps the latest messages
+	fmt.Println("\n2. Drop Oldest (capacity 2):")
+	latest, _ := broker.Subscribe("prices", 2, DropOldest)
+	for _, p := range []string{"100", "101", "102"} {
+		broker.Publish(ctx, "prices", p)
+	}
+	for latest.Len() > 0 {
+		msg, _ := latest.Receive(ctx)
+		fmt.Printf("  %s: %s\n", msg.Topic, msg.Payload)
+	}
+	fmt.Printf("  Dropped: %d\n", latest.Dropped())
+
+	// Example 3: Unsubscribe
+	fmt.Println("\n3. Unsubscribe:")
+	orders.Unsubscribe()
+	n, _ := broker.Publish(ctx, "orders.created", "order #2")
+	fmt.Printf("  Delivered to %d subscribers after unsubscribe\n", n)
+}
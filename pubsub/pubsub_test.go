@@ -0,0 +1,307 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPatternMatching(t *testing.T) {
+	tests := []struct {
+		pattern string
+		topic   string
+		matches bool
+	}{
+		{"orders", "orders", true},
+		{"orders", "users", false},
+		{"orders.created", "orders.created", true},
+		{"orders.created", "orders", false},
+		{"orders.*", "orders.created", true},
+		{"orders.*", "orders.created.eu", false},
+		{"*.created", "users.created", true},
+		{"orders.>", "orders.created", true},
+		{"orders.>", "orders.created.eu", true},
+		{"orders.>", "orders", false},
+		{">", "anything.at.all", true},
+		{"*.*.eu", "orders.created.eu", true},
+	}
+
+	for _, tt := range tests {
+		pattern, err := parsePattern(tt.pattern)
+		if err != nil {
+			t.Errorf("Unexpected error parsing %q: %v", tt.pattern, err)
+			continue
+		}
+		topic := splitTopic(tt.topic)
+		if got := match(pattern, topic); got != tt.matches {
+			t.Errorf("match(%q, %q) = %t, expected %t", tt.pattern, tt.topic, got, tt.matches)
+		}
+	}
+}
+
+func splitTopic(topic string) []string {
+	segments, _ := parsePattern(topic)
+	return segments
+}
+
+func TestInvalidPatterns(t *testing.T) {
+	b := NewBroker[int]()
+
+	for _, p := range []string{"", "a..b", "a.>.b", "a*", "a.b>"} {
+		if _, err := b.Subscribe(p, 1, Block); !errors.Is(err, ErrInvalidPattern) {
+			t.Errorf("Expected ErrInvalidPattern for %q, got %v", p, err)
+		}
+	}
+
+	if _, err := b.Subscribe("a", 0, Block); err == nil {
+		t.Error("Expected error for zero capacity")
+	}
+
+	for _, topic := range []string{"", "a.*", "a.>"} {
+		if _, err := b.Publish(context.Background(), topic, 1); !errors.Is(err, ErrInvalidTopic) {
+			t.Errorf("Expected ErrInvalidTopic for %q, got %v", topic, err)
+		}
+	}
+}
+
+func TestPublishReceive(t *testing.T) {
+	ctx := context.Background()
+	b := NewBroker[int]()
+
+	exact, _ := b.Subscribe("metrics.cpu", 10, Block)
+	wild, _ := b.Subscribe("metrics.*", 10, Block)
+
+	n, err := b.Publish(ctx, "metrics.cpu", 42)
+	if err != nil || n != 2 {
+		t.Errorf("Expected delivery to 2 subscribers, got %d with error %v", n, err)
+	}
+
+	n, _ = b.Publish(ctx, "metrics.mem", 7)
+	if n != 1 {
+		t.Errorf("Expected delivery to 1 subscriber, got %d", n)
+	}
+
+	msg, err := exact.Receive(ctx)
+	if err != nil || msg.Payload != 42 || msg.Topic != "metrics.cpu" {
+		t.Errorf("Expected metrics.cpu=42, got %v with error %v", msg, err)
+	}
+
+	// Wildcard subscriber sees both messages in order
+	for _, expected := range []int{42, 7} {
+		msg, err := wild.Receive(ctx)
+		if err != nil || msg.Payload != expected {
+			t.Errorf("Expected %d, got %v with error %v", expected, msg.Payload, err)
+		}
+	}
+
+	if _, ok := wild.TryReceive(); ok {
+		t.Error("Expected empty subscription")
+	}
+}
+
+func TestOverflowDropOldest(t *testing.T) {
+	ctx := context.Background()
+	b := NewBroker[int]()
+	s, _ := b.Subscribe("t", 3, DropOldest)
+
+	for i := 1; i <= 5; i++ {
+		b.Publish(ctx, "t", i)
+	}
+
+	if s.Dropped() != 2 {
+		t.Errorf("Expected 2 dropped, got %d", s.Dropped())
+	}
+
+	for _, expected := range []int{3, 4, 5} {
+		msg, _ := s.Receive(ctx)
+		if msg.Payload != expected {
+			t.Errorf("Expected %d, got %d", expected, msg.Payload)
+		}
+	}
+}
+
+func TestOverflowDropNewest(t *testing.T) {
+	ctx := context.Background()
+	b := NewBroker[int]()
+	s, _ := b.Subscribe("t", 3, DropNewest)
+
+	for i := 1; i <= 5; i++ {
+		n, _ := b.Publish(ctx, "t", i)
+		if i > 3 && n != 0 {
+			t.Errorf("Expected message %d to be dropped", i)
+		}
+	}
+
+	if s.Dropped() != 2 {
+		t.Errorf("Expected 2 dropped, got %d", s.Dropped())
+	}
+
+	for _, expected := range []int{1, 2, 3} {
+		msg, _ := s.Receive(ctx)
+		if msg.Payload != expected {
+			t.Errorf("Expected %d, got %d", expected, msg.Payload)
+		}
+	}
+}
+
+func TestOverflowBlock(t *testing.T) {
+	b := NewBroker[int]()
+	s, _ := b.Subscribe("t", 1, Block)
+
+	b.Publish(context.Background(), "t", 1)
+
+	// A full blocking subscription holds the publisher until the context ends
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := b.Publish(ctx, "t", 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+
+	// ...or until the subscriber makes room
+	published := make(chan struct{})
+	go func() {
+		b.Publish(context.Background(), "t", 3)
+		close(published)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	msg, _ := s.Receive(context.Background())
+	if msg.Payload != 1 {
+		t.Errorf("Expected 1, got %d", msg.Payload)
+	}
+
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Publisher was not released")
+	}
+
+	msg, _ = s.Receive(context.Background())
+	if msg.Payload != 3 {
+		t.Errorf("Expected 3, got %d", msg.Payload)
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	ctx := context.Background()
+	b := NewBroker[string]()
+	s, _ := b.Subscribe("t", 1, Block)
+
+	b.Publish(ctx, "t", "kept")
+
+	// A publisher blocked on the full subscription is released by Unsubscribe
+	released := make(chan int)
+	go func() {
+		n, _ := b.Publish(ctx, "t", "blocked")
+		released <- n
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	s.Unsubscribe()
+
+	select {
+	case n := <-released:
+		if n != 0 {
+			t.Errorf("Expected 0 deliveries, got %d", n)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Publisher was not released by Unsubscribe")
+	}
+
+	if b.Subscribers() != 0 {
+		t.Errorf("Expected 0 subscribers, got %d", b.Subscribers())
+	}
+
+	// Buffered messages can still be drained
+	msg, err := s.Receive(ctx)
+	if err != nil || msg.Payload != "kept" {
+		t.Errorf("Expected buffered message, got %v with error %v", msg, err)
+	}
+
+	if _, err := s.Receive(ctx); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+}
+
+func TestReceiveWakesOnUnsubscribe(t *testing.T) {
+	b := NewBroker[int]()
+	s, _ := b.Subscribe("t", 1, Block)
+
+	result := make(chan error)
+	go func() {
+		_, err := s.Receive(context.Background())
+		result <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	s.Unsubscribe()
+
+	if err := <-result; !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+}
+
+func TestBrokerClose(t *testing.T) {
+	b := NewBroker[int]()
+	s, _ := b.Subscribe("t", 1, Block)
+
+	b.Close()
+
+	if _, err := s.Receive(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+	if _, err := b.Publish(context.Background(), "t", 1); !errors.Is(err, ErrBrokerClosed) {
+		t.Errorf("Expected ErrBrokerClosed, got %v", err)
+	}
+	if _, err := b.Subscribe("t", 1, Block); !errors.Is(err, ErrBrokerClosed) {
+		t.Errorf("Expected ErrBrokerClosed, got %v", err)
+	}
+}
+
+func TestConcurrentPublishers(t *testing.T) {
+	ctx := context.Background()
+	b := NewBroker[int]()
+	s, _ := b.Subscribe("t", 4, Block)
+
+	const publishers, perPublisher = 8, 100
+
+	var wg sync.WaitGroup
+	for p := 0; p < publishers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perPublisher; i++ {
+				b.Publish(ctx, "t", i)
+			}
+		}()
+	}
+
+	received := 0
+	for received < publishers*perPublisher {
+		if _, err := s.Receive(ctx); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		received++
+	}
+	wg.Wait()
+
+	if s.Dropped() != 0 {
+		t.Errorf("Expected no drops with Block policy, got %d", s.Dropped())
+	}
+}
+
+// Benchmark tests
+func BenchmarkPublish(b *testing.B) {
+	ctx := context.Background()
+	broker := NewBroker[int]()
+	for i := 0; i < 10; i++ {
+		broker.Subscribe("events.*", 1024, DropOldest)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		broker.Publish(ctx, "events.click", i)
+	}
+}
@@ -0,0 +1,178 @@
+package queue
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Number is satisfied by the built-in integer and floating-point types
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Window aggregates the last n values pushed, for smoothing metrics with a
+// moving average
+// The values live in a RingQueue; Sum and Mean come from a running sum and
+// Min and Max from monotonic deques, so every query is O(1) and Push is
+// amortized O(1)
+// The running sum is kept in int64, uint64 or float64 so small integer types
+// such as int8 do not overflow it
+// A running float sum can drift by rounding error over many pushes; Reset
+// starts it afresh
+type Window[T Number] struct {
+	n      int
+	values *RingQueue[T]
+	sum    wideSum[T]
+	max    *WindowTracker[T]
+	min    *WindowTracker[T]
+}
+
+// NewWindow creates a window over the last n values
+// Panics if n < 1
+func NewWindow[T Number](n int) *Window[T] {
+	if n < 1 {
+		panic(fmt.Sprintf("queue: window size must be at least 1, got %d", n))
+	}
+	return &Window[T]{
+		n:      n,
+		values: NewRingQueue[T](n),
+		sum:    newWideSum[T](),
+		max:    NewMaxWindow(n, cmp.Compare[T]),
+		min:    NewMinWindow(n, cmp.Compare[T]),
+	}
+}
+
+// Push adds value to the window, evicting the oldest value once the window
+// holds n values
+func (w *Window[T]) Push(value T) {
+	if w.values.Size() == w.n {
+		oldest, _ := w.values.Pop()
+		w.sum.sub(oldest)
+	}
+	w.values.Push(value)
+	w.sum.add(value)
+	w.max.Push(value)
+	w.min.Push(value)
+}
+
+// Sum returns the sum of the values in the window, zero when it is empty
+// The result wraps if the total does not fit in T; Mean does not
+func (w *Window[T]) Sum() T {
+	return w.sum.value()
+}
+
+// Mean returns the average of the values in the window
+// Returns ErrEmpty if the window is empty
+func (w *Window[T]) Mean() (float64, error) {
+	if w.values.IsEmpty() {
+		return 0, ErrEmpty
+	}
+	return w.sum.float() / float64(w.values.Size()), nil
+}
+
+// Min returns the smallest value in the window
+// Returns ErrEmpty if the window is empty
+func (w *Window[T]) Min() (T, error) {
+	return w.min.Value()
+}
+
+// Max returns the largest value in the window
+// Returns ErrEmpty if the window is empty
+func (w *Window[T]) Max() (T, error) {
+	return w.max.Value()
+}
+
+// Size returns the number of values in the window, at most Cap
+func (w *Window[T]) Size() int {
+	return w.values.Size()
+}
+
+// Cap returns the number of values the window aggregates over
+func (w *Window[T]) Cap() int {
+	return w.n
+}
+
+// Full returns true once the window holds Cap values
+func (w *Window[T]) Full() bool {
+	return w.values.Size() == w.n
+}
+
+// Reset empties the window
+func (w *Window[T]) Reset() {
+	w.values.Clear()
+	w.sum.reset()
+	w.max.Reset()
+	w.min.Reset()
+}
+
+// ToSlice returns the values in the window from oldest to newest
+func (w *Window[T]) ToSlice() []T {
+	return w.values.ToSlice()
+}
+
+// wideSum is a running sum of T held in the widest type of the same kind:
+// int64 for signed integers, uint64 for unsigned ones and float64 for floats
+type wideSum[T Number] struct {
+	isFloat, signed bool
+	i               int64
+	u               uint64
+	f               float64
+}
+
+func newWideSum[T Number]() wideSum[T] {
+	var zero T
+	one := zero + 1
+	return wideSum[T]{isFloat: one/2 != 0, signed: zero-1 < 0}
+}
+
+func (s *wideSum[T]) add(v T) {
+	switch {
+	case s.isFloat:
+		s.f += float64(v)
+	case s.signed:
+		s.i += int64(v)
+	default:
+		s.u += uint64(v)
+	}
+}
+
+func (s *wideSum[T]) sub(v T) {
+	switch {
+	case s.isFloat:
+		s.f -= float64(v)
+	case s.signed:
+		s.i -= int64(v)
+	default:
+		s.u -= uint64(v)
+	}
+}
+
+// value converts the sum back to T
+func (s *wideSum[T]) value() T {
+	switch {
+	case s.isFloat:
+		return T(s.f)
+	case s.signed:
+		return T(s.i)
+	default:
+		return T(s.u)
+	}
+}
+
+// float returns the sum as a float64 without converting through T
+func (s *wideSum[T]) float() float64 {
+	switch {
+	case s.isFloat:
+		return s.f
+	case s.signed:
+		return float64(s.i)
+	default:
+		return float64(s.u)
+	}
+}
+
+func (s *wideSum[T]) reset() {
+	s.i, s.u, s.f = 0, 0, 0
+}
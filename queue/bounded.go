@@ -0,0 +1,312 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/anwar-arif/golang-dsa/internal/wake"
+)
+
+// FullPolicy decides what BoundedQueue.Push does when the queue is full
+type FullPolicy int
+
+const (
+	// RejectWhenFull makes Push return ErrFull
+	RejectWhenFull FullPolicy = iota
+	// DropOldest makes Push discard the front item to make room, so the
+	// queue keeps the most recent items
+	DropOldest
+	// BlockWhenFull makes Push wait until a Pop frees a slot
+	BlockWhenFull
+)
+
+// String returns the name of the policy
+func (p FullPolicy) String() string {
+	switch p {
+	case RejectWhenFull:
+		return "reject"
+	case DropOldest:
+		return "drop-oldest"
+	case BlockWhenFull:
+		return "block"
+	}
+	return "unknown"
+}
+
+// Option configures a BoundedQueue at construction
+type Option func(*options)
+
+type options struct {
+	policy FullPolicy
+}
+
+// WithFullPolicy sets what Push does when the queue is full; the default is
+// RejectWhenFull
+func WithFullPolicy(p FullPolicy) Option {
+	return func(o *options) { o.policy = p }
+}
+
+// BoundedQueue is a thread-safe FIFO queue holding at most a fixed number of
+// items in a preallocated ring, so its memory use never grows
+// PopContext blocks until an item arrives and Close ends the queue, which
+// makes it the blocking buffer the channel-like structures build on
+type BoundedQueue[T any] struct {
+	mu        sync.Mutex
+	ring      *RingQueue[T]
+	limit     int
+	policy    FullPolicy
+	dropped   int64
+	highWater int
+	closed    bool
+	notFull   wake.Signal   // wakes one blocked Push
+	notEmpty  wake.Signal   // wakes one blocked PopContext
+	done      chan struct{} // closed by Close to release every waiter
+}
+
+// NewBoundedQueue creates a queue that holds at most capacity items
+// Panics if capacity < 1
+func NewBoundedQueue[T any](capacity int, opts ...Option) *BoundedQueue[T] {
+	if capacity < 1 {
+		panic(fmt.Sprintf("queue: bounded capacity must be at least 1, got %d", capacity))
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &BoundedQueue[T]{
+		ring:     NewRingQueue[T](capacity),
+		limit:    capacity,
+		policy:   o.policy,
+		notFull:  wake.New(),
+		notEmpty: wake.New(),
+		done:     make(chan struct{}),
+	}
+}
+
+// push adds value to the ring; callers must hold mu and have checked for room
+func (q *BoundedQueue[T]) push(value T) {
+	q.ring.Push(value)
+	if size := q.ring.Size(); size > q.highWater {
+		q.highWater = size
+	}
+	if q.ring.Size() < q.limit {
+		// Pass the wake-up on to the next waiter
+		q.notFull.Notify()
+	}
+	q.notEmpty.Notify()
+}
+
+// Push adds an item to the rear of the queue, applying the full policy when
+// there is no room; with BlockWhenFull it waits as long as it takes
+// Returns ErrFull under RejectWhenFull, and ErrClosed once Close has been called
+func (q *BoundedQueue[T]) Push(value T) error {
+	return q.PushContext(context.Background(), value)
+}
+
+// PushContext is like Push but gives up waiting under BlockWhenFull when ctx
+// is done, returning its error
+func (q *BoundedQueue[T]) PushContext(ctx context.Context, value T) error {
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return ErrClosed
+		}
+		if q.ring.Size() < q.limit {
+			q.push(value)
+			q.mu.Unlock()
+			return nil
+		}
+
+		switch q.policy {
+		case RejectWhenFull:
+			q.mu.Unlock()
+			return ErrFull
+		case DropOldest:
+			q.ring.Pop()
+			q.dropped++
+			q.push(value)
+			q.mu.Unlock()
+			return nil
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-q.done:
+		case <-q.notFull:
+		}
+	}
+}
+
+// TryPush adds an item only if there is room, whatever the full policy, so
+// it never blocks or drops
+// Returns ErrFull if the queue is full and ErrClosed once Close has been called
+func (q *BoundedQueue[T]) TryPush(value T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+	if q.ring.Size() >= q.limit {
+		return ErrFull
+	}
+	q.push(value)
+	return nil
+}
+
+// pop removes the front item and wakes the next waiters; callers must hold mu
+func (q *BoundedQueue[T]) pop() (T, error) {
+	v, err := q.ring.Pop()
+	if err == nil {
+		q.notFull.Notify()
+		if !q.ring.IsEmpty() {
+			// Pass the wake-up on to the next waiter
+			q.notEmpty.Notify()
+		}
+	}
+	return v, err
+}
+
+// Pop removes and returns the item from the front of the queue
+// Returns ErrEmpty if the queue is empty
+func (q *BoundedQueue[T]) Pop() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.pop()
+}
+
+// PopContext removes and returns the front item, waiting for one to arrive
+// Items pushed before Close are still returned; after that it returns
+// ErrClosed, or ctx's error if ctx is done first
+func (q *BoundedQueue[T]) PopContext(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		v, err := q.pop()
+		closed := q.closed
+		q.mu.Unlock()
+
+		if err == nil {
+			return v, nil
+		}
+		if closed {
+			return v, ErrClosed
+		}
+		select {
+		case <-ctx.Done():
+			return v, ctx.Err()
+		case <-q.done:
+		case <-q.notEmpty:
+		}
+	}
+}
+
+// Close stops the queue accepting items and releases every blocked Push and
+// PopContext; items already queued can still be popped
+// Closing an already closed queue is a no-op
+func (q *BoundedQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.closed {
+		q.closed = true
+		close(q.done)
+	}
+}
+
+// IsClosed returns true once Close has been called
+func (q *BoundedQueue[T]) IsClosed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.closed
+}
+
+// Front returns the front item without removing it
+// Returns ErrEmpty if the queue is empty
+func (q *BoundedQueue[T]) Front() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.ring.Front()
+}
+
+// Size returns the number of items in the queue
+func (q *BoundedQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.ring.Size()
+}
+
+// IsEmpty returns true if the queue is empty
+func (q *BoundedQueue[T]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+// IsFull returns true if the queue holds its capacity
+func (q *BoundedQueue[T]) IsFull() bool {
+	return q.Size() == q.limit
+}
+
+// Cap returns the most items the queue holds
+func (q *BoundedQueue[T]) Cap() int {
+	return q.limit
+}
+
+// Policy returns what Push does when the queue is full
+func (q *BoundedQueue[T]) Policy() FullPolicy {
+	return q.policy
+}
+
+// Dropped returns the number of items discarded under DropOldest
+func (q *BoundedQueue[T]) Dropped() int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.dropped
+}
+
+// HighWaterMark returns the most items the queue has held at once since it
+// was created or the mark was last reset
+func (q *BoundedQueue[T]) HighWaterMark() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.highWater
+}
+
+// ResetHighWaterMark sets the high-water mark back to the current size
+func (q *BoundedQueue[T]) ResetHighWaterMark() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.highWater = q.ring.Size()
+}
+
+// Clear removes all items from the queue
+func (q *BoundedQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.ring.Clear()
+	q.notFull.Notify()
+}
+
+// ToSlice returns a snapshot of the items from front to rear
+func (q *BoundedQueue[T]) ToSlice() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.ring.ToSlice()
+}
+
+// String returns a string representation of the queue
+func (q *BoundedQueue[T]) String() string {
+	items := q.ToSlice()
+	return fmt.Sprintf("BoundedQueue{size: %d/%d, policy: %s, front->rear: %v}", len(items), q.limit, q.policy, items)
+}
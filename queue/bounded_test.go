@@ -0,0 +1,149 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBoundedQueueReject(t *testing.T) {
+	q := NewBoundedQueue[int](2)
+	q.Push(1)
+	q.Push(2)
+	if err := q.Push(3); !errors.Is(err, ErrFull) {
+		t.Errorf("Expected ErrFull, got %v", err)
+	}
+	if !q.IsFull() || !slices.Equal(q.ToSlice(), []int{1, 2}) {
+		t.Errorf("Unexpected queue %v", q)
+	}
+
+	q.Pop()
+	if err := q.Push(3); err != nil {
+		t.Errorf("Expected room after Pop, got %v", err)
+	}
+}
+
+func TestBoundedQueueDropOldest(t *testing.T) {
+	q := NewBoundedQueue[int](3, WithFullPolicy(DropOldest))
+	for i := 1; i <= 5; i++ {
+		if err := q.Push(i); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if !slices.Equal(q.ToSlice(), []int{3, 4, 5}) {
+		t.Errorf("Expected the newest items [3 4 5], got %v", q.ToSlice())
+	}
+	if q.Dropped() != 2 {
+		t.Errorf("Expected 2 dropped items, got %d", q.Dropped())
+	}
+}
+
+func TestBoundedQueueBlock(t *testing.T) {
+	q := NewBoundedQueue[int](1, WithFullPolicy(BlockWhenFull))
+	q.Push(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := q.PushContext(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		q.Push(2)
+		q.Push(3)
+	}()
+
+	var got []int
+	for len(got) < 3 {
+		if v, err := q.Pop(); err == nil {
+			got = append(got, v)
+		} else {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	wg.Wait()
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", got)
+	}
+}
+
+func TestBoundedQueuePopContextAndClose(t *testing.T) {
+	q := NewBoundedQueue[int](2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.PopContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	got := make(chan int)
+	go func() {
+		v, _ := q.PopContext(context.Background())
+		got <- v
+	}()
+	q.Push(7)
+	if v := <-got; v != 7 {
+		t.Errorf("Expected the blocked PopContext to receive 7, got %d", v)
+	}
+
+	q.Push(1)
+	q.Close()
+	q.Close()
+	if err := q.Push(2); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed pushing after Close, got %v", err)
+	}
+	if v, err := q.PopContext(context.Background()); err != nil || v != 1 {
+		t.Errorf("Expected the buffered 1 after Close, got %d with error %v", v, err)
+	}
+	if _, err := q.PopContext(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed once drained, got %v", err)
+	}
+}
+
+func TestBoundedQueueCloseReleasesBlockedPush(t *testing.T) {
+	q := NewBoundedQueue[int](1, WithFullPolicy(BlockWhenFull))
+	q.Push(1)
+
+	result := make(chan error)
+	go func() { result <- q.Push(2) }()
+	time.Sleep(5 * time.Millisecond)
+	q.Close()
+
+	if err := <-result; !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected the blocked Push to return ErrClosed, got %v", err)
+	}
+}
+
+func TestBoundedQueueTryPushAndHighWaterMark(t *testing.T) {
+	q := NewBoundedQueue[int](2, WithFullPolicy(BlockWhenFull))
+	if q.TryPush(1) != nil || q.TryPush(2) != nil {
+		t.Fatal("Expected TryPush to succeed while there is room")
+	}
+	if err := q.TryPush(3); !errors.Is(err, ErrFull) {
+		t.Errorf("Expected ErrFull whatever the policy, got %v", err)
+	}
+	if q.HighWaterMark() != 2 {
+		t.Errorf("Expected high-water mark 2, got %d", q.HighWaterMark())
+	}
+
+	q.Pop()
+	q.ResetHighWaterMark()
+	if q.HighWaterMark() != 1 {
+		t.Errorf("Expected high-water mark 1 after reset, got %d", q.HighWaterMark())
+	}
+}
+
+// Benchmark tests
+func BenchmarkBoundedQueueDropOldest(b *testing.B) {
+	q := NewBoundedQueue[int](1024, WithFullPolicy(DropOldest))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		q.Push(i)
+	}
+}
@@ -0,0 +1,163 @@
+package queue
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/anwar-arif/golang-dsa/internal/wake"
+	"github.com/anwar-arif/golang-dsa/priorityqueue"
+)
+
+// ErrNotReady is returned by DelayQueue.Pop when items are queued but none
+// is due yet
+var ErrNotReady = errors.New("queue: no item is ready")
+
+// delayed is an item with the time it becomes visible
+type delayed[T any] struct {
+	value   T
+	readyAt time.Time
+	seq     uint64 // push order, keeps items due at the same time FIFO
+}
+
+func byReadyAt[T any](a, b delayed[T]) int {
+	if c := a.readyAt.Compare(b.readyAt); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.seq, b.seq)
+}
+
+// DelayQueue is a thread-safe queue whose items only become visible once
+// their ready time has arrived; items leave in order of ready time
+// The items are held in a min priority queue keyed on ready time
+type DelayQueue[T any] struct {
+	mu   sync.Mutex
+	pq   *priorityqueue.PriorityQueue[delayed[T]]
+	seq  uint64
+	wake wake.Signal // notified when the earliest ready time may have changed
+	now  func() time.Time
+}
+
+// NewDelayQueue creates an empty delay queue
+func NewDelayQueue[T any]() *DelayQueue[T] {
+	return &DelayQueue[T]{
+		pq:   priorityqueue.NewMinQueue(byReadyAt[T]),
+		wake: wake.New(),
+		now:  time.Now,
+	}
+}
+
+// Push adds value, to become visible at readyAt
+func (q *DelayQueue[T]) Push(value T, readyAt time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.seq++
+	q.pq.Push(delayed[T]{value: value, readyAt: readyAt, seq: q.seq})
+	q.wake.Notify()
+}
+
+// PushAfter adds value, to become visible once d has elapsed
+func (q *DelayQueue[T]) PushAfter(value T, d time.Duration) {
+	q.Push(value, q.now().Add(d))
+}
+
+// Pop removes and returns the earliest item whose ready time has arrived
+// Returns ErrEmpty if the queue is empty, or ErrNotReady if no item is due
+func (q *DelayQueue[T]) Pop() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	v, _, err := q.pop()
+	return v, err
+}
+
+// pop takes the earliest item if it is due, otherwise reporting when it
+// will be; callers must hold mu
+func (q *DelayQueue[T]) pop() (T, time.Time, error) {
+	var zero T
+	top, err := q.pq.Peek()
+	if err != nil {
+		return zero, time.Time{}, ErrEmpty
+	}
+	if top.readyAt.After(q.now()) {
+		return zero, top.readyAt, ErrNotReady
+	}
+	q.pq.Pop()
+	if !q.pq.IsEmpty() {
+		// Let the next waiter look at the new earliest item
+		q.wake.Notify()
+	}
+	return top.value, top.readyAt, nil
+}
+
+// PopWait removes and returns the earliest item, waiting until its ready
+// time arrives; items pushed meanwhile with an earlier time are seen
+// Returns ctx's error if it is done first
+func (q *DelayQueue[T]) PopWait(ctx context.Context) (T, error) {
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		q.mu.Lock()
+		v, readyAt, err := q.pop()
+		q.mu.Unlock()
+		if err == nil {
+			return v, nil
+		}
+
+		var fire <-chan time.Time
+		if errors.Is(err, ErrNotReady) {
+			if timer == nil {
+				timer = time.NewTimer(readyAt.Sub(q.now()))
+			} else {
+				timer.Reset(readyAt.Sub(q.now()))
+			}
+			fire = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-q.wake:
+		case <-fire:
+		}
+	}
+}
+
+// NextReady returns the earliest ready time in the queue
+func (q *DelayQueue[T]) NextReady() (time.Time, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	top, err := q.pq.Peek()
+	return top.readyAt, err == nil
+}
+
+// Size returns the number of items, due or not
+func (q *DelayQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.pq.Size()
+}
+
+// IsEmpty returns true if the queue is empty
+func (q *DelayQueue[T]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+// Clear removes every item
+func (q *DelayQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.pq.Clear()
+}
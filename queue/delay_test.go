@@ -0,0 +1,81 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDelayQueuePop(t *testing.T) {
+	q := NewDelayQueue[string]()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	q.now = func() time.Time { return now }
+
+	if _, err := q.Pop(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	q.Push("later", now.Add(time.Minute))
+	q.Push("first", now.Add(time.Second))
+	q.Push("second", now.Add(time.Second))
+	if _, err := q.Pop(); !errors.Is(err, ErrNotReady) {
+		t.Errorf("Expected ErrNotReady, got %v", err)
+	}
+	if next, _ := q.NextReady(); !next.Equal(now.Add(time.Second)) {
+		t.Errorf("Expected next ready time %v, got %v", now.Add(time.Second), next)
+	}
+
+	now = now.Add(2 * time.Second)
+	for _, expected := range []string{"first", "second"} {
+		if v, err := q.Pop(); err != nil || v != expected {
+			t.Errorf("Expected %s, got %s (%v)", expected, v, err)
+		}
+	}
+	if _, err := q.Pop(); !errors.Is(err, ErrNotReady) {
+		t.Errorf("Expected later to still be waiting, got %v", err)
+	}
+	if q.Size() != 1 {
+		t.Errorf("Expected 1 item left, got %d", q.Size())
+	}
+}
+
+func TestDelayQueuePopWait(t *testing.T) {
+	q := NewDelayQueue[int]()
+	q.PushAfter(2, time.Hour)
+
+	result := make(chan int)
+	go func() {
+		v, _ := q.PopWait(context.Background())
+		result <- v
+	}()
+
+	// An earlier item pushed while waiting is picked up
+	time.Sleep(5 * time.Millisecond)
+	start := time.Now()
+	q.PushAfter(1, 20*time.Millisecond)
+	if v := <-result; v != 1 {
+		t.Errorf("Expected 1, got %d", v)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("Expected PopWait to wait for the ready time, returned after %v", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.PopWait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+// Benchmark tests
+func BenchmarkDelayQueue(b *testing.B) {
+	q := NewDelayQueue[int]()
+	past := time.Now().Add(-time.Hour)
+	for i := 0; i < b.N; i++ {
+		q.Push(i, past.Add(time.Duration(i%100)))
+		if i%2 == 1 {
+			q.Pop()
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package queue
+
+import "github.com/anwar-arif/golang-dsa/fn"
+
+// Map returns a new queue of f applied to each item of q, in the same order
+// q is left unchanged
+func Map[T, U any](q *Queue[T], f func(T) U) *Queue[U] {
+	result := NewQueue[U]()
+	for v := range fn.Map(q.All(), f) {
+		result.Push(v)
+	}
+	return result
+}
+
+// Filter returns a new queue of the items of q for which pred holds, in the
+// same order and sharing q's allocator
+// q is left unchanged
+func Filter[T any](q *Queue[T], pred func(T) bool) *Queue[T] {
+	result := NewQueueWithAllocator(q.alloc)
+	for v := range fn.Filter(q.All(), pred) {
+		result.Push(v)
+	}
+	return result
+}
+
+// Reduce folds the items of q from front to rear into a single value,
+// starting from init
+func Reduce[T, A any](q *Queue[T], init A, f func(acc A, v T) A) A {
+	return fn.Reduce(q.All(), init, f)
+}
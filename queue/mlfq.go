@@ -0,0 +1,153 @@
+package queue
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLevelRange is returned when a level outside an MLFQ's levels is given
+var ErrLevelRange = errors.New("queue: level out of range")
+
+// MLFQ is a multi-level feedback queue, the structure behind many CPU
+// schedulers: level 0 is served first, and each level is a FIFO
+// New items start at level 0. After running an item the caller hands it
+// back with Demote if it used its whole time slice, Requeue if it gave up
+// early or Promote to reward it, so long-running items sink while
+// interactive ones stay near the top. A periodic Boost lifts every item back
+// to level 0 so nothing starves
+type MLFQ[T any] struct {
+	levels     []*RingQueue[T]
+	size       int
+	boostEvery int // pops between automatic boosts, 0 for none
+	sinceBoost int
+}
+
+// NewMLFQ creates a queue with the given number of levels that boosts every
+// item to level 0 after every boostEvery pops, or never if boostEvery is 0
+// Panics if levels < 1 or boostEvery < 0
+func NewMLFQ[T any](levels, boostEvery int) *MLFQ[T] {
+	if levels < 1 {
+		panic(fmt.Sprintf("queue: MLFQ needs at least 1 level, got %d", levels))
+	}
+	if boostEvery < 0 {
+		panic(fmt.Sprintf("queue: boostEvery must not be negative, got %d", boostEvery))
+	}
+	q := &MLFQ[T]{levels: make([]*RingQueue[T], levels), boostEvery: boostEvery}
+	for i := range q.levels {
+		q.levels[i] = NewRingQueue[T](0)
+	}
+	return q
+}
+
+// Push adds a new item at level 0
+func (q *MLFQ[T]) Push(value T) {
+	q.levels[0].Push(value)
+	q.size++
+}
+
+// PushAt adds value at level
+// Returns ErrLevelRange if level does not exist
+func (q *MLFQ[T]) PushAt(value T, level int) error {
+	if level < 0 || level >= len(q.levels) {
+		return fmt.Errorf("%w: %d not in [0, %d]", ErrLevelRange, level, len(q.levels)-1)
+	}
+	q.levels[level].Push(value)
+	q.size++
+	return nil
+}
+
+// PopNext removes and returns the front item of the highest non-empty level
+// along with that level; the level is what Demote, Requeue and Promote take
+// Returns ErrEmpty if the queue is empty
+func (q *MLFQ[T]) PopNext() (T, int, error) {
+	if q.boostEvery > 0 && q.sinceBoost >= q.boostEvery {
+		q.Boost()
+	}
+	for level, lq := range q.levels {
+		if v, err := lq.Pop(); err == nil {
+			q.size--
+			q.sinceBoost++
+			return v, level, nil
+		}
+	}
+	var zero T
+	return zero, 0, ErrEmpty
+}
+
+// Demote hands back an item popped from level one level lower, or at the
+// bottom level if it is already there
+func (q *MLFQ[T]) Demote(value T, level int) {
+	q.levels[q.clamp(level+1)].Push(value)
+	q.size++
+}
+
+// Requeue hands back an item popped from level at the same level
+func (q *MLFQ[T]) Requeue(value T, level int) {
+	q.levels[q.clamp(level)].Push(value)
+	q.size++
+}
+
+// Promote hands back an item popped from level one level higher, or at
+// level 0 if it is already there
+func (q *MLFQ[T]) Promote(value T, level int) {
+	q.levels[q.clamp(level-1)].Push(value)
+	q.size++
+}
+
+// clamp limits level to the existing levels
+func (q *MLFQ[T]) clamp(level int) int {
+	return min(max(level, 0), len(q.levels)-1)
+}
+
+// Boost moves every item to level 0, higher levels first and FIFO within a
+// level, and restarts the count towards the next automatic boost
+func (q *MLFQ[T]) Boost() {
+	top := q.levels[0]
+	for _, lq := range q.levels[1:] {
+		for v, err := lq.Pop(); err == nil; v, err = lq.Pop() {
+			top.Push(v)
+		}
+	}
+	q.sinceBoost = 0
+}
+
+// Levels returns the number of levels
+func (q *MLFQ[T]) Levels() int {
+	return len(q.levels)
+}
+
+// LevelSize returns the number of items waiting at level, or 0 if level
+// does not exist
+func (q *MLFQ[T]) LevelSize(level int) int {
+	if level < 0 || level >= len(q.levels) {
+		return 0
+	}
+	return q.levels[level].Size()
+}
+
+// Size returns the number of items across all levels
+func (q *MLFQ[T]) Size() int {
+	return q.size
+}
+
+// IsEmpty returns true if every level is empty
+func (q *MLFQ[T]) IsEmpty() bool {
+	return q.size == 0
+}
+
+// Clear removes every item and restarts the boost count
+func (q *MLFQ[T]) Clear() {
+	for _, lq := range q.levels {
+		lq.Clear()
+	}
+	q.size, q.sinceBoost = 0, 0
+}
+
+// String returns a string representation of the queue
+func (q *MLFQ[T]) String() string {
+	levels := make([][]T, len(q.levels))
+	for i, lq := range q.levels {
+		levels[i] = lq.ToSlice()
+	}
+	return fmt.Sprintf("MLFQ{size: %d, levels: %v}", q.size, levels)
+}
@@ -0,0 +1,89 @@
+package queue
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMLFQ(t *testing.T) {
+	q := NewMLFQ[string](3, 0)
+	if _, _, err := q.PopNext(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+	if err := q.PushAt("x", 3); !errors.Is(err, ErrLevelRange) {
+		t.Errorf("Expected ErrLevelRange, got %v", err)
+	}
+
+	q.Push("batch")
+	q.Push("editor")
+
+	// batch uses its whole slice and sinks; editor yields early and stays
+	v, level, _ := q.PopNext()
+	if v != "batch" || level != 0 {
+		t.Fatalf("Expected batch at level 0, got %s at %d", v, level)
+	}
+	q.Demote(v, level)
+	v, level, _ = q.PopNext()
+	q.Requeue(v, level)
+
+	if q.LevelSize(0) != 1 || q.LevelSize(1) != 1 {
+		t.Errorf("Unexpected levels %v", q)
+	}
+	if v, _, _ := q.PopNext(); v != "editor" {
+		t.Errorf("Expected editor to be served before the demoted batch, got %s", v)
+	}
+
+	v, level, _ = q.PopNext()
+	q.Demote(v, level)
+	v, level, _ = q.PopNext()
+	q.Demote(v, level) // already at the bottom
+	if q.LevelSize(2) != 1 {
+		t.Errorf("Expected batch to stay at the bottom level, got %v", q)
+	}
+	v, level, _ = q.PopNext()
+	q.Promote(v, level)
+	if q.LevelSize(1) != 1 || q.Size() != 1 {
+		t.Errorf("Expected batch promoted to level 1, got %v", q)
+	}
+}
+
+func TestMLFQBoost(t *testing.T) {
+	q := NewMLFQ[int](2, 3)
+	q.PushAt(100, 1)
+	for i := range 5 {
+		q.Push(i)
+	}
+
+	for range 3 {
+		q.PopNext()
+	}
+	if q.LevelSize(1) != 1 {
+		t.Fatalf("Expected 100 to wait at level 1 before the boost, got %v", q)
+	}
+
+	// The fourth pop boosts first, lifting 100 behind the level 0 items
+	var got []int
+	for !q.IsEmpty() {
+		v, level, _ := q.PopNext()
+		if level != 0 {
+			t.Errorf("Expected every pop from level 0 after the boost, got level %d", level)
+		}
+		got = append(got, v)
+	}
+	if len(got) != 3 || got[0] != 3 || got[1] != 4 || got[2] != 100 {
+		t.Errorf("Expected [3 4 100], got %v", got)
+	}
+}
+
+// Benchmark tests
+func BenchmarkMLFQ(b *testing.B) {
+	q := NewMLFQ[int](4, 100)
+	for i := range 64 {
+		q.Push(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v, level, _ := q.PopNext()
+		q.Demote(v, level)
+	}
+}
@@ -1,7 +1,27 @@
 package queue
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"iter"
+
+	"github.com/anwar-arif/golang-dsa/alloc"
+	"github.com/anwar-arif/golang-dsa/codec"
+	"github.com/anwar-arif/golang-dsa/collections"
+	"github.com/anwar-arif/golang-dsa/metrics"
+)
+
+var (
+	// ErrEmpty is returned when reading from an empty queue
+	ErrEmpty = errors.New("queue is empty")
+	// ErrFull is returned when adding to a bounded queue that has no room left
+	ErrFull = errors.New("queue is full")
+	// ErrClosed is returned when adding to a closed bounded queue, or waiting
+	// on one that is closed and drained
+	ErrClosed = errors.New("queue is closed")
 )
 
 // Node represents a node in the queue
@@ -15,23 +35,76 @@ type Queue[T any] struct {
 	front *Node[T] // Points to the first element (dequeue from here)
 	rear  *Node[T] // Points to the last element (enqueue to here)
 	size  int
+	rec   metrics.Recorder         // optional, see SetRecorder
+	alloc alloc.Allocator[Node[T]] // optional, see NewQueueWithAllocator
+
+	observers collections.Observers[T]
+	stats     collections.StatsCounter
 }
 
+// Queue satisfies the shared collection interfaces
+var (
+	_ collections.Collection[int]     = (*Queue[int])(nil)
+	_ collections.Serializable        = (*Queue[int])(nil)
+	_ json.Marshaler                  = (*Queue[int])(nil)
+	_ json.Unmarshaler                = (*Queue[int])(nil)
+	_ collections.Cloner[*Queue[int]] = (*Queue[int])(nil)
+	_ collections.StatsReporter       = (*Queue[int])(nil)
+)
+
 // NewQueue creates a new empty queue
-func NewQueue[T any]() *Queue[T] {
-	return &Queue[T]{
+func NewQueue[T any](opts ...QueueOption) *Queue[T] {
+	var o queueOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	q := &Queue[T]{
 		front: nil,
 		rear:  nil,
 		size:  0,
 	}
+	if o.nodePool {
+		q.alloc = alloc.NewPool[Node[T]]()
+	}
+	return q
+}
+
+// QueueOption configures a Queue at construction
+// It is a separate type from Option so that options meant for one kind of
+// queue cannot be passed to the other
+type QueueOption func(*queueOptions)
+
+type queueOptions struct {
+	nodePool bool
+}
+
+// WithNodePool makes a Queue recycle its nodes through a sync.Pool instead of
+// leaving popped nodes to the garbage collector, which pays off under a high
+// churn of pushes and pops
+func WithNodePool() QueueOption {
+	return func(o *queueOptions) { o.nodePool = true }
+}
+
+// NewQueueWithAllocator creates a new empty queue that takes its nodes from a
+// and hands them back on Pop
+func NewQueueWithAllocator[T any](a alloc.Allocator[Node[T]]) *Queue[T] {
+	return &Queue[T]{alloc: a}
+}
+
+// newNode returns a node from the allocator, or from the heap when there is none
+func (q *Queue[T]) newNode(value T) *Node[T] {
+	q.stats.Alloc(1)
+	if q.alloc == nil {
+		return &Node[T]{Value: value}
+	}
+	n := q.alloc.New()
+	n.Value = value
+	return n
 }
 
 // Push adds an item to the rear of the queue
 func (q *Queue[T]) Push(value T) {
-	newNode := &Node[T]{
-		Value: value,
-		Next:  nil,
-	}
+	newNode := q.newNode(value)
 
 	if q.IsEmpty() {
 		// First element
@@ -44,6 +117,9 @@ func (q *Queue[T]) Push(value T) {
 	}
 
 	q.size++
+	q.stats.Push(1, q.size)
+	q.record("push")
+	q.observers.Notify(collections.OpPush, value)
 }
 
 // Pop removes and returns the item from the front of the queue
@@ -51,11 +127,15 @@ func (q *Queue[T]) Pop() (T, error) {
 	var zero T
 
 	if q.IsEmpty() {
-		return zero, fmt.Errorf("queue is empty")
+		return zero, ErrEmpty
 	}
 
-	value := q.front.Value
-	q.front = q.front.Next
+	popped := q.front
+	value := popped.Value
+	q.front = popped.Next
+	if q.alloc != nil {
+		q.alloc.Free(popped)
+	}
 
 	// If queue becomes empty, reset rear as well
 	if q.front == nil {
@@ -63,15 +143,42 @@ func (q *Queue[T]) Pop() (T, error) {
 	}
 
 	q.size--
+	q.stats.Pop(1)
+	q.record("pop")
+	q.observers.Notify(collections.OpPop, value)
 	return value, nil
 }
 
+// PushAll pushes values in order, so the last value ends up at the rear
+func (q *Queue[T]) PushAll(values ...T) {
+	for _, v := range values {
+		q.Push(v)
+	}
+}
+
+// PopN removes and returns up to n items, front first
+// Returns ErrEmpty if the queue is empty and n > 0; n <= 0 returns no items
+func (q *Queue[T]) PopN(n int) ([]T, error) {
+	if n <= 0 {
+		return []T{}, nil
+	}
+	if q.IsEmpty() {
+		return nil, ErrEmpty
+	}
+	result := make([]T, 0, min(n, q.Size()))
+	for len(result) < n && !q.IsEmpty() {
+		v, _ := q.Pop()
+		result = append(result, v)
+	}
+	return result, nil
+}
+
 // Front returns the front item without removing it
 func (q *Queue[T]) Front() (T, error) {
 	var zero T
 
 	if q.IsEmpty() {
-		return zero, fmt.Errorf("queue is empty")
+		return zero, ErrEmpty
 	}
 
 	return q.front.Value, nil
@@ -82,7 +189,7 @@ func (q *Queue[T]) Rear() (T, error) {
 	var zero T
 
 	if q.IsEmpty() {
-		return zero, fmt.Errorf("queue is empty")
+		return zero, ErrEmpty
 	}
 
 	return q.rear.Value, nil
@@ -103,6 +210,87 @@ func (q *Queue[T]) Clear() {
 	q.front = nil
 	q.rear = nil
 	q.size = 0
+	q.record("")
+
+	var zero T
+	q.observers.Notify(collections.OpClear, zero)
+}
+
+// Reverse reverses the order of the items in place, relinking the nodes
+func (q *Queue[T]) Reverse() {
+	var prev *Node[T]
+	for current := q.front; current != nil; {
+		next := current.Next
+		current.Next = prev
+		prev, current = current, next
+	}
+	q.front, q.rear = q.rear, q.front
+}
+
+// Rotate moves the front item to the rear n times, as round-robin scheduling
+// does, by relinking the queue once rather than popping and pushing
+// A negative n rotates the other way, moving rear items to the front
+func (q *Queue[T]) Rotate(n int) {
+	if q.size < 2 {
+		return
+	}
+	n = (n%q.size + q.size) % q.size
+	if n == 0 {
+		return
+	}
+
+	// Close the ring, then break it after the nth node
+	q.rear.Next = q.front
+	newRear := q.front
+	for i := 1; i < n; i++ {
+		newRear = newRear.Next
+	}
+	q.front, q.rear = newRear.Next, newRear
+	q.rear.Next = nil
+}
+
+// OnMutate registers fn to be called after every push, pop and clear, and
+// returns a function that unregisters it
+// fn runs synchronously and must not modify the queue
+func (q *Queue[T]) OnMutate(fn func(op collections.Op, value T)) (remove func()) {
+	return q.observers.Add(fn)
+}
+
+// OnPush registers fn to be called with every value pushed, for example to
+// count pushes, and returns a function that unregisters it
+func (q *Queue[T]) OnPush(fn func(value T)) (remove func()) {
+	return q.observers.AddOp(collections.OpPush, fn)
+}
+
+// OnPop registers fn to be called with every value popped and returns a
+// function that unregisters it
+func (q *Queue[T]) OnPop(fn func(value T)) (remove func()) {
+	return q.observers.AddOp(collections.OpPop, fn)
+}
+
+// Stats returns the queue's size and activity counters; Allocs counts the
+// nodes it created, whether from the heap or an allocator
+func (q *Queue[T]) Stats() collections.Stats {
+	return q.stats.Snapshot(q.size)
+}
+
+// SetRecorder reports queue activity to r: "push" and "pop" counters and a
+// "depth" gauge; use metrics.WithPrefix to tell several queues apart
+// Passing nil turns reporting off
+func (q *Queue[T]) SetRecorder(r metrics.Recorder) {
+	q.rec = r
+	q.record("")
+}
+
+// record counts op, if any, and updates the depth gauge
+func (q *Queue[T]) record(op string) {
+	if q.rec == nil {
+		return
+	}
+	if op != "" {
+		q.rec.Count(op, 1)
+	}
+	q.rec.Gauge("depth", float64(q.size))
 }
 
 // ToSlice returns all items as a slice from front to rear
@@ -118,6 +306,155 @@ func (q *Queue[T]) ToSlice() []T {
 	return result
 }
 
+// IndexOf returns the position of the first item equal to value by eq,
+// counting from the front as 0, or -1 if there is none
+// Walks the queue, so it costs O(n)
+func (q *Queue[T]) IndexOf(value T, eq func(a, b T) bool) int {
+	i := 0
+	for current := q.front; current != nil; current = current.Next {
+		if eq(current.Value, value) {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// Contains returns true if some item is equal to value by eq
+// Walks the queue, so it costs O(n)
+func (q *Queue[T]) Contains(value T, eq func(a, b T) bool) bool {
+	return q.IndexOf(value, eq) >= 0
+}
+
+// Equal returns true if other holds the same number of items as the queue
+// and each pair, from front to rear, is equal by eq
+func (q *Queue[T]) Equal(other *Queue[T], eq func(a, b T) bool) bool {
+	if q.size != other.size {
+		return false
+	}
+	for a, b := q.front, other.front; a != nil; a, b = a.Next, b.Next {
+		if !eq(a.Value, b.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a copy of the queue
+func (q *Queue[T]) Clone() *Queue[T] {
+	return q.CloneWith(func(v T) T { return v })
+}
+
+// CloneWith returns a copy of the queue with every item passed through copyFn,
+// for items that need a deep copy
+func (q *Queue[T]) CloneWith(copyFn func(T) T) *Queue[T] {
+	clone := NewQueueWithAllocator(q.alloc)
+	for current := q.front; current != nil; current = current.Next {
+		clone.Push(copyFn(current.Value))
+	}
+	return clone
+}
+
+// All returns an iterator over the items from front to rear
+func (q *Queue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for current := q.front; current != nil; current = current.Next {
+			if !yield(current.Value) {
+				return
+			}
+		}
+	}
+}
+
+// All2 returns an iterator over the items from front to rear paired with
+// their position, where the front is 0
+func (q *Queue[T]) All2() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for current := q.front; current != nil; current = current.Next {
+			if !yield(i, current.Value) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Iterator walks a Queue from front to rear
+// The zero value is an exhausted iterator. Pushing while iterating is fine;
+// popping the node the iterator is on leaves it walking detached nodes
+type Iterator[T any] struct {
+	next    *Node[T]
+	current *Node[T]
+}
+
+// NewIterator returns an iterator positioned before the front item
+func (q *Queue[T]) NewIterator() *Iterator[T] {
+	return &Iterator[T]{next: q.front}
+}
+
+// Next advances to the next item and reports whether there is one
+func (it *Iterator[T]) Next() bool {
+	it.current = it.next
+	if it.current == nil {
+		return false
+	}
+	it.next = it.current.Next
+	return true
+}
+
+// Value returns the item the iterator is on; call Next first
+// Panics if Next has not returned true
+func (it *Iterator[T]) Value() T {
+	if it.current == nil {
+		panic("queue: Value called without a successful Next")
+	}
+	return it.current.Value
+}
+
+// Save writes the items to w with enc, front to rear
+func (q *Queue[T]) Save(w io.Writer, enc codec.Encoder[[]T]) error {
+	return enc.Encode(w, q.ToSlice())
+}
+
+// Load replaces the contents of the queue with items read from r by dec
+func (q *Queue[T]) Load(r io.Reader, dec codec.Decoder[[]T]) error {
+	items, err := dec.Decode(r)
+	if err != nil {
+		return err
+	}
+
+	q.Clear()
+	for _, v := range items {
+		q.Push(v)
+	}
+	return nil
+}
+
+// MarshalBinary encodes the items with codec.Gob, front to rear
+func (q *Queue[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := q.Save(&buf, codec.Gob[[]T]()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the contents of the queue with items encoded by MarshalBinary
+func (q *Queue[T]) UnmarshalBinary(data []byte) error {
+	return q.Load(bytes.NewReader(data), codec.Gob[[]T]())
+}
+
+// MarshalJSON encodes the items as a JSON array, front to rear
+func (q *Queue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.ToSlice())
+}
+
+// UnmarshalJSON replaces the contents of the queue with items encoded by MarshalJSON
+func (q *Queue[T]) UnmarshalJSON(data []byte) error {
+	return q.Load(bytes.NewReader(data), codec.JSON[[]T]())
+}
+
 // String returns a string representation of the queue
 func (q *Queue[T]) String() string {
 	return fmt.Sprintf("Queue{size: %d, front->rear: %v}", q.size, q.ToSlice())
@@ -125,7 +462,7 @@ func (q *Queue[T]) String() string {
 
 // Example usage and demonstrations
 func ExampleUsage() {
-	fmt.Println("=== Generic Queue Examples ===\n")
+	fmt.Println("=== Generic Queue Examples ===")
 
 	// Example 1: Basic integer queue
 	fmt.Println("1. Basic Integer Queue (FIFO):")
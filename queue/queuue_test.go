@@ -1,8 +1,22 @@
 package queue
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/anwar-arif/golang-dsa/alloc"
+	"github.com/anwar-arif/golang-dsa/codec"
+	"github.com/anwar-arif/golang-dsa/collections"
+	"github.com/anwar-arif/golang-dsa/metrics"
 )
 
 func TestBasicEnqueueDequeue(t *testing.T) {
@@ -355,6 +369,622 @@ func TestEdgeCases(t *testing.T) {
 	})
 }
 
+func TestErrEmpty(t *testing.T) {
+	q := NewQueue[int]()
+	if _, err := q.Pop(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from Pop, got %v", err)
+	}
+	if _, err := q.Front(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from Front, got %v", err)
+	}
+	if _, err := q.Rear(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from Rear, got %v", err)
+	}
+}
+
+func TestAll2(t *testing.T) {
+	q := NewQueue[string]()
+	q.Push("first")
+	q.Push("second")
+	q.Push("third")
+
+	expected := []string{"first", "second", "third"}
+	count := 0
+	for i, v := range q.All2() {
+		if v != expected[i] {
+			t.Errorf("Expected %q at position %d, got %q", expected[i], i, v)
+		}
+		count++
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 items, got %d", count)
+	}
+}
+
+func TestAll(t *testing.T) {
+	q := NewQueue[int]()
+	for i := 1; i <= 4; i++ {
+		q.Push(i)
+	}
+
+	var got []int
+	for v := range q.All() {
+		got = append(got, v)
+	}
+
+	expected := []int{1, 2, 3, 4}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Expected %d at position %d, got %d", expected[i], i, got[i])
+		}
+	}
+
+	if q.Size() != 4 {
+		t.Errorf("Expected All to leave the queue unchanged, got size %d", q.Size())
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	q := NewQueue[int]()
+	q.Push(1)
+	q.Push(2)
+	q.Push(3)
+
+	data, err := json.Marshal(q)
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+	if string(data) != "[1,2,3]" {
+		t.Errorf("Expected front-to-rear array, got %s", data)
+	}
+
+	restored := NewQueue[int]()
+	restored.Push(99)
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+	if restored.Size() != 3 {
+		t.Fatalf("Expected unmarshal to replace the contents, got size %d", restored.Size())
+	}
+	for _, expected := range []int{1, 2, 3} {
+		if v, _ := restored.Pop(); v != expected {
+			t.Errorf("Expected %d, got %d", expected, v)
+		}
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	q := NewQueue[string]()
+	q.Push("first")
+	q.Push("second")
+	q.Push("third")
+
+	data, err := q.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+
+	restored := NewQueue[string]()
+	restored.Push("stale")
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+
+	if restored.Size() != 3 {
+		t.Fatalf("Expected size 3, got %d", restored.Size())
+	}
+	for _, expected := range []string{"first", "second", "third"} {
+		if v, _ := restored.Pop(); v != expected {
+			t.Errorf("Expected %s, got %s", expected, v)
+		}
+	}
+
+	empty := NewQueue[int]()
+	data, _ = empty.MarshalBinary()
+	if err := empty.UnmarshalBinary(data); err != nil || !empty.IsEmpty() {
+		t.Errorf("Expected empty round trip, got size %d with error %v", empty.Size(), err)
+	}
+}
+
+func TestFromJSONLines(t *testing.T) {
+	type event struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	input := `{"id": 1, "name": "start"}
+{"id": 2, "name": "tick"}
+
+{"id": 3, "name": "stop"}
+`
+	q, err := FromJSONLines[event](strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if q.Size() != 3 {
+		t.Fatalf("Expected 3 events, got %d", q.Size())
+	}
+	if first, _ := q.Front(); first.Name != "start" {
+		t.Errorf("Expected start at the front, got %+v", first)
+	}
+
+	_, err = FromJSONLines[event](strings.NewReader("{\"id\": 1}\n{\"id\": \"x\"}\n"))
+	if err == nil || !strings.Contains(err.Error(), "record 2") {
+		t.Errorf("Expected an error naming record 2, got %v", err)
+	}
+
+	empty, err := FromJSONLines[int](strings.NewReader(""))
+	if err != nil || !empty.IsEmpty() {
+		t.Errorf("Expected an empty queue, got %v with error %v", empty, err)
+	}
+}
+
+// countingReader counts how many bytes have been read so far
+type countingReader struct {
+	r    io.Reader
+	read int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.read += n
+	return n, err
+}
+
+func TestFromJSONLinesStreams(t *testing.T) {
+	// A huge stream that ends in a bad record fails without being buffered whole
+	var sb strings.Builder
+	for i := 0; i < 100000; i++ {
+		sb.WriteString("1\n")
+	}
+	sb.WriteString("oops\n")
+	for i := 0; i < 100000; i++ {
+		sb.WriteString("2\n")
+	}
+
+	cr := &countingReader{r: strings.NewReader(sb.String())}
+	if _, err := FromJSONLines[int](cr); err == nil {
+		t.Fatal("Expected decode error")
+	}
+	if cr.read >= sb.Len() {
+		t.Errorf("Expected decoding to stop early, read %d of %d bytes", cr.read, sb.Len())
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	q := NewQueue[int32]()
+	q.Push(1)
+	q.Push(2)
+	q.Push(3)
+
+	c := codec.Slice(codec.Binary[int32](binary.LittleEndian))
+
+	var buf bytes.Buffer
+	if err := q.Save(&buf, c); err != nil {
+		t.Fatalf("Unexpected save error: %v", err)
+	}
+
+	restored := NewQueue[int32]()
+	if err := restored.Load(&buf, c); err != nil {
+		t.Fatalf("Unexpected load error: %v", err)
+	}
+	for _, expected := range []int32{1, 2, 3} {
+		if v, _ := restored.Pop(); v != expected {
+			t.Errorf("Expected %d, got %d", expected, v)
+		}
+	}
+
+	if err := restored.Load(strings.NewReader(""), c); err == nil {
+		t.Error("Expected error loading from empty input")
+	}
+}
+
+func TestContainsIndexOf(t *testing.T) {
+	eq := func(a, b string) bool { return strings.EqualFold(a, b) }
+	q := NewQueue[string]()
+	if q.Contains("a", eq) || q.IndexOf("a", eq) != -1 {
+		t.Errorf("Expected an empty queue to contain nothing")
+	}
+
+	q.PushAll("a", "B", "c", "b")
+	if i := q.IndexOf("b", eq); i != 1 {
+		t.Errorf("Expected the first match at 1, got %d", i)
+	}
+	if !q.Contains("C", eq) || q.Contains("d", eq) {
+		t.Errorf("Unexpected Contains results")
+	}
+
+	q.Pop()
+	if i := q.IndexOf("c", eq); i != 1 {
+		t.Errorf("Expected positions to count from the front, got %d", i)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	q := NewQueue[int]()
+	q.Reverse()
+	if !q.IsEmpty() {
+		t.Errorf("Expected reversing an empty queue to leave it empty")
+	}
+
+	q.PushAll(1, 2, 3, 4)
+	q.Reverse()
+	if got := q.ToSlice(); !slices.Equal(got, []int{4, 3, 2, 1}) {
+		t.Errorf("Expected [4 3 2 1], got %v", got)
+	}
+	q.Push(0)
+	if rear, _ := q.Rear(); rear != 0 {
+		t.Errorf("Expected pushes to go after the new rear, got %d", rear)
+	}
+	if front, _ := q.Pop(); front != 4 {
+		t.Errorf("Expected 4 at the front, got %d", front)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	cases := []struct {
+		n    int
+		want []int
+	}{
+		{0, []int{1, 2, 3, 4}},
+		{1, []int{2, 3, 4, 1}},
+		{3, []int{4, 1, 2, 3}},
+		{6, []int{3, 4, 1, 2}},
+		{-1, []int{4, 1, 2, 3}},
+	}
+	for _, c := range cases {
+		q := NewQueue[int]()
+		q.PushAll(1, 2, 3, 4)
+		q.Rotate(c.n)
+		if got := q.ToSlice(); !slices.Equal(got, c.want) {
+			t.Errorf("Rotate(%d): expected %v, got %v", c.n, c.want, got)
+		}
+		q.Push(5)
+		if got := q.ToSlice(); got[len(got)-1] != 5 || q.Size() != 5 {
+			t.Errorf("Rotate(%d): expected the rear relinked, got %v", c.n, got)
+		}
+	}
+
+	q := NewQueue[int]()
+	q.Rotate(3)
+	q.Push(1)
+	q.Rotate(3)
+	if got := q.ToSlice(); !slices.Equal(got, []int{1}) {
+		t.Errorf("Expected a single item to stay put, got %v", got)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+	a, b := NewQueue[int](), NewQueue[int]()
+	if !a.Equal(b, eq) {
+		t.Error("Expected empty queues to be equal")
+	}
+
+	a.PushAll(1, 2, 3)
+	b.PushAll(1, 2)
+	if a.Equal(b, eq) || b.Equal(a, eq) {
+		t.Error("Expected queues of different sizes to differ")
+	}
+	b.Push(3)
+	if !a.Equal(b, eq) {
+		t.Error("Expected equal queues")
+	}
+	b.Reverse()
+	if a.Equal(b, eq) {
+		t.Error("Expected order to matter")
+	}
+	if !a.Equal(b, func(x, y int) bool { return x%2 == y%2 }) {
+		t.Error("Expected eq to decide equality")
+	}
+}
+
+func TestMapFilterReduce(t *testing.T) {
+	q := NewQueue[int]()
+	q.PushAll(1, 2, 3, 4, 5)
+
+	strs := Map(q, func(v int) string { return strings.Repeat("*", v) })
+	if got := strs.ToSlice(); !slices.Equal(got, []string{"*", "**", "***", "****", "*****"}) {
+		t.Errorf("Unexpected Map result %v", got)
+	}
+
+	evens := Filter(q, func(v int) bool { return v%2 == 0 })
+	if got := evens.ToSlice(); !slices.Equal(got, []int{2, 4}) {
+		t.Errorf("Expected [2 4], got %v", got)
+	}
+	evens.Push(6)
+
+	if sum := Reduce(q, 0, func(acc, v int) int { return acc + v }); sum != 15 {
+		t.Errorf("Expected 15, got %d", sum)
+	}
+	if got := q.ToSlice(); !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Expected the source untouched, got %v", got)
+	}
+
+	if Map(NewQueue[int](), func(v int) int { return v }).Size() != 0 {
+		t.Error("Expected mapping an empty queue to give an empty queue")
+	}
+}
+
+func TestClone(t *testing.T) {
+	q := NewQueue[int]()
+	q.Push(1)
+	q.Push(2)
+
+	clone := q.Clone()
+	clone.Push(3)
+	q.Pop()
+
+	if q.Size() != 1 || clone.Size() != 3 {
+		t.Fatalf("Expected sizes 1 and 3, got %d and %d", q.Size(), clone.Size())
+	}
+	for _, expected := range []int{1, 2, 3} {
+		if v, _ := clone.Pop(); v != expected {
+			t.Errorf("Expected %d from clone, got %d", expected, v)
+		}
+	}
+
+	doubled := q.CloneWith(func(v int) int { return v * 2 })
+	if v, _ := doubled.Front(); v != 4 {
+		t.Errorf("Expected 4, got %d", v)
+	}
+}
+
+func TestNodePool(t *testing.T) {
+	q := NewQueue[int](WithNodePool())
+	if q.alloc == nil {
+		t.Fatal("Expected WithNodePool to set an allocator")
+	}
+	for round := 0; round < 3; round++ {
+		q.PushAll(1, 2, 3)
+		if got, _ := q.PopN(3); !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("Expected [1 2 3], got %v", got)
+		}
+	}
+	if NewQueue[int]().alloc != nil {
+		t.Error("Expected no allocator by default")
+	}
+}
+
+func TestAllocator(t *testing.T) {
+	pool := alloc.NewPool[Node[string]]()
+	q := NewQueueWithAllocator[string](pool)
+	q.Push("a")
+	q.Push("b")
+
+	clone := q.Clone()
+	q.Pop()
+	q.Push("c")
+
+	if got := q.ToSlice(); fmt.Sprint(got) != "[b c]" {
+		t.Errorf("Expected [b c], got %v", got)
+	}
+	if got := clone.ToSlice(); fmt.Sprint(got) != "[a b]" {
+		t.Errorf("Expected clone [a b], got %v", got)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	m := metrics.NewMemory()
+	q := NewQueue[int]()
+	q.SetRecorder(metrics.WithPrefix(m, "q."))
+
+	q.Push(1)
+	q.Push(2)
+	q.Pop()
+
+	if m.Counter("q.push") != 2 || m.Counter("q.pop") != 1 {
+		t.Errorf("Expected 2 pushes and 1 pop, got %d and %d", m.Counter("q.push"), m.Counter("q.pop"))
+	}
+	if depth, _ := m.GaugeValue("q.depth"); depth != 1 {
+		t.Errorf("Expected depth 1, got %v", depth)
+	}
+
+	q.Clear()
+	if depth, _ := m.GaugeValue("q.depth"); depth != 0 {
+		t.Errorf("Expected depth 0 after Clear, got %v", depth)
+	}
+
+	// Failed pops are not counted, and nil turns reporting off
+	q.Pop()
+	q.SetRecorder(nil)
+	q.Push(3)
+	if m.Counter("q.pop") != 1 || m.Counter("q.push") != 2 {
+		t.Error("Expected no further updates")
+	}
+}
+
+func TestOnMutate(t *testing.T) {
+	q := NewQueue[string]()
+
+	// Maintain a derived count of items per first letter
+	counts := map[byte]int{}
+	q.OnMutate(func(op collections.Op, value string) {
+		switch op {
+		case collections.OpPush:
+			counts[value[0]]++
+		case collections.OpPop:
+			counts[value[0]]--
+		case collections.OpClear:
+			clear(counts)
+		}
+	})
+
+	q.Push("apple")
+	q.Push("avocado")
+	q.Push("banana")
+	q.Pop()
+
+	if counts['a'] != 1 || counts['b'] != 1 {
+		t.Errorf("Unexpected derived counts %v", counts)
+	}
+
+	q.Clear()
+	if len(counts) != 0 {
+		t.Errorf("Expected counts to be cleared, got %v", counts)
+	}
+}
+
+func TestOnPushOnPop(t *testing.T) {
+	q := NewQueue[string]()
+	var popped []string
+	pushes := 0
+	q.OnPush(func(string) { pushes++ })
+	q.OnPop(func(v string) { popped = append(popped, v) })
+
+	q.Push("a")
+	q.Push("b")
+	q.Pop()
+	q.Clear()
+
+	if pushes != 2 || len(popped) != 1 || popped[0] != "a" {
+		t.Errorf("Expected 2 pushes and pops [a], got %d and %v", pushes, popped)
+	}
+}
+
+func TestStats(t *testing.T) {
+	q := NewQueue[int]()
+	q.Push(1)
+	q.Push(2)
+	q.Pop()
+	q.Clear()
+	q.Pop() // failed pops are not counted
+
+	expected := collections.Stats{Size: 0, PeakSize: 2, Pushes: 2, Pops: 1, Allocs: 2}
+	if got := q.Stats(); got != expected {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestPushAllPopN(t *testing.T) {
+	for name, q := range map[string]interface {
+		PushAll(...int)
+		PopN(int) ([]int, error)
+	}{"linked": NewQueue[int](), "ring": NewRingQueue[int](2)} {
+		q.PushAll(1, 2, 3, 4)
+
+		got, err := q.PopN(3)
+		if err != nil || fmt.Sprint(got) != "[1 2 3]" {
+			t.Errorf("%s: expected [1 2 3], got %v (%v)", name, got, err)
+		}
+		if got, _ := q.PopN(5); fmt.Sprint(got) != "[4]" {
+			t.Errorf("%s: expected the remaining [4], got %v", name, got)
+		}
+		if _, err := q.PopN(1); !errors.Is(err, ErrEmpty) {
+			t.Errorf("%s: expected ErrEmpty, got %v", name, err)
+		}
+	}
+}
+
+func TestIterator(t *testing.T) {
+	q := NewQueue[int]()
+	if q.NewIterator().Next() {
+		t.Error("Expected no items from an empty queue")
+	}
+
+	q.Push(1)
+	q.Push(2)
+	it := q.NewIterator()
+	var got []int
+	for it.Next() {
+		got = append(got, it.Value())
+		if len(got) == 1 {
+			q.Push(3) // pushes during iteration are seen
+		}
+	}
+	if fmt.Sprint(got) != "[1 2 3]" {
+		t.Errorf("Expected [1 2 3], got %v", got)
+	}
+	if it.Next() {
+		t.Error("Expected an exhausted iterator to stay exhausted")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Value on an exhausted iterator to panic")
+		}
+	}()
+	it.Value()
+}
+
+func TestChannelBridge(t *testing.T) {
+	in := make(chan int)
+	go func() {
+		for i := 1; i <= 5; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+
+	q := FromChannel(context.Background(), in)
+	if got := q.ToSlice(); !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("Expected [1 2 3 4 5], got %v", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := q.ToChannel(ctx)
+	if v := <-out; v != 1 {
+		t.Errorf("Expected 1, got %d", v)
+	}
+	cancel()
+	for range out {
+	}
+	// Cancelling never loses an item that was not received
+	var got []int
+	for v := range q.ToChannel(context.Background()) {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{2, 3, 4, 5}) || !q.IsEmpty() {
+		t.Errorf("Expected [2 3 4 5] and an empty queue, got %v", got)
+	}
+}
+
+func TestPipe(t *testing.T) {
+	in := make(chan int)
+	out := Pipe(context.Background(), in, 2)
+
+	go func() {
+		for i := 0; i < 100; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+
+	var got []int
+	for v := range out {
+		got = append(got, v)
+	}
+	if len(got) != 100 || !slices.IsSorted(got) {
+		t.Errorf("Expected 0..99 in order, got %v", got)
+	}
+}
+
+func TestPipeBackpressure(t *testing.T) {
+	in := make(chan int)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Pipe(ctx, in, 2)
+
+	// Nobody reads the output: the pipe takes the buffered values plus the one
+	// held for sending, then stops reading
+	sent := 0
+	for sent < 10 {
+		select {
+		case in <- sent:
+			sent++
+			continue
+		case <-time.After(50 * time.Millisecond):
+		}
+		break
+	}
+	if sent != 4 {
+		t.Errorf("Expected the pipe to accept 4 values, got %d", sent)
+	}
+}
+
 // Benchmark tests
 func BenchmarkEnqueue(b *testing.B) {
 	q := NewQueue[int]()
@@ -401,8 +1031,41 @@ func BenchmarkEnqueueDequeue(b *testing.B) {
 	}
 }
 
+func BenchmarkEnqueueDequeuePool(b *testing.B) {
+	q := NewQueueWithAllocator[int](alloc.NewPool[Node[int]]())
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.Push(i)
+		q.Pop()
+	}
+}
+
+func BenchmarkNodePool(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		opts []QueueOption
+	}{
+		{"heap", nil},
+		{"pool", []QueueOption{WithNodePool()}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			q := NewQueue[int](bc.opts...)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < 64; j++ {
+					q.Push(j)
+				}
+				for j := 0; j < 64; j++ {
+					q.Pop()
+				}
+			}
+		})
+	}
+}
+
 // Example tests for documentation
-func ExampleNew() {
+func ExampleNewQueue() {
 	q := NewQueue[int]()
 
 	q.Push(10)
@@ -411,7 +1074,7 @@ func ExampleNew() {
 
 	for !q.IsEmpty() {
 		val, _ := q.Pop()
-		println("Dequeued:", val)
+		fmt.Println("Dequeued:", val)
 	}
 	// Output:
 	// Dequeued: 10
@@ -419,18 +1082,18 @@ func ExampleNew() {
 	// Dequeued: 30
 }
 
-func ExampleQueue_Enqueue() {
+func ExampleQueue_Push() {
 	q := NewQueue[string]()
 
 	q.Push("first")
 	q.Push("second")
 	q.Push("third")
 
-	println("Queue size:", q.Size())
+	fmt.Println("Queue size:", q.Size())
 	// Output: Queue size: 3
 }
 
-func ExampleQueue_Dequeue() {
+func ExampleQueue_Pop() {
 	q := NewQueue[string]()
 
 	q.Push("A")
@@ -439,8 +1102,8 @@ func ExampleQueue_Dequeue() {
 	first, _ := q.Pop()
 	second, _ := q.Pop()
 
-	println("First:", first)
-	println("Second:", second)
+	fmt.Println("First:", first)
+	fmt.Println("Second:", second)
 	// Output:
 	// First: A
 	// Second: B
@@ -453,8 +1116,8 @@ func ExampleQueue_Front() {
 	q.Push(200)
 
 	front, _ := q.Front()
-	println("Front item:", front)
-	println("Queue size after Front():", q.Size())
+	fmt.Println("Front item:", front)
+	fmt.Println("Queue size after Front():", q.Size())
 	// Output:
 	// Front item: 100
 	// Queue size after Front(): 2
@@ -468,7 +1131,7 @@ func ExampleQueue_ToSlice() {
 	q.Push("cherry")
 
 	items := q.ToSlice()
-	println("Queue contents:", items)
+	fmt.Println("Queue contents:", items)
 	// Output: Queue contents: [apple banana cherry]
 }
 
@@ -479,8 +1142,9 @@ func ExampleQueue_NewIterator() {
 	q.Push(2)
 	q.Push(3)
 
-	for !q.IsEmpty() {
-		fmt.Println("Item: ", q.front)
+	it := q.NewIterator()
+	for it.Next() {
+		fmt.Println("Item:", it.Value())
 	}
 	// Output:
 	// Item: 1
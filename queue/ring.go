@@ -0,0 +1,305 @@
+package queue
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/anwar-arif/golang-dsa/codec"
+	"github.com/anwar-arif/golang-dsa/collections"
+	"github.com/anwar-arif/golang-dsa/metrics"
+)
+
+// RingQueue is a FIFO queue stored in a circular buffer that doubles when
+// full, offering the same API as Queue
+// Values sit next to each other in memory and a push allocates only when the
+// buffer grows, which makes it faster than the linked Queue for most uses
+type RingQueue[T any] struct {
+	buf  []T
+	head int // index of the front value
+	size int
+	rec  metrics.Recorder // optional, see SetRecorder
+
+	observers collections.Observers[T]
+	stats     collections.StatsCounter
+}
+
+// RingQueue satisfies the shared collection interfaces
+var (
+	_ collections.Collection[int]         = (*RingQueue[int])(nil)
+	_ collections.Serializable            = (*RingQueue[int])(nil)
+	_ json.Marshaler                      = (*RingQueue[int])(nil)
+	_ json.Unmarshaler                    = (*RingQueue[int])(nil)
+	_ collections.Cloner[*RingQueue[int]] = (*RingQueue[int])(nil)
+	_ collections.StatsReporter           = (*RingQueue[int])(nil)
+)
+
+// NewRingQueue creates an empty ring queue with room for initialCap values
+// before it has to grow
+// Panics if initialCap is negative
+func NewRingQueue[T any](initialCap int) *RingQueue[T] {
+	if initialCap < 0 {
+		panic(fmt.Sprintf("queue: capacity must not be negative, got %d", initialCap))
+	}
+	q := &RingQueue[T]{buf: make([]T, initialCap)}
+	if initialCap > 0 {
+		q.stats.Alloc(1)
+	}
+	return q
+}
+
+// at returns the buffer index of the i-th value from the front
+func (q *RingQueue[T]) at(i int) int {
+	i += q.head
+	if i >= len(q.buf) {
+		i -= len(q.buf)
+	}
+	return i
+}
+
+// grow doubles the buffer, moving the values to its start
+func (q *RingQueue[T]) grow() {
+	buf := make([]T, max(2*len(q.buf), 4))
+	n := copy(buf, q.buf[q.head:])
+	copy(buf[n:], q.buf[:q.head])
+	q.buf, q.head = buf, 0
+	q.stats.Alloc(1)
+}
+
+// Push adds an item to the rear of the queue
+func (q *RingQueue[T]) Push(value T) {
+	if q.size == len(q.buf) {
+		q.grow()
+	}
+	q.buf[q.at(q.size)] = value
+	q.size++
+	q.stats.Push(1, q.size)
+	q.record("push")
+	q.observers.Notify(collections.OpPush, value)
+}
+
+// Pop removes and returns the item from the front of the queue
+func (q *RingQueue[T]) Pop() (T, error) {
+	var zero T
+
+	if q.size == 0 {
+		return zero, ErrEmpty
+	}
+
+	value := q.buf[q.head]
+	q.buf[q.head] = zero // avoid memory leak
+	q.head = q.at(1)
+	q.size--
+	q.stats.Pop(1)
+	q.record("pop")
+	q.observers.Notify(collections.OpPop, value)
+	return value, nil
+}
+
+// PushAll pushes values in order, so the last value ends up at the rear
+func (q *RingQueue[T]) PushAll(values ...T) {
+	for _, v := range values {
+		q.Push(v)
+	}
+}
+
+// PopN removes and returns up to n items, front first
+// Returns ErrEmpty if the queue is empty and n > 0; n <= 0 returns no items
+func (q *RingQueue[T]) PopN(n int) ([]T, error) {
+	if n <= 0 {
+		return []T{}, nil
+	}
+	if q.IsEmpty() {
+		return nil, ErrEmpty
+	}
+	result := make([]T, 0, min(n, q.Size()))
+	for len(result) < n && !q.IsEmpty() {
+		v, _ := q.Pop()
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+// Front returns the front item without removing it
+func (q *RingQueue[T]) Front() (T, error) {
+	if q.size == 0 {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return q.buf[q.head], nil
+}
+
+// Rear returns the rear item without removing it
+func (q *RingQueue[T]) Rear() (T, error) {
+	if q.size == 0 {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return q.buf[q.at(q.size-1)], nil
+}
+
+// IsEmpty returns true if the queue is empty
+func (q *RingQueue[T]) IsEmpty() bool {
+	return q.size == 0
+}
+
+// Size returns the number of items in the queue
+func (q *RingQueue[T]) Size() int {
+	return q.size
+}
+
+// Cap returns the number of items the queue can hold before it has to grow
+func (q *RingQueue[T]) Cap() int {
+	return len(q.buf)
+}
+
+// Clear removes all items from the queue, keeping the buffer
+func (q *RingQueue[T]) Clear() {
+	clear(q.buf)
+	q.head, q.size = 0, 0
+	q.record("")
+
+	var zero T
+	q.observers.Notify(collections.OpClear, zero)
+}
+
+// OnMutate registers fn to be called after every push, pop and clear, and
+// returns a function that unregisters it
+// fn runs synchronously and must not modify the queue
+func (q *RingQueue[T]) OnMutate(fn func(op collections.Op, value T)) (remove func()) {
+	return q.observers.Add(fn)
+}
+
+// OnPush registers fn to be called with every value pushed and returns a
+// function that unregisters it
+func (q *RingQueue[T]) OnPush(fn func(value T)) (remove func()) {
+	return q.observers.AddOp(collections.OpPush, fn)
+}
+
+// OnPop registers fn to be called with every value popped and returns a
+// function that unregisters it
+func (q *RingQueue[T]) OnPop(fn func(value T)) (remove func()) {
+	return q.observers.AddOp(collections.OpPop, fn)
+}
+
+// Stats returns the queue's size and activity counters; Allocs counts the
+// buffers it allocated
+func (q *RingQueue[T]) Stats() collections.Stats {
+	return q.stats.Snapshot(q.size)
+}
+
+// SetRecorder reports queue activity to r: "push" and "pop" counters and a
+// "depth" gauge
+// Passing nil turns reporting off
+func (q *RingQueue[T]) SetRecorder(r metrics.Recorder) {
+	q.rec = r
+	q.record("")
+}
+
+// record counts op, if any, and updates the depth gauge
+func (q *RingQueue[T]) record(op string) {
+	if q.rec == nil {
+		return
+	}
+	if op != "" {
+		q.rec.Count(op, 1)
+	}
+	q.rec.Gauge("depth", float64(q.size))
+}
+
+// ToSlice returns all items as a slice from front to rear
+func (q *RingQueue[T]) ToSlice() []T {
+	result := make([]T, 0, q.size)
+	for v := range q.All() {
+		result = append(result, v)
+	}
+	return result
+}
+
+// Clone returns a copy of the queue
+func (q *RingQueue[T]) Clone() *RingQueue[T] {
+	return q.CloneWith(func(v T) T { return v })
+}
+
+// CloneWith returns a copy of the queue with every item passed through copyFn,
+// for items that need a deep copy
+func (q *RingQueue[T]) CloneWith(copyFn func(T) T) *RingQueue[T] {
+	clone := NewRingQueue[T](q.size)
+	for v := range q.All() {
+		clone.Push(copyFn(v))
+	}
+	return clone
+}
+
+// All returns an iterator over the items from front to rear
+func (q *RingQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range q.size {
+			if !yield(q.buf[q.at(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// All2 returns an iterator over the items from front to rear paired with
+// their position, where the front is 0
+func (q *RingQueue[T]) All2() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range q.size {
+			if !yield(i, q.buf[q.at(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Save writes the items to w with enc, front to rear
+func (q *RingQueue[T]) Save(w io.Writer, enc codec.Encoder[[]T]) error {
+	return enc.Encode(w, q.ToSlice())
+}
+
+// Load replaces the contents of the queue with items read from r by dec
+func (q *RingQueue[T]) Load(r io.Reader, dec codec.Decoder[[]T]) error {
+	items, err := dec.Decode(r)
+	if err != nil {
+		return err
+	}
+
+	q.Clear()
+	for _, v := range items {
+		q.Push(v)
+	}
+	return nil
+}
+
+// MarshalBinary encodes the items with codec.Gob, front to rear
+func (q *RingQueue[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := q.Save(&buf, codec.Gob[[]T]()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the contents of the queue with items encoded by MarshalBinary
+func (q *RingQueue[T]) UnmarshalBinary(data []byte) error {
+	return q.Load(bytes.NewReader(data), codec.Gob[[]T]())
+}
+
+// MarshalJSON encodes the items as a JSON array, front to rear
+func (q *RingQueue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.ToSlice())
+}
+
+// UnmarshalJSON replaces the contents of the queue with items encoded by MarshalJSON
+func (q *RingQueue[T]) UnmarshalJSON(data []byte) error {
+	return q.Load(bytes.NewReader(data), codec.JSON[[]T]())
+}
+
+// String returns a string representation of the queue
+func (q *RingQueue[T]) String() string {
+	return fmt.Sprintf("RingQueue{size: %d, front->rear: %v}", q.size, q.ToSlice())
+}
@@ -0,0 +1,134 @@
+package queue
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/anwar-arif/golang-dsa/collections"
+)
+
+func TestRingQueue(t *testing.T) {
+	q := NewRingQueue[int](2)
+	if _, err := q.Pop(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+	if _, err := q.Rear(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from Rear, got %v", err)
+	}
+
+	// Interleave pushes and pops so the buffer wraps before it grows
+	rng := rand.New(rand.NewSource(1))
+	var model []int
+	for i := 0; i < 1000; i++ {
+		if rng.Intn(3) == 0 && len(model) > 0 {
+			v, _ := q.Pop()
+			if v != model[0] {
+				t.Fatalf("Step %d: expected %d, got %d", i, model[0], v)
+			}
+			model = model[1:]
+			continue
+		}
+		q.Push(i)
+		model = append(model, i)
+		if rear, _ := q.Rear(); rear != i {
+			t.Fatalf("Step %d: expected rear %d, got %d", i, i, rear)
+		}
+	}
+	if !slices.Equal(q.ToSlice(), model) {
+		t.Errorf("Expected %v, got %v", model, q.ToSlice())
+	}
+	if q.Size() != len(model) || q.Cap() < q.Size() {
+		t.Errorf("Unexpected size %d and capacity %d", q.Size(), q.Cap())
+	}
+
+	q.Clear()
+	if !q.IsEmpty() {
+		t.Error("Expected empty queue after Clear")
+	}
+}
+
+func TestRingQueueAll2AndClone(t *testing.T) {
+	q := NewRingQueue[string](0)
+	for _, s := range []string{"a", "b", "c"} {
+		q.Push(s)
+	}
+	q.Pop()
+	q.Push("d")
+
+	for i, v := range q.All2() {
+		if expected := []string{"b", "c", "d"}[i]; v != expected {
+			t.Errorf("Position %d: expected %s, got %s", i, expected, v)
+		}
+	}
+
+	clone := q.Clone()
+	clone.Pop()
+	if q.Size() != 3 || clone.Size() != 2 {
+		t.Errorf("Expected the clone to be independent, got sizes %d and %d", q.Size(), clone.Size())
+	}
+}
+
+func TestRingQueueJSON(t *testing.T) {
+	q := NewRingQueue[int](4)
+	for i := range 3 {
+		q.Push(i)
+	}
+
+	data, err := json.Marshal(q)
+	if err != nil || string(data) != "[0,1,2]" {
+		t.Fatalf("Expected [0,1,2], got %s (%v)", data, err)
+	}
+
+	restored := NewRingQueue[int](0)
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if front, _ := restored.Front(); front != 0 || restored.Size() != 3 {
+		t.Errorf("Unexpected restored queue %v", restored)
+	}
+}
+
+func TestRingQueueObserversAndStats(t *testing.T) {
+	q := NewRingQueue[int](1)
+	var ops []collections.Op
+	q.OnMutate(func(op collections.Op, _ int) { ops = append(ops, op) })
+
+	q.Push(1)
+	q.Push(2) // grows the buffer
+	q.Pop()
+
+	if len(ops) != 3 || ops[2] != collections.OpPop {
+		t.Errorf("Unexpected operations %v", ops)
+	}
+	expected := collections.Stats{Size: 1, PeakSize: 2, Pushes: 2, Pops: 1, Allocs: 2}
+	if got := q.Stats(); got != expected {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+// Benchmark tests
+func BenchmarkRingVsLinked(b *testing.B) {
+	b.Run("linked", func(b *testing.B) {
+		q := NewQueue[int]()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			q.Push(i)
+			if i%2 == 0 {
+				q.Pop()
+			}
+		}
+	})
+	b.Run("ring", func(b *testing.B) {
+		q := NewRingQueue[int](16)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			q.Push(i)
+			if i%2 == 0 {
+				q.Pop()
+			}
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// FromJSONLines builds a queue from a stream of JSON values, one per line,
+// pushing each value as soon as it is decoded so the input is never held in
+// memory as a whole
+// Any whitespace may separate the values; an error names the failing record
+func FromJSONLines[T any](r io.Reader) (*Queue[T], error) {
+	q := NewQueue[T]()
+	dec := json.NewDecoder(r)
+
+	for n := 1; ; n++ {
+		var v T
+		if err := dec.Decode(&v); err != nil {
+			if errors.Is(err, io.EOF) {
+				return q, nil
+			}
+			return nil, fmt.Errorf("record %d: %w", n, err)
+		}
+		q.Push(v)
+	}
+}
+
+// FromChannel builds a queue from the values received on ch, returning once
+// ch is closed or ctx is done, with whatever has arrived by then
+func FromChannel[T any](ctx context.Context, ch <-chan T) *Queue[T] {
+	q := NewQueue[T]()
+	for {
+		select {
+		case <-ctx.Done():
+			return q
+		case v, ok := <-ch:
+			if !ok {
+				return q
+			}
+			q.Push(v)
+		}
+	}
+}
+
+// ToChannel returns a channel that delivers the items of the queue from
+// front to rear, each popped only once it has been received, and is closed
+// when the queue is empty or ctx is done
+// The queue is not thread-safe, so leave it alone until the channel closes
+func (q *Queue[T]) ToChannel(ctx context.Context) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			v, err := q.Front()
+			if err != nil {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- v:
+				q.Pop()
+			}
+		}
+	}()
+	return out
+}
+
+// Pipe buffers the values from in through a BoundedQueue of the given
+// capacity and delivers them on the returned channel, which is closed once
+// in is closed and drained, or when ctx is done
+// The full policy defaults to BlockWhenFull, so a slow consumer makes Pipe
+// stop reading from in; under DropOldest the oldest buffered value gives way
+// instead, and under RejectWhenFull the newest is discarded
+// Panics if capacity < 1
+func Pipe[T any](ctx context.Context, in <-chan T, capacity int, opts ...Option) <-chan T {
+	q := NewBoundedQueue[T](capacity, append([]Option{WithFullPolicy(BlockWhenFull)}, opts...)...)
+	out := make(chan T)
+
+	go func() {
+		defer q.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				if err := q.PushContext(ctx, v); err != nil && !errors.Is(err, ErrFull) {
+					return
+				}
+			}
+		}
+	}()
+
+	go func() {
+		defer close(out)
+		for {
+			v, err := q.PopContext(ctx)
+			if err != nil {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- v:
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,128 @@
+package queue
+
+import (
+	"fmt"
+
+	"github.com/anwar-arif/golang-dsa/compare"
+)
+
+// windowEntry is a value with its position in the stream
+type windowEntry[T any] struct {
+	pos   int
+	value T
+}
+
+// WindowTracker reports the maximum, or minimum, of the last k values pushed
+// in O(1), with Push costing amortized O(1)
+// It keeps a monotonic deque: positions in the window whose values are
+// strictly better than every later value, best first. A new value evicts the
+// values it beats from the back, and the front leaves once it slides out of
+// the window
+type WindowTracker[T any] struct {
+	k       int
+	pushed  int // values pushed so far, the position of the next value
+	entries []windowEntry[T]
+	head    int // entries[head:] is the deque
+	better  func(a, b T) bool
+}
+
+// NewMaxWindow creates a tracker of the largest of the last k values
+// Panics if k < 1
+func NewMaxWindow[T any](k int, compare compare.CompareFunc[T]) *WindowTracker[T] {
+	return newWindow(k, func(a, b T) bool { return compare(a, b) > 0 })
+}
+
+// NewMinWindow creates a tracker of the smallest of the last k values
+// Panics if k < 1
+func NewMinWindow[T any](k int, compare compare.CompareFunc[T]) *WindowTracker[T] {
+	return newWindow(k, func(a, b T) bool { return compare(a, b) < 0 })
+}
+
+func newWindow[T any](k int, better func(a, b T) bool) *WindowTracker[T] {
+	if k < 1 {
+		panic(fmt.Sprintf("queue: window size must be at least 1, got %d", k))
+	}
+	return &WindowTracker[T]{k: k, better: better}
+}
+
+// Push adds value to the window, sliding the oldest value out once the
+// window holds k values
+func (w *WindowTracker[T]) Push(value T) {
+	// Drop values that can never be the answer again
+	for len(w.entries) > w.head && !w.better(w.entries[len(w.entries)-1].value, value) {
+		w.entries = w.entries[:len(w.entries)-1]
+	}
+	w.entries = append(w.entries, windowEntry[T]{pos: w.pushed, value: value})
+	w.pushed++
+
+	if w.entries[w.head].pos <= w.pushed-1-w.k {
+		w.entries[w.head] = windowEntry[T]{} // avoid memory leak
+		w.head++
+	}
+	// Reclaim the space before head once it is most of the slice
+	if w.head > len(w.entries)/2 {
+		n := copy(w.entries, w.entries[w.head:])
+		clear(w.entries[n:])
+		w.entries, w.head = w.entries[:n], 0
+	}
+}
+
+// Value returns the best value in the window: the largest for a max window
+// or the smallest for a min window; among equal values, the latest
+// Returns ErrEmpty if nothing has been pushed
+func (w *WindowTracker[T]) Value() (T, error) {
+	if len(w.entries) == w.head {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return w.entries[w.head].value, nil
+}
+
+// Size returns the number of values in the window, at most k
+func (w *WindowTracker[T]) Size() int {
+	return min(w.pushed, w.k)
+}
+
+// Full returns true once k values have been pushed
+func (w *WindowTracker[T]) Full() bool {
+	return w.pushed >= w.k
+}
+
+// K returns the window size
+func (w *WindowTracker[T]) K() int {
+	return w.k
+}
+
+// Reset empties the window
+func (w *WindowTracker[T]) Reset() {
+	clear(w.entries)
+	w.entries, w.head, w.pushed = w.entries[:0], 0, 0
+}
+
+// SlidingWindowMax returns the largest value of every window of k
+// consecutive values, len(values)-k+1 results in all, or none if k exceeds
+// len(values). O(n)
+// Panics if k < 1
+func SlidingWindowMax[T any](values []T, k int, compare compare.CompareFunc[T]) []T {
+	return slide(values, NewMaxWindow(k, compare))
+}
+
+// SlidingWindowMin returns the smallest value of every window of k
+// consecutive values, len(values)-k+1 results in all, or none if k exceeds
+// len(values). O(n)
+// Panics if k < 1
+func SlidingWindowMin[T any](values []T, k int, compare compare.CompareFunc[T]) []T {
+	return slide(values, NewMinWindow(k, compare))
+}
+
+func slide[T any](values []T, w *WindowTracker[T]) []T {
+	result := make([]T, 0, max(len(values)-w.k+1, 0))
+	for _, v := range values {
+		w.Push(v)
+		if w.Full() {
+			best, _ := w.Value()
+			result = append(result, best)
+		}
+	}
+	return result
+}
@@ -0,0 +1,154 @@
+package queue
+
+import (
+	"cmp"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSlidingWindow(t *testing.T) {
+	values := []int{1, 3, -1, -3, 5, 3, 6, 7}
+
+	if got := SlidingWindowMax(values, 3, cmp.Compare[int]); !slices.Equal(got, []int{3, 3, 5, 5, 6, 7}) {
+		t.Errorf("Unexpected maxima %v", got)
+	}
+	if got := SlidingWindowMin(values, 3, cmp.Compare[int]); !slices.Equal(got, []int{-1, -3, -3, -3, 3, 3}) {
+		t.Errorf("Unexpected minima %v", got)
+	}
+	if got := SlidingWindowMax(values, 1, cmp.Compare[int]); !slices.Equal(got, values) {
+		t.Errorf("Expected k = 1 to return the input, got %v", got)
+	}
+	if got := SlidingWindowMax(values, 9, cmp.Compare[int]); len(got) != 0 {
+		t.Errorf("Expected no windows when k exceeds the input, got %v", got)
+	}
+}
+
+func TestWindowTrackerMatchesScan(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, k := range []int{1, 2, 5, 17} {
+		w := NewMaxWindow(k, cmp.Compare[int])
+		if _, err := w.Value(); !errors.Is(err, ErrEmpty) {
+			t.Errorf("Expected ErrEmpty, got %v", err)
+		}
+
+		var seen []int
+		for i := 0; i < 500; i++ {
+			v := rng.Intn(50)
+			w.Push(v)
+			seen = append(seen, v)
+
+			window := seen[max(len(seen)-k, 0):]
+			if got, _ := w.Value(); got != slices.Max(window) {
+				t.Fatalf("k=%d step %d: expected %d, got %d", k, i, slices.Max(window), got)
+			}
+			if w.Size() != len(window) {
+				t.Fatalf("k=%d step %d: expected size %d, got %d", k, i, len(window), w.Size())
+			}
+		}
+
+		w.Reset()
+		if w.Size() != 0 || w.Full() {
+			t.Errorf("Expected an empty window after Reset")
+		}
+	}
+}
+
+func TestWindowAggregates(t *testing.T) {
+	w := NewWindow[int](3)
+	if _, err := w.Mean(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+	if _, err := w.Max(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	for _, v := range []int{4, 1, 7, 2} {
+		w.Push(v)
+	}
+	// The window now holds 1, 7, 2
+	if got := w.ToSlice(); !slices.Equal(got, []int{1, 7, 2}) {
+		t.Errorf("Expected [1 7 2], got %v", got)
+	}
+	if w.Sum() != 10 {
+		t.Errorf("Expected sum 10, got %d", w.Sum())
+	}
+	if mean, _ := w.Mean(); mean != 10.0/3 {
+		t.Errorf("Expected mean %v, got %v", 10.0/3, mean)
+	}
+	if lo, _ := w.Min(); lo != 1 {
+		t.Errorf("Expected min 1, got %d", lo)
+	}
+	if hi, _ := w.Max(); hi != 7 {
+		t.Errorf("Expected max 7, got %d", hi)
+	}
+	if !w.Full() || w.Size() != 3 || w.Cap() != 3 {
+		t.Errorf("Expected a full window of 3")
+	}
+
+	w.Reset()
+	w.Push(5)
+	if mean, _ := w.Mean(); mean != 5 || w.Sum() != 5 {
+		t.Errorf("Expected a fresh window after Reset, got mean %v sum %d", mean, w.Sum())
+	}
+}
+
+func TestWindowFloat(t *testing.T) {
+	w := NewWindow[float64](2)
+	w.Push(1.5)
+	w.Push(-0.5)
+	w.Push(3)
+	if lo, _ := w.Min(); lo != -0.5 {
+		t.Errorf("Expected min -0.5, got %v", lo)
+	}
+	if mean, _ := w.Mean(); mean != 1.25 {
+		t.Errorf("Expected mean 1.25, got %v", mean)
+	}
+}
+
+func TestWindowSmallIntegers(t *testing.T) {
+	// 3 * 100 does not fit in an int8, but the mean must still be exact
+	w := NewWindow[int8](3)
+	for range 4 {
+		w.Push(100)
+	}
+	if mean, _ := w.Mean(); mean != 100 {
+		t.Errorf("Expected mean 100, got %v", mean)
+	}
+	w.Push(-100)
+	if mean, _ := w.Mean(); mean != 100.0/3 {
+		t.Errorf("Expected mean %v, got %v", 100.0/3, mean)
+	}
+	if w.Sum() != 100 {
+		t.Errorf("Expected sum 100, got %d", w.Sum())
+	}
+
+	u := NewWindow[uint8](2)
+	for _, v := range []uint8{200, 250, 240} {
+		u.Push(v)
+	}
+	if mean, _ := u.Mean(); mean != 245 {
+		t.Errorf("Expected mean 245, got %v", mean)
+	}
+}
+
+// Benchmark tests
+func BenchmarkWindowTracker(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	w := NewMaxWindow(64, cmp.Compare[int])
+	for i := 0; i < b.N; i++ {
+		w.Push(rng.Int())
+		w.Value()
+	}
+}
+
+func BenchmarkWindow(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	w := NewWindow[float64](64)
+	for i := 0; i < b.N; i++ {
+		w.Push(rng.Float64())
+		w.Mean()
+		w.Max()
+	}
+}
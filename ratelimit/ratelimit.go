@@ -0,0 +1,400 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/anwar-arif/golang-dsa/queue"
+)
+
+// ErrLimitExceeded is returned by Wait when a request can never be admitted,
+// e.g. when a leaky bucket is full
+var ErrLimitExceeded = errors.New("rate limit exceeded")
+
+// ErrInvalidRate is returned when a token bucket is given a rate that is not positive
+var ErrInvalidRate = errors.New("rate must be positive")
+
+// Limiter is implemented by every rate limiter in this package
+type Limiter interface {
+	// Allow reports whether a request may proceed right now
+	Allow() bool
+	// Wait blocks until a request may proceed or the context is done
+	Wait(ctx context.Context) error
+	// Reserve books a slot for a future request and reports how long to wait for it
+	Reserve() *Reservation
+}
+
+// Reservation holds the outcome of a Reserve call
+type Reservation struct {
+	ok     bool
+	delay  time.Duration
+	cancel func()
+}
+
+// OK returns false if the request can never be admitted
+func (r *Reservation) OK() bool {
+	return r.ok
+}
+
+// Delay returns how long the caller must wait before acting on the reservation
+func (r *Reservation) Delay() time.Duration {
+	return r.delay
+}
+
+// Cancel gives the reserved slot back to the limiter where possible
+func (r *Reservation) Cancel() {
+	if r.ok && r.cancel != nil {
+		r.cancel()
+		r.cancel = nil
+	}
+}
+
+// wait sleeps for the reservation delay, cancelling the reservation if the context ends first
+func wait(ctx context.Context, r *Reservation) error {
+	if !r.OK() {
+		return ErrLimitExceeded
+	}
+	if r.Delay() <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(r.Delay())
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		r.Cancel()
+		return ctx.Err()
+	}
+}
+
+// TokenBucket is a limiter that refills tokens at a constant rate up to a burst size
+// Each request consumes one token
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  int
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewTokenBucket creates a full token bucket that refills rate tokens per second
+// and holds at most burst tokens
+// Returns ErrInvalidRate if rate <= 0
+func NewTokenBucket(rate float64, burst int) (*TokenBucket, error) {
+	if err := checkRate(rate); err != nil {
+		return nil, err
+	}
+	return newTokenBucket(rate, burst, time.Now), nil
+}
+
+// checkRate rejects rates that would stop a token bucket from ever refilling
+func checkRate(rate float64) error {
+	if !(rate > 0) {
+		return fmt.Errorf("ratelimit: %w, got %v", ErrInvalidRate, rate)
+	}
+	return nil
+}
+
+func newTokenBucket(rate float64, burst int, now func() time.Time) *TokenBucket {
+	return &TokenBucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: float64(burst),
+		last:   now(),
+		now:    now,
+	}
+}
+
+// refill adds the tokens accumulated since the last update; callers must hold mu
+func (tb *TokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(tb.last); elapsed > 0 {
+		tb.tokens = math.Min(float64(tb.burst), tb.tokens+elapsed.Seconds()*tb.rate)
+		tb.last = now
+	}
+}
+
+// Allow consumes a token if one is available
+func (tb *TokenBucket) Allow() bool {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.refill(tb.now())
+	if tb.tokens < 1 {
+		return false
+	}
+
+	tb.tokens--
+	return true
+}
+
+// Reserve consumes a token now, going into debt if necessary, and returns
+// the time until that debt is paid off
+func (tb *TokenBucket) Reserve() *Reservation {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.refill(tb.now())
+	tb.tokens--
+
+	var delay time.Duration
+	if tb.tokens < 0 {
+		delay = time.Duration(-tb.tokens / tb.rate * float64(time.Second))
+	}
+
+	return &Reservation{
+		ok:    true,
+		delay: delay,
+		cancel: func() {
+			tb.mu.Lock()
+			defer tb.mu.Unlock()
+			tb.refill(tb.now())
+			tb.tokens = math.Min(float64(tb.burst), tb.tokens+1)
+		},
+	}
+}
+
+// Wait blocks until a token is available or the context is done
+func (tb *TokenBucket) Wait(ctx context.Context) error {
+	return wait(ctx, tb.Reserve())
+}
+
+// SetRate changes the refill rate to rate tokens per second, keeping the
+// tokens accumulated at the old rate
+// Returns ErrInvalidRate and leaves the rate unchanged if rate <= 0
+func (tb *TokenBucket) SetRate(rate float64) error {
+	if err := checkRate(rate); err != nil {
+		return err
+	}
+
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.refill(tb.now())
+	tb.rate = rate
+	return nil
+}
+
+// Rate returns the refill rate in tokens per second
+func (tb *TokenBucket) Rate() float64 {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return tb.rate
+}
+
+// Tokens returns the number of tokens currently available
+func (tb *TokenBucket) Tokens() float64 {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.refill(tb.now())
+	return tb.tokens
+}
+
+// LeakyBucket is a limiter that lets requests out at a constant rate
+// Requests that cannot leave immediately wait in a queue of bounded capacity;
+// once the queue is full further requests are rejected
+type LeakyBucket struct {
+	mu       sync.Mutex
+	interval time.Duration // time between two departures
+	capacity int
+	pending  *queue.Queue[time.Time] // departure times of waiting requests
+	last     time.Time               // departure time of the latest admitted request
+	now      func() time.Time
+}
+
+// NewLeakyBucket creates a leaky bucket that lets rate requests out per second
+// and queues at most capacity requests waiting for their turn
+// Panics if rate <= 0
+func NewLeakyBucket(rate float64, capacity int) *LeakyBucket {
+	return newLeakyBucket(rate, capacity, time.Now)
+}
+
+func newLeakyBucket(rate float64, capacity int, now func() time.Time) *LeakyBucket {
+	return &LeakyBucket{
+		interval: leakInterval(rate),
+		capacity: capacity,
+		pending:  queue.NewQueue[time.Time](),
+		now:      now,
+	}
+}
+
+// leakInterval returns the time between two departures at rate per second
+// Panics if rate <= 0, which would give an infinite or negative interval
+func leakInterval(rate float64) time.Duration {
+	if !(rate > 0) {
+		panic(fmt.Sprintf("ratelimit: leaky bucket rate must be positive, got %v", rate))
+	}
+	return time.Duration(float64(time.Second) / rate)
+}
+
+// leak drops every request whose departure time has passed; callers must hold mu
+func (lb *LeakyBucket) leak(now time.Time) {
+	for !lb.pending.IsEmpty() {
+		next, _ := lb.pending.Front()
+		if next.After(now) {
+			break
+		}
+		lb.pending.Pop()
+	}
+}
+
+// nextDeparture returns the earliest departure time for a new request; callers must hold mu
+func (lb *LeakyBucket) nextDeparture(now time.Time) time.Time {
+	if lb.last.IsZero() {
+		return now
+	}
+	next := lb.last.Add(lb.interval)
+	if next.Before(now) {
+		return now
+	}
+	return next
+}
+
+// Allow admits a request only if it can leave the bucket immediately
+func (lb *LeakyBucket) Allow() bool {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	now := lb.now()
+	lb.leak(now)
+
+	departure := lb.nextDeparture(now)
+	if departure.After(now) {
+		return false
+	}
+
+	lb.last = departure
+	return true
+}
+
+// Reserve queues a request and returns how long it has to wait for its departure
+// The reservation is not OK if the queue is already full
+func (lb *LeakyBucket) Reserve() *Reservation {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	now := lb.now()
+	lb.leak(now)
+
+	departure := lb.nextDeparture(now)
+	if !departure.After(now) {
+		lb.last = departure
+		return &Reservation{ok: true}
+	}
+
+	if lb.pending.Size() >= lb.capacity {
+		return &Reservation{ok: false}
+	}
+
+	lb.pending.Push(departure)
+	lb.last = departure
+
+	return &Reservation{
+		ok:    true,
+		delay: departure.Sub(now),
+		cancel: func() {
+			lb.mu.Lock()
+			defer lb.mu.Unlock()
+			// Only the most recent reservation can be handed back without
+			// shifting the departure times of the ones queued behind it
+			if rear, err := lb.pending.Rear(); err == nil && rear.Equal(departure) && lb.last.Equal(departure) {
+				lb.removeRear()
+			}
+		},
+	}
+}
+
+// removeRear drops the latest queued departure; callers must hold mu
+func (lb *LeakyBucket) removeRear() {
+	// Bring the rear round to the front, where it can be popped
+	lb.pending.Rotate(-1)
+	lb.pending.Pop()
+
+	if rear, err := lb.pending.Rear(); err == nil {
+		lb.last = rear
+	} else {
+		lb.last = lb.last.Add(-lb.interval)
+	}
+}
+
+// Wait blocks until the request leaves the bucket or the context is done
+// It returns ErrLimitExceeded without waiting if the queue is full
+func (lb *LeakyBucket) Wait(ctx context.Context) error {
+	return wait(ctx, lb.Reserve())
+}
+
+// SetRate changes the bucket to let rate requests out per second
+// Requests already queued keep their departure times; the new rate spaces
+// out the ones admitted from now on
+// Panics if rate <= 0
+func (lb *LeakyBucket) SetRate(rate float64) {
+	interval := leakInterval(rate)
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	lb.interval = interval
+}
+
+// Rate returns the number of requests let out per second
+func (lb *LeakyBucket) Rate() float64 {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	return float64(time.Second) / float64(lb.interval)
+}
+
+// Pending returns the number of requests waiting in the bucket
+func (lb *LeakyBucket) Pending() int {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	lb.leak(lb.now())
+	return lb.pending.Size()
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Rate Limiter Examples ===")
+
+	// Example 1: Token bucket allows bursts up to its size
+	fmt.Println("1. Token Bucket (rate 2/s, burst 3):")
+	tb, _ := NewTokenBucket(2, 3)
+
+	for i := 1; i <= 5; i++ {
+		fmt.Printf("  Request %d allowed: %t\n", i, tb.Allow())
+	}
+
+	// Example 2: Leaky bucket smooths requests to a constant rate
+	fmt.Println("\n2. Leaky Bucket (rate 10/s, queue 3):")
+	lb := NewLeakyBucket(10, 3)
+
+	for i := 1; i <= 5; i++ {
+		r := lb.Reserve()
+		if !r.OK() {
+			fmt.Printf("  Request %d rejected: bucket full\n", i)
+			continue
+		}
+		fmt.Printf("  Request %d leaves after %v\n", i, r.Delay())
+	}
+
+	// Example 3: Waiting with a deadline
+	fmt.Println("\n3. Wait With Deadline:")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	limiter, err := NewTokenBucket(1, 1)
+	if err != nil {
+		fmt.Printf("  Error: %v\n", err)
+		return
+	}
+	limiter.Allow()
+	if err := limiter.Wait(ctx); err != nil {
+		fmt.Printf("  Wait error: %v\n", err)
+	}
+}
@@ -0,0 +1,310 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for deterministic tests
+type fakeClock struct {
+	t time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{t: time.Unix(1000, 0)}
+}
+
+func (c *fakeClock) Now() time.Time { return c.t }
+
+func (c *fakeClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func mustTokenBucket(tb testing.TB, rate float64, burst int) *TokenBucket {
+	tb.Helper()
+	b, err := NewTokenBucket(rate, burst)
+	if err != nil {
+		tb.Fatalf("NewTokenBucket: %v", err)
+	}
+	return b
+}
+
+func TestTokenBucketAllow(t *testing.T) {
+	clock := newFakeClock()
+	tb := newTokenBucket(10, 3, clock.Now)
+
+	// Burst of 3 should be allowed
+	for i := 0; i < 3; i++ {
+		if !tb.Allow() {
+			t.Errorf("Expected request %d to be allowed", i)
+		}
+	}
+
+	if tb.Allow() {
+		t.Error("Expected request to be rejected after burst")
+	}
+
+	// 10 tokens per second: one token every 100ms
+	clock.Advance(100 * time.Millisecond)
+	if !tb.Allow() {
+		t.Error("Expected request to be allowed after refill")
+	}
+
+	// Refill never exceeds the burst size
+	clock.Advance(time.Hour)
+	if tokens := tb.Tokens(); tokens != 3 {
+		t.Errorf("Expected 3 tokens, got %v", tokens)
+	}
+}
+
+func TestTokenBucketReserve(t *testing.T) {
+	clock := newFakeClock()
+	tb := newTokenBucket(10, 1, clock.Now)
+
+	r := tb.Reserve()
+	if !r.OK() || r.Delay() != 0 {
+		t.Errorf("Expected immediate reservation, got ok=%v delay=%v", r.OK(), r.Delay())
+	}
+
+	r = tb.Reserve()
+	if !r.OK() || r.Delay() != 100*time.Millisecond {
+		t.Errorf("Expected 100ms delay, got ok=%v delay=%v", r.OK(), r.Delay())
+	}
+
+	r = tb.Reserve()
+	if r.Delay() != 200*time.Millisecond {
+		t.Errorf("Expected 200ms delay, got %v", r.Delay())
+	}
+
+	// Cancelling hands the token back
+	r.Cancel()
+	r = tb.Reserve()
+	if r.Delay() != 200*time.Millisecond {
+		t.Errorf("Expected 200ms delay after cancel, got %v", r.Delay())
+	}
+}
+
+func TestTokenBucketRejectsNonPositiveRate(t *testing.T) {
+	for _, rate := range []float64{0, -1} {
+		if tb, err := NewTokenBucket(rate, 1); !errors.Is(err, ErrInvalidRate) || tb != nil {
+			t.Errorf("NewTokenBucket(%v): expected ErrInvalidRate, got %v", rate, err)
+		}
+	}
+
+	tb := mustTokenBucket(t, 10, 1)
+	if err := tb.SetRate(0); !errors.Is(err, ErrInvalidRate) {
+		t.Errorf("SetRate(0): expected ErrInvalidRate, got %v", err)
+	}
+	if tb.Rate() != 10 {
+		t.Errorf("Expected a rejected SetRate to keep rate 10, got %v", tb.Rate())
+	}
+}
+
+func TestTokenBucketWait(t *testing.T) {
+	tb := mustTokenBucket(t, 100, 1)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := tb.Wait(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	// Two of the three requests had to wait 10ms each
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("Expected waits to take at least 15ms, took %v", elapsed)
+	}
+}
+
+func TestTokenBucketWaitCancelled(t *testing.T) {
+	tb := mustTokenBucket(t, 1, 1)
+	tb.Allow()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := tb.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+
+	// The cancelled reservation must not leave the bucket in debt
+	if tokens := tb.Tokens(); tokens < 0 {
+		t.Errorf("Expected no token debt after cancel, got %v", tokens)
+	}
+}
+
+func TestLeakyBucketAllow(t *testing.T) {
+	clock := newFakeClock()
+	lb := newLeakyBucket(10, 5, clock.Now)
+
+	if !lb.Allow() {
+		t.Error("Expected first request to be allowed")
+	}
+
+	// Requests leave at a constant rate, so no bursts
+	if lb.Allow() {
+		t.Error("Expected second immediate request to be rejected")
+	}
+
+	clock.Advance(100 * time.Millisecond)
+	if !lb.Allow() {
+		t.Error("Expected request to be allowed after one interval")
+	}
+}
+
+func TestLeakyBucketReserve(t *testing.T) {
+	clock := newFakeClock()
+	lb := newLeakyBucket(10, 2, clock.Now)
+
+	expected := []time.Duration{0, 100 * time.Millisecond, 200 * time.Millisecond}
+	for i, want := range expected {
+		r := lb.Reserve()
+		if !r.OK() || r.Delay() != want {
+			t.Errorf("Reservation %d: expected delay %v, got ok=%v delay=%v", i, want, r.OK(), r.Delay())
+		}
+	}
+
+	if lb.Pending() != 2 {
+		t.Errorf("Expected 2 pending requests, got %d", lb.Pending())
+	}
+
+	// Queue is full
+	if r := lb.Reserve(); r.OK() {
+		t.Error("Expected reservation to fail on full bucket")
+	}
+
+	// The first queued request leaks out after 100ms
+	clock.Advance(100 * time.Millisecond)
+	if lb.Pending() != 1 {
+		t.Errorf("Expected 1 pending request, got %d", lb.Pending())
+	}
+
+	r := lb.Reserve()
+	if !r.OK() || r.Delay() != 200*time.Millisecond {
+		t.Errorf("Expected delay 200ms, got ok=%v delay=%v", r.OK(), r.Delay())
+	}
+
+	// Cancelling the latest reservation frees its slot
+	r.Cancel()
+	if lb.Pending() != 1 {
+		t.Errorf("Expected 1 pending request after cancel, got %d", lb.Pending())
+	}
+}
+
+func TestLeakyBucketWaitFull(t *testing.T) {
+	lb := NewLeakyBucket(1, 1)
+
+	lb.Allow()
+	lb.Reserve()
+
+	if err := lb.Wait(context.Background()); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Expected ErrLimitExceeded, got %v", err)
+	}
+}
+
+func TestSetRate(t *testing.T) {
+	clock := newFakeClock()
+	tb := newTokenBucket(10, 5, clock.Now)
+	for tb.Allow() {
+	}
+
+	// Tokens earned at the old rate are kept
+	clock.Advance(100 * time.Millisecond)
+	if err := tb.SetRate(1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tb.Rate() != 1 || tb.Tokens() != 1 {
+		t.Errorf("Expected rate 1 with 1 token, got rate %v with %v tokens", tb.Rate(), tb.Tokens())
+	}
+	clock.Advance(500 * time.Millisecond)
+	if tokens := tb.Tokens(); tokens != 1.5 {
+		t.Errorf("Expected 1.5 tokens at the new rate, got %v", tokens)
+	}
+
+	lb := newLeakyBucket(10, 5, clock.Now)
+	lb.Reserve()
+	if r := lb.Reserve(); r.Delay() != 100*time.Millisecond {
+		t.Errorf("Expected 100ms delay, got %v", r.Delay())
+	}
+	lb.SetRate(2)
+	if lb.Rate() != 2 {
+		t.Errorf("Expected rate 2, got %v", lb.Rate())
+	}
+	if r := lb.Reserve(); r.Delay() != 600*time.Millisecond {
+		t.Errorf("Expected 600ms delay after slowing down, got %v", r.Delay())
+	}
+}
+
+func TestLeakyBucketRejectsNonPositiveRate(t *testing.T) {
+	mustPanic := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s: expected a panic", name)
+			}
+		}()
+		fn()
+	}
+
+	mustPanic("NewLeakyBucket(0)", func() { NewLeakyBucket(0, 2) })
+	mustPanic("NewLeakyBucket(-1)", func() { NewLeakyBucket(-1, 2) })
+	lb := NewLeakyBucket(10, 2)
+	mustPanic("SetRate(0)", func() { lb.SetRate(0) })
+	if lb.Rate() != 10 {
+		t.Errorf("Expected a rejected SetRate to keep rate 10, got %v", lb.Rate())
+	}
+}
+
+func TestLeakyBucketCancelRear(t *testing.T) {
+	clock := newFakeClock()
+	lb := newLeakyBucket(10, 5, clock.Now)
+	lb.Reserve()
+	lb.Reserve()
+	lb.Reserve()
+	r := lb.Reserve()
+	if lb.Pending() != 3 {
+		t.Fatalf("Expected 3 pending, got %d", lb.Pending())
+	}
+
+	r.Cancel()
+	if lb.Pending() != 2 {
+		t.Errorf("Expected 2 pending after cancel, got %d", lb.Pending())
+	}
+	if r := lb.Reserve(); r.Delay() != 300*time.Millisecond {
+		t.Errorf("Expected the freed slot to be reused, got %v", r.Delay())
+	}
+}
+
+func TestLimiterInterface(t *testing.T) {
+	limiters := []Limiter{
+		mustTokenBucket(t, 1000, 1),
+		NewLeakyBucket(1000, 10),
+	}
+
+	for _, l := range limiters {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Errorf("Unexpected error from %T: %v", l, err)
+		}
+	}
+}
+
+// Benchmark tests
+func BenchmarkTokenBucketAllow(b *testing.B) {
+	tb := mustTokenBucket(b, 1e9, 1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tb.Allow()
+	}
+}
+
+func BenchmarkLeakyBucketReserve(b *testing.B) {
+	clock := newFakeClock()
+	lb := newLeakyBucket(1e6, 1000, clock.Now)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		lb.Reserve()
+		clock.Advance(time.Microsecond)
+	}
+}
@@ -0,0 +1,430 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/anwar-arif/golang-dsa/future"
+	"github.com/anwar-arif/golang-dsa/internal/wake"
+	"github.com/anwar-arif/golang-dsa/priorityqueue"
+)
+
+var (
+	// ErrJobNotFound is returned when a job ID is unknown, cancelled or already finished
+	ErrJobNotFound = errors.New("job not found")
+	// ErrCancelled settles the future of a submitted job that is cancelled before it runs
+	ErrCancelled = errors.New("job cancelled")
+	// ErrStopped is returned by Run once Stop has been called
+	ErrStopped = errors.New("scheduler stopped")
+)
+
+// JobID identifies a scheduled job
+type JobID uint64
+
+// Func is the work executed when a job fires
+// The context is cancelled when the scheduler shuts down
+type Func func(ctx context.Context)
+
+// job holds the state of a scheduled job
+type job struct {
+	id       JobID
+	fn       Func
+	schedule Schedule
+	next     time.Time // next fire time, zero when the job has no more runs
+	paused   bool
+	item     *priorityqueue.Item[entry] // queued fire time, nil when not queued
+	onCancel func(err error)            // called when the job is cancelled or dropped on shutdown, may be nil
+}
+
+// entry is what the priority queue orders on
+type entry struct {
+	at  time.Time
+	job *job
+}
+
+func entryByTime(a, b entry) int {
+	return a.at.Compare(b.at)
+}
+
+// Scheduler runs one-shot and recurring jobs at their fire times
+// Pending fire times are kept in a min priority queue so the loop only ever
+// has to look at the earliest one; each job keeps the handle of its queued
+// item so Pause and Cancel can take it out in O(log n)
+type Scheduler struct {
+	mu     sync.Mutex
+	queue  *priorityqueue.PriorityQueue[entry]
+	jobs   map[JobID]*job
+	nextID JobID
+	wake   wake.Signal
+	now    func() time.Time
+
+	stop     chan struct{} // closed by Stop
+	stopOnce sync.Once
+	runs     []chan struct{} // one per active Run call, closed and removed as it returns
+}
+
+// New creates an idle scheduler; call Run to start firing jobs
+func New() *Scheduler {
+	return &Scheduler{
+		queue: priorityqueue.NewMinQueue(entryByTime),
+		jobs:  make(map[JobID]*job),
+		wake:  wake.New(),
+		now:   time.Now,
+		stop:  make(chan struct{}),
+	}
+}
+
+// Schedule adds a job that fires according to schedule
+func (s *Scheduler) Schedule(schedule Schedule, fn Func) JobID {
+	return s.add(schedule, fn, nil)
+}
+
+func (s *Scheduler) add(schedule Schedule, fn Func, onCancel func(err error)) JobID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	j := &job{
+		id:       s.nextID,
+		fn:       fn,
+		schedule: schedule,
+		onCancel: onCancel,
+	}
+
+	// A stopped scheduler never runs again, so settle submitted jobs now
+	// instead of leaving their futures pending forever
+	if onCancel != nil {
+		select {
+		case <-s.stop:
+			onCancel(ErrStopped)
+			return j.id
+		default:
+		}
+	}
+	s.jobs[j.id] = j
+
+	// Once schedules may already be due; fire them as soon as possible
+	if once, ok := schedule.(onceSchedule); ok {
+		j.next = once.at
+	} else {
+		j.next = schedule.Next(s.now())
+	}
+
+	s.enqueue(j)
+	return j.id
+}
+
+// At adds a one-shot job that fires at t
+func (s *Scheduler) At(t time.Time, fn Func) JobID {
+	return s.Schedule(Once(t), fn)
+}
+
+// After adds a one-shot job that fires once d has elapsed
+func (s *Scheduler) After(d time.Duration, fn Func) JobID {
+	return s.At(s.now().Add(d), fn)
+}
+
+// Every adds a recurring job that fires every interval
+func (s *Scheduler) Every(interval time.Duration, fn Func) JobID {
+	return s.Schedule(Every(interval), fn)
+}
+
+// Cron adds a recurring job described by a cron-like spec (see ParseCron)
+func (s *Scheduler) Cron(spec string, fn Func) (JobID, error) {
+	schedule, err := ParseCron(spec)
+	if err != nil {
+		return 0, err
+	}
+	return s.Schedule(schedule, fn), nil
+}
+
+// Pause stops a job from firing until it is resumed
+func (s *Scheduler) Pause(id JobID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	j.paused = true
+	s.dequeue(j)
+	return nil
+}
+
+// Resume lets a paused job fire again
+// Recurring jobs continue from their next fire time after now; a one-shot job
+// whose time passed while paused fires immediately
+func (s *Scheduler) Resume(id JobID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok {
+		return ErrJobNotFound
+	}
+	if !j.paused {
+		return nil
+	}
+
+	j.paused = false
+	if _, once := j.schedule.(onceSchedule); !once {
+		j.next = j.schedule.Next(s.now())
+	}
+
+	s.enqueue(j)
+	return nil
+}
+
+// Cancel removes a job so it never fires again
+// A run that is already in progress is not interrupted
+func (s *Scheduler) Cancel(id JobID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	s.dequeue(j)
+	delete(s.jobs, id)
+	if j.onCancel != nil {
+		j.onCancel(ErrCancelled)
+	}
+	return nil
+}
+
+// Submit schedules fn to run once at t and returns a future for its result
+// The future is rejected with ErrCancelled if the job is cancelled before it
+// runs, and with ErrStopped or the Run context error if the scheduler shuts
+// down first; the job is then dropped
+// After Stop the future is rejected with ErrStopped straight away
+func Submit[T any](s *Scheduler, at time.Time, fn func(ctx context.Context) (T, error)) (JobID, *future.Future[T]) {
+	p := future.NewPromise[T]()
+
+	id := s.add(Once(at), func(ctx context.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				p.Reject(fmt.Errorf("%w: %v", future.ErrPanicked, r))
+			}
+		}()
+		p.Complete(fn(ctx))
+	}, func(err error) {
+		p.Reject(err)
+	})
+
+	return id, p.Future()
+}
+
+// Len returns the number of jobs that are scheduled or paused
+func (s *Scheduler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.jobs)
+}
+
+// NextRun returns the next fire time of a job
+func (s *Scheduler) NextRun(id JobID) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok {
+		return time.Time{}, ErrJobNotFound
+	}
+	return j.next, nil
+}
+
+// enqueue pushes the current fire time of a job and wakes the run loop; callers must hold mu
+func (s *Scheduler) enqueue(j *job) {
+	if j.next.IsZero() {
+		delete(s.jobs, j.id)
+		return
+	}
+
+	s.dequeue(j)
+	j.item = priorityqueue.NewItem(entry{at: j.next, job: j})
+	s.queue.PushItem(j.item)
+
+	s.wake.Notify()
+}
+
+// shutdown drops every pending job that has a cancel hook, such as the jobs
+// behind Submit, calling the hook with err, and returns err
+func (s *Scheduler) shutdown(err error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, j := range s.jobs {
+		if j.onCancel == nil {
+			continue
+		}
+		s.dequeue(j)
+		delete(s.jobs, id)
+		j.onCancel(err)
+	}
+	return err
+}
+
+// dequeue removes the queued fire time of a job, if any; callers must hold mu
+func (s *Scheduler) dequeue(j *job) {
+	if j.item != nil {
+		s.queue.Remove(j.item)
+		j.item = nil
+	}
+}
+
+// Run fires due jobs until ctx is cancelled or Stop is called, then waits for
+// running jobs to return before returning the context error or ErrStopped
+// Submitted jobs that have not fired are dropped and their futures rejected
+// with that same error
+// Each run happens on its own goroutine and receives ctx
+func (s *Scheduler) Run(ctx context.Context) error {
+	done := make(chan struct{})
+	s.mu.Lock()
+	s.runs = append(s.runs, done)
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.runs = slices.DeleteFunc(s.runs, func(c chan struct{}) bool { return c == done })
+		s.mu.Unlock()
+		close(done)
+	}()
+
+	var running sync.WaitGroup
+	defer running.Wait()
+
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return s.shutdown(ErrStopped)
+		default:
+		}
+
+		s.mu.Lock()
+		e, err := s.queue.Peek()
+		ok := err == nil
+		now := s.now()
+
+		if ok && !e.at.After(now) {
+			s.queue.Pop()
+			j := e.job
+			j.item = nil
+
+			running.Add(1)
+			go func(fn Func) {
+				defer running.Done()
+				fn(ctx)
+			}(j.fn)
+
+			// Skip missed fire times instead of running them back to back
+			next := j.schedule.Next(e.at)
+			if !next.IsZero() && !next.After(now) {
+				next = j.schedule.Next(now)
+			}
+			j.next = next
+			s.enqueue(j)
+
+			s.mu.Unlock()
+			continue
+		}
+		s.mu.Unlock()
+
+		wait := time.Hour
+		if ok {
+			wait = e.at.Sub(now)
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+
+		select {
+		case <-ctx.Done():
+			return s.shutdown(ctx.Err())
+		case <-s.stop:
+			return s.shutdown(ErrStopped)
+		case <-s.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// Stop shuts the scheduler down gracefully: Run stops firing jobs and
+// returns ErrStopped once the runs in progress have finished on their own,
+// without their context being cancelled
+// Stop waits for that to happen or for ctx to be done, whichever is first;
+// jobs that have not fired stay scheduled but will not run, and the futures of
+// submitted jobs are rejected with ErrStopped
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.stop) })
+	s.shutdown(ErrStopped)
+
+	s.mu.Lock()
+	runs := slices.Clone(s.runs)
+	s.mu.Unlock()
+
+	for _, done := range runs {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// String returns a string representation of the scheduler
+func (s *Scheduler) String() string {
+	return fmt.Sprintf("Scheduler{jobs: %d}", s.Len())
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Scheduler Examples ===")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 350*time.Millisecond)
+	defer cancel()
+
+	s := New()
+
+	// Example 1: One-shot job
+	s.After(50*time.Millisecond, func(ctx context.Context) {
+		fmt.Println("  One-shot job fired")
+	})
+
+	// Example 2: Recurring job
+	ticks := s.Every(100*time.Millisecond, func(ctx context.Context) {
+		fmt.Println("  Tick")
+	})
+
+	// Example 3: Cron-like job (fires at the top of every hour)
+	if _, err := s.Cron("@hourly", func(ctx context.Context) {
+		fmt.Println("  Hourly report")
+	}); err != nil {
+		fmt.Printf("  Cron error: %v\n", err)
+	}
+
+	// Example 4: Pausing a job
+	go func() {
+		time.Sleep(250 * time.Millisecond)
+		s.Pause(ticks)
+		fmt.Println("  Ticks paused")
+	}()
+
+	s.Run(ctx)
+	fmt.Printf("Scheduler stopped with %d jobs left\n", s.Len())
+}
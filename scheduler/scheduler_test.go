@@ -0,0 +1,473 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	base := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC) // a Monday
+
+	tests := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2024, time.January, 15, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, time.January, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2024, time.January, 15, 11, 0, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2024, time.January, 16, 9, 0, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2024, time.January, 15, 13, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 5", time.Date(2024, time.January, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, time.January, 21, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 5-7", time.Date(2024, time.January, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 6-7", time.Date(2024, time.January, 20, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"5,35 10 * * *", time.Date(2024, time.January, 15, 10, 35, 0, 0, time.UTC)},
+		// Day-of-month and day-of-week are OR-ed when both are restricted
+		{"0 0 20 * 3", time.Date(2024, time.January, 17, 0, 0, 0, 0, time.UTC)},
+		// A stepped "*" still counts as unrestricted, so the fields are AND-ed
+		{"0 0 */2 * 1", time.Date(2024, time.January, 29, 0, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, time.January, 16, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 90s", time.Date(2024, time.January, 15, 10, 31, 30, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		s, err := ParseCron(tt.spec)
+		if err != nil {
+			t.Errorf("ParseCron(%q) unexpected error: %v", tt.spec, err)
+			continue
+		}
+		if next := s.Next(base); !next.Equal(tt.expected) {
+			t.Errorf("ParseCron(%q).Next = %v, expected %v", tt.spec, next, tt.expected)
+		}
+	}
+}
+
+func TestCronNonWholeHourOffset(t *testing.T) {
+	for _, offset := range []int{5*3600 + 30*60, 5*3600 + 45*60, -(9*3600 + 30*60)} {
+		loc := time.FixedZone("test", offset)
+		base := time.Date(2024, time.January, 15, 10, 30, 15, 0, loc)
+
+		s, _ := ParseCron("0 12 * * *")
+		expected := time.Date(2024, time.January, 15, 12, 0, 0, 0, loc)
+		if next := s.Next(base); !next.Equal(expected) {
+			t.Errorf("Offset %d: daily Next = %v, expected %v", offset, next, expected)
+		}
+
+		s, _ = ParseCron("* * * * *")
+		expected = time.Date(2024, time.January, 15, 10, 31, 0, 0, loc)
+		if next := s.Next(base); !next.Equal(expected) {
+			t.Errorf("Offset %d: minutely Next = %v, expected %v", offset, next, expected)
+		}
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"* * * * 17",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@every nope",
+	}
+
+	for _, spec := range specs {
+		if _, err := ParseCron(spec); err == nil {
+			t.Errorf("Expected error for spec %q", spec)
+		}
+	}
+}
+
+func TestOnceAndEvery(t *testing.T) {
+	at := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	once := Once(at)
+	if next := once.Next(at.Add(-time.Second)); !next.Equal(at) {
+		t.Errorf("Expected %v, got %v", at, next)
+	}
+	if next := once.Next(at); !next.IsZero() {
+		t.Errorf("Expected zero time after firing, got %v", next)
+	}
+
+	every := Every(time.Minute)
+	if next := every.Next(at); !next.Equal(at.Add(time.Minute)) {
+		t.Errorf("Expected %v, got %v", at.Add(time.Minute), next)
+	}
+}
+
+func TestOneShotJobs(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var mu sync.Mutex
+	var order []int
+	done := make(chan struct{})
+
+	record := func(n int) Func {
+		return func(ctx context.Context) {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, n)
+			if len(order) == 3 {
+				close(done)
+			}
+		}
+	}
+
+	s.After(60*time.Millisecond, record(3))
+	s.After(20*time.Millisecond, record(1))
+	s.After(40*time.Millisecond, record(2))
+
+	go s.Run(ctx)
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for jobs")
+	}
+	cancel()
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i, v := range []int{1, 2, 3} {
+		if order[i] != v {
+			t.Errorf("Expected job %d at position %d, got %d", v, i, order[i])
+		}
+	}
+
+	if s.Len() != 0 {
+		t.Errorf("Expected finished one-shot jobs to be removed, got %d", s.Len())
+	}
+}
+
+func TestRecurringJobAndCancel(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var count atomic.Int32
+	id := s.Every(10*time.Millisecond, func(ctx context.Context) {
+		count.Add(1)
+	})
+
+	go s.Run(ctx)
+
+	time.Sleep(75 * time.Millisecond)
+	if err := s.Cancel(id); err != nil {
+		t.Fatalf("Unexpected cancel error: %v", err)
+	}
+	fired := count.Load()
+
+	if fired < 3 {
+		t.Errorf("Expected at least 3 runs, got %d", fired)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	if count.Load() != fired {
+		t.Errorf("Expected no runs after cancel, got %d more", count.Load()-fired)
+	}
+
+	if err := s.Cancel(id); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected ErrJobNotFound, got %v", err)
+	}
+}
+
+func TestPauseResume(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var count atomic.Int32
+	id := s.Every(10*time.Millisecond, func(ctx context.Context) {
+		count.Add(1)
+	})
+
+	if err := s.Pause(id); err != nil {
+		t.Fatalf("Unexpected pause error: %v", err)
+	}
+
+	go s.Run(ctx)
+
+	time.Sleep(50 * time.Millisecond)
+	if count.Load() != 0 {
+		t.Errorf("Expected no runs while paused, got %d", count.Load())
+	}
+
+	if err := s.Resume(id); err != nil {
+		t.Fatalf("Unexpected resume error: %v", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if count.Load() == 0 {
+		t.Error("Expected runs after resume")
+	}
+
+	if err := s.Pause(JobID(999)); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected ErrJobNotFound, got %v", err)
+	}
+}
+
+func TestPausedOneShotFiresOnResume(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fired := make(chan struct{})
+	id := s.After(10*time.Millisecond, func(ctx context.Context) {
+		close(fired)
+	})
+	s.Pause(id)
+
+	go s.Run(ctx)
+
+	select {
+	case <-fired:
+		t.Fatal("Paused job should not fire")
+	case <-time.After(40 * time.Millisecond):
+	}
+
+	s.Resume(id)
+
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("Expected overdue job to fire after resume")
+	}
+}
+
+func TestRunWaitsForRunningJobs(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var finished atomic.Bool
+	started := make(chan struct{})
+	s.After(0, func(jobCtx context.Context) {
+		close(started)
+		<-jobCtx.Done()
+		time.Sleep(20 * time.Millisecond)
+		finished.Store(true)
+	})
+
+	result := make(chan error)
+	go func() { result <- s.Run(ctx) }()
+
+	<-started
+	cancel()
+
+	if err := <-result; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if !finished.Load() {
+		t.Error("Expected Run to wait for the running job")
+	}
+}
+
+func TestCancelRemovesQueuedEntry(t *testing.T) {
+	s := New()
+	first := s.After(time.Hour, func(ctx context.Context) {})
+	s.After(2*time.Hour, func(ctx context.Context) {})
+	s.Pause(first)
+
+	if s.queue.Size() != 1 {
+		t.Errorf("Expected pause to dequeue the job, got %d queued", s.queue.Size())
+	}
+	s.Resume(first)
+	if err := s.Cancel(first); err != nil {
+		t.Fatalf("Unexpected cancel error: %v", err)
+	}
+	if s.queue.Size() != 1 {
+		t.Errorf("Expected 1 queued entry after cancel, got %d", s.queue.Size())
+	}
+}
+
+func TestStop(t *testing.T) {
+	s := New()
+
+	var finished atomic.Bool
+	started := make(chan struct{})
+	s.After(0, func(jobCtx context.Context) {
+		close(started)
+		time.Sleep(20 * time.Millisecond)
+		if jobCtx.Err() != nil {
+			t.Error("Expected a graceful stop to leave the job context alive")
+		}
+		finished.Store(true)
+	})
+	s.After(time.Hour, func(ctx context.Context) {
+		t.Error("Expected no jobs to fire after Stop")
+	})
+
+	result := make(chan error)
+	go func() { result <- s.Run(context.Background()) }()
+
+	<-started
+	if err := s.Stop(context.Background()); err != nil {
+		t.Fatalf("Unexpected stop error: %v", err)
+	}
+	if !finished.Load() {
+		t.Error("Expected Stop to wait for the running job")
+	}
+	if err := <-result; !errors.Is(err, ErrStopped) {
+		t.Errorf("Expected ErrStopped, got %v", err)
+	}
+	if err := s.Run(context.Background()); !errors.Is(err, ErrStopped) {
+		t.Errorf("Expected Run after Stop to return ErrStopped, got %v", err)
+	}
+}
+
+func TestRunReleasesItsEntry(t *testing.T) {
+	s := New()
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		s.Run(ctx)
+	}
+
+	s.mu.Lock()
+	runs := len(s.runs)
+	s.mu.Unlock()
+	if runs != 0 {
+		t.Errorf("Expected finished Run calls to be forgotten, got %d", runs)
+	}
+}
+
+func TestStopTimeout(t *testing.T) {
+	s := New()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	s.After(0, func(ctx context.Context) {
+		close(started)
+		<-release
+	})
+	go s.Run(context.Background())
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	close(release)
+}
+
+func TestNextRun(t *testing.T) {
+	s := New()
+	at := time.Now().Add(time.Hour)
+
+	id := s.At(at, func(ctx context.Context) {})
+
+	next, err := s.NextRun(id)
+	if err != nil || !next.Equal(at) {
+		t.Errorf("Expected next run %v, got %v with error %v", at, next, err)
+	}
+
+	if _, err := s.Cron("bad spec", func(ctx context.Context) {}); err == nil {
+		t.Error("Expected error for invalid cron spec")
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go s.Run(ctx)
+
+	_, f := Submit(s, time.Now().Add(10*time.Millisecond), func(ctx context.Context) (int, error) {
+		return 42, nil
+	})
+
+	v, err := f.Get(ctx)
+	if err != nil || v != 42 {
+		t.Errorf("Expected 42, got %v with error %v", v, err)
+	}
+
+	id, cancelled := Submit(s, time.Now().Add(time.Hour), func(ctx context.Context) (int, error) {
+		return 0, nil
+	})
+	s.Cancel(id)
+
+	if _, err := cancelled.Get(ctx); !errors.Is(err, ErrCancelled) {
+		t.Errorf("Expected ErrCancelled, got %v", err)
+	}
+}
+
+func TestSubmitRejectedOnShutdown(t *testing.T) {
+	s := New()
+	_, stopped := Submit(s, time.Now().Add(time.Hour), func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	if err := s.Stop(context.Background()); err != nil {
+		t.Fatalf("Unexpected stop error: %v", err)
+	}
+	if _, err := stopped.Get(context.Background()); !errors.Is(err, ErrStopped) {
+		t.Errorf("Expected ErrStopped after Stop, got %v", err)
+	}
+	if s.Len() != 0 {
+		t.Errorf("Expected the submitted job to be dropped, got %d jobs", s.Len())
+	}
+
+	id, late := Submit(s, time.Now(), func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	if _, err := late.Get(context.Background()); !errors.Is(err, ErrStopped) {
+		t.Errorf("Expected ErrStopped for a Submit after Stop, got %v", err)
+	}
+	if _, err := s.NextRun(id); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected the late job not to be scheduled, got %v", err)
+	}
+
+	s = New()
+	ctx, cancel := context.WithCancel(context.Background())
+	_, cancelled := Submit(s, time.Now().Add(time.Hour), func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	kept := s.After(time.Hour, func(ctx context.Context) {})
+	result := make(chan error)
+	go func() { result <- s.Run(ctx) }()
+	cancel()
+
+	if err := <-result; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled from Run, got %v", err)
+	}
+	if _, err := cancelled.Get(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled from the future, got %v", err)
+	}
+	if _, err := s.NextRun(kept); err != nil {
+		t.Errorf("Expected plain jobs to stay scheduled, got %v", err)
+	}
+}
+
+// Benchmark tests
+func BenchmarkCronNext(b *testing.B) {
+	s, _ := ParseCron("*/5 9-17 * * 1-5")
+	t := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		t = s.Next(t)
+	}
+}
+
+func BenchmarkSchedule(b *testing.B) {
+	s := New()
+	at := time.Now().Add(time.Hour)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.At(at.Add(time.Duration(i)), func(ctx context.Context) {})
+	}
+}
@@ -0,0 +1,227 @@
+package semaphore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/anwar-arif/golang-dsa/queue"
+)
+
+// waiter is a blocked Acquire call
+type waiter struct {
+	n         int64
+	ready     chan struct{} // closed once the weight is granted
+	cancelled bool
+}
+
+// Weighted is a semaphore whose permits can be acquired in arbitrary weights
+// Waiters are served strictly in FIFO order, so a large request is never
+// starved by a stream of small ones
+type Weighted struct {
+	mu      sync.Mutex
+	size    int64
+	cur     int64
+	waiters *queue.Queue[*waiter]
+}
+
+// NewWeighted creates a semaphore with a total weight of n
+func NewWeighted(n int64) *Weighted {
+	return &Weighted{
+		size:    n,
+		waiters: queue.NewQueue[*waiter](),
+	}
+}
+
+// Acquire blocks until n permits are available or ctx is done
+// On failure it returns ctx.Err() and leaves the semaphore unchanged
+func (s *Weighted) Acquire(ctx context.Context, n int64) error {
+	s.mu.Lock()
+	if s.size-s.cur >= n && s.waiters.IsEmpty() {
+		s.cur += n
+		s.mu.Unlock()
+		return nil
+	}
+
+	if n > s.size {
+		// Can never succeed; just wait for the context
+		s.mu.Unlock()
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	w := &waiter{n: n, ready: make(chan struct{})}
+	s.waiters.Push(w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-w.ready:
+			// Granted while we were giving up; hand the permits back
+			s.cur -= n
+		default:
+			w.cancelled = true
+		}
+		s.notifyWaiters()
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// TryAcquire acquires n permits without blocking and reports whether it succeeded
+func (s *Weighted) TryAcquire(n int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size-s.cur >= n && s.waiters.IsEmpty() {
+		s.cur += n
+		return true
+	}
+	return false
+}
+
+// Release returns n permits to the semaphore
+// It panics if more permits are released than are held
+func (s *Weighted) Release(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cur -= n
+	if s.cur < 0 {
+		panic("semaphore: released more than held")
+	}
+	s.notifyWaiters()
+}
+
+// notifyWaiters grants permits to waiters in FIFO order; callers must hold mu
+// Afterwards the front waiter, if any, is a live one that does not fit yet
+func (s *Weighted) notifyWaiters() {
+	for !s.waiters.IsEmpty() {
+		w, _ := s.waiters.Front()
+		if w.cancelled {
+			s.waiters.Pop()
+			continue
+		}
+		if s.size-s.cur < w.n {
+			return
+		}
+		s.cur += w.n
+		s.waiters.Pop()
+		close(w.ready)
+	}
+}
+
+// Available returns the number of permits that are not held
+func (s *Weighted) Available() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.size - s.cur
+}
+
+// String returns a string representation of the semaphore
+func (s *Weighted) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return fmt.Sprintf("Weighted{held: %d, size: %d, waiters: %d}", s.cur, s.size, s.waiters.Size())
+}
+
+// Limiter bounds how many functions run at the same time
+type Limiter struct {
+	sem *Weighted
+	wg  sync.WaitGroup
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewLimiter creates a limiter that runs at most maxConcurrent functions at once
+func NewLimiter(maxConcurrent int) *Limiter {
+	return &Limiter{sem: NewWeighted(int64(maxConcurrent))}
+}
+
+// Do runs fn on the calling goroutine once a slot is free
+func (l *Limiter) Do(ctx context.Context, fn func() error) error {
+	if err := l.sem.Acquire(ctx, 1); err != nil {
+		return err
+	}
+	defer l.sem.Release(1)
+
+	return fn()
+}
+
+// Go waits for a free slot and then runs fn on a new goroutine
+// It only returns an error if ctx ends before a slot frees up; errors from fn
+// are reported by Wait
+func (l *Limiter) Go(ctx context.Context, fn func() error) error {
+	if err := l.sem.Acquire(ctx, 1); err != nil {
+		return err
+	}
+
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		defer l.sem.Release(1)
+
+		if err := fn(); err != nil {
+			l.mu.Lock()
+			if l.err == nil {
+				l.err = err
+			}
+			l.mu.Unlock()
+		}
+	}()
+	return nil
+}
+
+// Wait blocks until every function started with Go has returned and reports the first error
+func (l *Limiter) Wait() error {
+	l.wg.Wait()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.err
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Semaphore Examples ===")
+	ctx := context.Background()
+
+	// Example 1: Weighted permits
+	fmt.Println("1. Weighted Semaphore (size 10):")
+	sem := NewWeighted(10)
+
+	sem.Acquire(ctx, 6)
+	fmt.Printf("  After acquiring 6: %d available\n", sem.Available())
+	fmt.Printf("  TryAcquire(5): %t\n", sem.TryAcquire(5))
+	sem.Release(6)
+	fmt.Printf("  TryAcquire(5) after release: %t\n", sem.TryAcquire(5))
+	sem.Release(5)
+
+	// Example 2: Bounding parallel work
+	fmt.Println("\n2. Limiter (3 at a time):")
+	limiter := NewLimiter(3)
+
+	var mu sync.Mutex
+	results := make([]int, 0)
+	for i := 1; i <= 6; i++ {
+		limiter.Go(ctx, func() error {
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, i*i)
+			return nil
+		})
+	}
+
+	if err := limiter.Wait(); err != nil {
+		fmt.Printf("  Error: %v\n", err)
+	}
+	fmt.Printf("  Computed %d squares\n", len(results))
+}
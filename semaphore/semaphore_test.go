@@ -0,0 +1,261 @@
+package semaphore
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAcquireRelease(t *testing.T) {
+	ctx := context.Background()
+	s := NewWeighted(5)
+
+	if err := s.Acquire(ctx, 3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s.Available() != 2 {
+		t.Errorf("Expected 2 available, got %d", s.Available())
+	}
+
+	if s.TryAcquire(3) {
+		t.Error("Expected TryAcquire(3) to fail")
+	}
+	if !s.TryAcquire(2) {
+		t.Error("Expected TryAcquire(2) to succeed")
+	}
+
+	s.Release(5)
+	if s.Available() != 5 {
+		t.Errorf("Expected 5 available, got %d", s.Available())
+	}
+}
+
+func TestReleaseTooMuchPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic when releasing more than held")
+		}
+	}()
+
+	s := NewWeighted(1)
+	s.Release(1)
+}
+
+func TestAcquireBlocksUntilRelease(t *testing.T) {
+	ctx := context.Background()
+	s := NewWeighted(2)
+	s.Acquire(ctx, 2)
+
+	acquired := make(chan struct{})
+	go func() {
+		s.Acquire(ctx, 1)
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Acquire should block while the semaphore is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	s.Release(1)
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("Acquire was not woken by Release")
+	}
+}
+
+func TestFIFOFairness(t *testing.T) {
+	ctx := context.Background()
+	s := NewWeighted(4)
+	s.Acquire(ctx, 4)
+
+	var mu sync.Mutex
+	var order []int64
+
+	// Queue a big waiter followed by small ones
+	var wg sync.WaitGroup
+	for i, n := range []int64{4, 1, 1} {
+		wg.Add(1)
+		go func(n int64) {
+			defer wg.Done()
+			s.Acquire(ctx, n)
+			mu.Lock()
+			order = append(order, n)
+			mu.Unlock()
+			s.Release(n)
+		}(n)
+		// Make sure waiters enqueue in order
+		for {
+			s.mu.Lock()
+			queued := s.waiters.Size()
+			s.mu.Unlock()
+			if queued == i+1 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// Releasing a single permit must not let the small waiters overtake
+	s.Release(1)
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	if len(order) != 0 {
+		t.Errorf("Small waiters overtook the large one: %v", order)
+	}
+	mu.Unlock()
+
+	s.Release(3)
+	wg.Wait()
+
+	if order[0] != 4 {
+		t.Errorf("Expected the large waiter to go first, got %v", order)
+	}
+}
+
+func TestAcquireCancelled(t *testing.T) {
+	s := NewWeighted(1)
+	s.Acquire(context.Background(), 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := s.Acquire(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+
+	// The cancelled waiter must not block later acquirers
+	s.Release(1)
+	if !s.TryAcquire(1) {
+		t.Error("Expected TryAcquire to succeed after cancelled waiter")
+	}
+}
+
+func TestCancelledFrontWaiterUnblocksOthers(t *testing.T) {
+	s := NewWeighted(2)
+	s.Acquire(context.Background(), 1)
+
+	// A large waiter at the front blocks a small one behind it
+	ctx, cancel := context.WithCancel(context.Background())
+	bigDone := make(chan error)
+	go func() { bigDone <- s.Acquire(ctx, 2) }()
+
+	for {
+		s.mu.Lock()
+		queued := s.waiters.Size()
+		s.mu.Unlock()
+		if queued == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	smallDone := make(chan error)
+	go func() { smallDone <- s.Acquire(context.Background(), 1) }()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	if err := <-bigDone; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	select {
+	case err := <-smallDone:
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Small waiter was not unblocked by the cancellation")
+	}
+}
+
+func TestAcquireMoreThanSize(t *testing.T) {
+	s := NewWeighted(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := s.Acquire(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+	l := NewLimiter(3)
+
+	var active, peak atomic.Int32
+	for i := 0; i < 20; i++ {
+		err := l.Go(ctx, func() error {
+			cur := active.Add(1)
+			for {
+				old := peak.Load()
+				if cur <= old || peak.CompareAndSwap(old, cur) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			active.Add(-1)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if err := l.Wait(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if peak.Load() > 3 {
+		t.Errorf("Expected at most 3 concurrent runs, peak was %d", peak.Load())
+	}
+}
+
+func TestLimiterErrors(t *testing.T) {
+	ctx := context.Background()
+	l := NewLimiter(2)
+	boom := errors.New("boom")
+
+	l.Go(ctx, func() error { return nil })
+	l.Go(ctx, func() error { return boom })
+
+	if err := l.Wait(); !errors.Is(err, boom) {
+		t.Errorf("Expected boom, got %v", err)
+	}
+
+	if err := l.Do(ctx, func() error { return boom }); !errors.Is(err, boom) {
+		t.Errorf("Expected Do to return boom, got %v", err)
+	}
+}
+
+// Benchmark tests
+func BenchmarkAcquireRelease(b *testing.B) {
+	ctx := context.Background()
+	s := NewWeighted(1)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Acquire(ctx, 1)
+		s.Release(1)
+	}
+}
+
+func BenchmarkAcquireReleaseParallel(b *testing.B) {
+	ctx := context.Background()
+	s := NewWeighted(4)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			s.Acquire(ctx, 1)
+			s.Release(1)
+		}
+	})
+}
@@ -0,0 +1,240 @@
+package singleflight
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrPanicked wraps a panic raised by a shared computation
+var ErrPanicked = errors.New("singleflight function panicked")
+
+// Cache stores finished results so later calls can skip the computation
+// Implementations must be safe for concurrent use
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Set(key K, value V)
+}
+
+// Result is what DoChan delivers once the shared computation finishes
+type Result[V any] struct {
+	Value  V
+	Err    error
+	Shared bool // true if the value was handed to more than one caller
+}
+
+// call is an in-flight or finished computation
+type call[V any] struct {
+	wg    sync.WaitGroup
+	value V
+	err   error
+	dups  int
+	chans []chan<- Result[V]
+}
+
+// Group deduplicates concurrent computations that share a key
+type Group[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*call[V]
+	cache Cache[K, V]
+}
+
+// New creates a group without result caching
+func New[K comparable, V any]() *Group[K, V] {
+	return &Group[K, V]{calls: make(map[K]*call[V])}
+}
+
+// NewWithCache creates a group that stores successful results in cache and
+// answers from it before starting a computation
+func NewWithCache[K comparable, V any](cache Cache[K, V]) *Group[K, V] {
+	g := New[K, V]()
+	g.cache = cache
+	return g
+}
+
+// Do runs fn for key unless a call for the same key is already running, in
+// which case it waits for that call and returns its result
+// shared reports whether the result went to more than one caller
+func (g *Group[K, V]) Do(key K, fn func() (V, error)) (value V, err error, shared bool) {
+	g.mu.Lock()
+	if v, ok := g.cached(key); ok {
+		g.mu.Unlock()
+		return v, nil, false
+	}
+
+	if c, ok := g.calls[key]; ok {
+		c.dups++
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.value, c.err, true
+	}
+
+	c := &call[V]{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	g.run(key, c, fn)
+	return c.value, c.err, c.dups > 0
+}
+
+// DoChan is like Do but returns a channel that receives the result, so the
+// caller can stop waiting without cancelling the computation for others
+func (g *Group[K, V]) DoChan(key K, fn func() (V, error)) <-chan Result[V] {
+	ch := make(chan Result[V], 1)
+
+	g.mu.Lock()
+	if v, ok := g.cached(key); ok {
+		g.mu.Unlock()
+		ch <- Result[V]{Value: v}
+		return ch
+	}
+
+	if c, ok := g.calls[key]; ok {
+		c.dups++
+		c.chans = append(c.chans, ch)
+		g.mu.Unlock()
+		return ch
+	}
+
+	c := &call[V]{chans: []chan<- Result[V]{ch}}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	go g.run(key, c, fn)
+	return ch
+}
+
+// cached looks key up in the cache, if any; callers must hold mu
+func (g *Group[K, V]) cached(key K) (V, bool) {
+	if g.cache == nil {
+		var zero V
+		return zero, false
+	}
+	return g.cache.Get(key)
+}
+
+// run executes fn and publishes its result to every waiter
+func (g *Group[K, V]) run(key K, c *call[V], fn func() (V, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.err = fmt.Errorf("%w: %v", ErrPanicked, r)
+		}
+
+		g.mu.Lock()
+		// Forget may already have replaced this call with a newer one
+		if g.calls[key] == c {
+			delete(g.calls, key)
+		}
+		if c.err == nil && g.cache != nil {
+			g.cache.Set(key, c.value)
+		}
+		shared := c.dups > 0
+		chans := c.chans
+		g.mu.Unlock()
+
+		c.wg.Done()
+		for _, ch := range chans {
+			ch <- Result[V]{Value: c.value, Err: c.err, Shared: shared}
+		}
+	}()
+
+	c.value, c.err = fn()
+}
+
+// Forget drops the in-flight call for key so the next Do starts a fresh
+// computation instead of joining it
+// Callers already waiting still receive the original result
+func (g *Group[K, V]) Forget(key K) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.calls, key)
+}
+
+// InFlight returns the number of keys with a running computation
+func (g *Group[K, V]) InFlight() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return len(g.calls)
+}
+
+// String returns a string representation of the group
+func (g *Group[K, V]) String() string {
+	return fmt.Sprintf("Group{inFlight: %d, cached: %t}", g.InFlight(), g.cache != nil)
+}
+
+// MapCache is a minimal unbounded Cache backed by a map
+type MapCache[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+}
+
+// NewMapCache creates an empty MapCache
+func NewMapCache[K comparable, V any]() *MapCache[K, V] {
+	return &MapCache[K, V]{m: make(map[K]V)}
+}
+
+// Get returns the cached value for key
+func (c *MapCache[K, V]) Get(key K) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	v, ok := c.m[key]
+	return v, ok
+}
+
+// Set stores value under key
+func (c *MapCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.m[key] = value
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Single-Flight Examples ===")
+
+	// Example 1: Concurrent callers share one computation
+	fmt.Println("1. Deduplicated Calls:")
+	g := New[string, int]()
+	var computations int
+	var mu sync.Mutex
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.Do("fib(30)", func() (int, error) {
+				time.Sleep(50 * time.Millisecond) // slow enough for the others to join
+				mu.Lock()
+				computations++
+				mu.Unlock()
+				return 832040, nil
+			})
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("  5 callers, %d computation(s)\n", computations)
+
+	// Example 2: Results cached for later callers
+	fmt.Println("\n2. With Cache:")
+	cached := NewWithCache[string, int](NewMapCache[string, int]())
+	for i := 0; i < 3; i++ {
+		v, _, _ := cached.Do("answer", func() (int, error) {
+			fmt.Println("  Computing answer")
+			return 42, nil
+		})
+		fmt.Printf("  Got %d\n", v)
+	}
+
+	// Example 3: Waiting through a channel
+	fmt.Println("\n3. DoChan:")
+	res := <-g.DoChan("pi", func() (int, error) { return 314, nil })
+	fmt.Printf("  Value: %d, shared: %t\n", res.Value, res.Shared)
+}
@@ -0,0 +1,178 @@
+package singleflight
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDo(t *testing.T) {
+	g := New[string, int]()
+
+	v, err, shared := g.Do("key", func() (int, error) { return 7, nil })
+	if v != 7 || err != nil || shared {
+		t.Errorf("Expected (7, nil, false), got (%d, %v, %t)", v, err, shared)
+	}
+
+	boom := errors.New("boom")
+	if _, err, _ := g.Do("key", func() (int, error) { return 0, boom }); !errors.Is(err, boom) {
+		t.Errorf("Expected boom, got %v", err)
+	}
+
+	if g.InFlight() != 0 {
+		t.Errorf("Expected no calls in flight, got %d", g.InFlight())
+	}
+}
+
+func TestDoDeduplicates(t *testing.T) {
+	g := New[string, int]()
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	fn := func() (int, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	const n = 10
+	var wg sync.WaitGroup
+	results := make([]int, n)
+	sharedCount := atomic.Int32{}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		v, _, _ := g.Do("key", fn)
+		results[0] = v
+	}()
+	for g.InFlight() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	for i := 1; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, _, shared := g.Do("key", fn)
+			results[i] = v
+			if shared {
+				sharedCount.Add(1)
+			}
+		}(i)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected 1 computation, got %d", calls.Load())
+	}
+	for i, v := range results {
+		if v != 42 {
+			t.Errorf("Expected 42 for caller %d, got %d", i, v)
+		}
+	}
+	if sharedCount.Load() != n-1 {
+		t.Errorf("Expected %d shared results, got %d", n-1, sharedCount.Load())
+	}
+}
+
+func TestDoChan(t *testing.T) {
+	g := New[int, string]()
+	release := make(chan struct{})
+
+	first := g.DoChan(1, func() (string, error) {
+		<-release
+		return "done", nil
+	})
+	second := g.DoChan(1, func() (string, error) {
+		t.Error("Second function should not run")
+		return "", nil
+	})
+	close(release)
+
+	for _, ch := range []<-chan Result[string]{first, second} {
+		select {
+		case res := <-ch:
+			if res.Value != "done" || res.Err != nil || !res.Shared {
+				t.Errorf("Unexpected result %+v", res)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for DoChan")
+		}
+	}
+}
+
+func TestForget(t *testing.T) {
+	g := New[string, int]()
+	release := make(chan struct{})
+
+	first := g.DoChan("key", func() (int, error) {
+		<-release
+		return 1, nil
+	})
+	g.Forget("key")
+
+	v, _, shared := g.Do("key", func() (int, error) { return 2, nil })
+	if v != 2 || shared {
+		t.Errorf("Expected fresh computation after Forget, got %d (shared %t)", v, shared)
+	}
+
+	close(release)
+	if res := <-first; res.Value != 1 {
+		t.Errorf("Expected original waiter to get 1, got %d", res.Value)
+	}
+}
+
+func TestPanicBecomesError(t *testing.T) {
+	g := New[string, int]()
+
+	_, err, _ := g.Do("key", func() (int, error) { panic("kaboom") })
+	if !errors.Is(err, ErrPanicked) {
+		t.Errorf("Expected ErrPanicked, got %v", err)
+	}
+	if g.InFlight() != 0 {
+		t.Error("Expected panicking call to be cleaned up")
+	}
+}
+
+func TestCache(t *testing.T) {
+	cache := NewMapCache[string, int]()
+	g := NewWithCache[string, int](cache)
+	var calls int
+
+	fn := func() (int, error) {
+		calls++
+		return 99, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		if v, err, _ := g.Do("key", fn); v != 99 || err != nil {
+			t.Errorf("Expected 99, got %d with error %v", v, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 computation with caching, got %d", calls)
+	}
+
+	// Errors are not cached
+	boom := errors.New("boom")
+	g.Do("bad", func() (int, error) { return 0, boom })
+	if _, ok := cache.Get("bad"); ok {
+		t.Error("Expected failed result not to be cached")
+	}
+}
+
+// Benchmark tests
+func BenchmarkDo(b *testing.B) {
+	g := New[int, int]()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Do(i, func() (int, error) { return i, nil })
+	}
+}
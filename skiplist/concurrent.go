@@ -0,0 +1,377 @@
+package skiplist
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"math/bits"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+
+	"github.com/anwar-arif/golang-dsa/compare"
+)
+
+// maxLevel bounds the height of a tower; 2^32 keys before towers stop growing
+const maxLevel = 32
+
+// randomLevel returns a tower height where each extra level has probability 1/2
+func randomLevel() int {
+	level := bits.TrailingZeros64(rand.Uint64()) + 1
+	if level > maxLevel {
+		return maxLevel
+	}
+	return level
+}
+
+// cnode is a node of the concurrent skip list
+// Links are read without locks; a node's mutex only guards changes to the
+// links leaving it
+type cnode[K, V any] struct {
+	key         K
+	value       atomic.Pointer[V]
+	next        []atomic.Pointer[cnode[K, V]]
+	mu          sync.Mutex
+	marked      atomic.Bool // logically deleted
+	fullyLinked atomic.Bool // linked at every level of its tower
+}
+
+func newCNode[K, V any](key K, value V, level int) *cnode[K, V] {
+	n := &cnode[K, V]{
+		key:  key,
+		next: make([]atomic.Pointer[cnode[K, V]], level),
+	}
+	n.value.Store(&value)
+	return n
+}
+
+func (n *cnode[K, V]) topLevel() int {
+	return len(n.next)
+}
+
+// ConcurrentMap is an ordered map safe for concurrent use, based on the lazy
+// skip list of Herlihy et al.
+// Get and Range never take locks; Put and Delete only lock the nodes next to
+// the key they change, so writers on different parts of the key space do not
+// contend with each other
+type ConcurrentMap[K, V any] struct {
+	head    *cnode[K, V]
+	compare compare.CompareFunc[K]
+	size    atomic.Int64
+}
+
+// NewConcurrentMap creates an empty map ordered by compare
+// compare must return a negative number when a < b, zero when a == b and a
+// positive number when a > b
+func NewConcurrentMap[K, V any](compare compare.CompareFunc[K]) *ConcurrentMap[K, V] {
+	var zeroK K
+	var zeroV V
+	return &ConcurrentMap[K, V]{
+		head:    newCNode(zeroK, zeroV, maxLevel),
+		compare: compare,
+	}
+}
+
+// NewOrderedConcurrentMap creates an empty map ordered by the natural order of K
+func NewOrderedConcurrentMap[K cmp.Ordered, V any]() *ConcurrentMap[K, V] {
+	return NewConcurrentMap[K, V](compare.Ordered[K]())
+}
+
+// find fills preds and succs with the nodes around key at every level and
+// returns the highest level at which key was found, or -1
+func (m *ConcurrentMap[K, V]) find(key K, preds, succs []*cnode[K, V]) int {
+	found := -1
+	pred := m.head
+
+	for level := maxLevel - 1; level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && m.compare(curr.key, key) < 0 {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+		if found == -1 && curr != nil && m.compare(curr.key, key) == 0 {
+			found = level
+		}
+		preds[level] = pred
+		succs[level] = curr
+	}
+
+	return found
+}
+
+// unlockPreds releases the distinct predecessors locked up to level highest
+func unlockPreds[K, V any](preds []*cnode[K, V], highest int) {
+	var prev *cnode[K, V]
+	for level := 0; level <= highest; level++ {
+		if preds[level] != prev {
+			preds[level].mu.Unlock()
+			prev = preds[level]
+		}
+	}
+}
+
+// Get returns the value stored for key
+func (m *ConcurrentMap[K, V]) Get(key K) (V, bool) {
+	pred := m.head
+
+	for level := maxLevel - 1; level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && m.compare(curr.key, key) < 0 {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+		if curr != nil && m.compare(curr.key, key) == 0 {
+			if curr.fullyLinked.Load() && !curr.marked.Load() {
+				return *curr.value.Load(), true
+			}
+			break
+		}
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether key is present
+func (m *ConcurrentMap[K, V]) Contains(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Put stores value for key and reports whether the key was newly inserted
+func (m *ConcurrentMap[K, V]) Put(key K, value V) bool {
+	topLevel := randomLevel()
+	var predsBuf, succsBuf [maxLevel]*cnode[K, V]
+	preds, succs := predsBuf[:], succsBuf[:]
+
+	for {
+		if found := m.find(key, preds, succs); found != -1 {
+			existing := succs[found]
+			if !existing.marked.Load() {
+				// Another writer may still be linking the node in
+				for !existing.fullyLinked.Load() {
+				}
+				existing.value.Store(&value)
+				return false
+			}
+			// The node is being deleted; retry once it is gone
+			continue
+		}
+
+		highestLocked := -1
+		valid := true
+		var prev *cnode[K, V]
+
+		for level := 0; valid && level < topLevel; level++ {
+			pred, succ := preds[level], succs[level]
+			if pred != prev {
+				pred.mu.Lock()
+				highestLocked = level
+				prev = pred
+			}
+			valid = !pred.marked.Load() &&
+				(succ == nil || !succ.marked.Load()) &&
+				pred.next[level].Load() == succ
+		}
+
+		if !valid {
+			unlockPreds(preds, highestLocked)
+			continue
+		}
+
+		n := newCNode(key, value, topLevel)
+		for level := 0; level < topLevel; level++ {
+			n.next[level].Store(succs[level])
+		}
+		for level := 0; level < topLevel; level++ {
+			preds[level].next[level].Store(n)
+		}
+		n.fullyLinked.Store(true)
+
+		unlockPreds(preds, highestLocked)
+		m.size.Add(1)
+		return true
+	}
+}
+
+// Delete removes key and reports whether it was present
+func (m *ConcurrentMap[K, V]) Delete(key K) bool {
+	var predsBuf, succsBuf [maxLevel]*cnode[K, V]
+	preds, succs := predsBuf[:], succsBuf[:]
+
+	var victim *cnode[K, V]
+	isMarked := false
+
+	for {
+		found := m.find(key, preds, succs)
+
+		if !isMarked {
+			if found == -1 {
+				return false
+			}
+			victim = succs[found]
+			// Only delete nodes that are fully linked and found at their top level
+			if !victim.fullyLinked.Load() || victim.topLevel()-1 != found || victim.marked.Load() {
+				return false
+			}
+
+			victim.mu.Lock()
+			if victim.marked.Load() {
+				victim.mu.Unlock()
+				return false
+			}
+			victim.marked.Store(true)
+			isMarked = true
+		}
+
+		highestLocked := -1
+		valid := true
+		var prev *cnode[K, V]
+
+		for level := 0; valid && level < victim.topLevel(); level++ {
+			pred := preds[level]
+			if pred != prev {
+				pred.mu.Lock()
+				highestLocked = level
+				prev = pred
+			}
+			valid = !pred.marked.Load() && pred.next[level].Load() == victim
+		}
+
+		if !valid {
+			unlockPreds(preds, highestLocked)
+			continue
+		}
+
+		for level := victim.topLevel() - 1; level >= 0; level-- {
+			preds[level].next[level].Store(victim.next[level].Load())
+		}
+
+		victim.mu.Unlock()
+		unlockPreds(preds, highestLocked)
+		m.size.Add(-1)
+		return true
+	}
+}
+
+// Len returns the number of keys in the map
+func (m *ConcurrentMap[K, V]) Len() int {
+	return int(m.size.Load())
+}
+
+// IsEmpty returns true if the map has no keys
+func (m *ConcurrentMap[K, V]) IsEmpty() bool {
+	return m.Len() == 0
+}
+
+// Range calls fn for every key in ascending order until fn returns false
+// Iteration is weakly consistent: it never sees a key twice and never blocks
+// writers, but may or may not reflect changes made while it runs
+func (m *ConcurrentMap[K, V]) Range(fn func(key K, value V) bool) {
+	for n := m.head.next[0].Load(); n != nil; n = n.next[0].Load() {
+		if n.fullyLinked.Load() && !n.marked.Load() {
+			if !fn(n.key, *n.value.Load()) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the keys in ascending order
+// It has the same consistency guarantees as Range
+func (m *ConcurrentMap[K, V]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.Range(func(key K, _ V) bool {
+			return yield(key)
+		})
+	}
+}
+
+// All2 returns an iterator over the key/value pairs in ascending key order
+// It has the same consistency guarantees as Range
+func (m *ConcurrentMap[K, V]) All2() iter.Seq2[K, V] {
+	return m.Range
+}
+
+// RangeBetween calls fn in ascending order for every key k with lo <= k <= hi
+// It has the same consistency guarantees as Range
+func (m *ConcurrentMap[K, V]) RangeBetween(lo, hi K, fn func(key K, value V) bool) {
+	pred := m.head
+	for level := maxLevel - 1; level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && m.compare(curr.key, lo) < 0 {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+	}
+
+	for n := pred.next[0].Load(); n != nil && m.compare(n.key, hi) <= 0; n = n.next[0].Load() {
+		if n.fullyLinked.Load() && !n.marked.Load() {
+			if !fn(n.key, *n.value.Load()) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns a snapshot of the keys in ascending order
+func (m *ConcurrentMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Len())
+	m.Range(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// String returns a string representation of the map
+func (m *ConcurrentMap[K, V]) String() string {
+	return fmt.Sprintf("ConcurrentMap{size: %d}", m.Len())
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Skip List Examples ===")
+
+	// Example 1: Concurrent writers
+	fmt.Println("1. Concurrent Writers:")
+	m := NewOrderedConcurrentMap[int, string]()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < 20; i += 4 {
+				m.Put(i, fmt.Sprintf("value-%d", i))
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	fmt.Printf("  Size after 4 writers: %d\n", m.Len())
+
+	// Example 2: Ordered range over an interval
+	fmt.Println("\n2. Range [5, 9]:")
+	m.RangeBetween(5, 9, func(key int, value string) bool {
+		fmt.Printf("  %d -> %s\n", key, value)
+		return true
+	})
+
+	// Example 3: Deletes
+	fmt.Println("\n3. Delete Even Keys:")
+	for i := 0; i < 20; i += 2 {
+		m.Delete(i)
+	}
+	fmt.Printf("  Remaining keys: %v\n", m.Keys())
+
+	// Example 4: Sequential map with floor and ceiling lookups
+	fmt.Println("\n4. Floor and Ceiling:")
+	prices := NewOrderedMap[int, string]()
+	for _, p := range []int{10, 25, 40, 60} {
+		prices.Set(p, fmt.Sprintf("tier-%d", p))
+	}
+	floor, tier, _ := prices.Floor(30)
+	ceiling, _, _ := prices.Ceiling(30)
+	fmt.Printf("  Floor(30) = %d (%s), Ceiling(30) = %d\n", floor, tier, ceiling)
+}
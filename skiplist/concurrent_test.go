@@ -0,0 +1,361 @@
+package skiplist
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentMapBasic(t *testing.T) {
+	m := NewOrderedConcurrentMap[int, string]()
+
+	if !m.IsEmpty() || m.Len() != 0 {
+		t.Error("Expected empty map")
+	}
+
+	if _, ok := m.Get(1); ok {
+		t.Error("Expected missing key")
+	}
+
+	if !m.Put(2, "two") || !m.Put(1, "one") || !m.Put(3, "three") {
+		t.Error("Expected new keys to be inserted")
+	}
+
+	if m.Put(2, "TWO") {
+		t.Error("Expected existing key to be updated, not inserted")
+	}
+
+	if m.Len() != 3 {
+		t.Errorf("Expected size 3, got %d", m.Len())
+	}
+
+	val, ok := m.Get(2)
+	if !ok || val != "TWO" {
+		t.Errorf("Expected 'TWO', got %v (found=%t)", val, ok)
+	}
+
+	if !m.Delete(2) {
+		t.Error("Expected delete to succeed")
+	}
+	if m.Delete(2) {
+		t.Error("Expected second delete to fail")
+	}
+	if m.Contains(2) {
+		t.Error("Expected key 2 to be gone")
+	}
+
+	if m.Len() != 2 {
+		t.Errorf("Expected size 2, got %d", m.Len())
+	}
+}
+
+func TestConcurrentMapOrdering(t *testing.T) {
+	m := NewOrderedConcurrentMap[int, int]()
+
+	keys := rand.Perm(500)
+	for _, k := range keys {
+		m.Put(k, k*10)
+	}
+
+	prev := -1
+	count := 0
+	m.Range(func(key, value int) bool {
+		if key <= prev {
+			t.Errorf("Keys not in ascending order: %d after %d", key, prev)
+		}
+		if value != key*10 {
+			t.Errorf("Expected value %d for key %d, got %d", key*10, key, value)
+		}
+		prev = key
+		count++
+		return true
+	})
+
+	if count != 500 {
+		t.Errorf("Expected 500 keys in range, got %d", count)
+	}
+
+	// Early stop
+	seen := 0
+	m.Range(func(key, value int) bool {
+		seen++
+		return seen < 10
+	})
+	if seen != 10 {
+		t.Errorf("Expected range to stop after 10 keys, got %d", seen)
+	}
+}
+
+func TestConcurrentMapRangeBetween(t *testing.T) {
+	m := NewOrderedConcurrentMap[int, bool]()
+	for i := 0; i < 100; i += 5 {
+		m.Put(i, true)
+	}
+
+	var got []int
+	m.RangeBetween(12, 40, func(key int, _ bool) bool {
+		got = append(got, key)
+		return true
+	})
+
+	expected := []int{15, 20, 25, 30, 35, 40}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i, v := range expected {
+		if got[i] != v {
+			t.Errorf("Expected %d at position %d, got %d", v, i, got[i])
+		}
+	}
+
+	got = got[:0]
+	m.RangeBetween(200, 300, func(key int, _ bool) bool {
+		got = append(got, key)
+		return true
+	})
+	if len(got) != 0 {
+		t.Errorf("Expected empty range, got %v", got)
+	}
+}
+
+func TestConcurrentMapCustomCompare(t *testing.T) {
+	// Case-insensitive keys
+	m := NewConcurrentMap[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+
+	m.Put("Banana", 1)
+	m.Put("apple", 2)
+	m.Put("BANANA", 3)
+
+	if m.Len() != 2 {
+		t.Errorf("Expected 2 keys, got %d", m.Len())
+	}
+
+	keys := m.Keys()
+	if keys[0] != "apple" || keys[1] != "Banana" {
+		t.Errorf("Unexpected key order %v", keys)
+	}
+
+	if v, _ := m.Get("banana"); v != 3 {
+		t.Errorf("Expected updated value 3, got %d", v)
+	}
+}
+
+func TestConcurrentMapParallelWriters(t *testing.T) {
+	m := NewOrderedConcurrentMap[int, int]()
+
+	const writers, perWriter = 8, 500
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				m.Put(w*perWriter+i, w)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if m.Len() != writers*perWriter {
+		t.Errorf("Expected %d keys, got %d", writers*perWriter, m.Len())
+	}
+
+	keys := m.Keys()
+	if !sort.IntsAreSorted(keys) {
+		t.Error("Expected keys in ascending order")
+	}
+
+	// Delete every odd key in parallel while readers run
+	var readers sync.WaitGroup
+	stop := make(chan struct{})
+	for r := 0; r < 4; r++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				prev := -1
+				m.Range(func(key, _ int) bool {
+					if key <= prev {
+						t.Errorf("Range saw %d after %d", key, prev)
+					}
+					prev = key
+					return true
+				})
+			}
+		}()
+	}
+
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w*perWriter + 1; i < (w+1)*perWriter; i += 2 {
+				if !m.Delete(i) {
+					t.Errorf("Expected to delete %d", i)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(stop)
+	readers.Wait()
+
+	if m.Len() != writers*perWriter/2 {
+		t.Errorf("Expected %d keys, got %d", writers*perWriter/2, m.Len())
+	}
+	m.Range(func(key, _ int) bool {
+		if key%2 != 0 {
+			t.Errorf("Odd key %d survived deletion", key)
+		}
+		return true
+	})
+}
+
+func TestConcurrentMapContendedKeys(t *testing.T) {
+	m := NewOrderedConcurrentMap[int, int]()
+
+	// Many goroutines fighting over a handful of keys
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			r := rand.New(rand.NewPCG(uint64(g), 0))
+			for i := 0; i < 2000; i++ {
+				k := r.IntN(16)
+				switch r.IntN(3) {
+				case 0:
+					m.Put(k, g)
+				case 1:
+					m.Delete(k)
+				default:
+					m.Get(k)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	count := 0
+	m.Range(func(_, _ int) bool {
+		count++
+		return true
+	})
+	if count != m.Len() {
+		t.Errorf("Len %d does not match range count %d", m.Len(), count)
+	}
+}
+
+func TestConcurrentMapAll(t *testing.T) {
+	m := NewOrderedConcurrentMap[int, string]()
+	for _, k := range []int{3, 1, 2} {
+		m.Put(k, fmt.Sprint(k*10))
+	}
+
+	var keys []int
+	for k := range m.All() {
+		keys = append(keys, k)
+	}
+	if !slices.Equal(keys, []int{1, 2, 3}) {
+		t.Errorf("Expected keys [1 2 3], got %v", keys)
+	}
+
+	for k, v := range m.All2() {
+		if v != fmt.Sprint(k*10) {
+			t.Errorf("Expected value %d for key %d, got %s", k*10, k, v)
+		}
+		if k == 2 {
+			break
+		}
+	}
+}
+
+func TestConcurrentMapValidate(t *testing.T) {
+	m := NewOrderedConcurrentMap[int, int]()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				k := (i*31 + w) % 200
+				if i%3 == 0 {
+					m.Delete(k)
+				} else {
+					m.Put(k, i)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if err := m.Validate(); err != nil {
+		t.Fatalf("Unexpected error after concurrent writes: %v", err)
+	}
+
+	// Corrupt the bottom level by swapping two keys
+	first := m.head.next[0].Load()
+	second := first.next[0].Load()
+	first.key, second.key = second.key, first.key
+	if err := m.Validate(); err == nil {
+		t.Error("Expected error for out-of-order keys")
+	}
+	first.key, second.key = second.key, first.key
+
+	m.size.Add(1)
+	if err := m.Validate(); err == nil {
+		t.Error("Expected error for a wrong size")
+	}
+}
+
+// Benchmark tests
+func BenchmarkConcurrentMapPut(b *testing.B) {
+	m := NewOrderedConcurrentMap[int, int]()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Put(i, i)
+	}
+}
+
+func BenchmarkConcurrentMapGetParallel(b *testing.B) {
+	m := NewOrderedConcurrentMap[int, int]()
+	for i := 0; i < 10000; i++ {
+		m.Put(i, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.Get(i % 10000)
+			i++
+		}
+	})
+}
+
+func BenchmarkConcurrentMapPutParallel(b *testing.B) {
+	m := NewOrderedConcurrentMap[int, int]()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewPCG(rand.Uint64(), 0))
+		for pb.Next() {
+			k := r.IntN(1 << 20)
+			m.Put(k, k)
+		}
+	})
+}
@@ -0,0 +1,7 @@
+//go:build !dsadebug
+
+package skiplist
+
+// debugChecks enables validation after every mutation; build with
+// -tags dsadebug to turn it on
+const debugChecks = false
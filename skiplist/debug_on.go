@@ -0,0 +1,6 @@
+//go:build dsadebug
+
+package skiplist
+
+// debugChecks enables validation after every mutation
+const debugChecks = true
@@ -0,0 +1,273 @@
+// Package skiplist provides ordered maps built on skip lists, which give
+// expected O(log n) operations with far simpler code than a balanced tree
+package skiplist
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+
+	"github.com/anwar-arif/golang-dsa/collections"
+	"github.com/anwar-arif/golang-dsa/compare"
+)
+
+// node is a node of the sequential skip list
+type node[K, V any] struct {
+	key   K
+	value V
+	next  []*node[K, V]
+}
+
+// Map is an ordered map based on a skip list
+// Each key sits in a tower whose height is drawn at random, each level
+// linking about half the keys of the level below, so searches skip ahead on
+// the upper levels and finish on the bottom one
+// A Map is not safe for concurrent use; see ConcurrentMap
+type Map[K, V any] struct {
+	head    *node[K, V]
+	level   int // height of the tallest tower
+	size    int
+	compare compare.CompareFunc[K]
+}
+
+// Map can be snapshotted through the shared Cloner interface
+var _ collections.Cloner[*Map[int, int]] = (*Map[int, int])(nil)
+
+// NewMap creates an empty map ordered by compare
+func NewMap[K, V any](compare compare.CompareFunc[K]) *Map[K, V] {
+	return &Map[K, V]{
+		head:    &node[K, V]{next: make([]*node[K, V], maxLevel)},
+		compare: compare,
+	}
+}
+
+// NewOrderedMap creates an empty map ordered by the natural order of K
+func NewOrderedMap[K cmp.Ordered, V any]() *Map[K, V] {
+	return NewMap[K, V](compare.Ordered[K]())
+}
+
+// seek returns the last node with a key less than key, filling preds with
+// the last such node at every level when preds is not nil
+func (m *Map[K, V]) seek(key K, preds []*node[K, V]) *node[K, V] {
+	pred := m.head
+	for level := m.level - 1; level >= 0; level-- {
+		for next := pred.next[level]; next != nil && m.compare(next.key, key) < 0; next = pred.next[level] {
+			pred = next
+		}
+		if preds != nil {
+			preds[level] = pred
+		}
+	}
+	return pred
+}
+
+// find returns the node holding key, or nil
+func (m *Map[K, V]) find(key K) *node[K, V] {
+	n := m.seek(key, nil).next[0]
+	if n != nil && m.compare(n.key, key) == 0 {
+		return n
+	}
+	return nil
+}
+
+// Get returns the value stored for key
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	if n := m.find(key); n != nil {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether key is present
+func (m *Map[K, V]) Contains(key K) bool {
+	return m.find(key) != nil
+}
+
+// Set stores value for key and reports whether the key was newly inserted
+func (m *Map[K, V]) Set(key K, value V) bool {
+	var preds [maxLevel]*node[K, V]
+	pred := m.seek(key, preds[:])
+	if n := pred.next[0]; n != nil && m.compare(n.key, key) == 0 {
+		n.value = value
+		return false
+	}
+
+	level := randomLevel()
+	for ; m.level < level; m.level++ {
+		preds[m.level] = m.head
+	}
+	n := &node[K, V]{key: key, value: value, next: make([]*node[K, V], level)}
+	for i := 0; i < level; i++ {
+		n.next[i] = preds[i].next[i]
+		preds[i].next[i] = n
+	}
+	m.size++
+	m.debugCheck()
+	return true
+}
+
+// Delete removes key and reports whether it was present
+func (m *Map[K, V]) Delete(key K) bool {
+	var preds [maxLevel]*node[K, V]
+	n := m.seek(key, preds[:]).next[0]
+	if n == nil || m.compare(n.key, key) != 0 {
+		return false
+	}
+
+	for i := range n.next {
+		preds[i].next[i] = n.next[i]
+	}
+	for m.level > 0 && m.head.next[m.level-1] == nil {
+		m.level--
+	}
+	m.size--
+	m.debugCheck()
+	return true
+}
+
+// Floor returns the greatest key less than or equal to key, with its value
+func (m *Map[K, V]) Floor(key K) (K, V, bool) {
+	pred := m.head
+	for level := m.level - 1; level >= 0; level-- {
+		for next := pred.next[level]; next != nil && m.compare(next.key, key) <= 0; next = pred.next[level] {
+			pred = next
+		}
+	}
+	if pred == m.head {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return pred.key, pred.value, true
+}
+
+// Ceiling returns the least key greater than or equal to key, with its value
+func (m *Map[K, V]) Ceiling(key K) (K, V, bool) {
+	n := m.seek(key, nil).next[0]
+	if n == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return n.key, n.value, true
+}
+
+// Min returns the smallest key with its value
+func (m *Map[K, V]) Min() (K, V, bool) {
+	n := m.head.next[0]
+	if n == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return n.key, n.value, true
+}
+
+// Max returns the largest key with its value
+func (m *Map[K, V]) Max() (K, V, bool) {
+	pred := m.head
+	for level := m.level - 1; level >= 0; level-- {
+		for pred.next[level] != nil {
+			pred = pred.next[level]
+		}
+	}
+	if pred == m.head {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return pred.key, pred.value, true
+}
+
+// Len returns the number of keys in the map
+func (m *Map[K, V]) Len() int {
+	return m.size
+}
+
+// IsEmpty returns true if the map has no keys
+func (m *Map[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Clear removes every key
+func (m *Map[K, V]) Clear() {
+	clear(m.head.next)
+	m.level = 0
+	m.size = 0
+}
+
+// Clone returns a copy of the map in O(n) that keeps every tower height, so
+// the copy performs like the original
+func (m *Map[K, V]) Clone() *Map[K, V] {
+	return m.CloneWith(func(v V) V { return v })
+}
+
+// CloneWith returns a copy of the map with every value passed through
+// copyFn, for values that need a deep copy; keys are copied by assignment
+func (m *Map[K, V]) CloneWith(copyFn func(V) V) *Map[K, V] {
+	clone := NewMap[K, V](m.compare)
+	clone.level, clone.size = m.level, m.size
+
+	// last holds the newest copied node at every level
+	var last [maxLevel]*node[K, V]
+	for i := range last {
+		last[i] = clone.head
+	}
+	for n := m.head.next[0]; n != nil; n = n.next[0] {
+		c := &node[K, V]{key: n.key, value: copyFn(n.value), next: make([]*node[K, V], len(n.next))}
+		for i := range c.next {
+			last[i].next[i] = c
+			last[i] = c
+		}
+	}
+	clone.debugCheck()
+	return clone
+}
+
+// Range calls fn for every key in ascending order until fn returns false
+func (m *Map[K, V]) Range(fn func(key K, value V) bool) {
+	for n := m.head.next[0]; n != nil; n = n.next[0] {
+		if !fn(n.key, n.value) {
+			return
+		}
+	}
+}
+
+// RangeBetween calls fn in ascending order for every key k with lo <= k <= hi
+// until fn returns false
+func (m *Map[K, V]) RangeBetween(lo, hi K, fn func(key K, value V) bool) {
+	for n := m.seek(lo, nil).next[0]; n != nil && m.compare(n.key, hi) <= 0; n = n.next[0] {
+		if !fn(n.key, n.value) {
+			return
+		}
+	}
+}
+
+// All returns an iterator over the keys in ascending order
+func (m *Map[K, V]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.Range(func(key K, _ V) bool {
+			return yield(key)
+		})
+	}
+}
+
+// All2 returns an iterator over the key/value pairs in ascending key order
+func (m *Map[K, V]) All2() iter.Seq2[K, V] {
+	return m.Range
+}
+
+// Keys returns the keys in ascending order
+func (m *Map[K, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	for key := range m.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// String returns a string representation of the map
+func (m *Map[K, V]) String() string {
+	return fmt.Sprintf("Map{size: %d, levels: %d}", m.size, m.level)
+}
@@ -0,0 +1,283 @@
+package skiplist
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMapBasic(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	if _, ok := m.Get("a"); ok {
+		t.Error("Expected missing key in empty map")
+	}
+	if _, _, ok := m.Min(); ok {
+		t.Error("Expected no min in an empty map")
+	}
+
+	if !m.Set("b", 2) || !m.Set("a", 1) || !m.Set("c", 3) {
+		t.Error("Expected new keys to be inserted")
+	}
+	if m.Set("b", 20) {
+		t.Error("Expected an update to report false")
+	}
+	if v, ok := m.Get("b"); !ok || v != 20 {
+		t.Errorf("Expected 20, got %d (%v)", v, ok)
+	}
+	if m.Len() != 3 || !slices.Equal(m.Keys(), []string{"a", "b", "c"}) {
+		t.Errorf("Expected keys [a b c], got %v", m.Keys())
+	}
+
+	if !m.Delete("a") || m.Delete("a") || m.Contains("a") {
+		t.Error("Expected a to be deleted once")
+	}
+	if k, _, _ := m.Min(); k != "b" {
+		t.Errorf("Expected min b, got %s", k)
+	}
+	if k, v, _ := m.Max(); k != "c" || v != 3 {
+		t.Errorf("Expected max c=3, got %s=%d", k, v)
+	}
+
+	m.Clear()
+	if !m.IsEmpty() || m.Contains("b") {
+		t.Error("Expected an empty map after Clear")
+	}
+	m.Set("z", 26)
+	if v, _ := m.Get("z"); v != 26 {
+		t.Error("Expected the map to be usable after Clear")
+	}
+}
+
+func TestMapFloorCeiling(t *testing.T) {
+	m := NewOrderedMap[int, string]()
+	for _, k := range []int{10, 20, 30} {
+		m.Set(k, "")
+	}
+
+	testCases := []struct {
+		key                  int
+		floor, ceiling       int
+		hasFloor, hasCeiling bool
+	}{
+		{5, 0, 10, false, true},
+		{10, 10, 10, true, true},
+		{15, 10, 20, true, true},
+		{30, 30, 30, true, true},
+		{35, 30, 0, true, false},
+	}
+	for _, tc := range testCases {
+		if k, _, ok := m.Floor(tc.key); ok != tc.hasFloor || (ok && k != tc.floor) {
+			t.Errorf("Floor(%d): expected %d (%v), got %d (%v)", tc.key, tc.floor, tc.hasFloor, k, ok)
+		}
+		if k, _, ok := m.Ceiling(tc.key); ok != tc.hasCeiling || (ok && k != tc.ceiling) {
+			t.Errorf("Ceiling(%d): expected %d (%v), got %d (%v)", tc.key, tc.ceiling, tc.hasCeiling, k, ok)
+		}
+	}
+}
+
+func TestMapRangeBetween(t *testing.T) {
+	m := NewOrderedMap[int, int]()
+	for i := 0; i < 50; i += 5 {
+		m.Set(i, i*i)
+	}
+
+	var keys []int
+	m.RangeBetween(12, 31, func(k, v int) bool {
+		if v != k*k {
+			t.Errorf("Expected %d for key %d, got %d", k*k, k, v)
+		}
+		keys = append(keys, k)
+		return true
+	})
+	if !slices.Equal(keys, []int{15, 20, 25, 30}) {
+		t.Errorf("Expected [15 20 25 30], got %v", keys)
+	}
+
+	keys = keys[:0]
+	m.RangeBetween(0, 100, func(k, _ int) bool {
+		keys = append(keys, k)
+		return len(keys) < 2
+	})
+	if !slices.Equal(keys, []int{0, 5}) {
+		t.Errorf("Expected to stop after two keys, got %v", keys)
+	}
+
+	for k, v := range m.All2() {
+		if v != k*k {
+			t.Errorf("All2: expected %d for key %d, got %d", k*k, k, v)
+		}
+	}
+}
+
+func TestMapCustomCompare(t *testing.T) {
+	m := NewMap[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	m.Set("Go", 1)
+	if m.Set("GO", 2) {
+		t.Error("Expected keys equal under compare to be the same key")
+	}
+	if v, _ := m.Get("go"); v != 2 || m.Len() != 1 {
+		t.Errorf("Expected a single key holding 2, got %d (len %d)", v, m.Len())
+	}
+}
+
+func TestMapMatchesModel(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	m := NewOrderedMap[int, int]()
+	model := map[int]int{}
+
+	for i := 0; i < 5000; i++ {
+		k := rng.Intn(500)
+		switch rng.Intn(3) {
+		case 0:
+			_, existed := model[k]
+			if deleted := m.Delete(k); deleted != existed {
+				t.Fatalf("Delete(%d): expected %v, got %v", k, existed, deleted)
+			}
+			delete(model, k)
+		default:
+			_, existed := model[k]
+			if inserted := m.Set(k, i); inserted == existed {
+				t.Fatalf("Set(%d): expected inserted %v", k, !existed)
+			}
+			model[k] = i
+		}
+	}
+
+	if m.Len() != len(model) {
+		t.Fatalf("Expected %d keys, got %d", len(model), m.Len())
+	}
+	keys := m.Keys()
+	if !slices.IsSorted(keys) {
+		t.Fatalf("Expected sorted keys")
+	}
+	for _, k := range keys {
+		if v, ok := m.Get(k); !ok || v != model[k] {
+			t.Fatalf("Get(%d): expected %d, got %d (%v)", k, model[k], v, ok)
+		}
+	}
+
+	// Floor and Ceiling agree with a scan of the sorted keys
+	for q := -1; q <= 501; q++ {
+		i, found := slices.BinarySearch(keys, q)
+		k, _, ok := m.Ceiling(q)
+		if ok != (i < len(keys)) || (ok && k != keys[i]) {
+			t.Fatalf("Ceiling(%d) disagrees with the model", q)
+		}
+		if !found {
+			i--
+		}
+		k, _, ok = m.Floor(q)
+		if ok != (i >= 0) || (ok && k != keys[i]) {
+			t.Fatalf("Floor(%d) disagrees with the model", q)
+		}
+	}
+
+	for k := range model {
+		m.Delete(k)
+	}
+	if !m.IsEmpty() || m.level != 0 {
+		t.Errorf("Expected an empty map with no levels, got len %d and %d levels", m.Len(), m.level)
+	}
+}
+
+func TestMapClone(t *testing.T) {
+	m := NewOrderedMap[int, []int]()
+	for i := 0; i < 100; i++ {
+		m.Set(i, []int{i})
+	}
+
+	clone := m.Clone()
+	if !slices.Equal(clone.Keys(), m.Keys()) || clone.level != m.level {
+		t.Errorf("Expected the clone to match the original")
+	}
+	if err := clone.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	// Changes to either map stay out of the other
+	clone.Delete(5)
+	m.Set(500, nil)
+	if !m.Contains(5) || clone.Contains(500) || clone.Len() != 99 {
+		t.Errorf("Expected independent maps, got sizes %d and %d", m.Len(), clone.Len())
+	}
+
+	// CloneWith copies the values themselves
+	deep := m.CloneWith(slices.Clone[[]int])
+	v, _ := deep.Get(1)
+	v[0] = -1
+	if orig, _ := m.Get(1); orig[0] != 1 {
+		t.Errorf("Expected CloneWith to deep copy values, original now %v", orig)
+	}
+}
+
+func TestMapValidate(t *testing.T) {
+	m := NewOrderedMap[int, int]()
+	if err := m.Validate(); err != nil {
+		t.Fatalf("Unexpected error for an empty map: %v", err)
+	}
+	for i := 0; i < 200; i++ {
+		m.Set((i*37)%101, i)
+		if i%4 == 0 {
+			m.Delete((i * 11) % 101)
+		}
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatalf("Unexpected error after writes: %v", err)
+	}
+
+	// Corrupt the bottom level by swapping two keys
+	first := m.head.next[0]
+	second := first.next[0]
+	first.key, second.key = second.key, first.key
+	if err := m.Validate(); err == nil {
+		t.Error("Expected error for out-of-order keys")
+	}
+	first.key, second.key = second.key, first.key
+
+	// Unlink a tall node from the bottom level only
+	var tall *node[int, int]
+	for n := m.head.next[1]; n != nil && tall == nil; n = n.next[1] {
+		tall = n
+	}
+	if tall != nil {
+		pred := m.seek(tall.key, nil)
+		pred.next[0] = tall.next[0]
+		if err := m.Validate(); err == nil {
+			t.Error("Expected error for a node missing from the level below")
+		}
+		pred.next[0] = tall
+	}
+
+	m.size++
+	if err := m.Validate(); err == nil {
+		t.Error("Expected error for a wrong size")
+	}
+	m.size--
+	if err := m.Validate(); err != nil {
+		t.Errorf("Unexpected error after undoing the corruption: %v", err)
+	}
+}
+
+// Benchmark tests
+func BenchmarkMapSet(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	m := NewOrderedMap[int, int]()
+	for i := 0; i < b.N; i++ {
+		m.Set(rng.Intn(1<<16), i)
+	}
+}
+
+func BenchmarkMapGet(b *testing.B) {
+	m := NewOrderedMap[int, int]()
+	for i := 0; i < 1<<16; i++ {
+		m.Set(i, i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Get(i & (1<<16 - 1))
+	}
+}
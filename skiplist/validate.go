@@ -0,0 +1,109 @@
+package skiplist
+
+import "fmt"
+
+// Validate checks the skip list invariants: keys strictly ascend at every
+// level, every node reachable at a level is also linked at all lower levels,
+// no node is left half-linked or marked, and Len matches the bottom level
+// It must only be called while no Put or Delete is running; concurrent writers
+// legitimately leave nodes in intermediate states
+func (m *ConcurrentMap[K, V]) Validate() error {
+	count := 0
+	onLevel := make(map[*cnode[K, V]]bool)
+
+	for level := 0; level < maxLevel; level++ {
+		below := onLevel
+		onLevel = make(map[*cnode[K, V]]bool)
+
+		var prev *cnode[K, V]
+		for n := m.head.next[level].Load(); n != nil; n = n.next[level].Load() {
+			if n.topLevel() <= level {
+				return fmt.Errorf("skiplist: node %v linked at level %d above its height %d", n.key, level, n.topLevel())
+			}
+			if prev != nil && m.compare(prev.key, n.key) >= 0 {
+				return fmt.Errorf("skiplist: keys %v and %v out of order at level %d", prev.key, n.key, level)
+			}
+			if level > 0 && !below[n] {
+				return fmt.Errorf("skiplist: node %v at level %d is missing from level %d", n.key, level, level-1)
+			}
+			if n.marked.Load() {
+				return fmt.Errorf("skiplist: deleted node %v still linked at level %d", n.key, level)
+			}
+			if !n.fullyLinked.Load() {
+				return fmt.Errorf("skiplist: node %v is not fully linked", n.key)
+			}
+
+			onLevel[n] = true
+			if level == 0 {
+				count++
+			}
+			prev = n
+		}
+
+		if len(onLevel) == 0 {
+			break
+		}
+	}
+
+	if count != m.Len() {
+		return fmt.Errorf("skiplist: Len reports %d but %d nodes are linked", m.Len(), count)
+	}
+	return nil
+}
+
+// Validate checks the invariants of a Map: keys strictly ascend at every
+// level, each level links a subset of the nodes of the level below, no level
+// above the recorded height is in use and Len matches the bottom level
+func (m *Map[K, V]) Validate() error {
+	count := 0
+	var below map[*node[K, V]]bool
+
+	for level := 0; level < maxLevel; level++ {
+		if level >= m.level {
+			if m.head.next[level] != nil {
+				return fmt.Errorf("skiplist: level %d in use above the recorded height %d", level, m.level)
+			}
+			continue
+		}
+
+		onLevel := make(map[*node[K, V]]bool)
+		var prev *node[K, V]
+		for n := m.head.next[level]; n != nil; n = n.next[level] {
+			if len(n.next) <= level {
+				return fmt.Errorf("skiplist: node %v linked at level %d above its height %d", n.key, level, len(n.next))
+			}
+			if prev != nil && m.compare(prev.key, n.key) >= 0 {
+				return fmt.Errorf("skiplist: keys %v and %v out of order at level %d", prev.key, n.key, level)
+			}
+			if level > 0 && !below[n] {
+				return fmt.Errorf("skiplist: node %v at level %d is missing from level %d", n.key, level, level-1)
+			}
+
+			onLevel[n] = true
+			if level == 0 {
+				count++
+			}
+			prev = n
+		}
+		if len(onLevel) == 0 {
+			return fmt.Errorf("skiplist: level %d is empty below the recorded height %d", level, m.level)
+		}
+		below = onLevel
+	}
+
+	if count != m.size {
+		return fmt.Errorf("skiplist: Len reports %d but %d nodes are linked", m.size, count)
+	}
+	return nil
+}
+
+// debugCheck panics if the map is invalid; it does nothing unless built with
+// the dsadebug tag
+func (m *Map[K, V]) debugCheck() {
+	if !debugChecks {
+		return
+	}
+	if err := m.Validate(); err != nil {
+		panic(err)
+	}
+}
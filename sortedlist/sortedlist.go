@@ -0,0 +1,189 @@
+// Package sortedlist provides a slice kept in order under a compare function,
+// with binary-search lookups and range queries
+package sortedlist
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"sort"
+
+	"github.com/anwar-arif/golang-dsa/collections"
+	"github.com/anwar-arif/golang-dsa/compare"
+)
+
+var (
+	// ErrEmpty is returned when reading from an empty list
+	ErrEmpty = errors.New("sorted list is empty")
+	// ErrIndexOutOfRange is returned for positions outside the list
+	ErrIndexOutOfRange = errors.New("index out of range")
+)
+
+// List keeps its values sorted in a slice
+// Lookups are O(log n) binary searches and inserts and removals are O(n)
+// moves, which for up to a few thousand values usually beats a balanced
+// tree; equal values keep their insertion order
+type List[T any] struct {
+	items   []T
+	compare compare.CompareFunc[T]
+}
+
+// List satisfies the shared collection interface
+var _ collections.Collection[int] = (*List[int])(nil)
+
+// New creates an empty list ordered by compare
+func New[T any](compare compare.CompareFunc[T]) *List[T] {
+	return &List[T]{compare: compare}
+}
+
+// lowerBound returns the index of the first value not less than v
+func (l *List[T]) lowerBound(v T) int {
+	i, _ := slices.BinarySearchFunc(l.items, v, l.compare)
+	return i
+}
+
+// upperBound returns the index of the first value greater than v
+func (l *List[T]) upperBound(v T) int {
+	return sort.Search(len(l.items), func(i int) bool { return l.compare(l.items[i], v) > 0 })
+}
+
+// Insert adds v after any equal values and returns its index
+func (l *List[T]) Insert(v T) int {
+	i := l.upperBound(v)
+	l.items = slices.Insert(l.items, i, v)
+	return i
+}
+
+// IndexOf returns the index of the first value equal to v, or -1 if there
+// is none
+func (l *List[T]) IndexOf(v T) int {
+	i := l.lowerBound(v)
+	if i < len(l.items) && l.compare(l.items[i], v) == 0 {
+		return i
+	}
+	return -1
+}
+
+// Contains returns true if some value is equal to v
+func (l *List[T]) Contains(v T) bool {
+	return l.IndexOf(v) >= 0
+}
+
+// Count returns the number of values equal to v
+func (l *List[T]) Count(v T) int {
+	return l.upperBound(v) - l.lowerBound(v)
+}
+
+// At returns the value at index i, where 0 is the smallest
+func (l *List[T]) At(i int) (T, error) {
+	if i < 0 || i >= len(l.items) {
+		var zero T
+		return zero, fmt.Errorf("%w: %d", ErrIndexOutOfRange, i)
+	}
+	return l.items[i], nil
+}
+
+// Remove deletes the first value equal to v and reports whether there was one
+func (l *List[T]) Remove(v T) bool {
+	i := l.IndexOf(v)
+	if i < 0 {
+		return false
+	}
+	l.items = slices.Delete(l.items, i, i+1)
+	return true
+}
+
+// RemoveAt deletes and returns the value at index i
+func (l *List[T]) RemoveAt(i int) (T, error) {
+	v, err := l.At(i)
+	if err != nil {
+		return v, err
+	}
+	l.items = slices.Delete(l.items, i, i+1)
+	return v, nil
+}
+
+// Min returns the smallest value
+// Returns ErrEmpty if the list is empty
+func (l *List[T]) Min() (T, error) {
+	if len(l.items) == 0 {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return l.items[0], nil
+}
+
+// Max returns the largest value
+// Returns ErrEmpty if the list is empty
+func (l *List[T]) Max() (T, error) {
+	if len(l.items) == 0 {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return l.items[len(l.items)-1], nil
+}
+
+// Between returns a copy of the values v with lo <= v <= hi, in order
+// It is empty when hi is less than lo
+func (l *List[T]) Between(lo, hi T) []T {
+	i, j := l.lowerBound(lo), l.upperBound(hi)
+	if i >= j {
+		return []T{}
+	}
+	return slices.Clone(l.items[i:j])
+}
+
+// Size returns the number of values in the list
+func (l *List[T]) Size() int {
+	return len(l.items)
+}
+
+// IsEmpty returns true if the list is empty
+func (l *List[T]) IsEmpty() bool {
+	return len(l.items) == 0
+}
+
+// Clear removes all values from the list
+func (l *List[T]) Clear() {
+	clear(l.items)
+	l.items = l.items[:0]
+}
+
+// All returns an iterator over the values in ascending order
+func (l *List[T]) All() iter.Seq[T] {
+	return slices.Values(l.items)
+}
+
+// ToSlice returns a copy of the values in ascending order
+func (l *List[T]) ToSlice() []T {
+	return slices.Clone(l.items)
+}
+
+// String returns a string representation of the list
+func (l *List[T]) String() string {
+	return fmt.Sprintf("%v", l.items)
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Sorted List Examples ===")
+
+	// Example 1: Values stay sorted whatever the insertion order
+	fmt.Println("1. Insert:")
+	l := New(compare.Ordered[int]())
+	for _, v := range []int{42, 7, 19, 7, 88, 3} {
+		l.Insert(v)
+	}
+	fmt.Printf("  List: %v\n", l)
+
+	// Example 2: Binary-search lookups
+	fmt.Println("\n2. Lookups:")
+	fmt.Printf("  IndexOf(19) = %d, Contains(20) = %v, Count(7) = %d\n", l.IndexOf(19), l.Contains(20), l.Count(7))
+	third, _ := l.At(2)
+	fmt.Printf("  At(2) = %d\n", third)
+
+	// Example 3: Range queries
+	fmt.Println("\n3. Between(5, 50):")
+	fmt.Printf("  %v\n", l.Between(5, 50))
+}
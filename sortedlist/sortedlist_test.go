@@ -0,0 +1,168 @@
+package sortedlist
+
+import (
+	"cmp"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+type entry struct {
+	key  int
+	name string
+}
+
+func byKey(a, b entry) int {
+	return cmp.Compare(a.key, b.key)
+}
+
+func TestInsertLookup(t *testing.T) {
+	l := New(cmp.Compare[int])
+	if _, err := l.Min(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	for _, v := range []int{5, 1, 4, 1, 9} {
+		l.Insert(v)
+	}
+	if got := l.ToSlice(); !slices.Equal(got, []int{1, 1, 4, 5, 9}) {
+		t.Fatalf("Expected [1 1 4 5 9], got %v", got)
+	}
+
+	if i := l.IndexOf(1); i != 0 {
+		t.Errorf("Expected the first 1 at 0, got %d", i)
+	}
+	if i := l.IndexOf(5); i != 3 {
+		t.Errorf("Expected 5 at 3, got %d", i)
+	}
+	if l.IndexOf(3) != -1 || l.Contains(10) || !l.Contains(9) {
+		t.Error("Unexpected lookup results")
+	}
+	if l.Count(1) != 2 || l.Count(2) != 0 {
+		t.Errorf("Expected counts 2 and 0, got %d and %d", l.Count(1), l.Count(2))
+	}
+
+	if v, _ := l.At(2); v != 4 {
+		t.Errorf("Expected 4 at 2, got %d", v)
+	}
+	if _, err := l.At(5); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Expected ErrIndexOutOfRange, got %v", err)
+	}
+	lo, _ := l.Min()
+	hi, _ := l.Max()
+	if lo != 1 || hi != 9 {
+		t.Errorf("Expected min 1 and max 9, got %d and %d", lo, hi)
+	}
+}
+
+func TestEqualValuesKeepInsertionOrder(t *testing.T) {
+	l := New(byKey)
+	l.Insert(entry{2, "b"})
+	l.Insert(entry{1, "first"})
+	if i := l.Insert(entry{1, "second"}); i != 1 {
+		t.Errorf("Expected the second 1 at index 1, got %d", i)
+	}
+
+	if v, _ := l.At(0); v.name != "first" {
+		t.Errorf("Expected first, got %s", v.name)
+	}
+	l.Remove(entry{key: 1})
+	if v, _ := l.At(0); v.name != "second" {
+		t.Errorf("Expected Remove to take the first equal value, left %s", v.name)
+	}
+}
+
+func TestBetween(t *testing.T) {
+	l := New(cmp.Compare[int])
+	for v := 0; v < 20; v += 2 {
+		l.Insert(v)
+	}
+
+	testCases := []struct {
+		lo, hi   int
+		expected []int
+	}{
+		{3, 9, []int{4, 6, 8}},
+		{4, 8, []int{4, 6, 8}},
+		{-5, 1, []int{0}},
+		{19, 30, []int{}},
+		{9, 3, []int{}},
+		{5, 5, []int{}},
+	}
+	for _, tc := range testCases {
+		if got := l.Between(tc.lo, tc.hi); !slices.Equal(got, tc.expected) {
+			t.Errorf("Between(%d, %d): expected %v, got %v", tc.lo, tc.hi, tc.expected, got)
+		}
+	}
+
+	// The result is a copy
+	got := l.Between(0, 2)
+	got[0] = 100
+	if v, _ := l.At(0); v != 0 {
+		t.Error("Expected Between to return a copy")
+	}
+}
+
+func TestRemove(t *testing.T) {
+	l := New(cmp.Compare[int])
+	rng := rand.New(rand.NewSource(1))
+	var model []int
+
+	for i := 0; i < 500; i++ {
+		v := rng.Intn(30)
+		if rng.Intn(3) == 0 {
+			j := slices.Index(model, v)
+			if removed := l.Remove(v); removed != (j >= 0) {
+				t.Fatalf("Remove(%d): expected %v, got %v", v, j >= 0, removed)
+			}
+			if j >= 0 {
+				model = slices.Delete(model, j, j+1)
+			}
+		} else {
+			l.Insert(v)
+			model = append(model, v)
+			slices.Sort(model)
+		}
+		if !slices.Equal(l.ToSlice(), model) {
+			t.Fatalf("Step %d: expected %v, got %v", i, model, l.ToSlice())
+		}
+	}
+
+	if _, err := l.RemoveAt(-1); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Expected ErrIndexOutOfRange, got %v", err)
+	}
+	first := model[0]
+	if v, _ := l.RemoveAt(0); v != first {
+		t.Errorf("Expected %d, got %d", first, v)
+	}
+
+	l.Clear()
+	if !l.IsEmpty() || l.Size() != 0 {
+		t.Error("Expected an empty list after Clear")
+	}
+}
+
+// Benchmark tests
+func BenchmarkInsert(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	l := New(cmp.Compare[int])
+	for i := 0; i < b.N; i++ {
+		if l.Size() == 4096 {
+			l.Clear()
+		}
+		l.Insert(rng.Int())
+	}
+}
+
+func BenchmarkContains(b *testing.B) {
+	l := New(cmp.Compare[int])
+	for i := 0; i < 4096; i++ {
+		l.Insert(i * 2)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Contains(i % 8192)
+	}
+}
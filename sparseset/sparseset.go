@@ -0,0 +1,159 @@
+// Package sparseset provides a set of small integers with O(1) add, remove,
+// contains and clear
+package sparseset
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/anwar-arif/golang-dsa/collections"
+)
+
+// Integer is the set of element types a Set can hold
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Set holds integers from the universe [0, n)
+// Members are packed into a dense slice, so iteration touches only members and
+// is cache friendly; a sparse slice maps each value to its dense position
+// Clear is O(1) because stale sparse entries are detected, never reset, which
+// makes a Set cheap to reuse as a visited-set across many runs
+type Set[T Integer] struct {
+	dense  []T
+	sparse []int
+}
+
+// Set satisfies the shared collection interface
+var _ collections.Collection[int] = (*Set[int])(nil)
+
+// New creates an empty set for values in [0, universe)
+func New[T Integer](universe int) *Set[T] {
+	return &Set[T]{
+		dense:  make([]T, 0, universe),
+		sparse: make([]int, universe),
+	}
+}
+
+// Universe returns the exclusive upper bound of storable values
+func (s *Set[T]) Universe() int {
+	return len(s.sparse)
+}
+
+// index returns the dense position of v, or -1 if v is not a member
+func (s *Set[T]) index(v T) int {
+	if v < 0 || uint64(v) >= uint64(len(s.sparse)) {
+		return -1
+	}
+	i := s.sparse[v]
+	if i < len(s.dense) && s.dense[i] == v {
+		return i
+	}
+	return -1
+}
+
+// Add inserts v and reports whether it was not already present
+// It panics if v is outside the universe
+func (s *Set[T]) Add(v T) bool {
+	if v < 0 || uint64(v) >= uint64(len(s.sparse)) {
+		panic(fmt.Sprintf("sparseset: value %d outside universe [0, %d)", v, len(s.sparse)))
+	}
+	if s.index(v) >= 0 {
+		return false
+	}
+	s.sparse[v] = len(s.dense)
+	s.dense = append(s.dense, v)
+	return true
+}
+
+// Remove deletes v and reports whether it was present
+// The last member takes v's slot, so removal changes iteration order
+func (s *Set[T]) Remove(v T) bool {
+	i := s.index(v)
+	if i < 0 {
+		return false
+	}
+	last := s.dense[len(s.dense)-1]
+	s.dense[i] = last
+	s.sparse[last] = i
+	s.dense = s.dense[:len(s.dense)-1]
+	return true
+}
+
+// Contains reports whether v is a member; values outside the universe never are
+func (s *Set[T]) Contains(v T) bool {
+	return s.index(v) >= 0
+}
+
+// Size returns the number of members
+func (s *Set[T]) Size() int {
+	return len(s.dense)
+}
+
+// IsEmpty returns true if the set has no members
+func (s *Set[T]) IsEmpty() bool {
+	return len(s.dense) == 0
+}
+
+// Clear removes every member in O(1)
+func (s *Set[T]) Clear() {
+	s.dense = s.dense[:0]
+}
+
+// Members returns the members in iteration order
+// The slice aliases the set's storage and is only valid until the next change
+func (s *Set[T]) Members() []T {
+	return s.dense
+}
+
+// All returns an iterator over the members in iteration order
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s.dense {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// String returns a string representation of the set
+func (s *Set[T]) String() string {
+	return fmt.Sprintf("SparseSet{size: %d, universe: %d}", len(s.dense), len(s.sparse))
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Sparse Set Examples ===")
+
+	// Example 1: Basic operations
+	fmt.Println("1. Basic Operations:")
+	s := New[uint32](100)
+	for _, v := range []uint32{42, 7, 99, 7} {
+		s.Add(v)
+	}
+	fmt.Printf("  Members: %v\n", s.Members())
+	s.Remove(42)
+	fmt.Printf("  After Remove(42): %v, Contains(42)=%t\n", s.Members(), s.Contains(42))
+
+	// Example 2: Reusing a visited-set across graph searches
+	fmt.Println("\n2. Visited Set for BFS:")
+	graph := [][]int{{1, 2}, {3}, {3}, {4}, {}}
+	visited := New[int](len(graph))
+	for _, start := range []int{0, 2} {
+		visited.Clear() // O(1), no allocation
+		frontier := []int{start}
+		visited.Add(start)
+		for len(frontier) > 0 {
+			node := frontier[0]
+			frontier = frontier[1:]
+			for _, next := range graph[node] {
+				if visited.Add(next) {
+					frontier = append(frontier, next)
+				}
+			}
+		}
+		fmt.Printf("  Reachable from %d: %d nodes\n", start, visited.Size())
+	}
+}
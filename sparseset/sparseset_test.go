@@ -0,0 +1,115 @@
+package sparseset
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestAddRemoveContains(t *testing.T) {
+	s := New[int](10)
+
+	if !s.Add(3) || !s.Add(7) || !s.Add(0) || s.Add(3) {
+		t.Error("Unexpected Add result")
+	}
+	if s.Size() != 3 || !s.Contains(7) || s.Contains(5) {
+		t.Errorf("Unexpected state %v", s)
+	}
+	if s.Contains(-1) || s.Contains(10) || s.Contains(1000) {
+		t.Error("Expected values outside the universe not to be members")
+	}
+
+	if !s.Remove(3) || s.Remove(3) || s.Contains(3) {
+		t.Error("Unexpected Remove result")
+	}
+	// The last member moved into the removed slot
+	if got := s.Members(); !slices.Equal(got, []int{0, 7}) && !slices.Equal(got, []int{7, 0}) {
+		t.Errorf("Expected members 0 and 7, got %v", got)
+	}
+	if !s.Contains(0) || !s.Contains(7) {
+		t.Error("Expected remaining members to still be found")
+	}
+}
+
+func TestClear(t *testing.T) {
+	s := New[uint16](5)
+	for v := range uint16(5) {
+		s.Add(v)
+	}
+	s.Clear()
+
+	if !s.IsEmpty() {
+		t.Error("Expected empty set after Clear")
+	}
+	// Stale sparse entries must not resurrect members
+	for v := range uint16(5) {
+		if s.Contains(v) {
+			t.Errorf("Expected %d to be gone after Clear", v)
+		}
+	}
+
+	s.Add(4)
+	if s.Contains(0) || !s.Contains(4) || s.Size() != 1 {
+		t.Errorf("Unexpected state after reuse: %v", s.Members())
+	}
+}
+
+func TestAddOutsideUniversePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic")
+		}
+	}()
+	New[int](4).Add(4)
+}
+
+func TestMatchesMap(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	s := New[int](64)
+	ref := make(map[int]bool)
+
+	for i := 0; i < 5000; i++ {
+		v := rng.Intn(64)
+		switch rng.Intn(10) {
+		case 0:
+			s.Clear()
+			clear(ref)
+		case 1, 2, 3:
+			if s.Remove(v) != ref[v] {
+				t.Fatalf("Step %d: Remove(%d) disagreed with map", i, v)
+			}
+			delete(ref, v)
+		default:
+			if s.Add(v) == ref[v] {
+				t.Fatalf("Step %d: Add(%d) disagreed with map", i, v)
+			}
+			ref[v] = true
+		}
+	}
+
+	if s.Size() != len(ref) {
+		t.Fatalf("Expected size %d, got %d", len(ref), s.Size())
+	}
+	for v := range s.All() {
+		if !ref[v] {
+			t.Errorf("Unexpected member %d", v)
+		}
+	}
+}
+
+// Benchmark tests
+func BenchmarkAddRemove(b *testing.B) {
+	s := New[int](1024)
+	for i := 0; i < b.N; i++ {
+		s.Add(i & 1023)
+		s.Remove((i * 7) & 1023)
+	}
+}
+
+func BenchmarkClear(b *testing.B) {
+	s := New[int](1 << 16)
+	for i := 0; i < b.N; i++ {
+		s.Add(i & 0xffff)
+		s.Clear()
+	}
+}
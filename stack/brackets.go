@@ -0,0 +1,51 @@
+package stack
+
+import "maps"
+
+// defaultPairs backs DefaultPairs and the nil case of IsBalanced
+var defaultPairs = map[rune]rune{'(': ')', '[': ']', '{': '}'}
+
+// DefaultPairs returns the bracket pairs IsBalanced checks when given none:
+// parentheses, square brackets and curly braces
+// The map is a fresh copy that the caller may change
+func DefaultPairs() map[rune]rune {
+	return maps.Clone(defaultPairs)
+}
+
+// IsBalanced returns true if every opening bracket in s is closed by its
+// partner, in the right order
+// pairs maps each opening rune to its closing rune, with nil meaning
+// DefaultPairs; other runes are ignored
+// A rune paired with itself, such as a quote, closes the innermost open one
+// and opens a new one otherwise
+func IsBalanced(s string, pairs map[rune]rune) bool {
+	if pairs == nil {
+		pairs = defaultPairs
+	}
+	closers := make(map[rune]bool, len(pairs))
+	for _, closer := range pairs {
+		closers[closer] = true
+	}
+
+	// The stack holds the closer each open bracket is waiting for
+	expected := NewStack[rune]()
+	for _, r := range s {
+		closer, opens := pairs[r]
+		if opens && closer == r {
+			if top, err := expected.Peek(); err == nil && top == r {
+				expected.Pop()
+				continue
+			}
+		}
+		if opens {
+			expected.Push(closer)
+			continue
+		}
+		if closers[r] {
+			if want, err := expected.Pop(); err != nil || want != r {
+				return false
+			}
+		}
+	}
+	return expected.IsEmpty()
+}
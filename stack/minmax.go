@@ -0,0 +1,133 @@
+package stack
+
+import "github.com/anwar-arif/golang-dsa/compare"
+
+// minNode is a stack node that also records the minimum of itself and every
+// node below it
+type minNode[T any] struct {
+	value T
+	min   T
+	next  *minNode[T]
+}
+
+// MinStack is a LIFO stack that reports its minimum in O(1)
+// Each node stores the minimum of the stack as it was when the node was
+// pushed, so popping restores the previous minimum for free
+type MinStack[T any] struct {
+	top     *minNode[T]
+	size    int
+	compare compare.CompareFunc[T]
+}
+
+// NewMinStack creates an empty stack ordered by compare
+func NewMinStack[T any](compare compare.CompareFunc[T]) *MinStack[T] {
+	return &MinStack[T]{compare: compare}
+}
+
+// Push adds an item to the top of the stack
+func (s *MinStack[T]) Push(value T) {
+	n := &minNode[T]{value: value, min: value, next: s.top}
+	if s.top != nil && s.compare(s.top.min, value) < 0 {
+		n.min = s.top.min
+	}
+	s.top = n
+	s.size++
+}
+
+// Pop removes and returns the item from the top of the stack
+// Returns ErrEmpty if the stack is empty
+func (s *MinStack[T]) Pop() (T, error) {
+	if s.top == nil {
+		var zero T
+		return zero, ErrEmpty
+	}
+	value := s.top.value
+	s.top = s.top.next
+	s.size--
+	return value, nil
+}
+
+// Peek returns the top item without removing it
+// Returns ErrEmpty if the stack is empty
+func (s *MinStack[T]) Peek() (T, error) {
+	if s.top == nil {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return s.top.value, nil
+}
+
+// Min returns the smallest item in the stack
+// Returns ErrEmpty if the stack is empty
+func (s *MinStack[T]) Min() (T, error) {
+	if s.top == nil {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return s.top.min, nil
+}
+
+// IsEmpty returns true if the stack is empty
+func (s *MinStack[T]) IsEmpty() bool {
+	return s.top == nil
+}
+
+// Size returns the number of items in the stack
+func (s *MinStack[T]) Size() int {
+	return s.size
+}
+
+// Clear removes all items from the stack
+func (s *MinStack[T]) Clear() {
+	s.top = nil
+	s.size = 0
+}
+
+// MaxStack is a LIFO stack that reports its maximum in O(1)
+// It is a MinStack under the reversed order
+type MaxStack[T any] struct {
+	s MinStack[T]
+}
+
+// NewMaxStack creates an empty stack ordered by compare
+func NewMaxStack[T any](c compare.CompareFunc[T]) *MaxStack[T] {
+	return &MaxStack[T]{s: MinStack[T]{compare: compare.Reverse(c)}}
+}
+
+// Push adds an item to the top of the stack
+func (s *MaxStack[T]) Push(value T) {
+	s.s.Push(value)
+}
+
+// Pop removes and returns the item from the top of the stack
+// Returns ErrEmpty if the stack is empty
+func (s *MaxStack[T]) Pop() (T, error) {
+	return s.s.Pop()
+}
+
+// Peek returns the top item without removing it
+// Returns ErrEmpty if the stack is empty
+func (s *MaxStack[T]) Peek() (T, error) {
+	return s.s.Peek()
+}
+
+// Max returns the largest item in the stack
+// Returns ErrEmpty if the stack is empty
+func (s *MaxStack[T]) Max() (T, error) {
+	return s.s.Min()
+}
+
+// IsEmpty returns true if the stack is empty
+func (s *MaxStack[T]) IsEmpty() bool {
+	return s.s.IsEmpty()
+}
+
+// Size returns the number of items in the stack
+func (s *MaxStack[T]) Size() int {
+	return s.s.Size()
+}
+
+// Clear removes all items from the stack
+func (s *MaxStack[T]) Clear() {
+	s.s.Clear()
+}
@@ -0,0 +1,88 @@
+package stack
+
+import (
+	"cmp"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestMinStack(t *testing.T) {
+	s := NewMinStack(cmp.Compare[int])
+	if _, err := s.Min(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+	if _, err := s.Pop(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	steps := []struct {
+		push int
+		min  int
+	}{{5, 5}, {3, 3}, {7, 3}, {3, 3}, {1, 1}}
+	for _, st := range steps {
+		s.Push(st.push)
+		if got, _ := s.Min(); got != st.min {
+			t.Errorf("After pushing %d: expected min %d, got %d", st.push, st.min, got)
+		}
+	}
+
+	// Popping restores earlier minima, including duplicates
+	for i := len(steps) - 1; i > 0; i-- {
+		s.Pop()
+		if got, _ := s.Min(); got != steps[i-1].min {
+			t.Errorf("Expected min %d after pop, got %d", steps[i-1].min, got)
+		}
+	}
+	if top, _ := s.Peek(); top != 5 || s.Size() != 1 {
+		t.Errorf("Expected only 5 left, got top %d size %d", top, s.Size())
+	}
+
+	s.Clear()
+	if !s.IsEmpty() {
+		t.Error("Expected an empty stack after Clear")
+	}
+}
+
+func TestMaxStackMatchesScan(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	s := NewMaxStack(cmp.Compare[int])
+	var shadow []int
+
+	for i := 0; i < 1000; i++ {
+		if len(shadow) > 0 && rng.Intn(3) == 0 {
+			got, _ := s.Pop()
+			if want := shadow[len(shadow)-1]; got != want {
+				t.Fatalf("Expected to pop %d, got %d", want, got)
+			}
+			shadow = shadow[:len(shadow)-1]
+		} else {
+			v := rng.Intn(100)
+			s.Push(v)
+			shadow = append(shadow, v)
+		}
+
+		if len(shadow) == 0 {
+			if _, err := s.Max(); !errors.Is(err, ErrEmpty) {
+				t.Fatalf("Expected ErrEmpty, got %v", err)
+			}
+			continue
+		}
+		if got, _ := s.Max(); got != slices.Max(shadow) {
+			t.Fatalf("Expected max %d, got %d", slices.Max(shadow), got)
+		}
+	}
+}
+
+// Benchmark tests
+func BenchmarkMinStack(b *testing.B) {
+	s := NewMinStack(cmp.Compare[int])
+	for i := 0; i < b.N; i++ {
+		s.Push(i)
+		s.Min()
+		if i%2 == 0 {
+			s.Pop()
+		}
+	}
+}
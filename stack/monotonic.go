@@ -0,0 +1,109 @@
+package stack
+
+import (
+	"iter"
+
+	"github.com/anwar-arif/golang-dsa/compare"
+)
+
+// MonotonicStack keeps its items in non-decreasing order from bottom to top
+// by evicting, on every push, the items above the new one in that order
+// Each eviction answers "next smaller" for the evicted item, and the item
+// left below the new one answers "previous smaller or equal" for it, which
+// is the building block of next-greater, stock-span and largest-rectangle
+// style problems; use compare.Reverse for the greater-than versions
+// Push is amortized O(1)
+type MonotonicStack[T any] struct {
+	items   []T
+	compare compare.CompareFunc[T]
+}
+
+// NewMonotonicStack creates an empty monotonic stack ordered by compare
+func NewMonotonicStack[T any](compare compare.CompareFunc[T]) *MonotonicStack[T] {
+	return &MonotonicStack[T]{compare: compare}
+}
+
+// Push evicts every item that compares greater than value, top first,
+// passing each to evicted unless it is nil, then pushes value
+// It returns the item left below value, if any
+func (s *MonotonicStack[T]) Push(value T, evicted func(T)) (below T, ok bool) {
+	for len(s.items) > 0 {
+		top := s.items[len(s.items)-1]
+		if s.compare(top, value) <= 0 {
+			below, ok = top, true
+			break
+		}
+		var zero T
+		s.items[len(s.items)-1] = zero // avoid memory leak
+		s.items = s.items[:len(s.items)-1]
+		if evicted != nil {
+			evicted(top)
+		}
+	}
+	s.items = append(s.items, value)
+	return below, ok
+}
+
+// Peek returns the top item, the latest pushed
+// Returns ErrEmpty if the stack is empty
+func (s *MonotonicStack[T]) Peek() (T, error) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return s.items[len(s.items)-1], nil
+}
+
+// Size returns the number of items in the stack
+func (s *MonotonicStack[T]) Size() int {
+	return len(s.items)
+}
+
+// IsEmpty returns true if the stack is empty
+func (s *MonotonicStack[T]) IsEmpty() bool {
+	return len(s.items) == 0
+}
+
+// Clear removes all items from the stack
+func (s *MonotonicStack[T]) Clear() {
+	clear(s.items)
+	s.items = s.items[:0]
+}
+
+// All returns an iterator over the items from top to bottom
+func (s *MonotonicStack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(s.items) - 1; i >= 0; i-- {
+			if !yield(s.items[i]) {
+				return
+			}
+		}
+	}
+}
+
+// NextGreater returns, for each position i, the index of the first later
+// value that is strictly greater than values[i], or -1 if there is none. O(n)
+func NextGreater[T any](values []T, c compare.CompareFunc[T]) []int {
+	return nextBy(values, compare.Reverse(c))
+}
+
+// NextSmaller returns, for each position i, the index of the first later
+// value that is strictly smaller than values[i], or -1 if there is none. O(n)
+func NextSmaller[T any](values []T, c compare.CompareFunc[T]) []int {
+	return nextBy(values, c)
+}
+
+// nextBy finds, for each position, the next value that evicts it from a
+// monotonic stack of indexes ordered by c
+func nextBy[T any](values []T, c compare.CompareFunc[T]) []int {
+	result := make([]int, len(values))
+	for i := range result {
+		result[i] = -1
+	}
+
+	s := NewMonotonicStack(func(a, b int) int { return c(values[a], values[b]) })
+	for i := range values {
+		s.Push(i, func(j int) { result[j] = i })
+	}
+	return result
+}
@@ -0,0 +1,126 @@
+package stack
+
+import (
+	"cmp"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/anwar-arif/golang-dsa/compare"
+)
+
+// nextScan is the quadratic reference for NextGreater and NextSmaller
+func nextScan(values []int, better func(a, b int) bool) []int {
+	result := make([]int, len(values))
+	for i := range values {
+		result[i] = -1
+		for j := i + 1; j < len(values); j++ {
+			if better(values[j], values[i]) {
+				result[i] = j
+				break
+			}
+		}
+	}
+	return result
+}
+
+func TestNextGreaterSmaller(t *testing.T) {
+	values := []int{2, 1, 2, 4, 3, 1}
+	if got := NextGreater(values, cmp.Compare[int]); !slices.Equal(got, []int{3, 2, 3, -1, -1, -1}) {
+		t.Errorf("Unexpected NextGreater %v", got)
+	}
+	if got := NextSmaller(values, cmp.Compare[int]); !slices.Equal(got, []int{1, -1, 5, 4, 5, -1}) {
+		t.Errorf("Unexpected NextSmaller %v", got)
+	}
+	if got := NextGreater([]int(nil), cmp.Compare[int]); len(got) != 0 {
+		t.Errorf("Expected no results for no values, got %v", got)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		values := make([]int, rng.Intn(40))
+		for i := range values {
+			values[i] = rng.Intn(10)
+		}
+		if got, want := NextGreater(values, cmp.Compare[int]), nextScan(values, func(a, b int) bool { return a > b }); !slices.Equal(got, want) {
+			t.Fatalf("NextGreater(%v): expected %v, got %v", values, want, got)
+		}
+		if got, want := NextSmaller(values, cmp.Compare[int]), nextScan(values, func(a, b int) bool { return a < b }); !slices.Equal(got, want) {
+			t.Fatalf("NextSmaller(%v): expected %v, got %v", values, want, got)
+		}
+	}
+}
+
+func TestMonotonicStackStockSpan(t *testing.T) {
+	// The span of a price is the number of consecutive days up to and
+	// including it with a price no higher; days are kept by index under a
+	// descending order, so the item below each push is the previous higher day
+	prices := []int{100, 80, 60, 70, 60, 75, 85}
+	s := NewMonotonicStack(compare.Reverse(func(a, b int) int {
+		if c := cmp.Compare(prices[a], prices[b]); c != 0 {
+			return c
+		}
+		return cmp.Compare(b, a) // equal prices: the later day is "smaller"
+	}))
+
+	var spans []int
+	for day := range prices {
+		prev, ok := s.Push(day, nil)
+		if !ok {
+			prev = -1
+		}
+		spans = append(spans, day-prev)
+	}
+	if !slices.Equal(spans, []int{1, 1, 1, 2, 1, 4, 6}) {
+		t.Errorf("Expected spans [1 1 1 2 1 4 6], got %v", spans)
+	}
+}
+
+func TestMonotonicStack(t *testing.T) {
+	s := NewMonotonicStack(cmp.Compare[int])
+	if _, err := s.Peek(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	for _, v := range []int{3, 1, 4, 1, 5} {
+		s.Push(v, nil)
+	}
+	var evicted []int
+	below, ok := s.Push(2, func(v int) { evicted = append(evicted, v) })
+	// The earlier pushes left [1 1 5] from bottom to top
+	if !slices.Equal(evicted, []int{5}) {
+		t.Errorf("Expected 5 evicted, got %v", evicted)
+	}
+	if !ok || below != 1 {
+		t.Errorf("Expected 1 below, got %d (%v)", below, ok)
+	}
+	if got := slices.Collect(s.All()); !slices.Equal(got, []int{2, 1, 1}) {
+		t.Errorf("Expected [2 1 1] top to bottom, got %v", got)
+	}
+	if top, _ := s.Peek(); top != 2 || s.Size() != 3 {
+		t.Errorf("Expected top 2 and size 3, got %d and %d", top, s.Size())
+	}
+
+	s.Clear()
+	if !s.IsEmpty() {
+		t.Error("Expected an empty stack after Clear")
+	}
+	if _, ok := s.Push(7, nil); ok {
+		t.Error("Expected nothing below the only item")
+	}
+}
+
+// Benchmark tests
+func BenchmarkNextGreater(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	values := make([]int, 1<<12)
+	for i := range values {
+		values[i] = rng.Int()
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NextGreater(values, cmp.Compare[int])
+	}
+}
@@ -1,7 +1,25 @@
 package stack
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/anwar-arif/golang-dsa/alloc"
+	"github.com/anwar-arif/golang-dsa/codec"
+	"github.com/anwar-arif/golang-dsa/collections"
+)
+
+var (
+	// ErrEmpty is returned when reading from an empty stack
+	ErrEmpty = errors.New("stack is empty")
+	// ErrFull is returned when adding to a bounded stack that has no room left
+	ErrFull = errors.New("stack is full")
 )
 
 // Node represents a node in the stack
@@ -12,27 +30,81 @@ type Node[T any] struct {
 
 // Stack represents a LIFO stack
 type Stack[T any] struct {
-	top  *Node[T] // Points to the top element (push/pop from here)
-	size int
+	top       *Node[T] // Points to the top element (push/pop from here)
+	size      int
+	alloc     alloc.Allocator[Node[T]] // optional, see NewStackWithAllocator
+	observers collections.Observers[T]
+	stats     collections.StatsCounter
+}
+
+// Stack satisfies the shared collection interfaces
+var (
+	_ collections.Collection[int]     = (*Stack[int])(nil)
+	_ collections.Serializable        = (*Stack[int])(nil)
+	_ json.Marshaler                  = (*Stack[int])(nil)
+	_ json.Unmarshaler                = (*Stack[int])(nil)
+	_ collections.Cloner[*Stack[int]] = (*Stack[int])(nil)
+	_ collections.StatsReporter       = (*Stack[int])(nil)
+)
+
+// Option configures a stack at construction
+type Option func(*options)
+
+type options struct {
+	nodePool bool
+}
+
+// WithNodePool makes the stack recycle its nodes through a sync.Pool instead
+// of leaving popped nodes to the garbage collector, which pays off under a
+// high churn of pushes and pops
+func WithNodePool() Option {
+	return func(o *options) { o.nodePool = true }
 }
 
 // NewStack creates a new empty stack
-func NewStack[T any]() *Stack[T] {
-	return &Stack[T]{
+func NewStack[T any](opts ...Option) *Stack[T] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	s := &Stack[T]{
 		top:  nil,
 		size: 0,
 	}
+	if o.nodePool {
+		s.alloc = alloc.NewPool[Node[T]]()
+	}
+	return s
+}
+
+// NewStackWithAllocator creates a new empty stack that takes its nodes from a
+// and hands them back on Pop
+// Clone copies every node instead of sharing them, since a shared node could
+// be freed by one stack while the other still uses it
+func NewStackWithAllocator[T any](a alloc.Allocator[Node[T]]) *Stack[T] {
+	return &Stack[T]{alloc: a}
+}
+
+// newNode returns a node from the allocator, or from the heap when there is none
+func (s *Stack[T]) newNode(value T, next *Node[T]) *Node[T] {
+	s.stats.Alloc(1)
+	if s.alloc == nil {
+		return &Node[T]{Value: value, Next: next}
+	}
+	n := s.alloc.New()
+	n.Value = value
+	n.Next = next
+	return n
 }
 
 // Push adds an item to the top of the stack
 func (s *Stack[T]) Push(value T) {
-	newNode := &Node[T]{
-		Value: value,
-		Next:  s.top, // Point to the previous top
-	}
+	newNode := s.newNode(value, s.top) // Point to the previous top
 
 	s.top = newNode
 	s.size++
+	s.stats.Push(1, s.size)
+	s.observers.Notify(collections.OpPush, value)
 }
 
 // Pop removes and returns the item from the top of the stack
@@ -40,22 +112,52 @@ func (s *Stack[T]) Pop() (T, error) {
 	var zero T
 
 	if s.IsEmpty() {
-		return zero, fmt.Errorf("stack is empty")
+		return zero, ErrEmpty
 	}
 
-	value := s.top.Value
-	s.top = s.top.Next
+	popped := s.top
+	value := popped.Value
+	s.top = popped.Next
 	s.size--
+	if s.alloc != nil {
+		s.alloc.Free(popped)
+	}
+	s.stats.Pop(1)
+	s.observers.Notify(collections.OpPop, value)
 
 	return value, nil
 }
 
+// PushAll pushes values in order, so the last value ends up on top
+func (s *Stack[T]) PushAll(values ...T) {
+	for _, v := range values {
+		s.Push(v)
+	}
+}
+
+// PopN removes and returns up to n items, top first
+// Returns ErrEmpty if the stack is empty and n > 0; n <= 0 returns no items
+func (s *Stack[T]) PopN(n int) ([]T, error) {
+	if n <= 0 {
+		return []T{}, nil
+	}
+	if s.IsEmpty() {
+		return nil, ErrEmpty
+	}
+	result := make([]T, 0, min(n, s.Size()))
+	for len(result) < n && !s.IsEmpty() {
+		v, _ := s.Pop()
+		result = append(result, v)
+	}
+	return result, nil
+}
+
 // Peek returns the top item without removing it
 func (s *Stack[T]) Peek() (T, error) {
 	var zero T
 
 	if s.IsEmpty() {
-		return zero, fmt.Errorf("stack is empty")
+		return zero, ErrEmpty
 	}
 
 	return s.top.Value, nil
@@ -75,11 +177,236 @@ func (s *Stack[T]) Size() int {
 func (s *Stack[T]) Clear() {
 	s.top = nil
 	s.size = 0
+
+	var zero T
+	s.observers.Notify(collections.OpClear, zero)
+}
+
+// OnMutate registers fn to be called after every push, pop and clear, and
+// returns a function that unregisters it
+// fn runs synchronously and must not modify the stack
+func (s *Stack[T]) OnMutate(fn func(op collections.Op, value T)) (remove func()) {
+	return s.observers.Add(fn)
+}
+
+// OnPush registers fn to be called with every value pushed, for example to
+// count pushes, and returns a function that unregisters it
+func (s *Stack[T]) OnPush(fn func(value T)) (remove func()) {
+	return s.observers.AddOp(collections.OpPush, fn)
+}
+
+// OnPop registers fn to be called with every value popped and returns a
+// function that unregisters it
+func (s *Stack[T]) OnPop(fn func(value T)) (remove func()) {
+	return s.observers.AddOp(collections.OpPop, fn)
+}
+
+// Stats returns the stack's size and activity counters; Allocs counts the
+// nodes it created, whether from the heap or an allocator
+func (s *Stack[T]) Stats() collections.Stats {
+	return s.stats.Snapshot(s.size)
+}
+
+// Clone returns a copy of the stack in O(1)
+// Nodes are never modified after a push, so the copy shares them with the
+// original; pushes and pops on either stack only move its own top pointer
+// Stacks with an allocator are copied node by node instead
+func (s *Stack[T]) Clone() *Stack[T] {
+	if s.alloc != nil {
+		return s.CloneWith(func(v T) T { return v })
+	}
+	return &Stack[T]{
+		top:  s.top,
+		size: s.size,
+	}
+}
+
+// CloneWith returns a copy of the stack with every item passed through copyFn,
+// for items that need a deep copy
+func (s *Stack[T]) CloneWith(copyFn func(T) T) *Stack[T] {
+	clone := &Stack[T]{alloc: s.alloc}
+	if s.top == nil {
+		return clone
+	}
+
+	clone.top = clone.newNode(copyFn(s.top.Value), nil)
+	tail := clone.top
+	for current := s.top.Next; current != nil; current = current.Next {
+		tail.Next = clone.newNode(copyFn(current.Value), nil)
+		tail = tail.Next
+	}
+	clone.size = s.size
+	return clone
+}
+
+// Search returns the 1-based distance from the top of the first item equal
+// to value by eq, so the top item is at 1, or -1 if there is none
+// Walks the stack, so it costs O(n)
+func (s *Stack[T]) Search(value T, eq func(a, b T) bool) int {
+	depth := 1
+	for current := s.top; current != nil; current = current.Next {
+		if eq(current.Value, value) {
+			return depth
+		}
+		depth++
+	}
+	return -1
+}
+
+// Equal returns true if other holds the same number of items as the stack
+// and each pair, from top to bottom, is equal by eq
+// Clones share nodes, so the walk stops early where the stacks meet
+func (s *Stack[T]) Equal(other *Stack[T], eq func(a, b T) bool) bool {
+	if s.size != other.size {
+		return false
+	}
+	a, b := s.top, other.top
+	for a != b {
+		if !eq(a.Value, b.Value) {
+			return false
+		}
+		a, b = a.Next, b.Next
+	}
+	return true
+}
+
+// All returns an iterator over the items from top to bottom
+func (s *Stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for current := s.top; current != nil; current = current.Next {
+			if !yield(current.Value) {
+				return
+			}
+		}
+	}
+}
+
+// All2 returns an iterator over the items from top to bottom paired with
+// their depth, where the top is 0
+func (s *Stack[T]) All2() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for current := s.top; current != nil; current = current.Next {
+			if !yield(i, current.Value) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Iterator walks a Stack from top to bottom without popping
+// The zero value is an exhausted iterator. Nodes never change once pushed,
+// so the iterator sees the stack as it was when created, whatever is pushed
+// or popped meanwhile; with an allocator, popped nodes are recycled and must
+// not be walked
+type Iterator[T any] struct {
+	next    *Node[T]
+	current *Node[T]
+}
+
+// NewIterator returns an iterator positioned above the top item
+func (s *Stack[T]) NewIterator() *Iterator[T] {
+	return &Iterator[T]{next: s.top}
+}
+
+// Next advances to the next item and reports whether there is one
+func (it *Iterator[T]) Next() bool {
+	it.current = it.next
+	if it.current == nil {
+		return false
+	}
+	it.next = it.current.Next
+	return true
+}
+
+// Value returns the item the iterator is on; call Next first
+// Panics if Next has not returned true
+func (it *Iterator[T]) Value() T {
+	if it.current == nil {
+		panic("stack: Value called without a successful Next")
+	}
+	return it.current.Value
+}
+
+// Save writes the items to w with enc, top to bottom
+func (s *Stack[T]) Save(w io.Writer, enc codec.Encoder[[]T]) error {
+	return enc.Encode(w, s.ToSlice())
+}
+
+// Load replaces the contents of the stack with items read from r by dec,
+// which must yield them top to bottom as written by Save
+func (s *Stack[T]) Load(r io.Reader, dec codec.Decoder[[]T]) error {
+	items, err := dec.Decode(r)
+	if err != nil {
+		return err
+	}
+
+	s.Clear()
+	for i := len(items) - 1; i >= 0; i-- {
+		s.Push(items[i])
+	}
+	return nil
+}
+
+// MarshalBinary encodes the items with codec.Gob, top to bottom
+func (s *Stack[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := s.Save(&buf, codec.Gob[[]T]()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the contents of the stack with items encoded by MarshalBinary
+func (s *Stack[T]) UnmarshalBinary(data []byte) error {
+	return s.Load(bytes.NewReader(data), codec.Gob[[]T]())
+}
+
+// MarshalJSON encodes the items as a JSON array, top to bottom
+func (s *Stack[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ToSlice())
+}
+
+// UnmarshalJSON replaces the contents of the stack with items encoded by MarshalJSON
+func (s *Stack[T]) UnmarshalJSON(data []byte) error {
+	return s.Load(bytes.NewReader(data), codec.JSON[[]T]())
+}
+
+// ToSlice returns all items as a slice from top to bottom
+func (s *Stack[T]) ToSlice() []T {
+	result := make([]T, 0, s.size)
+	for current := s.top; current != nil; current = current.Next {
+		result = append(result, current.Value)
+	}
+	return result
+}
+
+// ToSliceBottomUp returns all items as a slice from bottom to top, the order
+// they were pushed in
+func (s *Stack[T]) ToSliceBottomUp() []T {
+	result := s.ToSlice()
+	slices.Reverse(result)
+	return result
+}
+
+// String returns a string representation of the stack
+func (s *Stack[T]) String() string {
+	return fmt.Sprintf("Stack{size: %d, top->bottom: %v}", s.size, s.ToSlice())
+}
+
+// StringWith is like String but renders each item with format
+func (s *Stack[T]) StringWith(format func(T) string) string {
+	items := make([]string, 0, s.size)
+	for current := s.top; current != nil; current = current.Next {
+		items = append(items, format(current.Value))
+	}
+	return fmt.Sprintf("Stack{size: %d, top->bottom: [%s]}", s.size, strings.Join(items, " "))
 }
 
 // Example usage and demonstrations
 func ExampleUsage() {
-	fmt.Println("=== Generic Stack Examples ===\n")
+	fmt.Println("=== Generic Stack Examples ===")
 
 	// Example 1: Basic integer stack
 	fmt.Println("1. Basic Integer Stack (LIFO):")
@@ -206,10 +533,12 @@ func ExampleUsage() {
 		"()()())",
 		"(()())",
 		"(()",
+		"{[()]}",
+		"{[(])}",
 	}
 
 	for _, expr := range expressions {
-		valid := isValidParentheses(expr)
+		valid := IsBalanced(expr, nil)
 		fmt.Printf("  '%s' -> %t\n", expr, valid)
 	}
 
@@ -227,32 +556,3 @@ func ExampleUsage() {
 		fmt.Printf("Peek error: %v\n", err)
 	}
 }
-
-// Helper function for parentheses matching
-func isValidParentheses(s string) bool {
-	stack := NewStack[rune]()
-
-	pairs := map[rune]rune{
-		')': '(',
-		']': '[',
-		'}': '{',
-	}
-
-	for _, char := range s {
-		switch char {
-		case '(', '[', '{':
-			stack.Push(char)
-		case ')', ']', '}':
-			if stack.IsEmpty() {
-				return false
-			}
-
-			top, _ := stack.Pop()
-			if top != pairs[char] {
-				return false
-			}
-		}
-	}
-
-	return stack.IsEmpty()
-}
\ No newline at end of file
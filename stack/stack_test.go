@@ -1,8 +1,16 @@
 package stack
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/anwar-arif/golang-dsa/alloc"
+	"github.com/anwar-arif/golang-dsa/codec"
+	"github.com/anwar-arif/golang-dsa/collections"
 )
 
 func TestBasicPushPop(t *testing.T) {
@@ -223,30 +231,47 @@ func TestParenthesesMatching(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		result := isValidParenthesesTest(tc.input)
+		result := IsBalanced(tc.input, nil)
 		if result != tc.expected {
 			t.Errorf("For input '%s', expected %t, got %t", tc.input, tc.expected, result)
 		}
 	}
 }
 
-// Helper function for testing parentheses matching
-func isValidParenthesesTest(s string) bool {
-	stack := NewStack[rune]()
+func TestIsBalanced(t *testing.T) {
+	testCases := []struct {
+		input    string
+		pairs    map[rune]rune
+		expected bool
+	}{
+		{"{[()]}", nil, true},
+		{"{[(])}", nil, false},
+		{"f(a[i], {k: v})", nil, true},
+		{"]", nil, false},
+		{"[[", nil, false},
+		{"<a<b>>", nil, true},
+		{"<a<b>>", map[rune]rune{'<': '>'}, true},
+		{"<a<b>", map[rune]rune{'<': '>'}, false},
+		{"(<)>", map[rune]rune{'<': '>', '(': ')'}, false},
+		{"«»", map[rune]rune{'«': '»'}, true},
+		{`"a" "b"`, map[rune]rune{'"': '"'}, true},
+		{`"a`, map[rune]rune{'"': '"'}, false},
+		{`("a")`, map[rune]rune{'"': '"', '(': ')'}, true},
+		{`("a)"`, map[rune]rune{'"': '"', '(': ')'}, false},
+		{`|(|)|`, map[rune]rune{'|': '|', '(': ')'}, false},
+	}
 
-	for _, char := range s {
-		switch char {
-		case '(':
-			stack.Push(char)
-		case ')':
-			if stack.IsEmpty() {
-				return false
-			}
-			stack.Pop()
+	for _, tc := range testCases {
+		if got := IsBalanced(tc.input, tc.pairs); got != tc.expected {
+			t.Errorf("For input %q, expected %t, got %t", tc.input, tc.expected, got)
 		}
 	}
 
-	return stack.IsEmpty()
+	pairs := DefaultPairs()
+	delete(pairs, '(')
+	if IsBalanced("(", nil) || len(DefaultPairs()) != 3 {
+		t.Error("Expected changes to DefaultPairs to leave the defaults alone")
+	}
 }
 
 func TestPostfixEvaluation(t *testing.T) {
@@ -365,6 +390,447 @@ func TestEdgeCases(t *testing.T) {
 	})
 }
 
+func TestErrEmpty(t *testing.T) {
+	s := NewStack[int]()
+	if _, err := s.Pop(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from Pop, got %v", err)
+	}
+	if _, err := s.Peek(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from Peek, got %v", err)
+	}
+}
+
+func TestAll2(t *testing.T) {
+	s := NewStack[string]()
+	s.Push("bottom")
+	s.Push("middle")
+	s.Push("top")
+
+	expected := []string{"top", "middle", "bottom"}
+	count := 0
+	for i, v := range s.All2() {
+		if v != expected[i] {
+			t.Errorf("Expected %q at depth %d, got %q", expected[i], i, v)
+		}
+		count++
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 items, got %d", count)
+	}
+
+	for i := range s.All2() {
+		if i > 0 {
+			t.Error("Expected iteration to stop after break")
+		}
+		break
+	}
+}
+
+func TestAll(t *testing.T) {
+	s := NewStack[int]()
+	for i := 1; i <= 4; i++ {
+		s.Push(i)
+	}
+
+	var got []int
+	for v := range s.All() {
+		got = append(got, v)
+	}
+
+	expected := []int{4, 3, 2, 1}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Expected %d at position %d, got %d", expected[i], i, got[i])
+		}
+	}
+
+	// Stopping early must not visit the rest
+	count := 0
+	for range s.All() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("Expected early break after 1 item, got %d", count)
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	s := NewStack[string]()
+	s.Push("bottom")
+	s.Push("top")
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+	if string(data) != `["top","bottom"]` {
+		t.Errorf("Expected top-to-bottom array, got %s", data)
+	}
+
+	// A stack inside a struct round-trips without a constructor
+	var state struct {
+		Undo *Stack[string] `json:"undo"`
+	}
+	if err := json.Unmarshal([]byte(`{"undo":["top","bottom"]}`), &state); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+	for _, expected := range []string{"top", "bottom"} {
+		if v, _ := state.Undo.Pop(); v != expected {
+			t.Errorf("Expected %q, got %q", expected, v)
+		}
+	}
+
+	if data, _ := json.Marshal(NewStack[int]()); string(data) != "[]" {
+		t.Errorf("Expected [] for an empty stack, got %s", data)
+	}
+	if err := s.UnmarshalJSON([]byte(`{"not": "an array"}`)); err == nil {
+		t.Error("Expected error for a non-array document")
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	s := NewStack[string]()
+	s.Push("bottom")
+	s.Push("middle")
+	s.Push("top")
+
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+
+	restored := NewStack[string]()
+	restored.Push("stale")
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+
+	if restored.Size() != 3 {
+		t.Fatalf("Expected size 3, got %d", restored.Size())
+	}
+	for _, expected := range []string{"top", "middle", "bottom"} {
+		if v, _ := restored.Pop(); v != expected {
+			t.Errorf("Expected %s, got %s", expected, v)
+		}
+	}
+
+	if err := restored.UnmarshalBinary([]byte("garbage")); err == nil {
+		t.Error("Expected error for invalid data")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	s := NewStack[int]()
+	s.Push(1)
+	s.Push(2)
+	s.Push(3)
+
+	var buf bytes.Buffer
+	if err := s.Save(&buf, codec.JSON[[]int]()); err != nil {
+		t.Fatalf("Unexpected save error: %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "[3,2,1]" {
+		t.Errorf("Expected [3,2,1], got %s", got)
+	}
+
+	restored := NewStack[int]()
+	if err := restored.Load(&buf, codec.JSON[[]int]()); err != nil {
+		t.Fatalf("Unexpected load error: %v", err)
+	}
+	if top, _ := restored.Peek(); top != 3 || restored.Size() != 3 {
+		t.Errorf("Expected top 3 and size 3, got %d and %d", top, restored.Size())
+	}
+}
+
+func TestClone(t *testing.T) {
+	s := NewStack[int]()
+	s.Push(1)
+	s.Push(2)
+
+	clone := s.Clone()
+	clone.Push(3)
+	s.Pop()
+
+	if s.Size() != 1 || clone.Size() != 3 {
+		t.Fatalf("Expected sizes 1 and 3, got %d and %d", s.Size(), clone.Size())
+	}
+	for _, expected := range []int{3, 2, 1} {
+		if v, _ := clone.Pop(); v != expected {
+			t.Errorf("Expected %d from clone, got %d", expected, v)
+		}
+	}
+	if v, _ := s.Peek(); v != 1 {
+		t.Errorf("Expected original top 1, got %d", v)
+	}
+}
+
+func TestCloneWith(t *testing.T) {
+	s := NewStack[[]int]()
+	s.Push([]int{1})
+	s.Push([]int{2})
+
+	clone := s.CloneWith(func(v []int) []int {
+		return append([]int(nil), v...)
+	})
+
+	top, _ := clone.Peek()
+	top[0] = 99
+
+	if v, _ := s.Peek(); v[0] != 2 {
+		t.Errorf("Expected deep copy to leave original untouched, got %d", v[0])
+	}
+	if clone.Size() != 2 {
+		t.Errorf("Expected clone size 2, got %d", clone.Size())
+	}
+	if empty := NewStack[int]().CloneWith(func(v int) int { return v }); !empty.IsEmpty() {
+		t.Error("Expected empty clone")
+	}
+}
+
+func TestOnMutate(t *testing.T) {
+	s := NewStack[int]()
+
+	var ops []string
+	remove := s.OnMutate(func(op collections.Op, value int) {
+		ops = append(ops, fmt.Sprintf("%s:%d", op, value))
+	})
+
+	s.Push(1)
+	s.Push(2)
+	s.Pop()
+	s.Clear()
+	s.Pop() // failed pops are not reported
+
+	expected := []string{"push:1", "push:2", "pop:2", "clear:0"}
+	if fmt.Sprint(ops) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v, got %v", expected, ops)
+	}
+
+	remove()
+	s.Push(3)
+	if len(ops) != len(expected) {
+		t.Errorf("Expected no notifications after remove, got %v", ops)
+	}
+}
+
+func TestOnPushOnPop(t *testing.T) {
+	s := NewStack[int]()
+	pushes, pops := 0, 0
+	s.OnPush(func(int) { pushes++ })
+	removePop := s.OnPop(func(int) { pops++ })
+
+	s.Push(1)
+	s.Push(2)
+	s.Pop()
+	removePop()
+	s.Pop()
+
+	if pushes != 2 || pops != 1 {
+		t.Errorf("Expected 2 pushes and 1 pop, got %d and %d", pushes, pops)
+	}
+}
+
+func TestStats(t *testing.T) {
+	s := NewStack[int]()
+	for i := range 3 {
+		s.Push(i)
+	}
+	s.Pop()
+	s.Pop()
+	s.Push(9)
+
+	expected := collections.Stats{Size: 2, PeakSize: 3, Pushes: 4, Pops: 2, Allocs: 4}
+	if got := s.Stats(); got != expected {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestPushAllPopN(t *testing.T) {
+	s := NewStack[int]()
+	s.PushAll(1, 2, 3, 4)
+
+	got, err := s.PopN(3)
+	if err != nil || fmt.Sprint(got) != "[4 3 2]" {
+		t.Errorf("Expected [4 3 2], got %v (%v)", got, err)
+	}
+	if got, _ := s.PopN(5); fmt.Sprint(got) != "[1]" {
+		t.Errorf("Expected the remaining [1], got %v", got)
+	}
+	if _, err := s.PopN(1); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+	if got, err := s.PopN(0); err != nil || len(got) != 0 {
+		t.Errorf("Expected no items and no error for n = 0, got %v (%v)", got, err)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	eq := func(a, b string) bool { return a == b }
+	s := NewStack[string]()
+	if d := s.Search("main", eq); d != -1 {
+		t.Errorf("Expected -1 for an empty stack, got %d", d)
+	}
+
+	s.PushAll("main", "run", "handle", "run")
+	testCases := []struct {
+		value string
+		depth int
+	}{
+		{"run", 1}, // the nearest match wins
+		{"handle", 2},
+		{"main", 4},
+		{"exit", -1},
+	}
+	for _, tc := range testCases {
+		if d := s.Search(tc.value, eq); d != tc.depth {
+			t.Errorf("Search(%q): expected %d, got %d", tc.value, tc.depth, d)
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+	a, b := NewStack[int](), NewStack[int]()
+	if !a.Equal(b, eq) {
+		t.Error("Expected empty stacks to be equal")
+	}
+
+	a.PushAll(1, 2, 3)
+	b.PushAll(1, 2)
+	if a.Equal(b, eq) || b.Equal(a, eq) {
+		t.Error("Expected stacks of different sizes to differ")
+	}
+	b.Push(4)
+	if a.Equal(b, eq) {
+		t.Error("Expected different tops to differ")
+	}
+	b.Pop()
+	b.Push(3)
+	if !a.Equal(b, eq) {
+		t.Error("Expected equal stacks")
+	}
+
+	// A clone shares nodes with the original until they diverge
+	c := a.Clone()
+	if !a.Equal(c, eq) {
+		t.Error("Expected a clone to be equal")
+	}
+	c.Pop()
+	c.Push(0)
+	if a.Equal(c, eq) {
+		t.Error("Expected a changed clone to differ")
+	}
+}
+
+func TestToSliceString(t *testing.T) {
+	s := NewStack[int]()
+	if got := s.ToSlice(); got == nil || len(got) != 0 {
+		t.Errorf("Expected an empty non-nil slice, got %#v", got)
+	}
+	if got := s.String(); got != "Stack{size: 0, top->bottom: []}" {
+		t.Errorf("Unexpected String for an empty stack: %q", got)
+	}
+
+	s.PushAll(1, 2, 3)
+	if got := fmt.Sprint(s.ToSlice()); got != "[3 2 1]" {
+		t.Errorf("Expected [3 2 1], got %s", got)
+	}
+	if got := fmt.Sprint(s.ToSliceBottomUp()); got != "[1 2 3]" {
+		t.Errorf("Expected [1 2 3], got %s", got)
+	}
+	if got := s.String(); got != "Stack{size: 3, top->bottom: [3 2 1]}" {
+		t.Errorf("Unexpected String: %q", got)
+	}
+	if got := s.StringWith(func(v int) string { return fmt.Sprintf("%02d", v) }); got != "Stack{size: 3, top->bottom: [03 02 01]}" {
+		t.Errorf("Unexpected StringWith: %q", got)
+	}
+}
+
+func TestIterator(t *testing.T) {
+	s := NewStack[int]()
+	if s.NewIterator().Next() {
+		t.Error("Expected no items from an empty stack")
+	}
+
+	s.PushAll(1, 2, 3)
+	it := s.NewIterator()
+	var got []int
+	for it.Next() {
+		got = append(got, it.Value())
+		if len(got) == 1 {
+			s.Pop() // the iterator keeps walking its snapshot
+			s.Push(9)
+		}
+	}
+	if fmt.Sprint(got) != "[3 2 1]" {
+		t.Errorf("Expected [3 2 1], got %v", got)
+	}
+	if s.Size() != 3 {
+		t.Errorf("Expected iteration to leave the stack alone, size %d", s.Size())
+	}
+	if it.Next() {
+		t.Error("Expected an exhausted iterator to stay exhausted")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Value on an exhausted iterator to panic")
+		}
+	}()
+	it.Value()
+}
+
+func TestNodePool(t *testing.T) {
+	s := NewStack[int](WithNodePool())
+	if s.alloc == nil {
+		t.Fatal("Expected WithNodePool to set an allocator")
+	}
+	for round := 0; round < 3; round++ {
+		s.PushAll(1, 2, 3)
+		if got, _ := s.PopN(3); fmt.Sprint(got) != "[3 2 1]" {
+			t.Errorf("Expected [3 2 1], got %v", got)
+		}
+	}
+	if NewStack[int]().alloc != nil {
+		t.Error("Expected no allocator by default")
+	}
+}
+
+func TestAllocator(t *testing.T) {
+	pool := alloc.NewPool[Node[int]]()
+	s := NewStackWithAllocator[int](pool)
+	s.Push(1)
+	s.Push(2)
+
+	// Popped nodes are zeroed by the pool, so a clone must not share them
+	clone := s.Clone()
+	s.Pop()
+	s.Pop()
+	s.Push(7)
+
+	for _, expected := range []int{2, 1} {
+		if v, _ := clone.Pop(); v != expected {
+			t.Errorf("Expected %d from clone, got %d", expected, v)
+		}
+	}
+	if v, _ := s.Pop(); v != 7 {
+		t.Errorf("Expected 7, got %d", v)
+	}
+
+	arena := alloc.NewArena[Node[int]](16)
+	s = NewStackWithAllocator[int](arena)
+	for i := 0; i < 20; i++ {
+		s.Push(i)
+	}
+	if arena.Allocated() != 20 || s.Size() != 20 {
+		t.Errorf("Expected 20 nodes from the arena, got %v", arena)
+	}
+}
+
 // Benchmark tests
 func BenchmarkPush(b *testing.B) {
 	s := NewStack[int]()
@@ -411,8 +877,45 @@ func BenchmarkPushPop(b *testing.B) {
 	}
 }
 
+func BenchmarkPushArena(b *testing.B) {
+	arena := alloc.NewArena[Node[int]](4096)
+	s := NewStackWithAllocator[int](arena)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Push(i)
+		if i%4096 == 4095 {
+			s.Clear()
+			arena.Reset()
+		}
+	}
+}
+
+func BenchmarkNodePool(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		opts []Option
+	}{
+		{"heap", nil},
+		{"pool", []Option{WithNodePool()}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			s := NewStack[int](bc.opts...)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < 64; j++ {
+					s.Push(j)
+				}
+				for j := 0; j < 64; j++ {
+					s.Pop()
+				}
+			}
+		})
+	}
+}
+
 // Example tests for documentation
-func ExampleNew() {
+func ExampleNewStack() {
 	s := NewStack[int]()
 
 	s.Push(10)
@@ -421,7 +924,7 @@ func ExampleNew() {
 
 	for !s.IsEmpty() {
 		val, _ := s.Pop()
-		println("Popped:", val)
+		fmt.Println("Popped:", val)
 	}
 	// Output:
 	// Popped: 30
@@ -436,7 +939,7 @@ func ExampleStack_Push() {
 	s.Push("second")
 	s.Push("third")
 
-	println("Stack size:", s.Size())
+	fmt.Println("Stack size:", s.Size())
 	// Output: Stack size: 3
 }
 
@@ -449,8 +952,8 @@ func ExampleStack_Pop() {
 	second, _ := s.Pop()
 	first, _ := s.Pop()
 
-	println("Second (last in):", second)
-	println("First (first in):", first)
+	fmt.Println("Second (last in):", second)
+	fmt.Println("First (first in):", first)
 	// Output:
 	// Second (last in): B
 	// First (first in): A
@@ -463,8 +966,8 @@ func ExampleStack_Peek() {
 	s.Push(200)
 
 	top, _ := s.Peek()
-	println("Top item:", top)
-	println("Stack size after Peek():", s.Size())
+	fmt.Println("Top item:", top)
+	fmt.Println("Stack size after Peek():", s.Size())
 	// Output:
 	// Top item: 200
 	// Stack size after Peek(): 2
@@ -0,0 +1,131 @@
+package stripedlock
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/anwar-arif/golang-dsa/hashutil"
+)
+
+// DefaultStripes is the number of stripes used when New is given a non-positive count
+const DefaultStripes = 64
+
+// Striped guards per-key critical sections with a fixed set of read/write
+// mutexes chosen by key hash
+// Different keys may share a stripe, so holding two keys at once can deadlock
+// unless they are always taken in the same stripe order
+type Striped[K comparable] struct {
+	hasher  hashutil.Hasher[K]
+	mask    uint64
+	stripes []sync.RWMutex
+}
+
+// New creates a striped lock with at least n stripes, rounded up to a power of two
+func New[K comparable](n int) *Striped[K] {
+	return NewWithHasher(n, hashutil.Comparable[K]())
+}
+
+// NewWithHasher is like New but picks stripes with h, for keys whose
+// equality is looser than ==
+func NewWithHasher[K comparable](n int, h hashutil.Hasher[K]) *Striped[K] {
+	if n <= 0 {
+		n = DefaultStripes
+	}
+
+	size := 1
+	for size < n {
+		size <<= 1
+	}
+
+	return &Striped[K]{
+		hasher:  h,
+		mask:    uint64(size - 1),
+		stripes: make([]sync.RWMutex, size),
+	}
+}
+
+// stripe returns the index of the stripe guarding key
+func (s *Striped[K]) stripe(key K) int {
+	return int(s.hasher.Hash(key) & s.mask)
+}
+
+// Lock returns the mutex guarding key
+func (s *Striped[K]) Lock(key K) *sync.RWMutex {
+	return &s.stripes[s.stripe(key)]
+}
+
+// LockKey acquires the write lock for key
+func (s *Striped[K]) LockKey(key K) {
+	s.Lock(key).Lock()
+}
+
+// UnlockKey releases the write lock for key
+func (s *Striped[K]) UnlockKey(key K) {
+	s.Lock(key).Unlock()
+}
+
+// RLockKey acquires the read lock for key
+func (s *Striped[K]) RLockKey(key K) {
+	s.Lock(key).RLock()
+}
+
+// RUnlockKey releases the read lock for key
+func (s *Striped[K]) RUnlockKey(key K) {
+	s.Lock(key).RUnlock()
+}
+
+// WithKey runs fn while holding the write lock for key
+func (s *Striped[K]) WithKey(key K, fn func()) {
+	l := s.Lock(key)
+	l.Lock()
+	defer l.Unlock()
+
+	fn()
+}
+
+// WithKeyRead runs fn while holding the read lock for key
+func (s *Striped[K]) WithKeyRead(key K, fn func()) {
+	l := s.Lock(key)
+	l.RLock()
+	defer l.RUnlock()
+
+	fn()
+}
+
+// Stripes returns the number of internal mutexes
+func (s *Striped[K]) Stripes() int {
+	return len(s.stripes)
+}
+
+// String returns a string representation of the striped lock
+func (s *Striped[K]) String() string {
+	return fmt.Sprintf("Striped{stripes: %d}", len(s.stripes))
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Striped Lock Examples ===")
+
+	// Example 1: Per-key counters
+	fmt.Println("1. Per-Key Critical Sections:")
+	locks := New[int](16)
+	balances := make([]int, 4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		account := i % len(balances)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			locks.WithKey(account, func() {
+				balances[account] += 10
+			})
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("  Balances: %v\n", balances)
+
+	// Example 2: Stripe count is rounded to a power of two
+	fmt.Println("\n2. Stripes:")
+	fmt.Printf("  New(10) -> %v\n", New[int](10))
+}
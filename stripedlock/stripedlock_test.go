@@ -0,0 +1,128 @@
+package stripedlock
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anwar-arif/golang-dsa/hashutil"
+)
+
+func TestStripeCount(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected int
+	}{
+		{0, DefaultStripes},
+		{-3, DefaultStripes},
+		{1, 1},
+		{10, 16},
+		{64, 64},
+	}
+
+	for _, tt := range tests {
+		if got := New[int](tt.n).Stripes(); got != tt.expected {
+			t.Errorf("New(%d).Stripes() = %d, expected %d", tt.n, got, tt.expected)
+		}
+	}
+}
+
+func TestSameKeySameStripe(t *testing.T) {
+	s := New[string](32)
+
+	if s.Lock("alpha") != s.Lock("alpha") {
+		t.Error("Expected the same key to map to the same mutex")
+	}
+}
+
+func TestNewWithHasher(t *testing.T) {
+	// Case-insensitive keys must share a stripe
+	str := hashutil.String()
+	s := NewWithHasher(32, hashutil.Field(strings.ToLower, str))
+
+	if s.Lock("Alpha") != s.Lock("ALPHA") {
+		t.Error("Expected keys equal under the hasher to share a mutex")
+	}
+}
+
+func TestLockKeyExcludes(t *testing.T) {
+	s := New[int](8)
+	s.LockKey(1)
+
+	acquired := make(chan struct{})
+	go func() {
+		s.LockKey(1)
+		close(acquired)
+		s.UnlockKey(1)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Second LockKey should block while the key is held")
+	case <-time.After(30 * time.Millisecond):
+	}
+
+	s.UnlockKey(1)
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("Expected LockKey to proceed after UnlockKey")
+	}
+}
+
+func TestReadersShare(t *testing.T) {
+	s := New[int](8)
+	s.RLockKey(1)
+	defer s.RUnlockKey(1)
+
+	done := make(chan struct{})
+	go func() {
+		s.WithKeyRead(1, func() {})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected concurrent readers to share the stripe")
+	}
+}
+
+func TestWithKeyConcurrent(t *testing.T) {
+	s := New[int](4)
+	counts := make([]int, 10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func(key int) {
+			defer wg.Done()
+			s.WithKey(key, func() {
+				counts[key]++
+			})
+		}(i % 10)
+	}
+	wg.Wait()
+
+	for key, c := range counts {
+		if c != 100 {
+			t.Errorf("Expected 100 increments for key %d, got %d", key, c)
+		}
+	}
+}
+
+// Benchmark tests
+func BenchmarkWithKeyParallel(b *testing.B) {
+	s := New[int](64)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			s.WithKey(i, func() {})
+			i++
+		}
+	})
+}
@@ -0,0 +1,277 @@
+// Package timeseries keeps recent timestamped samples in a ring buffer and
+// answers aggregates over trailing windows
+package timeseries
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrOutOfOrder is returned when a sample is older than the newest one stored
+var ErrOutOfOrder = errors.New("sample is older than the newest sample")
+
+// Sample is a value observed at a point in time
+type Sample struct {
+	Time  time.Time
+	Value float64
+}
+
+// Stats summarises the samples of a window
+// Avg, Min and Max are zero when Count is zero
+type Stats struct {
+	Count int
+	Sum   float64
+	Avg   float64
+	Min   float64
+	Max   float64
+}
+
+// Series is a fixed-capacity ring of samples in time order
+// Samples are dropped once they are older than the retention window or when
+// the ring is full and a new sample arrives, whichever comes first
+// Window queries locate their start by binary search, so they cost
+// O(log n + k) for k samples in the window
+// A Series is safe for concurrent use
+type Series struct {
+	mu        sync.Mutex
+	buf       []Sample
+	head      int // index of the oldest sample
+	size      int
+	retention time.Duration
+	now       func() time.Time
+}
+
+// New creates a series holding at most capacity samples for at most retention
+// A retention of zero keeps samples until the ring overwrites them
+func New(capacity int, retention time.Duration) *Series {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Series{
+		buf:       make([]Sample, capacity),
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// at returns the i-th oldest sample; callers must hold mu
+func (s *Series) at(i int) Sample {
+	return s.buf[(s.head+i)%len(s.buf)]
+}
+
+// Add records v at the current time
+func (s *Series) Add(v float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.now()
+	if s.size > 0 {
+		// Never let a clock step backwards break the time order
+		if last := s.at(s.size - 1).Time; t.Before(last) {
+			t = last
+		}
+	}
+	s.add(t, v)
+}
+
+// AddAt records v at t, which must not be older than the newest sample
+func (s *Series) AddAt(t time.Time, v float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size > 0 && t.Before(s.at(s.size-1).Time) {
+		return ErrOutOfOrder
+	}
+	s.add(t, v)
+	return nil
+}
+
+// add appends a sample, overwriting the oldest when full; callers must hold mu
+func (s *Series) add(t time.Time, v float64) {
+	if s.size == len(s.buf) {
+		s.buf[s.head] = Sample{Time: t, Value: v}
+		s.head = (s.head + 1) % len(s.buf)
+	} else {
+		s.buf[(s.head+s.size)%len(s.buf)] = Sample{Time: t, Value: v}
+		s.size++
+	}
+	s.expire(s.now())
+}
+
+// expire drops samples that have left the retention window; callers must hold mu
+func (s *Series) expire(now time.Time) {
+	if s.retention <= 0 {
+		return
+	}
+	n := s.since(now.Add(-s.retention))
+	s.head = (s.head + n) % len(s.buf)
+	s.size -= n
+}
+
+// since returns the number of samples at or before cutoff, which is the
+// logical index of the first sample after it; callers must hold mu
+func (s *Series) since(cutoff time.Time) int {
+	return sort.Search(s.size, func(i int) bool {
+		return s.at(i).Time.After(cutoff)
+	})
+}
+
+// window returns copies of the samples newer than now-d; callers must hold mu
+func (s *Series) window(d time.Duration) []Sample {
+	now := s.now()
+	s.expire(now)
+
+	start := s.since(now.Add(-d))
+	samples := make([]Sample, 0, s.size-start)
+	for i := start; i < s.size; i++ {
+		samples = append(samples, s.at(i))
+	}
+	return samples
+}
+
+// Window returns the samples recorded within the last d, oldest first
+func (s *Series) Window(d time.Duration) []Sample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.window(d)
+}
+
+// Stats returns the count, sum, average, minimum and maximum of the samples
+// recorded within the last d
+func (s *Series) Stats(d time.Duration) Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var st Stats
+	now := s.now()
+	s.expire(now)
+
+	for i := s.since(now.Add(-d)); i < s.size; i++ {
+		v := s.at(i).Value
+		if st.Count == 0 {
+			st.Min, st.Max = v, v
+		}
+		st.Count++
+		st.Sum += v
+		st.Min = min(st.Min, v)
+		st.Max = max(st.Max, v)
+	}
+	if st.Count > 0 {
+		st.Avg = st.Sum / float64(st.Count)
+	}
+	return st
+}
+
+// Percentile returns the q-quantile (0 <= q <= 1) of the samples recorded
+// within the last d, interpolating linearly between neighbouring ranks
+// It reports false when the window is empty or q is out of range
+// Percentiles are exact: the window's values are copied and sorted
+func (s *Series) Percentile(d time.Duration, q float64) (float64, bool) {
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return 0, false
+	}
+
+	s.mu.Lock()
+	samples := s.window(d)
+	s.mu.Unlock()
+
+	if len(samples) == 0 {
+		return 0, false
+	}
+
+	values := make([]float64, len(samples))
+	for i, sample := range samples {
+		values[i] = sample.Value
+	}
+	slices.Sort(values)
+
+	rank := q * float64(len(values)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	frac := rank - float64(lo)
+	return values[lo] + (values[hi]-values[lo])*frac, true
+}
+
+// Last returns the newest sample
+func (s *Series) Last() (Sample, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expire(s.now())
+	if s.size == 0 {
+		return Sample{}, false
+	}
+	return s.at(s.size - 1), true
+}
+
+// Len returns the number of samples within the retention window
+func (s *Series) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expire(s.now())
+	return s.size
+}
+
+// Cap returns the maximum number of samples the series holds
+func (s *Series) Cap() int {
+	return len(s.buf)
+}
+
+// Clear removes every sample
+func (s *Series) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.head = 0
+	s.size = 0
+}
+
+// String returns a string representation of the series
+func (s *Series) String() string {
+	return fmt.Sprintf("Series{samples: %d, cap: %d, retention: %v}", s.Len(), s.Cap(), s.retention)
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Time Series Examples ===")
+
+	// Samples are recorded with explicit timestamps so the output is stable
+	start := time.Now().Add(-time.Minute)
+	latencies := New(1000, time.Hour)
+	for i := 0; i < 60; i++ {
+		ms := float64(10 + i%7*5)
+		if i%20 == 19 {
+			ms = 250 // occasional slow request
+		}
+		latencies.AddAt(start.Add(time.Duration(i)*time.Second), ms)
+	}
+
+	// Example 1: Aggregates over trailing windows
+	fmt.Println("1. Windowed Stats:")
+	for _, d := range []time.Duration{10 * time.Second, time.Minute} {
+		st := latencies.Stats(d)
+		fmt.Printf("  Last %v: count=%d avg=%.1f min=%.0f max=%.0f\n", d, st.Count, st.Avg, st.Min, st.Max)
+	}
+
+	// Example 2: Percentiles
+	fmt.Println("\n2. Percentiles Over the Last Minute:")
+	for _, q := range []float64{0.5, 0.9, 0.99} {
+		p, _ := latencies.Percentile(time.Minute, q)
+		fmt.Printf("  p%.0f: %.1f ms\n", q*100, p)
+	}
+
+	// Example 3: Bounded memory
+	fmt.Println("\n3. Ring Capacity:")
+	small := New(3, 0)
+	for i := 1; i <= 5; i++ {
+		small.Add(float64(i))
+	}
+	fmt.Printf("  %v, sum=%.0f\n", small, small.Stats(time.Hour).Sum)
+}
@@ -0,0 +1,148 @@
+package timeseries
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/anwar-arif/golang-dsa/internal/testclock"
+)
+
+// newTestSeries returns a series whose clock is controlled by the returned test clock
+func newTestSeries(capacity int, retention time.Duration) (*Series, *testclock.Clock) {
+	clock := testclock.New()
+	s := New(capacity, retention)
+	s.now = clock.Now
+	return s, clock
+}
+
+func TestWindowedStats(t *testing.T) {
+	s, clock := newTestSeries(100, 0)
+	for i := 1; i <= 10; i++ {
+		s.Add(float64(i))
+		clock.Advance(time.Second)
+	}
+	// now is 10s after the first sample; the newest sample is 1s old
+
+	tests := []struct {
+		window   time.Duration
+		expected Stats
+	}{
+		{3 * time.Second, Stats{Count: 2, Sum: 19, Avg: 9.5, Min: 9, Max: 10}},
+		{time.Minute, Stats{Count: 10, Sum: 55, Avg: 5.5, Min: 1, Max: 10}},
+		{0, Stats{}},
+	}
+
+	for _, tt := range tests {
+		if got := s.Stats(tt.window); got != tt.expected {
+			t.Errorf("Stats(%v): expected %+v, got %+v", tt.window, tt.expected, got)
+		}
+	}
+
+	if w := s.Window(5 * time.Second); len(w) != 4 || w[0].Value != 7 {
+		t.Errorf("Expected 4 samples starting at 7, got %v", w)
+	}
+}
+
+func TestRetentionAndCapacity(t *testing.T) {
+	s, clock := newTestSeries(100, 5*time.Second)
+	for i := 0; i < 10; i++ {
+		s.Add(float64(i))
+		clock.Advance(time.Second)
+	}
+	if s.Len() != 4 {
+		t.Errorf("Expected 4 samples inside retention, got %d", s.Len())
+	}
+
+	clock.Advance(time.Hour)
+	if s.Len() != 0 {
+		t.Errorf("Expected every sample to expire, got %d", s.Len())
+	}
+	if _, ok := s.Last(); ok {
+		t.Error("Expected no last sample")
+	}
+
+	ring, _ := newTestSeries(3, 0)
+	for i := 1; i <= 5; i++ {
+		ring.Add(float64(i))
+	}
+	if ring.Len() != 3 || ring.Stats(time.Hour).Sum != 12 {
+		t.Errorf("Expected the oldest samples to be overwritten, got %v", ring.Window(time.Hour))
+	}
+	if last, _ := ring.Last(); last.Value != 5 {
+		t.Errorf("Expected last value 5, got %v", last.Value)
+	}
+
+	ring.Clear()
+	if ring.Len() != 0 {
+		t.Error("Expected empty series after Clear")
+	}
+}
+
+func TestAddAtOrder(t *testing.T) {
+	s, clock := newTestSeries(10, 0)
+	if err := s.AddAt(clock.Now(), 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := s.AddAt(clock.Now().Add(-time.Second), 2); !errors.Is(err, ErrOutOfOrder) {
+		t.Errorf("Expected ErrOutOfOrder, got %v", err)
+	}
+	if err := s.AddAt(clock.Now(), 3); err != nil {
+		t.Errorf("Expected equal timestamps to be accepted, got %v", err)
+	}
+
+	// A clock stepping backwards is clamped rather than rejected
+	clock.Advance(-time.Minute)
+	s.Add(4)
+	if last, _ := s.Last(); last.Value != 4 || !last.Time.Equal(clock.Now().Add(time.Minute)) {
+		t.Errorf("Expected clamped timestamp, got %+v", last)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	s, _ := newTestSeries(100, 0)
+	for _, v := range []float64{5, 1, 4, 2, 3} {
+		s.Add(v)
+	}
+
+	tests := []struct {
+		q        float64
+		expected float64
+	}{
+		{0, 1}, {0.5, 3}, {1, 5}, {0.25, 2}, {0.9, 4.6},
+	}
+	for _, tt := range tests {
+		got, ok := s.Percentile(time.Hour, tt.q)
+		if !ok || math.Abs(got-tt.expected) > 1e-9 {
+			t.Errorf("Percentile(%v): expected %v, got %v", tt.q, tt.expected, got)
+		}
+	}
+
+	if _, ok := s.Percentile(time.Hour, 1.5); ok {
+		t.Error("Expected out-of-range q to fail")
+	}
+	if _, ok := New(1, 0).Percentile(time.Hour, 0.5); ok {
+		t.Error("Expected empty window to fail")
+	}
+}
+
+// Benchmark tests
+func BenchmarkAdd(b *testing.B) {
+	s := New(4096, time.Minute)
+	for i := 0; i < b.N; i++ {
+		s.Add(float64(i))
+	}
+}
+
+func BenchmarkStats(b *testing.B) {
+	s := New(4096, 0)
+	for i := 0; i < 4096; i++ {
+		s.Add(float64(i))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Stats(time.Second)
+	}
+}
@@ -0,0 +1,198 @@
+// Package undo records reversible commands on two stacks so they can be
+// undone and redone, as in an editor's history
+package undo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/anwar-arif/golang-dsa/stack"
+)
+
+var (
+	// ErrNothingToUndo is returned by Undo when the history is empty
+	ErrNothingToUndo = errors.New("undo: nothing to undo")
+	// ErrNothingToRedo is returned by Redo when no undone command is left
+	ErrNothingToRedo = errors.New("undo: nothing to redo")
+)
+
+// Command is a reversible change
+// Undo must revert what Do did, and Do must be safe to run again after Undo
+type Command interface {
+	Do() error
+	Undo() error
+}
+
+// funcCommand adapts a pair of functions to Command
+type funcCommand struct {
+	do, undo func() error
+}
+
+func (c funcCommand) Do() error   { return c.do() }
+func (c funcCommand) Undo() error { return c.undo() }
+
+// NewCommand returns a Command that runs do and undo
+func NewCommand(do, undo func() error) Command {
+	return funcCommand{do: do, undo: undo}
+}
+
+// Manager keeps the history of executed commands
+// Doing a new command discards everything that was undone. With a capacity,
+// only the latest commands stay undoable; older ones are forgotten
+// A Manager is not safe for concurrent use
+type Manager struct {
+	done     *stack.Stack[Command]
+	undone   *stack.Stack[Command]
+	capacity int // 0 means unlimited
+	undoable int // commands at the top of done that Undo may still reach
+}
+
+// New creates a manager that remembers at most capacity commands
+// A capacity of zero or less means unlimited
+func New(capacity int) *Manager {
+	return &Manager{
+		done:     stack.NewStack[Command](),
+		undone:   stack.NewStack[Command](),
+		capacity: max(capacity, 0),
+	}
+}
+
+// Do runs cmd and records it for Undo, discarding any undone commands
+// If cmd fails nothing is recorded and its error is returned
+func (m *Manager) Do(cmd Command) error {
+	if err := cmd.Do(); err != nil {
+		return err
+	}
+	m.record(cmd)
+	m.undone.Clear()
+	return nil
+}
+
+// record pushes cmd onto the history, forgetting the oldest command beyond
+// capacity
+// Forgotten commands stay on the stack until it holds twice the capacity and
+// is trimmed in one go, which keeps Do amortized O(1)
+func (m *Manager) record(cmd Command) {
+	m.done.Push(cmd)
+	m.undoable++
+	if m.capacity == 0 || m.undoable <= m.capacity {
+		return
+	}
+	m.undoable = m.capacity
+	if m.done.Size() >= 2*m.capacity {
+		keep, _ := m.done.PopN(m.undoable)
+		m.done.Clear()
+		slices.Reverse(keep)
+		m.done.PushAll(keep...)
+	}
+}
+
+// Undo reverts the latest command and makes it available to Redo
+// Returns ErrNothingToUndo if there is none. If the command's Undo fails,
+// it stays in the history and the error is returned
+func (m *Manager) Undo() error {
+	if m.undoable == 0 {
+		return ErrNothingToUndo
+	}
+	cmd, _ := m.done.Peek()
+	if err := cmd.Undo(); err != nil {
+		return fmt.Errorf("undo: %w", err)
+	}
+	m.done.Pop()
+	m.undoable--
+	m.undone.Push(cmd)
+	return nil
+}
+
+// Redo runs the most recently undone command again
+// Returns ErrNothingToRedo if there is none. If the command fails, it stays
+// available to Redo and the error is returned
+func (m *Manager) Redo() error {
+	cmd, err := m.undone.Peek()
+	if err != nil {
+		return ErrNothingToRedo
+	}
+	if err := cmd.Do(); err != nil {
+		return fmt.Errorf("redo: %w", err)
+	}
+	m.undone.Pop()
+	m.record(cmd)
+	return nil
+}
+
+// CanUndo returns true if Undo has a command to revert
+func (m *Manager) CanUndo() bool {
+	return m.undoable > 0
+}
+
+// CanRedo returns true if Redo has a command to run
+func (m *Manager) CanRedo() bool {
+	return !m.undone.IsEmpty()
+}
+
+// UndoLen returns the number of commands Undo can revert
+func (m *Manager) UndoLen() int {
+	return m.undoable
+}
+
+// RedoLen returns the number of commands Redo can run
+func (m *Manager) RedoLen() int {
+	return m.undone.Size()
+}
+
+// Capacity returns the most commands the history keeps, 0 if unlimited
+func (m *Manager) Capacity() int {
+	return m.capacity
+}
+
+// Clear forgets the whole history without reverting anything
+func (m *Manager) Clear() {
+	m.done.Clear()
+	m.undone.Clear()
+	m.undoable = 0
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Undo/Redo Examples ===")
+
+	// Example 1: Editing a document
+	fmt.Println("1. Text editing:")
+	text := ""
+	insert := func(s string) Command {
+		return NewCommand(
+			func() error { text += s; return nil },
+			func() error { text = text[:len(text)-len(s)]; return nil },
+		)
+	}
+
+	history := New(0)
+	history.Do(insert("Hello"))
+	history.Do(insert(", world"))
+	fmt.Printf("  After typing: %q\n", text)
+	history.Undo()
+	fmt.Printf("  After undo: %q\n", text)
+	history.Redo()
+	fmt.Printf("  After redo: %q\n", text)
+
+	// Example 2: A new command discards the redo history
+	fmt.Println("\n2. Branching:")
+	history.Undo()
+	history.Do(insert("!"))
+	fmt.Printf("  Text %q, can redo: %v\n", text, history.CanRedo())
+
+	// Example 3: Bounded history
+	fmt.Println("\n3. Capacity:")
+	counter := 0
+	bounded := New(2)
+	for i := 0; i < 5; i++ {
+		bounded.Do(NewCommand(
+			func() error { counter++; return nil },
+			func() error { counter--; return nil },
+		))
+	}
+	for bounded.Undo() == nil {
+	}
+	fmt.Printf("  Counter after undoing everything possible: %d\n", counter)
+}
@@ -0,0 +1,128 @@
+package undo
+
+import (
+	"errors"
+	"testing"
+)
+
+// add returns a command that adds n to *total
+func add(total *int, n int) Command {
+	return NewCommand(
+		func() error { *total += n; return nil },
+		func() error { *total -= n; return nil },
+	)
+}
+
+func TestUndoRedo(t *testing.T) {
+	total := 0
+	m := New(0)
+	if err := m.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Expected ErrNothingToUndo, got %v", err)
+	}
+	if err := m.Redo(); !errors.Is(err, ErrNothingToRedo) {
+		t.Errorf("Expected ErrNothingToRedo, got %v", err)
+	}
+
+	m.Do(add(&total, 1))
+	m.Do(add(&total, 10))
+	m.Do(add(&total, 100))
+	if total != 111 || m.UndoLen() != 3 {
+		t.Fatalf("Expected 111 with 3 undoable, got %d with %d", total, m.UndoLen())
+	}
+
+	m.Undo()
+	m.Undo()
+	if total != 1 || m.RedoLen() != 2 || !m.CanRedo() {
+		t.Errorf("Expected 1 with 2 redoable, got %d with %d", total, m.RedoLen())
+	}
+	m.Redo()
+	if total != 11 {
+		t.Errorf("Expected 11 after redo, got %d", total)
+	}
+
+	// A new command discards the redo history
+	m.Do(add(&total, 1000))
+	if m.CanRedo() || total != 1011 {
+		t.Errorf("Expected no redo after Do, total %d", total)
+	}
+
+	m.Clear()
+	if m.CanUndo() || m.CanRedo() || total != 1011 {
+		t.Errorf("Expected Clear to forget history without reverting")
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	total := 0
+	m := New(3)
+	for i := 1; i <= 10; i++ {
+		m.Do(add(&total, i))
+		if m.UndoLen() != min(i, 3) {
+			t.Fatalf("Step %d: expected %d undoable, got %d", i, min(i, 3), m.UndoLen())
+		}
+	}
+	if m.done.Size() >= 2*m.Capacity() {
+		t.Errorf("Expected forgotten commands to be trimmed, stack holds %d", m.done.Size())
+	}
+
+	for m.Undo() == nil {
+	}
+	// Only 10, 9 and 8 were undone
+	if total != 55-27 {
+		t.Errorf("Expected %d, got %d", 55-27, total)
+	}
+
+	// Redoing and undoing again stays within the capacity
+	m.Redo()
+	m.Redo()
+	m.Do(add(&total, 0))
+	m.Do(add(&total, 0))
+	if m.UndoLen() != 3 {
+		t.Errorf("Expected 3 undoable, got %d", m.UndoLen())
+	}
+}
+
+func TestFailures(t *testing.T) {
+	boom := errors.New("boom")
+	m := New(0)
+
+	if err := m.Do(NewCommand(func() error { return boom }, nil)); !errors.Is(err, boom) {
+		t.Errorf("Expected the command's error, got %v", err)
+	}
+	if m.CanUndo() {
+		t.Error("Expected a failed command not to be recorded")
+	}
+
+	fail := true
+	m.Do(NewCommand(
+		func() error { return nil },
+		func() error {
+			if fail {
+				return boom
+			}
+			return nil
+		},
+	))
+	if err := m.Undo(); !errors.Is(err, boom) {
+		t.Errorf("Expected the undo error, got %v", err)
+	}
+	if m.UndoLen() != 1 {
+		t.Error("Expected a failed undo to stay in the history")
+	}
+	fail = false
+	if err := m.Undo(); err != nil || m.RedoLen() != 1 {
+		t.Errorf("Expected the retry to succeed, got %v", err)
+	}
+}
+
+// Benchmark tests
+func BenchmarkDoUndo(b *testing.B) {
+	total := 0
+	m := New(64)
+	for i := 0; i < b.N; i++ {
+		m.Do(add(&total, 1))
+		if i%4 == 0 {
+			m.Undo()
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// Package viz renders data structures as Graphviz DOT or Mermaid diagrams
+package viz
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// Node is a vertex of a rendered graph
+type Node struct {
+	ID    string
+	Label string
+	Shape string // DOT shape such as "box" or "circle"; empty for the default
+}
+
+// Edge connects two nodes by ID
+type Edge struct {
+	From  string
+	To    string
+	Label string
+}
+
+// Graph is a renderer-independent description of a structure's shape
+type Graph struct {
+	Name        string
+	Directed    bool
+	LeftToRight bool // lay the graph out horizontally instead of top to bottom
+	Nodes       []Node
+	Edges       []Edge
+}
+
+// NewGraph creates an empty directed graph
+func NewGraph(name string) *Graph {
+	return &Graph{Name: name, Directed: true}
+}
+
+// AddNode appends a node and returns its ID
+func (g *Graph) AddNode(id, label string) string {
+	g.Nodes = append(g.Nodes, Node{ID: id, Label: label})
+	return id
+}
+
+// AddEdge appends an edge between two node IDs
+func (g *Graph) AddEdge(from, to, label string) {
+	g.Edges = append(g.Edges, Edge{From: from, To: to, Label: label})
+}
+
+// Labeler turns an element into the text shown inside its node
+type Labeler[T any] func(T) string
+
+// Sprint is the default Labeler, using fmt.Sprint
+func Sprint[T any](v T) string {
+	return fmt.Sprint(v)
+}
+
+// nodeID returns the ID used for the i-th generated node
+func nodeID(i int) string {
+	return fmt.Sprintf("n%d", i)
+}
+
+// Chain renders a sequence as a linked chain, suitable for lists, stacks and queues
+// A nil label uses Sprint
+func Chain[T any](name string, seq iter.Seq[T], label Labeler[T]) *Graph {
+	if label == nil {
+		label = Sprint[T]
+	}
+
+	g := NewGraph(name)
+	g.LeftToRight = true
+
+	prev := ""
+	i := 0
+	for v := range seq {
+		id := g.AddNode(nodeID(i), label(v))
+		g.Nodes[i].Shape = "box"
+		if prev != "" {
+			g.AddEdge(prev, id, "")
+		}
+		prev = id
+		i++
+	}
+	return g
+}
+
+// Heap renders values stored in binary-heap array order as a tree, where the
+// children of index i are at 2i+1 and 2i+2
+// Pair it with PriorityQueue.All, which yields values in heap order
+func Heap[T any](name string, seq iter.Seq[T], label Labeler[T]) *Graph {
+	return DaryHeap(name, seq, 2, label)
+}
+
+// DaryHeap renders values stored in d-ary heap array order as a tree, where
+// the children of index i are at d*i+1 through d*i+d
+// Panics if d < 2
+func DaryHeap[T any](name string, seq iter.Seq[T], d int, label Labeler[T]) *Graph {
+	if d < 2 {
+		panic(fmt.Sprintf("viz: heap arity must be at least 2, got %d", d))
+	}
+	if label == nil {
+		label = Sprint[T]
+	}
+
+	g := NewGraph(name)
+	i := 0
+	for v := range seq {
+		id := g.AddNode(nodeID(i), label(v))
+		g.Nodes[i].Shape = "circle"
+		if i > 0 {
+			g.AddEdge(nodeID((i-1)/d), id, "")
+		}
+		i++
+	}
+	return g
+}
+
+// Tree renders a rooted tree given a function listing each node's children,
+// which covers binary search trees, tries and n-ary trees alike
+// children may return nil entries for missing children; they are skipped
+// edgeLabel, when non-nil, labels the edge to the i-th child (for example the
+// rune of a trie edge or "L"/"R" for a binary tree)
+func Tree[N comparable](name string, root N, children func(N) []N, label Labeler[N], edgeLabel func(parent N, i int) string) *Graph {
+	if label == nil {
+		label = Sprint[N]
+	}
+
+	g := NewGraph(name)
+	var zero N
+	if root == zero {
+		return g
+	}
+
+	next := 0
+	var walk func(n N) string
+	walk = func(n N) string {
+		id := g.AddNode(nodeID(next), label(n))
+		next++
+		for i, child := range children(n) {
+			if child == zero {
+				continue
+			}
+			childID := walk(child)
+			el := ""
+			if edgeLabel != nil {
+				el = edgeLabel(n, i)
+			}
+			g.AddEdge(id, childID, el)
+		}
+		return id
+	}
+	walk(root)
+	return g
+}
+
+// escapeDOT quotes s for use as a DOT string
+func escapeDOT(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + strings.ReplaceAll(s, "\n", `\n`) + `"`
+}
+
+// escapeMermaid quotes s for use as a Mermaid node or edge label
+func escapeMermaid(s string) string {
+	s = strings.ReplaceAll(s, `"`, "#quot;")
+	return `"` + strings.ReplaceAll(s, "\n", "<br/>") + `"`
+}
+
+// WriteDOT writes the graph in Graphviz DOT format
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+
+	kind, arrow := "graph", "--"
+	if g.Directed {
+		kind, arrow = "digraph", "->"
+	}
+	fmt.Fprintf(&b, "%s %s {\n", kind, escapeDOT(g.Name))
+	if g.LeftToRight {
+		b.WriteString("  rankdir=LR;\n")
+	}
+
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "  %s [label=%s", n.ID, escapeDOT(n.Label))
+		if n.Shape != "" {
+			fmt.Fprintf(&b, ", shape=%s", n.Shape)
+		}
+		b.WriteString("];\n")
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s %s %s", e.From, arrow, e.To)
+		if e.Label != "" {
+			fmt.Fprintf(&b, " [label=%s]", escapeDOT(e.Label))
+		}
+		b.WriteString(";\n")
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid writes the graph as a Mermaid flowchart
+func (g *Graph) WriteMermaid(w io.Writer) error {
+	var b strings.Builder
+
+	direction := "TD"
+	if g.LeftToRight {
+		direction = "LR"
+	}
+	fmt.Fprintf(&b, "flowchart %s\n", direction)
+
+	for _, n := range g.Nodes {
+		left, right := "[", "]"
+		if n.Shape == "circle" {
+			left, right = "((", "))"
+		}
+		fmt.Fprintf(&b, "  %s%s%s%s\n", n.ID, left, escapeMermaid(n.Label), right)
+	}
+
+	arrow := "---"
+	if g.Directed {
+		arrow = "-->"
+	}
+	for _, e := range g.Edges {
+		if e.Label != "" {
+			fmt.Fprintf(&b, "  %s %s|%s| %s\n", e.From, arrow, escapeMermaid(e.Label), e.To)
+		} else {
+			fmt.Fprintf(&b, "  %s %s %s\n", e.From, arrow, e.To)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// DOT returns the graph in Graphviz DOT format
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	g.WriteDOT(&b)
+	return b.String()
+}
+
+// Mermaid returns the graph as a Mermaid flowchart
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	g.WriteMermaid(&b)
+	return b.String()
+}
+
+// String returns a string representation of the graph
+func (g *Graph) String() string {
+	return fmt.Sprintf("Graph{name: %s, nodes: %d, edges: %d}", g.Name, len(g.Nodes), len(g.Edges))
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Visualization Examples ===")
+
+	// Example 1: A linked chain in DOT
+	fmt.Println("1. Chain (DOT):")
+	chain := Chain("queue", slices.Values([]string{"a", "b", "c"}), nil)
+	fmt.Print(chain.DOT())
+
+	// Example 2: A binary heap in Mermaid
+	fmt.Println("\n2. Heap (Mermaid):")
+	heap := Heap("heap", slices.Values([]int{1, 3, 2, 7}), func(v int) string {
+		return fmt.Sprintf("p=%d", v)
+	})
+	fmt.Print(heap.Mermaid())
+}
@@ -0,0 +1,109 @@
+package viz
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestChainDOT(t *testing.T) {
+	g := Chain("list", slices.Values([]int{1, 2, 3}), nil)
+
+	expected := `digraph "list" {
+  rankdir=LR;
+  n0 [label="1", shape=box];
+  n1 [label="2", shape=box];
+  n2 [label="3", shape=box];
+  n0 -> n1;
+  n1 -> n2;
+}
+`
+	if got := g.DOT(); got != expected {
+		t.Errorf("Unexpected DOT output:\n%s", got)
+	}
+}
+
+func TestHeapEdges(t *testing.T) {
+	g := Heap("heap", slices.Values([]int{1, 2, 3, 4, 5}), nil)
+
+	expected := []Edge{{"n0", "n1", ""}, {"n0", "n2", ""}, {"n1", "n3", ""}, {"n1", "n4", ""}}
+	if !slices.Equal(g.Edges, expected) {
+		t.Errorf("Expected edges %v, got %v", expected, g.Edges)
+	}
+}
+
+func TestDaryHeapEdges(t *testing.T) {
+	g := DaryHeap("heap", slices.Values([]int{1, 2, 3, 4, 5}), 3, nil)
+
+	expected := []Edge{{"n0", "n1", ""}, {"n0", "n2", ""}, {"n0", "n3", ""}, {"n1", "n4", ""}}
+	if !slices.Equal(g.Edges, expected) {
+		t.Errorf("Expected edges %v, got %v", expected, g.Edges)
+	}
+}
+
+type treeNode struct {
+	key         int
+	left, right *treeNode
+}
+
+func TestTree(t *testing.T) {
+	root := &treeNode{key: 2, left: &treeNode{key: 1}, right: &treeNode{key: 3, right: &treeNode{key: 4}}}
+
+	g := Tree("bst", root,
+		func(n *treeNode) []*treeNode { return []*treeNode{n.left, n.right} },
+		func(n *treeNode) string { return strings.Repeat("*", n.key) },
+		func(_ *treeNode, i int) string { return []string{"L", "R"}[i] },
+	)
+
+	if len(g.Nodes) != 4 || len(g.Edges) != 3 {
+		t.Fatalf("Expected 4 nodes and 3 edges, got %d and %d", len(g.Nodes), len(g.Edges))
+	}
+	if g.Nodes[0].Label != "**" {
+		t.Errorf("Expected root label **, got %s", g.Nodes[0].Label)
+	}
+	if last := g.Edges[len(g.Edges)-1]; last.Label != "R" || last.From != "n0" {
+		t.Errorf("Unexpected root-to-right edge %+v", last)
+	}
+
+	if empty := Tree[*treeNode]("empty", nil, nil, nil, nil); len(empty.Nodes) != 0 {
+		t.Error("Expected no nodes for a nil root")
+	}
+}
+
+func TestMermaid(t *testing.T) {
+	g := NewGraph("g")
+	g.AddNode("a", `say "hi"`)
+	g.AddNode("b", "b")
+	g.AddEdge("a", "b", "x")
+
+	expected := "flowchart TD\n  a[\"say #quot;hi#quot;\"]\n  b[\"b\"]\n  a -->|\"x\"| b\n"
+	if got := g.Mermaid(); got != expected {
+		t.Errorf("Unexpected Mermaid output:\n%s", got)
+	}
+}
+
+func TestDOTEscaping(t *testing.T) {
+	g := NewGraph(`a"b`)
+	g.Directed = false
+	g.AddNode("n0", `back\slash`)
+
+	got := g.DOT()
+	if !strings.HasPrefix(got, `graph "a\"b" {`) {
+		t.Errorf("Expected escaped undirected header, got %s", got)
+	}
+	if !strings.Contains(got, `label="back\\slash"`) {
+		t.Errorf("Expected escaped backslash, got %s", got)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
+
+func TestWriteError(t *testing.T) {
+	g := NewGraph("g")
+	if err := g.WriteDOT(failingWriter{}); err == nil {
+		t.Error("Expected WriteDOT to report the writer error")
+	}
+}
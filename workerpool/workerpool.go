@@ -0,0 +1,272 @@
+// Package workerpool runs jobs on a fixed number of goroutines, taking the
+// highest-priority job first from a shared priority queue
+package workerpool
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/anwar-arif/golang-dsa/future"
+	"github.com/anwar-arif/golang-dsa/priorityqueue"
+)
+
+// ErrClosed is returned by Submit once the pool has been closed or its
+// workers have exited
+var ErrClosed = errors.New("worker pool is closed")
+
+// Job is a unit of work for the pool
+type Job[T any] struct {
+	Payload  T
+	Priority int // higher runs first; equal priorities run in submission order
+	Retries  int // extra attempts allowed after the handler fails
+}
+
+// Result reports the outcome of a job after its last attempt
+type Result[T, R any] struct {
+	Job      Job[T]
+	Value    R
+	Err      error
+	Attempts int
+}
+
+// Handler processes a job payload
+// The context is cancelled when the pool's context is
+type Handler[T, R any] func(ctx context.Context, payload T) (R, error)
+
+// queued is a job waiting in the queue along with its ordering data
+type queued[T, R any] struct {
+	job      Job[T]
+	seq      uint64                        // submission order, breaks priority ties
+	attempts int                           // attempts made so far
+	promise  *future.Promise[Result[T, R]] // settled instead of sending on results, may be nil
+}
+
+func byPriority[T, R any](a, b queued[T, R]) int {
+	if c := cmp.Compare(a.job.Priority, b.job.Priority); c != 0 {
+		return c
+	}
+	// Earlier submissions rank higher in the max-queue
+	return cmp.Compare(b.seq, a.seq)
+}
+
+// Pool runs submitted jobs on a fixed set of workers
+// Every job produces exactly one Result once it succeeds or runs out of
+// retries; a failed attempt goes back into the queue at the job's priority
+// Results must be drained, since workers block until theirs is received;
+// jobs given to SubmitFuture settle their future instead
+type Pool[T, R any] struct {
+	ctx     context.Context
+	queue   *priorityqueue.ConcurrentQueue[queued[T, R]]
+	handler Handler[T, R]
+	results chan Result[T, R]
+	wg      sync.WaitGroup
+
+	mu      sync.Mutex
+	seq     uint64
+	pending int // jobs submitted whose Result has not been produced
+	closed  bool
+}
+
+// New starts a pool of workers goroutines running handler
+// When ctx is cancelled, workers stop after their current attempt and jobs
+// still queued are dropped without a Result; the futures of dropped jobs are
+// rejected with the context error
+// Panics if workers < 1
+func New[T, R any](ctx context.Context, workers int, handler Handler[T, R]) *Pool[T, R] {
+	if workers < 1 {
+		panic(fmt.Sprintf("workerpool: need at least 1 worker, got %d", workers))
+	}
+
+	p := &Pool[T, R]{
+		ctx:     ctx,
+		queue:   priorityqueue.NewConcurrentMaxQueue(byPriority[T, R]),
+		handler: handler,
+		results: make(chan Result[T, R], workers),
+	}
+	p.wg.Add(workers)
+	for range workers {
+		go p.work()
+	}
+	go func() {
+		p.wg.Wait()
+		p.drop()
+		close(p.results)
+	}()
+	return p
+}
+
+// Submit queues job; its Result is sent on Results
+// Returns ErrClosed once Close has been called
+func (p *Pool[T, R]) Submit(job Job[T]) error {
+	return p.submit(job, nil)
+}
+
+// SubmitFuture queues job and returns a future that is resolved with the
+// job's Result after its last attempt, which is not sent on Results
+// The future is rejected with the pool's context error if the job is dropped
+// Returns ErrClosed once Close has been called
+func (p *Pool[T, R]) SubmitFuture(job Job[T]) (*future.Future[Result[T, R]], error) {
+	promise := future.NewPromise[Result[T, R]]()
+	if err := p.submit(job, promise); err != nil {
+		return nil, err
+	}
+	return promise.Future(), nil
+}
+
+func (p *Pool[T, R]) submit(job Job[T], promise *future.Promise[Result[T, R]]) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrClosed
+	}
+	p.seq++
+	p.pending++
+	return p.queue.Push(queued[T, R]{job: job, seq: p.seq, promise: promise})
+}
+
+// Results returns the channel that receives one Result per job; it is closed
+// once every worker has exited
+func (p *Pool[T, R]) Results() <-chan Result[T, R] {
+	return p.results
+}
+
+// Close stops accepting jobs; workers finish the queued jobs, including
+// their retries, and then exit
+func (p *Pool[T, R]) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.closed {
+		p.closed = true
+		p.closeIfIdle()
+	}
+}
+
+// Pending returns the number of jobs that have not produced a Result yet
+func (p *Pool[T, R]) Pending() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.pending
+}
+
+// closeIfIdle closes the queue once the pool is closed and has no jobs left,
+// which lets the workers exit; callers must hold mu
+func (p *Pool[T, R]) closeIfIdle() {
+	if p.closed && p.pending == 0 {
+		p.queue.Close()
+	}
+}
+
+// drop closes the pool once the workers have exited and rejects the futures
+// of jobs left in the queue, which only happens when the context is cancelled
+func (p *Pool[T, R]) drop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	for {
+		q, err := p.queue.TryPop()
+		if err != nil {
+			return
+		}
+		if q.promise != nil {
+			q.promise.Reject(p.ctx.Err())
+		}
+	}
+}
+
+func (p *Pool[T, R]) work() {
+	defer p.wg.Done()
+
+	for p.ctx.Err() == nil {
+		q, err := p.queue.Pop(p.ctx)
+		if err != nil {
+			return
+		}
+
+		q.attempts++
+		value, err := p.handler(p.ctx, q.job.Payload)
+		if err != nil && q.attempts <= q.job.Retries && p.ctx.Err() == nil {
+			// The queue stays open while this job is pending
+			p.queue.Push(q)
+			continue
+		}
+
+		result := Result[T, R]{Job: q.job, Value: value, Err: err, Attempts: q.attempts}
+		if q.promise != nil {
+			q.promise.Resolve(result)
+		} else {
+			select {
+			case p.results <- result:
+			case <-p.ctx.Done():
+				return
+			}
+		}
+
+		p.mu.Lock()
+		p.pending--
+		p.closeIfIdle()
+		p.mu.Unlock()
+	}
+}
+
+// Example usage and demonstrations
+func ExampleUsage() {
+	fmt.Println("=== Worker Pool Examples ===")
+
+	// Example 1: Tasks run highest priority first on a single worker, which
+	// is held by the warm-up task until every task has been submitted
+	fmt.Println("1. Prioritised Tasks:")
+	release := make(chan struct{})
+	pool := New(context.Background(), 1, func(ctx context.Context, task priorityqueue.Task) (string, error) {
+		if task.ID == 0 {
+			<-release
+		}
+		return "done " + task.Name, nil
+	})
+	tasks := []priorityqueue.Task{
+		{ID: 0, Name: "warm-up", Priority: 100},
+		{ID: 1, Name: "cleanup", Priority: 1},
+		{ID: 2, Name: "deploy", Priority: 9},
+		{ID: 3, Name: "report", Priority: 5},
+	}
+	for _, task := range tasks {
+		pool.Submit(Job[priorityqueue.Task]{Payload: task, Priority: task.Priority})
+	}
+	pool.Close()
+	close(release)
+	for r := range pool.Results() {
+		fmt.Printf("  %s (priority %d)\n", r.Value, r.Job.Priority)
+	}
+
+	// Example 2: Retries
+	fmt.Println("\n2. Retrying a Flaky Job:")
+	calls := 0
+	flaky := New(context.Background(), 1, func(ctx context.Context, n int) (int, error) {
+		calls++
+		if calls < 3 {
+			return 0, errors.New("temporary failure")
+		}
+		return n * n, nil
+	})
+	flaky.Submit(Job[int]{Payload: 7, Retries: 5})
+	flaky.Close()
+	r := <-flaky.Results()
+	fmt.Printf("  Result %d after %d attempts (err: %v)\n", r.Value, r.Attempts, r.Err)
+
+	// Example 3: Waiting on a single job through its future
+	fmt.Println("\n3. Job Futures:")
+	squares := New(context.Background(), 2, func(ctx context.Context, n int) (int, error) {
+		return n * n, nil
+	})
+	f, _ := squares.SubmitFuture(Job[int]{Payload: 12})
+	if r, err := f.Get(context.Background()); err == nil {
+		fmt.Printf("  12 squared is %d\n", r.Value)
+	}
+	squares.Close()
+}
@@ -0,0 +1,173 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPriorityOrder(t *testing.T) {
+	release := make(chan struct{})
+	p := New(context.Background(), 1, func(ctx context.Context, n int) (int, error) {
+		if n < 0 {
+			<-release
+		}
+		return n, nil
+	})
+
+	// The gate job holds the only worker while the rest are queued
+	p.Submit(Job[int]{Payload: -1, Priority: 100})
+	for i, priority := range []int{1, 5, 3, 5} {
+		p.Submit(Job[int]{Payload: i, Priority: priority})
+	}
+	p.Close()
+	close(release)
+
+	var got []int
+	for r := range p.Results() {
+		got = append(got, r.Value)
+	}
+	expected := []int{-1, 1, 3, 2, 0}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected, got)
+		}
+	}
+
+	if err := p.Submit(Job[int]{}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	var calls atomic.Int32
+	failure := errors.New("boom")
+	p := New(context.Background(), 2, func(ctx context.Context, succeedOn int32) (int32, error) {
+		n := calls.Add(1)
+		if n < succeedOn {
+			return 0, failure
+		}
+		return n, nil
+	})
+
+	p.Submit(Job[int32]{Payload: 3, Retries: 5})
+	r := <-p.Results()
+	if r.Err != nil || r.Attempts != 3 {
+		t.Errorf("Expected success on attempt 3, got %+v", r)
+	}
+
+	p.Submit(Job[int32]{Payload: 100, Retries: 1})
+	r = <-p.Results()
+	if !errors.Is(r.Err, failure) || r.Attempts != 2 {
+		t.Errorf("Expected failure after 2 attempts, got %+v", r)
+	}
+
+	p.Close()
+	if _, ok := <-p.Results(); ok {
+		t.Error("Expected Results to be closed")
+	}
+	if p.Pending() != 0 {
+		t.Errorf("Expected no pending jobs, got %d", p.Pending())
+	}
+}
+
+func TestCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	p := New(ctx, 1, func(ctx context.Context, n int) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	p.Submit(Job[int]{Payload: 1, Retries: 10})
+	p.Submit(Job[int]{Payload: 2})
+	<-started
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		for range p.Results() {
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Results to close after cancellation")
+	}
+}
+
+func TestSubmitFuture(t *testing.T) {
+	var calls atomic.Int32
+	p := New(context.Background(), 2, func(ctx context.Context, n int) (int, error) {
+		if n == 3 && calls.Add(1) < 2 {
+			return 0, errors.New("flaky")
+		}
+		return n * 10, nil
+	})
+
+	f, err := p.SubmitFuture(Job[int]{Payload: 3, Retries: 1})
+	if err != nil {
+		t.Fatalf("Unexpected submit error: %v", err)
+	}
+	r, err := f.Get(context.Background())
+	if err != nil || r.Err != nil || r.Value != 30 || r.Attempts != 2 {
+		t.Errorf("Expected value 30 after 2 attempts, got %+v with error %v", r, err)
+	}
+
+	p.Close()
+	if _, err := p.SubmitFuture(Job[int]{Payload: 1}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed after Close, got %v", err)
+	}
+	for r := range p.Results() {
+		t.Errorf("Expected no Result on the channel for a future job, got %+v", r)
+	}
+}
+
+func TestSubmitFutureDropped(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	p := New(ctx, 1, func(ctx context.Context, n int) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+
+	running, _ := p.SubmitFuture(Job[int]{Payload: 1})
+	queued, _ := p.SubmitFuture(Job[int]{Payload: 2})
+	<-started
+	cancel()
+
+	r, err := running.Get(context.Background())
+	if err != nil || !errors.Is(r.Err, context.Canceled) {
+		t.Errorf("Expected the running job to report context.Canceled, got %+v with error %v", r, err)
+	}
+	if _, err := queued.Get(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the queued job's future to be rejected, got %v", err)
+	}
+	for range p.Results() {
+	}
+	if err := p.Submit(Job[int]{Payload: 3}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed once the workers exited, got %v", err)
+	}
+}
+
+// Benchmark tests
+func BenchmarkPool(b *testing.B) {
+	p := New(context.Background(), 4, func(ctx context.Context, n int) (int, error) {
+		return n, nil
+	})
+	go func() {
+		for i := 0; i < b.N; i++ {
+			p.Submit(Job[int]{Payload: i, Priority: i % 10})
+		}
+		p.Close()
+	}()
+	for range p.Results() {
+	}
+}